@@ -168,6 +168,12 @@ func buildWalletConfig(walletRequest *requests.WalletConfig, request *requests.W
 
 		TokensListsAutoRefreshCheckInterval: walletRequest.TokensListsAutoRefreshCheckInterval,
 		TokensListsAutoRefreshInterval:      walletRequest.TokensListsAutoRefreshInterval,
+		DisableCollectiblesMetadataHosts:    walletRequest.DisableCollectiblesMetadataHosts,
+		ProviderHedgeDelay:                  walletRequest.ProviderHedgeDelay,
+		VerifyStateReads:                    walletRequest.VerifyStateReads,
+		TrustedHeadersQuorum:                walletRequest.TrustedHeadersQuorum,
+		TrustedHeadersURLs:                  request.TrustedHeadersURLs,
+		VerifiedERC20BalanceSlots:           walletRequest.VerifiedERC20BalanceSlots,
 	}
 
 	if request.StatusProxyStageName != "" {
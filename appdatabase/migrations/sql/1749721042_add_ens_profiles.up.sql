@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS ens_profiles (
+    username VARCHAR NOT NULL,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    avatar VARCHAR NOT NULL DEFAULT '',
+    text_records TEXT NOT NULL DEFAULT '{}',
+    addresses TEXT NOT NULL DEFAULT '{}',
+    updated_at INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (username, chain_id)
+) WITHOUT ROWID;
@@ -0,0 +1 @@
+ALTER TABLE keypairs_accounts ADD COLUMN safe BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE keypairs_accounts ADD COLUMN smart_account BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+package chainlink
+
+//go:generate abigen --abi AggregatorV3Interface.abi --pkg chainlink --type AggregatorV3 --out aggregatorv3.go
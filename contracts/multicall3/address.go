@@ -0,0 +1,7 @@
+package multicall3
+
+import "github.com/ethereum/go-ethereum/common"
+
+// ContractAddress is the address Multicall3 is deployed at on every chain it is available on, it is deployed with a
+// presigned transaction so the address doesn't depend on the chain
+var ContractAddress = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
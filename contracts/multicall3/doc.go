@@ -0,0 +1,3 @@
+package multicall3
+
+//go:generate abigen --abi Multicall3.abi --pkg multicall3 --type Multicall3 --out multicall3.go
@@ -0,0 +1,3 @@
+package safe
+
+//go:generate abigen -abi ./Safe.abi -pkg safe -out safe.go
@@ -1,3 +1,4 @@
 package uniswapv3
 
 //go:generate abigen --abi IUniswapV3Pool.abi --pkg uniswapv3 --out uniswapv3pool.go
+//go:generate abigen --abi IUniswapV3Factory.abi --pkg uniswapv3 --type UniswapV3Factory --out uniswapv3factory.go
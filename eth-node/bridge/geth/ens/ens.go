@@ -11,6 +11,7 @@ import (
 	ens "github.com/wealdtech/go-ens/v3"
 	"go.uber.org/zap"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 
@@ -23,13 +24,32 @@ const (
 	contractQueryTimeout = 5000 * time.Millisecond
 )
 
+// PubKeyResolver resolves the pubkey record of an ENS name through the backend
+type PubKeyResolver func(ctx context.Context, backend bind.ContractBackend, name string) (x [32]byte, y [32]byte, err error)
+
 type Verifier struct {
-	logger *zap.Logger
+	logger         *zap.Logger
+	pubKeyResolver PubKeyResolver
 }
 
-// NewVerifier returns a Verifier attached to the specified logger
+// NewVerifier returns a Verifier attached to the specified logger, the names are resolved by the resolver
+// set in the registry
 func NewVerifier(logger *zap.Logger) *Verifier {
-	return &Verifier{logger: logger}
+	return NewVerifierWithResolver(logger, resolvePubKey)
+}
+
+// NewVerifierWithResolver returns a Verifier resolving the names with pubKeyResolver, e.g. to follow the wildcard and
+// offchain resolvers
+func NewVerifierWithResolver(logger *zap.Logger, pubKeyResolver PubKeyResolver) *Verifier {
+	return &Verifier{logger: logger, pubKeyResolver: pubKeyResolver}
+}
+
+func resolvePubKey(ctx context.Context, backend bind.ContractBackend, name string) (x [32]byte, y [32]byte, err error) {
+	resolver, err := ens.NewResolver(backend, name)
+	if err != nil {
+		return x, y, err
+	}
+	return resolver.PubKey()
 }
 
 func (m *Verifier) ReverseResolve(address common.Address, rpcEndpoint string) (string, error) {
@@ -66,13 +86,10 @@ func (m *Verifier) verifyENSName(ensInfo enstypes.ENSDetails, ethclient *ethclie
 	}
 
 	// Resolve ensName
-	resolver, err := ens.NewResolver(ethclient, ensName)
-	if err != nil {
-		m.logger.Error("error while creating ENS name resolver", zap.Error(err))
-		response.Error = err
-		return response
-	}
-	x, y, err := resolver.PubKey()
+	ctx, cancel := context.WithTimeout(context.Background(), contractQueryTimeout)
+	defer cancel()
+
+	x, y, err := m.pubKeyResolver(ctx, ethclient, ensName)
 	if err != nil {
 		m.logger.Error("error while resolving public key from ENS name", zap.Error(err))
 		response.Error = err
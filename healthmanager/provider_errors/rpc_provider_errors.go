@@ -60,6 +60,12 @@ func IsMethodNotFoundError(err error) bool {
 	return ok && code == -32601
 }
 
+// IsNotificationsUnsupportedError returns true when the subscriptions are not supported by the provider transport,
+// like HTTP, the provider keeps answering the other calls
+func IsNotificationsUnsupportedError(err error) bool {
+	return errors.Is(err, rpc.ErrNotificationsUnsupported)
+}
+
 func IsVMError(err error) bool {
 	_, code, ok := safeRPCError(err)
 	if ok && code == -32015 { // VM execution error code
@@ -85,7 +91,7 @@ func determineRpcErrorType(err error) RpcProviderErrorType {
 		return RpcErrorTypeNone
 	}
 
-	if IsMethodNotFoundError(err) || IsNotFoundError(err) {
+	if IsMethodNotFoundError(err) || IsNotFoundError(err) || IsNotificationsUnsupportedError(err) {
 		return RpcErrorTypeMethodNotFound
 	}
 	if IsVMError(err) {
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // TestIsRpsLimitError tests the IsRpsLimitError function.
@@ -157,3 +159,13 @@ func TestNilRPCErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestNotificationsUnsupportedIsNonCritical(t *testing.T) {
+	err := fmt.Errorf("provider.error: %w", rpc.ErrNotificationsUnsupported)
+
+	require.True(t, IsNotificationsUnsupportedError(err))
+	require.Equal(t, RpcErrorTypeMethodNotFound, determineRpcErrorType(err))
+	require.True(t, IsNonCriticalRpcError(err))
+
+	require.False(t, IsNotificationsUnsupportedError(errors.New("connection refused")))
+}
@@ -15,6 +15,7 @@ type ProvidersHealthManager struct {
 	aggregator          *aggregator.Aggregator
 	subscriptionManager *SubscriptionManager
 	lastStatus          *rpcstatus.ProviderStatus
+	scores              map[string]*rpcstatus.ProviderScore
 }
 
 // NewProvidersHealthManager creates a new instance of ProvidersHealthManager with the given chain ID.
@@ -25,6 +26,7 @@ func NewProvidersHealthManager(chainID uint64) *ProvidersHealthManager {
 		chainID:             chainID,
 		aggregator:          agg,
 		subscriptionManager: NewSubscriptionManager(),
+		scores:              make(map[string]*rpcstatus.ProviderScore),
 	}
 }
 
@@ -42,6 +44,7 @@ func (p *ProvidersHealthManager) doUpdate(callStatuses []rpcstatus.RpcProviderCa
 	for _, rpcCallStatus := range callStatuses {
 		providerStatus := rpcstatus.NewRpcProviderStatus(rpcCallStatus)
 		p.aggregator.Update(providerStatus)
+		p.updateScore(rpcCallStatus)
 	}
 
 	newStatus = p.aggregator.GetAggregatedStatus()
@@ -63,11 +66,42 @@ func (p *ProvidersHealthManager) Update(ctx context.Context, callStatuses []rpcs
 	p.lastStatus = &newStatus
 }
 
-// GetStatuses returns a copy of the current provider statuses.
+func (p *ProvidersHealthManager) updateScore(callStatus rpcstatus.RpcProviderCallStatus) {
+	if p.scores == nil {
+		p.scores = make(map[string]*rpcstatus.ProviderScore)
+	}
+	score, ok := p.scores[callStatus.Name]
+	if !ok {
+		score = &rpcstatus.ProviderScore{}
+		p.scores[callStatus.Name] = score
+	}
+	score.Update(callStatus)
+}
+
+// GetStatuses returns a copy of the current provider statuses, including their scores.
 func (p *ProvidersHealthManager) GetStatuses() map[string]rpcstatus.ProviderStatus {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	return p.aggregator.GetStatuses()
+	statuses := p.aggregator.GetStatuses()
+	for name, status := range statuses {
+		if score, ok := p.scores[name]; ok {
+			scoreCopy := *score
+			status.Score = &scoreCopy
+			statuses[name] = status
+		}
+	}
+	return statuses
+}
+
+// GetScores returns a copy of the current provider scores, the providers without calls yet have no score.
+func (p *ProvidersHealthManager) GetScores() map[string]rpcstatus.ProviderScore {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	scores := make(map[string]rpcstatus.ProviderScore, len(p.scores))
+	for name, score := range p.scores {
+		scores[name] = *score
+	}
+	return scores
 }
 
 // Subscribe allows providers to receive notifications about changes.
@@ -87,6 +121,7 @@ func (p *ProvidersHealthManager) Reset() {
 	newAgg := aggregator.NewAggregator(fmt.Sprintf("%d", p.chainID))
 	p.aggregator = newAgg
 	p.lastStatus = nil
+	p.scores = make(map[string]*rpcstatus.ProviderScore)
 }
 
 // Status returns the current aggregated status.
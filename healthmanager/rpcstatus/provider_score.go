@@ -0,0 +1,91 @@
+package rpcstatus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/status-im/status-go/healthmanager/provider_errors"
+)
+
+const (
+	// scoreSmoothing is the weight of the latest call in the moving averages
+	scoreSmoothing = 0.2
+	// referenceLatency halves the score of a provider without errors
+	referenceLatency = 500 * time.Millisecond
+
+	// NeutralScore is the score of the providers without calls yet, the score of a provider answering within
+	// referenceLatency without errors
+	NeutralScore = 0.5
+)
+
+// ProviderScore rates a provider from its recent calls, the providers with the highest score are called first.
+// The rates and the latency are exponentially weighted moving averages, so the provider recovers its score once
+// it is fast and reliable again.
+type ProviderScore struct {
+	LatencyEWMA   time.Duration `json:"-"`
+	ErrorRate     float64       `json:"error_rate"`
+	RateLimitRate float64       `json:"rate_limit_rate"`
+	TotalCalls    int64         `json:"total_calls"`
+	Score         float64       `json:"score"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ProviderScore
+func (s ProviderScore) MarshalJSON() ([]byte, error) {
+	type Alias ProviderScore // prevent recursive MarshalJSON calls
+
+	return json.Marshal(&struct {
+		Alias
+		LatencyEWMAMs int64 `json:"latency_ewma_ms"`
+	}{
+		Alias:         Alias(s),
+		LatencyEWMAMs: s.LatencyEWMA.Milliseconds(),
+	})
+}
+
+// Update adds the call to the averages and recomputes the score
+func (s *ProviderScore) Update(call RpcProviderCallStatus) {
+	latency := call.Timestamp.Sub(call.StartTime)
+	if errors.Is(call.Err, context.Canceled) {
+		// the call was given up by the caller, it only tells that the provider is at least this slow
+		if s.TotalCalls > 0 && latency > s.LatencyEWMA {
+			s.LatencyEWMA = ewmaDuration(s.LatencyEWMA, latency)
+			s.Score = s.compute()
+		}
+		return
+	}
+
+	failed := 0.0
+	if NewRpcProviderStatus(call).Status == StatusDown {
+		failed = 1
+	}
+	rateLimited := 0.0
+	if provider_errors.IsRateLimitError(call.Err) {
+		rateLimited = 1
+	}
+
+	if s.TotalCalls == 0 {
+		s.LatencyEWMA = latency
+		s.ErrorRate = failed
+		s.RateLimitRate = rateLimited
+	} else {
+		s.LatencyEWMA = ewmaDuration(s.LatencyEWMA, latency)
+		s.ErrorRate = ewma(s.ErrorRate, failed)
+		s.RateLimitRate = ewma(s.RateLimitRate, rateLimited)
+	}
+	s.TotalCalls++
+	s.Score = s.compute()
+}
+
+func (s *ProviderScore) compute() float64 {
+	return (1 - s.ErrorRate) * (1 - s.RateLimitRate) / (1 + float64(s.LatencyEWMA)/float64(referenceLatency))
+}
+
+func ewma(average float64, sample float64) float64 {
+	return average + scoreSmoothing*(sample-average)
+}
+
+func ewmaDuration(average time.Duration, sample time.Duration) time.Duration {
+	return time.Duration(ewma(float64(average), float64(sample)))
+}
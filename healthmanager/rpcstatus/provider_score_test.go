@@ -0,0 +1,51 @@
+package rpcstatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/rpc/chain/rpclimiter"
+)
+
+func callStatus(latency time.Duration, err error) RpcProviderCallStatus {
+	now := time.Now()
+	return RpcProviderCallStatus{
+		Name:      "Provider1",
+		Timestamp: now,
+		StartTime: now.Add(-latency),
+		Err:       err,
+	}
+}
+
+func TestProviderScore(t *testing.T) {
+	score := &ProviderScore{}
+	score.Update(callStatus(referenceLatency, nil))
+	require.Equal(t, int64(1), score.TotalCalls)
+	require.Equal(t, referenceLatency, score.LatencyEWMA)
+	require.InDelta(t, NeutralScore, score.Score, 1e-9)
+
+	// errors and rate limits lower the score
+	score.Update(callStatus(referenceLatency, errors.New("some critical error")))
+	require.InDelta(t, scoreSmoothing, score.ErrorRate, 1e-9)
+	score.Update(callStatus(referenceLatency, rpclimiter.ErrRequestsOverLimit))
+	require.Greater(t, score.RateLimitRate, 0.0)
+	require.Less(t, score.Score, NeutralScore)
+
+	// a faster provider without errors is scored higher
+	fast := &ProviderScore{}
+	fast.Update(callStatus(50*time.Millisecond, nil))
+	require.Greater(t, fast.Score, score.Score)
+
+	// a canceled call only raises the latency
+	latency := fast.LatencyEWMA
+	fast.Update(callStatus(10*time.Millisecond, context.Canceled))
+	require.Equal(t, latency, fast.LatencyEWMA)
+	fast.Update(callStatus(time.Second, context.Canceled))
+	require.Greater(t, fast.LatencyEWMA, latency)
+	require.Equal(t, int64(1), fast.TotalCalls)
+	require.Equal(t, 0.0, fast.ErrorRate)
+}
@@ -18,15 +18,16 @@ const (
 
 // ProviderStatus holds the status information for a single provider.
 type ProviderStatus struct {
-	Name              string        `json:"name"`
-	LastSuccessAt     time.Time     `json:"last_success_at"`
-	LastErrorAt       time.Time     `json:"last_error_at"`
-	LastError         error         `json:"-"` // ignore this field during standard marshaling
-	Status            StatusType    `json:"status"`
-	TotalDuration     time.Duration `json:"-"` // ignore this field during standard marshaling
-	TotalRequests     int64         `json:"total_requests"`
-	TotalTimeoutCount int64         `json:"total_timeout_count"`
-	TotalErrorCount   int64         `json:"total_error_count"`
+	Name              string         `json:"name"`
+	LastSuccessAt     time.Time      `json:"last_success_at"`
+	LastErrorAt       time.Time      `json:"last_error_at"`
+	LastError         error          `json:"-"` // ignore this field during standard marshaling
+	Status            StatusType     `json:"status"`
+	TotalDuration     time.Duration  `json:"-"` // ignore this field during standard marshaling
+	TotalRequests     int64          `json:"total_requests"`
+	TotalTimeoutCount int64          `json:"total_timeout_count"`
+	TotalErrorCount   int64          `json:"total_error_count"`
+	Score             *ProviderScore `json:"score,omitempty"` // set for the providers of a chain only
 }
 
 // MarshalJSON implements custom JSON marshaling for ProviderStatus
@@ -107,6 +107,12 @@ const (
 	AccountTypeKey       AccountType = "key"
 	AccountTypeSeed      AccountType = "seed"
 	AccountTypeWatch     AccountType = "watch"
+	// AccountTypeSafe is a Safe multisig account, like watch only accounts it's not backed by a keypair,
+	// transactions are signed by the owners of the Safe
+	AccountTypeSafe AccountType = "safe"
+	// AccountTypeSmart is an ERC-4337 smart account, like Safe accounts it's not backed by a keypair, its public key
+	// is the one of the owner which signs the user operations of the account
+	AccountTypeSmart AccountType = "smart"
 )
 
 const (
@@ -119,9 +125,14 @@ const (
 	TestSepoliaPreferredChainIDsDefault = "11155111:11155420:421614:84532:1660990954"
 )
 
+// Returns true if an account is not backed by a keypair, that's the case for watch only, Safe and smart accounts.
+func (a *Account) IsKeypairless() bool {
+	return a.Type == AccountTypeWatch || a.Type == AccountTypeSafe || a.Type == AccountTypeSmart
+}
+
 // Returns true if an account is a wallet account that logged in user has a control over, otherwise returns false.
 func (a *Account) IsWalletNonWatchOnlyAccount() bool {
-	return !a.Chat && len(a.Type) > 0 && a.Type != AccountTypeWatch
+	return !a.Chat && len(a.Type) > 0 && !a.IsKeypairless()
 }
 
 // Returns true if an account is a wallet account that is ready for sending transactions, otherwise returns false.
@@ -371,6 +382,8 @@ func (db *Database) processRows(rows *sql.Rows) ([]*Keypair, []*Account, error)
 		accProdPreferredChainIDs sql.NullString
 		accTestPreferredChainIDs sql.NullString
 		accAddressWasNotShown    sql.NullBool
+		accSafe                  sql.NullBool
+		accSmart                 sql.NullBool
 	)
 
 	for rows.Next() {
@@ -381,7 +394,7 @@ func (db *Database) processRows(rows *sql.Rows) ([]*Keypair, []*Account, error)
 			&kpKeyUID, &kpName, &kpType, &kpDerivedFrom, &kpLastUsedDerivationIndex, &kpSyncedFrom, &kpClock, &kpRemoved,
 			&accAddress, &accKeyUID, &pubkey, &accPath, &accName, &accColorID, &accEmoji,
 			&accWallet, &accChat, &accHidden, &accOperable, &accClock, &accCreatedAt, &accPosition, &accRemoved,
-			&accProdPreferredChainIDs, &accTestPreferredChainIDs, &accAddressWasNotShown)
+			&accProdPreferredChainIDs, &accTestPreferredChainIDs, &accAddressWasNotShown, &accSafe, &accSmart)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -468,6 +481,12 @@ func (db *Database) processRows(rows *sql.Rows) ([]*Keypair, []*Account, error)
 			acc.Removed = accRemoved.Bool
 		}
 		acc.Type = GetAccountTypeForKeypairType(kp.Type)
+		if accSafe.Valid && accSafe.Bool {
+			acc.Type = AccountTypeSafe
+		}
+		if accSmart.Valid && accSmart.Bool {
+			acc.Type = AccountTypeSmart
+		}
 
 		if kp.KeyUID != "" {
 			if _, ok := keypairMap[kp.KeyUID]; !ok {
@@ -550,7 +569,9 @@ func (db *Database) getKeypairs(tx *sql.Tx, keyUID string, includeRemoved bool)
 			ka.removed,
 			ka.prod_preferred_chain_ids,
 			ka.test_preferred_chain_ids,
-                        ka.address_was_not_shown
+                        ka.address_was_not_shown,
+			ka.safe,
+			ka.smart_account
 		FROM
 			keypairs k
 		LEFT JOIN
@@ -653,7 +674,9 @@ func (db *Database) getAccounts(tx *sql.Tx, address types.Address, includeRemove
 			ka.removed,
 			ka.prod_preferred_chain_ids,
 			ka.test_preferred_chain_ids,
-			ka.address_was_not_shown
+			ka.address_was_not_shown,
+			ka.safe,
+			ka.smart_account
 		FROM
 			keypairs_accounts ka
 		LEFT JOIN
@@ -816,28 +839,28 @@ func (db *Database) GetAccountByAddress(address types.Address) (*Account, error)
 	return db.getAccountByAddress(nil, address)
 }
 
-// Returns active watch only accounts (excluding removed).
+// Returns active watch only accounts (excluding removed), Safe and smart accounts are included since they are not backed by a keypair.
 func (db *Database) GetActiveWatchOnlyAccounts() (res []*Account, err error) {
 	accounts, err := db.getAccounts(nil, types.Address{}, false)
 	if err != nil {
 		return nil, err
 	}
 	for _, acc := range accounts {
-		if acc.Type == AccountTypeWatch {
+		if acc.IsKeypairless() {
 			res = append(res, acc)
 		}
 	}
 	return
 }
 
-// Returns all watch only accounts (including removed).
+// Returns all watch only accounts (including removed), Safe and smart accounts are included since they are not backed by a keypair.
 func (db *Database) GetAllWatchOnlyAccounts() (res []*Account, err error) {
 	accounts, err := db.getAccounts(nil, types.Address{}, true)
 	if err != nil {
 		return nil, err
 	}
 	for _, acc := range accounts {
-		if acc.Type == AccountTypeWatch {
+		if acc.IsKeypairless() {
 			res = append(res, acc)
 		}
 	}
@@ -1000,7 +1023,7 @@ func (db *Database) saveOrUpdateAccounts(tx *sql.Tx, accounts []*Account, update
 		}
 
 		// Apply default values if account is new and not a watch only
-		if !exists && acc.Type != AccountTypeWatch {
+		if !exists && !acc.IsKeypairless() {
 			if acc.ProdPreferredChainIDs == "" {
 				acc.ProdPreferredChainIDs = ProdPreferredChainIDsDefault
 			}
@@ -1012,9 +1035,9 @@ func (db *Database) saveOrUpdateAccounts(tx *sql.Tx, accounts []*Account, update
 
 		_, err = tx.Exec(`
 			INSERT OR IGNORE INTO
-				keypairs_accounts (address, key_uid, pubkey, path, wallet, address_was_not_shown, chat, created_at, updated_at)
+				keypairs_accounts (address, key_uid, pubkey, path, wallet, address_was_not_shown, chat, safe, smart_account, created_at, updated_at)
 			VALUES
-				(?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'));
+				(?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'));
 
 			UPDATE
 				keypairs_accounts
@@ -1034,6 +1057,7 @@ func (db *Database) saveOrUpdateAccounts(tx *sql.Tx, accounts []*Account, update
 				address = ?;
 		`,
 			acc.Address, keyUID, acc.PublicKey, acc.Path, acc.Wallet, acc.AddressWasNotShown, acc.Chat,
+			acc.Type == AccountTypeSafe, acc.Type == AccountTypeSmart,
 			acc.Name, acc.ColorID, acc.Emoji, acc.Hidden, acc.Operable, acc.Clock, acc.Position, acc.Removed,
 			acc.ProdPreferredChainIDs, acc.TestPreferredChainIDs, acc.Address)
 
@@ -260,6 +260,43 @@ func TestWatchOnlyAccounts(t *testing.T) {
 	require.True(t, err == ErrDbAccountNotFound)
 }
 
+func TestSmartAccounts(t *testing.T) {
+	db, stop := setupTestDB(t)
+	defer stop()
+
+	kp := GetProfileKeypairForTest(true, true, false)
+	require.NoError(t, db.SaveOrUpdateKeypair(kp))
+	owner := kp.Accounts[1]
+
+	// a smart account isn't backed by the keypair of its owner, it only holds the owner's public key
+	smart := &Account{
+		Address:   types.Address{0x15},
+		PublicKey: owner.PublicKey,
+		Type:      AccountTypeSmart,
+		Name:      "SmartAcc",
+		ColorID:   common.CustomizationColorPrimary,
+		Emoji:     "emoji-1",
+	}
+	require.True(t, smart.IsKeypairless())
+	require.NoError(t, db.SaveOrUpdateAccounts([]*Account{smart}, false))
+
+	dbAcc, err := db.GetAccountByAddress(smart.Address)
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeSmart, dbAcc.Type)
+	require.Empty(t, dbAcc.KeyUID)
+	require.Empty(t, dbAcc.Path)
+	require.Equal(t, owner.PublicKey, dbAcc.PublicKey)
+
+	dbKp, err := db.GetKeypairByKeyUID(kp.KeyUID)
+	require.NoError(t, err)
+	require.Len(t, dbKp.Accounts, len(kp.Accounts))
+
+	woAccounts, err := db.GetActiveWatchOnlyAccounts()
+	require.NoError(t, err)
+	require.Len(t, woAccounts, 1)
+	require.Equal(t, smart.Address, woAccounts[0].Address)
+}
+
 func TestUpdateKeypairName(t *testing.T) {
 	db, stop := setupTestDB(t)
 	defer stop()
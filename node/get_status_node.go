@@ -4,13 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"math/big"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/node"
 
@@ -20,6 +23,7 @@ import (
 	"github.com/status-im/status-go/multiaccounts"
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/rpc/verified"
 	"github.com/status-im/status-go/server"
 	accountssvc "github.com/status-im/status-go/services/accounts"
 	appgeneral "github.com/status-im/status-go/services/app-general"
@@ -282,6 +286,9 @@ func (n *StatusNode) setupRPCClient() (err error) {
 		WalletFeed:      &n.walletFeed,
 		SettingsFeed:    &n.settingsFeed,
 		NetworksFeed:    &n.networksFeed,
+
+		ProviderHedgeDelay: time.Duration(n.config.WalletConfig.ProviderHedgeDelay) * time.Millisecond,
+		Verification:       n.verificationConfig(),
 	}
 	n.rpcClient, err = rpc.NewClient(config)
 	if err != nil {
@@ -291,6 +298,43 @@ func (n *StatusNode) setupRPCClient() (err error) {
 	return
 }
 
+// verificationConfig returns the state reads verification of the wallet config, nil when it's disabled
+func (n *StatusNode) verificationConfig() *verified.Config {
+	if !n.config.WalletConfig.VerifyStateReads {
+		return nil
+	}
+
+	trustedNodes := make(map[uint64]string, len(n.config.WalletConfig.TrustedHeadersURLs))
+	for chainID, url := range n.config.WalletConfig.TrustedHeadersURLs {
+		trustedNodes[chainID] = url.Reveal()
+	}
+	return &verified.Config{
+		ProvidersQuorum:   n.config.WalletConfig.TrustedHeadersQuorum,
+		TrustedNodes:      trustedNodes,
+		ERC20BalanceSlots: erc20BalanceSlots(n.config.WalletConfig.VerifiedERC20BalanceSlots, n.logger),
+	}
+}
+
+// erc20BalanceSlots parses the configured balances slots, the invalid tokens and slots are skipped
+func erc20BalanceSlots(configured map[uint64]map[string]string, logger *zap.Logger) map[uint64]map[common.Address]common.Hash {
+	slots := make(map[uint64]map[common.Address]common.Hash, len(configured))
+	for chainID, tokens := range configured {
+		for token, slot := range tokens {
+			value, ok := new(big.Int).SetString(slot, 0)
+			if !common.IsHexAddress(token) || !ok || value.Sign() < 0 || value.BitLen() > 256 {
+				logger.Warn("invalid ERC20 balance slot",
+					zap.Uint64("chainID", chainID), zap.String("token", token), zap.String("slot", slot))
+				continue
+			}
+			if slots[chainID] == nil {
+				slots[chainID] = make(map[common.Address]common.Hash)
+			}
+			slots[chainID][common.HexToAddress(token)] = common.BigToHash(value)
+		}
+	}
+	return slots
+}
+
 // Stop will stop current StatusNode. A stopped node cannot be resumed.
 func (n *StatusNode) Stop() error {
 	n.mu.Lock()
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"math/big"
 	"os"
 	"path"
 	"testing"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/protocol/tt"
 	"github.com/status-im/status-go/t/utils"
@@ -90,3 +93,24 @@ func TestStatusNodeWithDataDir(t *testing.T) {
 	require.NoError(t, n.Start(&config, nil))
 	require.NoError(t, n.Stop())
 }
+
+func TestERC20BalanceSlots(t *testing.T) {
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	slots := erc20BalanceSlots(map[uint64]map[string]string{
+		1: {
+			usdc.Hex():    "0x9",
+			"not-a-token": "0x1",
+		},
+		10: {
+			usdc.Hex(): "not-a-slot",
+		},
+		8453: {
+			usdc.Hex(): "0x52c63247e1f47db19d5ce0460030c497f067ca4cebf71ba98eeadabe20bace00",
+		},
+	}, tt.MustCreateTestLogger())
+
+	require.Equal(t, map[uint64]map[common.Address]common.Hash{
+		1:    {usdc: common.BigToHash(big.NewInt(9))},
+		8453: {usdc: common.HexToHash("0x52c63247e1f47db19d5ce0460030c497f067ca4cebf71ba98eeadabe20bace00")},
+	}, slots)
+}
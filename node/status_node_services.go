@@ -41,6 +41,7 @@ import (
 	"github.com/status-im/status-go/services/chat"
 	"github.com/status-im/status-go/services/communitytokens"
 	"github.com/status-im/status-go/services/connector"
+	"github.com/status-im/status-go/services/connector/commands"
 	"github.com/status-im/status-go/services/ens"
 	"github.com/status-im/status-go/services/eth"
 	"github.com/status-im/status-go/services/gif"
@@ -92,7 +93,6 @@ func (b *StatusNode) initServices(config *params.NodeConfig, mediaServer *server
 	services = appendIf(config.BrowsersConfig.Enabled, services, b.browsersService())
 	services = appendIf(config.PermissionsConfig.Enabled, services, b.permissionsService())
 	services = appendIf(config.MailserversConfig.Enabled, services, b.mailserversService())
-	services = appendIf(config.ConnectorConfig.Enabled, services, b.connectorService())
 	services = append(services, b.gifService(accDB))
 	services = append(services, b.ChatService(accDB))
 
@@ -103,6 +103,9 @@ func (b *StatusNode) initServices(config *params.NodeConfig, mediaServer *server
 		services = append(services, walletService)
 	}
 
+	// Connector Service uses the wallet's token manager, keep it after the Wallet Service
+	services = appendIf(config.ConnectorConfig.Enabled, services, b.connectorService())
+
 	// CollectiblesManager needs the WakuExt service to get metadata for
 	// Community collectibles.
 	// Messenger needs the CollectiblesManager to get the list of collectibles owned
@@ -304,7 +307,19 @@ func setSettingsNotifier(db *accounts.Database, feed *event.Feed) {
 
 func (b *StatusNode) connectorService() *connector.Service {
 	if b.connectorSrvc == nil {
-		b.connectorSrvc = connector.NewService(b.walletDB, b.rpcClient, b.rpcClient.NetworkManager)
+		var tokenManager commands.TokenManagerInterface
+		if b.walletSrvc != nil {
+			tokenManager = b.walletSrvc.GetTokenManager()
+		}
+		var pendingTracker commands.PendingTxTrackerInterface
+		if b.pendingTracker != nil {
+			pendingTracker = b.pendingTracker
+		}
+		var signingPreviewer commands.SigningPreviewerInterface
+		if b.walletSrvc != nil {
+			signingPreviewer = b.walletSrvc.GetSigningPreviewer()
+		}
+		b.connectorSrvc = connector.NewService(b.walletDB, b.rpcClient, b.rpcClient.NetworkManager, tokenManager, pendingTracker, signingPreviewer)
 	}
 	return b.connectorSrvc
 }
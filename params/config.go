@@ -440,6 +440,34 @@ type WalletConfig struct {
 
 	TokensListsAutoRefreshInterval      int `json:"TokensListsAutoRefreshInterval"`      // in seconds
 	TokensListsAutoRefreshCheckInterval int `json:"TokensListsAutoRefreshCheckInterval"` // in seconds
+
+	// ERC-4337 bundler JSON-RPC endpoints per chain, used to send transactions from smart accounts
+	BundlerURLs map[uint64]security.SensitiveString `json:"BundlerURLs"`
+
+	// ERC-7821 batch executor contracts per chain, EOA accounts delegate to them with EIP-7702 to batch the
+	// transactions of a route
+	BatchExecutorAddresses map[uint64]string `json:"BatchExecutorAddresses"`
+
+	// DisableCollectiblesMetadataHosts keeps the on-chain collectibles metadata requests behind the IPFS gateways, the
+	// metadata hosted elsewhere isn't loaded as the requests reveal the user IP to its host
+	DisableCollectiblesMetadataHosts bool `json:"DisableCollectiblesMetadataHosts"`
+
+	// ProviderHedgeDelay is the delay after which the RPC calls still running are also sent to a second provider, 0
+	// disables hedging
+	ProviderHedgeDelay int `json:"ProviderHedgeDelay"` // in milliseconds
+
+	// VerifyStateReads serves the latest balances and nonces verified with eth_getProof against trusted state roots
+	// instead of trusting the providers
+	VerifyStateReads bool `json:"VerifyStateReads"`
+	// TrustedHeadersQuorum is the number of providers which must return the same header for its state root to be
+	// trusted, 0 uses the default quorum
+	TrustedHeadersQuorum int `json:"TrustedHeadersQuorum"`
+	// TrustedHeadersURLs are the nodes trusted for the headers of each chain instead of the quorum of the providers
+	TrustedHeadersURLs map[uint64]security.SensitiveString `json:"TrustedHeadersURLs"`
+	// VerifiedERC20BalanceSlots maps the token addresses of each chain to the storage slot of their balances mapping,
+	// in hex, the balanceOf calls of these tokens are verified too. The balances read through the balance checker
+	// contract stay unverified.
+	VerifiedERC20BalanceSlots map[uint64]map[string]string `json:"VerifiedERC20BalanceSlots"`
 }
 
 type MarketDataProxyConfig struct {
@@ -460,12 +488,23 @@ func (wc WalletConfig) MarshalJSON() ([]byte, error) {
 		EnableMercuryoProvider              bool `json:"EnableMercuryoProvider"`
 		TokensListsAutoRefreshInterval      int  `json:"TokensListsAutoRefreshInterval"`
 		TokensListsAutoRefreshCheckInterval int  `json:"TokensListsAutoRefreshCheckInterval"`
+		DisableCollectiblesMetadataHosts    bool `json:"DisableCollectiblesMetadataHosts"`
+		ProviderHedgeDelay                  int  `json:"ProviderHedgeDelay"`
+		VerifyStateReads                    bool `json:"VerifyStateReads"`
+		TrustedHeadersQuorum                int  `json:"TrustedHeadersQuorum"`
+
+		VerifiedERC20BalanceSlots map[uint64]map[string]string `json:"VerifiedERC20BalanceSlots"`
 	}{
 		Enabled:                             wc.Enabled,
 		EnableCelerBridge:                   wc.EnableCelerBridge,
 		EnableMercuryoProvider:              wc.EnableMercuryoProvider,
 		TokensListsAutoRefreshInterval:      wc.TokensListsAutoRefreshInterval,
 		TokensListsAutoRefreshCheckInterval: wc.TokensListsAutoRefreshCheckInterval,
+		DisableCollectiblesMetadataHosts:    wc.DisableCollectiblesMetadataHosts,
+		ProviderHedgeDelay:                  wc.ProviderHedgeDelay,
+		VerifyStateReads:                    wc.VerifyStateReads,
+		TrustedHeadersQuorum:                wc.TrustedHeadersQuorum,
+		VerifiedERC20BalanceSlots:           wc.VerifiedERC20BalanceSlots,
 	})
 }
 
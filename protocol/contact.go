@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
+	"maps"
 
 	accountJson "github.com/status-im/status-go/account/json"
 	"github.com/status-im/status-go/api/multiformat"
@@ -90,6 +91,10 @@ type Contact struct {
 	EnsName string `json:"name,omitempty"`
 	// EnsVerified whether we verified the name of the contact
 	ENSVerified bool `json:"ensVerified"`
+	// EnsAvatar is the avatar URL of the verified ENS name
+	EnsAvatar string `json:"ensAvatar,omitempty"`
+	// EnsLinks are the text records of the verified ENS name shown in the profile, e.g. url and com.twitter
+	EnsLinks map[string]string `json:"ensLinks,omitempty"`
 	// Generated username name of the contact
 	Alias string `json:"alias,omitempty"`
 	// Identicon generated from public key
@@ -163,6 +168,14 @@ func (c Contact) PublicKey() (*ecdsa.PublicKey, error) {
 	return crypto.UnmarshalPubkey(b)
 }
 
+// copy returns a copy of the contact which can be changed without affecting the readers of the original one
+func (c *Contact) copy() *Contact {
+	copied := *c
+	copied.EnsLinks = maps.Clone(c.EnsLinks)
+	copied.Images = maps.Clone(c.Images)
+	return &copied
+}
+
 func (c *Contact) Block(clock uint64) {
 	c.Blocked = true
 	c.DismissContactRequest(clock)
@@ -781,3 +781,19 @@ func TestProcessSyncContactRequestState(t *testing.T) {
 	require.Equal(t, ContactRequestStateSent, c.ContactRequestLocalState)
 	require.Equal(t, ContactRequestStateNone, c.ContactRequestRemoteState)
 }
+
+func TestContactCopy(t *testing.T) {
+	contact := &Contact{
+		ID:       "0x01",
+		EnsName:  "test.eth",
+		EnsLinks: map[string]string{"url": "https://test.eth.limo"},
+	}
+
+	copied := contact.copy()
+	copied.EnsName = "other.eth"
+	copied.EnsLinks["url"] = "https://other.eth.limo"
+
+	require.Equal(t, "test.eth", contact.EnsName)
+	require.Equal(t, "https://test.eth.limo", contact.EnsLinks["url"])
+	require.Equal(t, contact.ID, copied.ID)
+}
@@ -3,6 +3,7 @@ package ens
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 )
 
@@ -117,3 +118,16 @@ func (p *Persistence) GetVerifiedRecord(publicKey string) (*VerificationRecord,
 	return nil, err
 
 }
+
+// UpdateProfile stores the ENS avatar and links of a verified name
+func (p *Persistence) UpdateProfile(publicKey string, avatar string, links map[string]string) error {
+	if links == nil {
+		links = make(map[string]string)
+	}
+	encodedLinks, err := json.Marshal(links)
+	if err != nil {
+		return err
+	}
+	_, err = p.db.Exec(`UPDATE ens_verification_records SET avatar = ?, links = ? WHERE public_key = ?`, avatar, encodedLinks, publicKey)
+	return err
+}
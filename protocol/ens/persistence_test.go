@@ -87,3 +87,23 @@ func TestGetENSToBeVerified(t *testing.T) {
 	require.Equal(t, updatedName, toBeVerified[0].Name)
 	require.Equal(t, pk, toBeVerified[0].PublicKey)
 }
+
+func TestUpdateProfile(t *testing.T) {
+	db, err := helpers.SetupTestMemorySQLDB(appdatabase.DbInitializer{})
+	require.NoError(t, err)
+	err = sqlite.Migrate(db)
+	require.NoError(t, err)
+
+	persistence := NewPersistence(db)
+	_, err = persistence.AddRecord(VerificationRecord{Name: "test.eth", PublicKey: "1", Clock: 1})
+	require.NoError(t, err)
+
+	err = persistence.UpdateProfile("1", "https://example.com/avatar.png", map[string]string{"url": "https://example.com"})
+	require.NoError(t, err)
+
+	var avatar, links string
+	err = db.QueryRow(`SELECT avatar, links FROM ens_verification_records WHERE public_key = ?`, "1").Scan(&avatar, &links)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/avatar.png", avatar)
+	require.JSONEq(t, `{"url":"https://example.com"}`, links)
+}
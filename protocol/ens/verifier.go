@@ -12,6 +12,7 @@ import (
 	"github.com/status-im/status-go/protocol/common"
 
 	gethens "github.com/status-im/status-go/eth-node/bridge/geth/ens"
+	"github.com/status-im/status-go/services/ens/ensresolver/offchain"
 )
 
 type Verifier struct {
@@ -131,14 +132,14 @@ func (v *Verifier) publish(records []*VerificationRecord) {
 }
 
 func (v *Verifier) ReverseResolve(address gethcommon.Address) (string, error) {
-	verifier := gethens.NewVerifier(v.logger)
+	verifier := gethens.NewVerifierWithResolver(v.logger, offchain.ResolvePubKey)
 	return verifier.ReverseResolve(address, v.rpcEndpoint)
 }
 
 // Verify verifies that a registered ENS name matches the expected public key
 func (v *Verifier) verify(rpcEndpoint, contractAddress string) error {
 	v.logger.Debug("verifying ENS Names")
-	verifier := gethens.NewVerifier(v.logger)
+	verifier := gethens.NewVerifierWithResolver(v.logger, offchain.ResolvePubKey)
 
 	var ensDetails []enstypes.ENSDetails
 
@@ -64,10 +64,13 @@ import (
 	"github.com/status-im/status-go/server"
 	"github.com/status-im/status-go/services/browsers"
 	ensservice "github.com/status-im/status-go/services/ens"
+	"github.com/status-im/status-go/services/ens/ensresolver"
 	localnotifications "github.com/status-im/status-go/services/local-notifications"
 	mailserversDB "github.com/status-im/status-go/services/mailservers"
 	"github.com/status-im/status-go/services/wallet"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/community"
+	"github.com/status-im/status-go/services/wallet/spam"
 	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/signal"
 
@@ -95,6 +98,9 @@ const communityAdvertiseIntervalSecond int64 = 24 * 60 * 60
 // messageCacheIntervalMs is how long we should keep processed messages in the cache, in ms
 var messageCacheIntervalMs uint64 = 1000 * 60 * 60 * 48
 
+// ensProfileTimeout bounds the resolution of the ENS profile of a contact
+const ensProfileTimeout = time.Minute
+
 // Messenger is an entity managing chats and messages.
 // It acts as a bridge between the application and encryption
 // layers.
@@ -164,8 +170,10 @@ type Messenger struct {
 	connectionState       connection.State
 	wakuMetricsHandler    *wakumetrics.Client
 	contractMaker         *contracts.ContractMaker
+	ensResolver           *ensresolver.EnsResolver
 	verificationDatabase  *verification.Persistence
 	savedAddressesManager *wallet.SavedAddressesManager
+	tokenSpamDB           *spam.Database
 	walletAPI             *wallet.API
 
 	// TODO(samyoul) Determine if/how the remaining usage of this mutex can be removed
@@ -596,6 +604,7 @@ func NewMessenger(
 		},
 		logger:                           logger,
 		savedAddressesManager:            savedAddressesManager,
+		tokenSpamDB:                      spam.NewDB(c.walletDb),
 		retrievedMessagesIteratorFactory: NewDefaultMessagesIterator,
 	}
 
@@ -605,6 +614,7 @@ func NewMessenger(
 			return nil, err
 		}
 		messenger.contractMaker = contractMaker
+		messenger.ensResolver = ensresolver.NewEnsResolver(c.rpcClient, ensservice.URLUnfurlingEnabled(database))
 	}
 
 	messenger.mentionsManager = NewMentionManager(messenger)
@@ -1491,6 +1501,46 @@ func (m *Messenger) handleENSVerified(records []*ens.VerificationRecord) {
 	m.PublishMessengerResponse(&MessengerResponse{Contacts: contacts})
 }
 
+// FetchContactENSProfile resolves the avatar and links of the verified ENS name of the contact, it is meant to be
+// called when the profile is viewed. The profiles resolved less than a day ago are read from the database.
+func (m *Messenger) FetchContactENSProfile(ctx context.Context, publicKey string) (*Contact, error) {
+	contact, ok := m.allContacts.Load(publicKey)
+	if !ok {
+		return nil, ErrContactNotFound
+	}
+	if m.ensResolver == nil || !contact.ENSVerified || contact.EnsName == "" {
+		return contact, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ensProfileTimeout)
+	defer cancel()
+
+	now := m.getTimesource().GetCurrentTime() / 1000
+	profile, err := ensservice.CachedProfile(ctx, ensservice.NewEnsDatabase(m.database), m.ensResolver, walletCommon.EthereumMainnet, contact.EnsName, now)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make(map[string]string)
+	for key, value := range profile.TextRecords {
+		if key != "avatar" {
+			links[key] = value
+		}
+	}
+
+	err = ens.NewPersistence(m.database).UpdateProfile(publicKey, profile.Avatar, links)
+	if err != nil {
+		return nil, err
+	}
+
+	// the contacts are shared, the updated one replaces the stored one
+	contact = contact.copy()
+	contact.EnsAvatar = profile.Avatar
+	contact.EnsLinks = links
+	m.allContacts.Store(contact.ID, contact)
+	return contact, nil
+}
+
 func (m *Messenger) handleENSVerificationSubscription(c chan []*ens.VerificationRecord) {
 	go func() {
 		defer gocommon.LogOnPanic()
@@ -32,7 +32,6 @@ import (
 	"github.com/status-im/status-go/eth-node/crypto"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/images"
-	"github.com/status-im/status-go/multiaccounts/accounts"
 	"github.com/status-im/status-go/protocol/common"
 	"github.com/status-im/status-go/protocol/communities"
 	"github.com/status-im/status-go/protocol/communities/token"
@@ -1328,7 +1327,7 @@ func (m *Messenger) generateCommunityRequestsForSigning(memberPubKey string, com
 
 	msgsToSign := make([]personal.SignParams, 0)
 	for _, walletAccount := range walletAccounts {
-		if walletAccount.Chat || walletAccount.Type == accounts.AccountTypeWatch {
+		if walletAccount.Chat || walletAccount.IsKeypairless() {
 			continue
 		}
 
@@ -1375,7 +1374,7 @@ func (m *Messenger) SignData(signParams []personal.SignParams) ([]string, error)
 			return nil, err
 		}
 
-		if account.Chat || account.Type == accounts.AccountTypeWatch {
+		if account.Chat || account.IsKeypairless() {
 			return nil, errors.New(ErrForbiddenProfileOrWatchOnlyAccount)
 		}
 
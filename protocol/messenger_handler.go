@@ -37,6 +37,7 @@ import (
 	"github.com/status-im/status-go/protocol/requests"
 	v1protocol "github.com/status-im/status-go/protocol/v1"
 	"github.com/status-im/status-go/protocol/verification"
+	"github.com/status-im/status-go/services/wallet/spam"
 
 	messagingtypes "github.com/status-im/status-go/messaging/types"
 )
@@ -59,6 +60,7 @@ var (
 	ErrUnknownKeypairForWalletAccount         = errors.New("keypair is not known for the wallet account")
 	ErrInvalidCommunityID                     = errors.New("invalid community id")
 	ErrTryingToApplyOldTokenPreferences       = errors.New("trying to apply old token preferences")
+	ErrTryingToApplyOldTokenSpamOverrides     = errors.New("trying to apply old token spam overrides")
 	ErrTryingToApplyOldCollectiblePreferences = errors.New("trying to apply old collectible preferences")
 	ErrOutdatedCommunityRequestToJoin         = errors.New("outdated community request to join response")
 )
@@ -3396,7 +3398,7 @@ func (m *Messenger) handleSyncWatchOnlyAccount(message *protobuf.SyncAccount, fr
 		}
 	}
 
-	acc := mapSyncAccountToAccount(message, accountOperability, accounts.AccountTypeWatch)
+	acc := mapSyncAccountToAccount(message, accountOperability, syncedAccountType(message, accounts.AccountTypeWatch))
 
 	err = m.settings.SaveOrUpdateAccounts([]*accounts.Account{acc}, false)
 	if err != nil {
@@ -3458,6 +3460,33 @@ func (m *Messenger) handleSyncTokenPreferences(message *protobuf.SyncTokenPrefer
 	return tokenPreferences, nil
 }
 
+// handleSyncTokenSpamOverrides replaces the overrides with the synced ones, an empty list clears them
+func (m *Messenger) handleSyncTokenSpamOverrides(message *protobuf.SyncTokenSpamOverrides) ([]spam.Override, error) {
+	dbLastUpdate, err := m.tokenSpamDB.GetClockOfLastOverridesChange()
+	if err != nil {
+		return nil, err
+	}
+
+	if message.Clock < dbLastUpdate {
+		return nil, ErrTryingToApplyOldTokenSpamOverrides
+	}
+
+	overrides := make([]spam.Override, 0, len(message.Overrides))
+	for _, override := range message.Overrides {
+		overrides = append(overrides, spam.Override{
+			ChainID: override.ChainId,
+			Address: gethcommon.BytesToAddress(override.Address),
+			IsSpam:  override.IsSpam,
+		})
+	}
+
+	err = m.tokenSpamDB.UpdateOverrides(overrides, message.Clock)
+	if err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
 func (m *Messenger) handleSyncCollectiblePreferences(message *protobuf.SyncCollectiblePreferences) ([]walletsettings.CollectiblePreferences, error) {
 	if len(message.Preferences) == 0 {
 		return nil, nil
@@ -3620,7 +3649,12 @@ func (m *Messenger) handleSyncKeypair(message *protobuf.SyncKeypair, fromLocalPa
 		if err != nil {
 			return nil, err
 		}
-		acc := mapSyncAccountToAccount(sAcc, accountOperability, accounts.GetAccountTypeForKeypairType(kp.Type))
+		accType := syncedAccountType(sAcc, accounts.GetAccountTypeForKeypairType(kp.Type))
+		if accType == accounts.AccountTypeSmart {
+			// smart accounts are keypairless and synced on their own, they never share the keypair of their owner
+			continue
+		}
+		acc := mapSyncAccountToAccount(sAcc, accountOperability, accType)
 
 		kp.Accounts = append(kp.Accounts, acc)
 	}
@@ -3785,6 +3819,21 @@ func (m *Messenger) HandleSyncTokenPreferences(state *ReceivedMessageState, mess
 	return nil
 }
 
+func (m *Messenger) HandleSyncTokenSpamOverrides(state *ReceivedMessageState, message *protobuf.SyncTokenSpamOverrides, statusMessage *v1protocol.StatusMessage) error {
+	overrides, err := m.handleSyncTokenSpamOverrides(message)
+	if err != nil {
+		if err == ErrTryingToApplyOldTokenSpamOverrides {
+			m.logger.Warn("syncing token spam overrides issue", zap.Error(err))
+			return nil
+		}
+		return err
+	}
+
+	state.Response.TokenSpamOverrides = overrides
+
+	return nil
+}
+
 func (m *Messenger) HandleSyncCollectiblePreferences(state *ReceivedMessageState, message *protobuf.SyncCollectiblePreferences, statusMessage *v1protocol.StatusMessage) error {
 	collectiblePreferences, err := m.handleSyncCollectiblePreferences(message)
 	if err != nil {
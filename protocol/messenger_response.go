@@ -10,6 +10,7 @@ import (
 
 	"github.com/status-im/status-go/services/browsers"
 	"github.com/status-im/status-go/services/wallet"
+	"github.com/status-im/status-go/services/wallet/spam"
 
 	"github.com/status-im/status-go/appmetrics"
 	"github.com/status-im/status-go/images"
@@ -59,6 +60,7 @@ type MessengerResponse struct {
 	Keypairs                      []*accounts.Keypair
 	AccountsPositions             []*accounts.Account
 	TokenPreferences              []walletsettings.TokenPreferences
+	TokenSpamOverrides            []spam.Override
 	CollectiblePreferences        []walletsettings.CollectiblePreferences
 	DiscordCategories             []*discord.Category
 	DiscordChannels               []*discord.Channel
@@ -131,6 +133,7 @@ func (r *MessengerResponse) MarshalJSON() ([]byte, error) {
 		Keypairs                         []*accounts.Keypair                     `json:"keypairs,omitempty"`
 		AccountsPositions                []*accounts.Account                     `json:"accountsPositions,omitempty"`
 		TokenPreferences                 []walletsettings.TokenPreferences       `json:"tokenPreferences,omitempty"`
+		TokenSpamOverrides               []spam.Override                         `json:"tokenSpamOverrides,omitempty"`
 		CollectiblePreferences           []walletsettings.CollectiblePreferences `json:"collectiblePreferences,omitempty"`
 		DiscordCategories                []*discord.Category                     `json:"discordCategories,omitempty"`
 		DiscordChannels                  []*discord.Channel                      `json:"discordChannels,omitempty"`
@@ -158,6 +161,7 @@ func (r *MessengerResponse) MarshalJSON() ([]byte, error) {
 		Keypairs:                r.Keypairs,
 		AccountsPositions:       r.AccountsPositions,
 		TokenPreferences:        r.TokenPreferences,
+		TokenSpamOverrides:      r.TokenSpamOverrides,
 		CollectiblePreferences:  r.CollectiblePreferences,
 
 		Messages:                         r.Messages(),
@@ -324,6 +328,7 @@ func (r *MessengerResponse) IsEmpty() bool {
 		len(r.Keypairs)+
 		len(r.AccountsPositions)+
 		len(r.TokenPreferences)+
+		len(r.TokenSpamOverrides)+
 		len(r.CollectiblePreferences)+
 		len(r.notifications)+
 		len(r.statusUpdates)+
@@ -380,6 +385,7 @@ func (r *MessengerResponse) Merge(response *MessengerResponse) error {
 	r.Keypairs = append(r.Keypairs, response.Keypairs...)
 	r.AccountsPositions = append(r.AccountsPositions, response.AccountsPositions...)
 	r.TokenPreferences = append(r.TokenPreferences, response.TokenPreferences...)
+	r.TokenSpamOverrides = append(r.TokenSpamOverrides, response.TokenSpamOverrides...)
 	r.CollectiblePreferences = append(r.CollectiblePreferences, response.CollectiblePreferences...)
 
 	return nil
@@ -18,6 +18,7 @@ import (
 	"github.com/status-im/status-go/protocol/common"
 	"github.com/status-im/status-go/protocol/encryption/multidevice"
 	"github.com/status-im/status-go/protocol/protobuf"
+	"github.com/status-im/status-go/services/wallet/spam"
 )
 
 var (
@@ -204,7 +205,7 @@ func (m *Messenger) deleteKeystoreFileForAddress(address types.Address) error {
 		return nil
 	}
 
-	if acc.Type != accounts.AccountTypeWatch {
+	if !acc.IsKeypairless() {
 		kp, err := m.settings.GetKeypairByKeyUID(acc.KeyUID)
 		if err != nil {
 			return err
@@ -370,9 +371,27 @@ func (m *Messenger) prepareSyncAccountMessage(acc *accounts.Account) *protobuf.S
 		Position:              acc.Position,
 		ProdPreferredChainIDs: acc.ProdPreferredChainIDs,
 		TestPreferredChainIDs: acc.TestPreferredChainIDs,
+		Type:                  syncAccountType(acc),
 	}
 }
 
+// syncAccountType returns the type synced for the accounts whose type doesn't follow from their keypair
+func syncAccountType(acc *accounts.Account) string {
+	if acc.Type == accounts.AccountTypeSafe || acc.Type == accounts.AccountTypeSmart {
+		return string(acc.Type)
+	}
+	return ""
+}
+
+// syncedAccountType returns the type of a synced account, `defaultType` is used if no specific type was synced
+func syncedAccountType(message *protobuf.SyncAccount, defaultType accounts.AccountType) accounts.AccountType {
+	switch accounts.AccountType(message.Type) {
+	case accounts.AccountTypeSafe, accounts.AccountTypeSmart:
+		return accounts.AccountType(message.Type)
+	}
+	return defaultType
+}
+
 func (m *Messenger) getMyInstallationMetadata() (*multidevice.InstallationMetadata, error) {
 	installation, ok := m.allInstallations.Load(m.installationID)
 	if !ok {
@@ -524,6 +543,70 @@ func (m *Messenger) syncTokenPreferences(rawMessageHandler RawMessageHandler) er
 	return err
 }
 
+// UpdateTokenSpamOverrides replaces the spam verdicts set by the user and syncs them with the paired devices
+func (m *Messenger) UpdateTokenSpamOverrides(overrides []spam.Override) error {
+	clock, _ := m.getLastClockWithRelatedChat()
+
+	err := m.tokenSpamDB.UpdateOverrides(overrides, clock)
+	if err != nil {
+		return err
+	}
+
+	return m.syncTokenSpamOverrides(m.dispatchMessage)
+}
+
+func (m *Messenger) GetTokenSpamOverrides() ([]spam.Override, error) {
+	return m.tokenSpamDB.GetOverrides()
+}
+
+func (m *Messenger) syncTokenSpamOverrides(rawMessageHandler RawMessageHandler) error {
+	if !m.hasPairedDevices() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, chat := m.getLastClockWithRelatedChat()
+
+	lastUpdate, err := m.tokenSpamDB.GetClockOfLastOverridesChange()
+	if err != nil {
+		return err
+	}
+
+	overrides, err := m.GetTokenSpamOverrides()
+	if err != nil {
+		return err
+	}
+
+	message := &protobuf.SyncTokenSpamOverrides{
+		Clock: lastUpdate,
+	}
+
+	for _, override := range overrides {
+		message.Overrides = append(message.Overrides, &protobuf.TokenSpamOverride{
+			ChainId: override.ChainID,
+			Address: override.Address.Bytes(),
+			IsSpam:  override.IsSpam,
+		})
+	}
+
+	encodedMessage, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	rawMessage := common.RawMessage{
+		LocalChatID: chat.ID,
+		Payload:     encodedMessage,
+		MessageType: protobuf.ApplicationMetadataMessage_SYNC_TOKEN_SPAM_OVERRIDES,
+		ResendType:  common.ResendTypeDataSync,
+	}
+
+	_, err = rawMessageHandler(ctx, rawMessage)
+	return err
+}
+
 func (m *Messenger) UpdateCollectiblePreferences(preferences []walletsettings.CollectiblePreferences) error {
 	clock, _ := m.getLastClockWithRelatedChat()
 	testNetworksEnabled, err := m.settings.GetTestNetworksEnabled()
@@ -0,0 +1,2 @@
+ALTER TABLE ens_verification_records ADD COLUMN avatar TEXT NOT NULL DEFAULT '';
+ALTER TABLE ens_verification_records ADD COLUMN links TEXT NOT NULL DEFAULT '{}';
@@ -566,6 +566,8 @@ func (db sqlitePersistence) Contacts() ([]*Contact, error) {
 			c.address,
 			v.name,
 			v.verified,
+			v.avatar,
+			v.links,
 			c.alias,
 			c.display_name,
 			c.customization_color,
@@ -609,6 +611,8 @@ func (db sqlitePersistence) Contacts() ([]*Contact, error) {
 			imageType                 sql.NullString
 			ensName                   sql.NullString
 			ensVerified               sql.NullBool
+			ensAvatar                 sql.NullString
+			ensLinks                  []byte
 			blocked                   sql.NullBool
 			removed                   sql.NullBool
 			bio                       sql.NullString
@@ -624,6 +628,8 @@ func (db sqlitePersistence) Contacts() ([]*Contact, error) {
 			&contact.Address,
 			&ensName,
 			&ensVerified,
+			&ensAvatar,
+			&ensLinks,
 			&contact.Alias,
 			&displayName,
 			&customizationColor,
@@ -688,6 +694,17 @@ func (db sqlitePersistence) Contacts() ([]*Contact, error) {
 			contact.ENSVerified = ensVerified.Bool
 		}
 
+		if contact.ENSVerified {
+			if ensAvatar.Valid {
+				contact.EnsAvatar = ensAvatar.String
+			}
+			if len(ensLinks) != 0 {
+				if err := json.Unmarshal(ensLinks, &contact.EnsLinks); err != nil {
+					return nil, err
+				}
+			}
+		}
+
 		if blocked.Valid {
 			contact.Blocked = blocked.Bool
 		}
@@ -95,6 +95,14 @@ type WalletConfig struct {
 	TokensListsAutoRefreshCheckInterval int `json:"tokensListsAutoRefreshCheckInterval"` // in seconds
 	MarketDataFullDataRefreshInterval   int `json:"marketDataFullDataRefreshInterval"`   // in seconds
 	MarketDataPriceRefreshInterval      int `json:"marketDataPriceRefreshInterval"`      // in seconds
+	ProviderHedgeDelay                  int `json:"providerHedgeDelay"`                  // in milliseconds, 0 disables hedging
+	// DisableCollectiblesMetadataHosts loads the on-chain collectibles metadata only through the IPFS gateways
+	DisableCollectiblesMetadataHosts bool `json:"disableCollectiblesMetadataHosts"`
+	// VerifyStateReads verifies the latest balances and nonces with eth_getProof
+	VerifyStateReads     bool `json:"verifyStateReads"`
+	TrustedHeadersQuorum int  `json:"trustedHeadersQuorum"` // 0 uses the default quorum
+	// VerifiedERC20BalanceSlots are the hex storage slots of the balances mapping of the tokens of each chain
+	VerifiedERC20BalanceSlots map[uint64]map[string]string `json:"verifiedErc20BalanceSlots"`
 }
 type WalletSecretsConfig struct {
 	PoktToken            security.SensitiveString `json:"poktToken"`
@@ -127,6 +135,9 @@ type WalletSecretsConfig struct {
 	EthRpcProxyUrl      security.SensitiveString `json:"ethRpcProxyUrl"`
 	EthRpcProxyUser     security.SensitiveString `json:"ethRpcProxyUser"`
 	EthRpcProxyPassword security.SensitiveString `json:"ethRpcProxyPassword"`
+
+	// TrustedHeadersURLs are the nodes trusted for the headers of each chain by the state reads verification
+	TrustedHeadersURLs map[uint64]security.SensitiveString `json:"trustedHeadersUrls"`
 }
 
 func (c *CreateAccount) Validate(validation *CreateAccountValidation) error {
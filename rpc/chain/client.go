@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
+	"sort"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -21,12 +23,15 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/status-im/status-go/circuitbreaker"
+	gocommon "github.com/status-im/status-go/common"
 	"github.com/status-im/status-go/healthmanager"
 	"github.com/status-im/status-go/healthmanager/rpcstatus"
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/rpc/chain/ethclient"
 	"github.com/status-im/status-go/rpc/chain/rpclimiter"
 	"github.com/status-im/status-go/rpc/chain/tagger"
+	"github.com/status-im/status-go/rpc/multicall"
+	"github.com/status-im/status-go/rpc/verified"
 	"github.com/status-im/status-go/services/rpcstats"
 	"github.com/status-im/status-go/services/wallet/connection"
 )
@@ -80,9 +85,19 @@ type ClientWithFallback struct {
 
 	tag      string // tag for the limiter
 	groupTag string // tag for the limiter group
+
+	multicall *multicall.Aggregator // each copy batches its own calls, they are made with its tag
+
+	hedgeDelay time.Duration // delay after which a call is also sent to the next providers, 0 disables hedging
+
+	verifier *verified.Verifier // checks the balances, nonces and token balances against trusted state roots, nil disables it
 }
 
 func (c *ClientWithFallback) Copy() interface{} {
+	var aggregator *multicall.Aggregator
+	if c.multicall != nil {
+		aggregator = c.multicall.Copy()
+	}
 	return &ClientWithFallback{
 		ChainID:                c.ChainID,
 		ethClients:             c.ethClients,
@@ -94,6 +109,9 @@ func (c *ClientWithFallback) Copy() interface{} {
 		LastCheckedAt:          c.LastCheckedAt,
 		tag:                    c.tag,
 		groupTag:               c.groupTag,
+		multicall:              aggregator,
+		hedgeDelay:             c.hedgeDelay,
+		verifier:               c.verifier,
 	}
 }
 
@@ -135,6 +153,7 @@ func NewClient(ethClients []ethclient.RPSLimitedEthClientInterface, chainID uint
 		LastCheckedAt:          time.Now().Unix(),
 		circuitbreaker:         circuitbreaker.NewCircuitBreaker(cbConfig),
 		providersHealthManager: providersHealthManager,
+		multicall:              multicall.NewAggregator(),
 	}
 }
 
@@ -200,10 +219,58 @@ func (c *ClientWithFallback) makeCall(ctx context.Context, f MakeCallFunctor) (i
 
 	c.LastCheckedAt = time.Now().Unix()
 
+	ethClients := c.orderedEthClients()
+	if c.hedgeDelay > 0 && len(ethClients) > 1 && !unhedgedMethods[f.MethodName] {
+		return c.executeHedged(ctx, f, ethClients)
+	}
+	return c.execute(ctx, f, ethClients)
+}
+
+// SetHedgeDelay enables sending a call to the next providers as well when the best provider doesn't answer
+// within the delay, the first answer is used. Zero disables hedging.
+func (c *ClientWithFallback) SetHedgeDelay(delay time.Duration) {
+	c.hedgeDelay = delay
+}
+
+// SetVerifier serves BalanceAt, NonceAt and the balanceOf calls of the configured tokens verified with Merkle proofs
+// instead of trusting the providers. Only the latest state is verified, the historical reads are made as usual as
+// most providers don't keep the proofs of the old blocks. Nil disables the verification.
+func (c *ClientWithFallback) SetVerifier(verifier *verified.Verifier) {
+	c.verifier = verifier
+}
+
+// verifies tells if the read at the block is served by the verifier
+func (c *ClientWithFallback) verifies(blockNumber *big.Int) bool {
+	return c.verifier != nil && blockNumber == nil
+}
+
+// orderedEthClients returns the providers sorted by their score, the configured order is kept between the
+// providers with the same score
+func (c *ClientWithFallback) orderedEthClients() []ethclient.RPSLimitedEthClientInterface {
+	if c.providersHealthManager == nil || len(c.ethClients) < 2 {
+		return c.ethClients
+	}
+
+	scores := c.providersHealthManager.GetScores()
+	scoreOf := func(ethClient ethclient.RPSLimitedEthClientInterface) float64 {
+		if score, ok := scores[ethClient.GetProviderName()]; ok {
+			return score.Score
+		}
+		return rpcstatus.NeutralScore
+	}
+
+	ordered := slices.Clone(c.ethClients)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return scoreOf(ordered[i]) > scoreOf(ordered[j])
+	})
+	return ordered
+}
+
+func (c *ClientWithFallback) execute(ctx context.Context, f MakeCallFunctor, ethClients []ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
 	cmd := circuitbreaker.NewCommand(ctx, nil)
 	// Try making requests with each RPC provider.
 	// Cancel the command if we get a VM error or a context cancellation.
-	for _, ethProviderClient := range c.ethClients {
+	for _, ethProviderClient := range ethClients {
 		ethProviderClient := ethProviderClient
 		cmd.Add(circuitbreaker.NewFunctor(func() ([]interface{}, error) {
 			res, err := ethProviderClient.ExecuteWithRPSLimit(f.Func)
@@ -226,6 +293,54 @@ func (c *ClientWithFallback) makeCall(ctx context.Context, f MakeCallFunctor) (i
 	return result.Result()[0], nil
 }
 
+// unhedgedMethods are never sent to a second provider: a transaction must not be sent twice, the raw calls may send
+// transactions and decode their answer into the value of the caller, and a subscription would be made twice
+var unhedgedMethods = map[string]bool{
+	"eth_SendTransaction":     true,
+	"eth_CallContext":         true,
+	"eth_BatchCallContext":    true,
+	"eth_SubscribeFilterLogs": true,
+	"eth_SubscribeNewHead":    true,
+}
+
+type callResult struct {
+	res interface{}
+	err error
+}
+
+// executeHedged makes the call with all the providers and, if it didn't end within the hedge delay, with the
+// providers after the first one as well. The first successful answer is returned, the other call still ends
+// in the background so the latency of its providers is scored.
+func (c *ClientWithFallback) executeHedged(ctx context.Context, f MakeCallFunctor, ethClients []ethclient.RPSLimitedEthClientInterface) (interface{}, error) {
+	results := make(chan callResult, 2)
+	start := func(ethClients []ethclient.RPSLimitedEthClientInterface) {
+		go func() {
+			defer gocommon.LogOnPanic()
+			res, err := c.execute(ctx, f, ethClients)
+			results <- callResult{res: res, err: err}
+		}()
+	}
+
+	start(ethClients)
+	pending := 1
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			start(ethClients[1:])
+			pending++
+		case result := <-results:
+			pending--
+			// a VM error is the answer of the chain, the other providers would return it too
+			if result.err == nil || isVMError(result.err) || pending == 0 {
+				return result.res, result.err
+			}
+		}
+	}
+}
+
 type MakeCallFunctor struct {
 	MethodName string
 	Func       func(client ethclient.EthClientInterface) (interface{}, error)
@@ -389,6 +504,10 @@ func (c *ClientWithFallback) NetworkID() uint64 {
 }
 
 func (c *ClientWithFallback) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if c.verifies(blockNumber) {
+		return c.verifier.BalanceAt(ctx, c, c.ChainID, account, blockNumber)
+	}
+
 	res, err := c.makeCallAndToggleConnectionState(
 		ctx, MakeCallFunctor{
 			MethodName: "eth_BalanceAt",
@@ -437,6 +556,10 @@ func (c *ClientWithFallback) CodeAt(ctx context.Context, account common.Address,
 }
 
 func (c *ClientWithFallback) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if c.verifies(blockNumber) {
+		return c.verifier.NonceAt(ctx, c, c.ChainID, account, blockNumber)
+	}
+
 	res, err := c.makeCallAndToggleConnectionState(
 		ctx, MakeCallFunctor{
 			MethodName: "eth_NonceAt",
@@ -479,7 +602,7 @@ func (c *ClientWithFallback) FilterLogs(ctx context.Context, q ethereum.FilterQu
 }
 
 func (c *ClientWithFallback) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
-	res, err := c.makeCallAndToggleConnectionState(
+	return c.subscribe(
 		ctx, MakeCallFunctor{
 			MethodName: "eth_SubscribeFilterLogs",
 			Func: func(client ethclient.EthClientInterface) (interface{}, error) {
@@ -487,6 +610,37 @@ func (c *ClientWithFallback) SubscribeFilterLogs(ctx context.Context, q ethereum
 			},
 		},
 	)
+}
+
+// SubscribeNewHead subscribes to the headers of the new blocks, it fails with rpc.ErrNotificationsUnsupported
+// when none of the providers supports subscriptions
+func (c *ClientWithFallback) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return c.subscribe(
+		ctx, MakeCallFunctor{
+			MethodName: "eth_SubscribeNewHead",
+			Func: func(client ethclient.EthClientInterface) (interface{}, error) {
+				return client.SubscribeNewHead(ctx, ch)
+			},
+		},
+	)
+}
+
+// subscribe tries the providers in order until one of them accepts the subscription. The subscriptions use a
+// separate circuit as the HTTP providers don't support them at all, for the same reason the connection state
+// is toggled like for the other calls except when no provider supports subscriptions.
+func (c *ClientWithFallback) subscribe(ctx context.Context, f MakeCallFunctor) (ethereum.Subscription, error) {
+	rpcstats.CountCall(f.MethodName)
+
+	ordered := c.orderedEthClients()
+	ethClients := make([]ethclient.RPSLimitedEthClientInterface, len(ordered))
+	for i, client := range ordered {
+		ethClients[i] = client.CopyWithCircuitName(client.GetCircuitName() + "_Subscribe")
+	}
+
+	res, err := c.execute(ctx, f, ethClients)
+	if !errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		c.toggleConnectionState(err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -574,7 +728,29 @@ func (c *ClientWithFallback) PendingTransactionCount(ctx context.Context) (uint,
 	return res.(uint), nil
 }
 
+// CallContract batches the concurrent read-only calls with Multicall3
 func (c *ClientWithFallback) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if c.verifies(blockNumber) {
+		if data, handled, err := c.verifier.BalanceOfCall(ctx, c, c.ChainID, msg, blockNumber); handled {
+			return data, err
+		}
+	}
+	if c.multicall == nil {
+		return c.callContract(ctx, msg, blockNumber)
+	}
+	return c.multicall.CallContract(ctx, directCaller{c}, msg, blockNumber)
+}
+
+// directCaller sends the calls of the multicall aggregator without batching them again
+type directCaller struct {
+	*ClientWithFallback
+}
+
+func (d directCaller) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return d.callContract(ctx, msg, blockNumber)
+}
+
+func (c *ClientWithFallback) callContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
 	res, err := c.makeCallAndToggleConnectionState(
 		ctx, MakeCallFunctor{
 			MethodName: "eth_CallContract",
@@ -3,15 +3,21 @@ package chain
 import (
 	"context"
 	"errors"
+	"math/big"
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/status-im/status-go/healthmanager"
 	"github.com/status-im/status-go/rpc/chain/ethclient"
 	mock_ethclient "github.com/status-im/status-go/rpc/chain/ethclient/mock/client/ethclient"
+	"github.com/status-im/status-go/rpc/verified"
 
 	"github.com/stretchr/testify/require"
 
@@ -83,6 +89,83 @@ func TestClient_Fallbacks(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestClientWithFallback_SubscribeNewHead(t *testing.T) {
+	client, ethClients, cleanup := setupClientTest(t)
+	defer cleanup()
+	for _, ethClient := range ethClients {
+		ethClient.EXPECT().CopyWithCircuitName(gomock.Any()).Return(ethClient).AnyTimes()
+	}
+
+	ctx := context.Background()
+	ch := make(chan *types.Header)
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+	defer sub.Unsubscribe()
+
+	// The first provider doesn't support subscriptions, the second one is subscribed
+	ethClients[0].EXPECT().SubscribeNewHead(ctx, gomock.Any()).Return(nil, gethrpc.ErrNotificationsUnsupported).Times(1)
+	ethClients[1].EXPECT().SubscribeNewHead(ctx, gomock.Any()).Return(sub, nil).Times(1)
+	ethClients[2].EXPECT().SubscribeNewHead(ctx, gomock.Any()).Times(0)
+	res, err := client.SubscribeNewHead(ctx, ch)
+	require.NoError(t, err)
+	require.Equal(t, sub, res)
+
+	// No provider supports subscriptions, the chain is still connected for the other calls
+	for _, ethClient := range ethClients {
+		ethClient.EXPECT().SubscribeNewHead(ctx, gomock.Any()).Return(nil, gethrpc.ErrNotificationsUnsupported).Times(1)
+	}
+	_, err = client.SubscribeNewHead(ctx, ch)
+	require.ErrorIs(t, err, gethrpc.ErrNotificationsUnsupported)
+	require.True(t, client.IsConnected())
+
+	// The providers can't be reached, the chain is disconnected until a subscription succeeds
+	for _, ethClient := range ethClients {
+		ethClient.EXPECT().SubscribeNewHead(ctx, gomock.Any()).Return(nil, errors.New("connection refused")).Times(1)
+	}
+	_, err = client.SubscribeNewHead(ctx, ch)
+	require.Error(t, err)
+	require.False(t, client.IsConnected())
+
+	for _, ethClient := range ethClients {
+		ethClient.EXPECT().SubscribeNewHead(ctx, gomock.Any()).Return(sub, nil).MaxTimes(1)
+	}
+	_, err = client.SubscribeNewHead(ctx, ch)
+	require.NoError(t, err)
+	require.True(t, client.IsConnected())
+}
+
+func TestClientWithFallback_Verifier(t *testing.T) {
+	client, ethClients, cleanup := setupClientTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	account := common.HexToAddress("0x1234")
+	client.SetVerifier(verified.NewVerifier(verified.Config{Headers: verified.NewStaticHeaders()}))
+
+	// The reads are not sent to the providers without a trusted header to verify them
+	ethClients[0].EXPECT().BalanceAt(ctx, account, nil).Times(0)
+	ethClients[0].EXPECT().NonceAt(ctx, account, nil).Times(0)
+	_, err := client.BalanceAt(ctx, account, nil)
+	require.ErrorIs(t, err, verified.ErrNoTrustedHeader)
+	_, err = client.Copy().(*ClientWithFallback).NonceAt(ctx, account, nil)
+	require.ErrorIs(t, err, verified.ErrNoTrustedHeader)
+
+	// The historical reads are not verified
+	blockNumber := big.NewInt(10)
+	ethClients[0].EXPECT().BalanceAt(ctx, account, blockNumber).Return(big.NewInt(2), nil).Times(1)
+	balance, err := client.BalanceAt(ctx, account, blockNumber)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), balance.Int64())
+
+	client.SetVerifier(nil)
+	ethClients[0].EXPECT().BalanceAt(ctx, account, nil).Return(big.NewInt(1), nil).Times(1)
+	balance, err = client.BalanceAt(ctx, account, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), balance.Int64())
+}
+
 func TestClientWithFallback_Copy(t *testing.T) {
 	client, _, cleanup := setupClientTest(t)
 	defer cleanup()
@@ -136,3 +219,77 @@ func getFuncPtr(f func(uint64, string)) uintptr {
 	}
 	return reflect.ValueOf(f).Pointer()
 }
+
+func TestClient_ProvidersOrderedByScore(t *testing.T) {
+	client, ethClients, cleanup := setupClientTest(t)
+	defer cleanup()
+	client.providersHealthManager = healthmanager.NewProvidersHealthManager(0)
+
+	ctx := context.Background()
+	hash := common.HexToHash("0x1234")
+	block := &types.Block{}
+
+	// The first provider fails and is scored lower than the second one
+	ethClients[0].EXPECT().BlockByHash(ctx, hash).Return(nil, errors.New("some error")).Times(1)
+	ethClients[1].EXPECT().BlockByHash(ctx, hash).Return(block, nil).Times(1)
+	_, err := client.BlockByHash(ctx, hash)
+	require.NoError(t, err)
+
+	// The second provider is called first, the provider without calls yet comes before the failing one
+	ethClients[1].EXPECT().BlockByHash(ctx, hash).Return(nil, errors.New("some error")).Times(1)
+	ethClients[2].EXPECT().BlockByHash(ctx, hash).Return(block, nil).Times(1)
+	ethClients[0].EXPECT().BlockByHash(ctx, hash).Times(0)
+	_, err = client.BlockByHash(ctx, hash)
+	require.NoError(t, err)
+
+	scores := client.providersHealthManager.GetScores()
+	require.Len(t, scores, 3)
+	require.Greater(t, scores["test2_provider"].Score, scores["test1_provider"].Score)
+	require.Greater(t, scores["test1_provider"].Score, scores["test0_provider"].Score)
+}
+
+func TestClient_HedgedCall(t *testing.T) {
+	client, ethClients, cleanup := setupClientTest(t)
+	defer cleanup()
+	client.SetHedgeDelay(20 * time.Millisecond)
+
+	ctx := context.Background()
+	hash := common.HexToHash("0x1234")
+	slowBlock := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+	fastBlock := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(2)})
+
+	slowCallDone := make(chan struct{})
+	ethClients[0].EXPECT().BlockByHash(ctx, hash).DoAndReturn(func(ctx context.Context, hash common.Hash) (*types.Block, error) {
+		defer close(slowCallDone)
+		time.Sleep(300 * time.Millisecond)
+		return slowBlock, nil
+	}).Times(1)
+	ethClients[1].EXPECT().BlockByHash(ctx, hash).Return(fastBlock, nil).Times(1)
+	ethClients[2].EXPECT().BlockByHash(ctx, hash).Times(0)
+
+	start := time.Now()
+	block, err := client.BlockByHash(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, fastBlock.Number(), block.Number())
+	require.Less(t, time.Since(start), 300*time.Millisecond)
+	<-slowCallDone
+}
+
+func TestClient_SendTransactionNotHedged(t *testing.T) {
+	client, ethClients, cleanup := setupClientTest(t)
+	defer cleanup()
+	client.SetHedgeDelay(time.Millisecond)
+
+	ctx := context.Background()
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+
+	// a slow provider isn't doubled with another one, the transaction is sent once
+	ethClients[0].EXPECT().SendTransaction(ctx, tx).DoAndReturn(func(ctx context.Context, tx *types.Transaction) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}).Times(1)
+	ethClients[1].EXPECT().SendTransaction(ctx, tx).Times(0)
+	ethClients[2].EXPECT().SendTransaction(ctx, tx).Times(0)
+
+	require.NoError(t, client.SendTransaction(ctx, tx))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/status-im/status-go/rpc/chain/ethclient"
 	"github.com/status-im/status-go/rpc/chain/rpclimiter"
 	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/rpc/verified"
 	"github.com/status-im/status-go/services/rpcstats"
 	"github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/walletevent"
@@ -113,6 +114,10 @@ type Client struct {
 	logger     *zap.Logger
 
 	walletNotifier func(chainID uint64, message string)
+
+	providerHedgeDelay time.Duration
+
+	verifier *verified.Verifier
 }
 
 // Is initialized in a build-tag-dependent module
@@ -128,6 +133,12 @@ type ClientConfig struct {
 	WalletFeed      *event.Feed
 	SettingsFeed    *event.Feed
 	NetworksFeed    *event.Feed
+	// ProviderHedgeDelay enables sending the calls still running after the delay to a second provider, the first
+	// answer is used. Zero disables hedging.
+	ProviderHedgeDelay time.Duration
+	// Verification serves the balances, nonces and token balances of the chain clients verified with eth_getProof
+	// against trusted state roots. Nil trusts the providers.
+	Verification *verified.Config
 }
 
 // NewClient initializes Client
@@ -159,11 +170,16 @@ func NewClient(config ClientConfig) (*Client, error) {
 		walletFeed:         config.WalletFeed,
 		settingsFeed:       config.SettingsFeed,
 		networksFeed:       config.NetworksFeed,
+		providerHedgeDelay: config.ProviderHedgeDelay,
 	}
 
 	c.UpstreamChainID = config.UpstreamChainID
 	c.router = newRouter(true)
 
+	if config.Verification != nil {
+		c.verifier = c.newVerifier(*config.Verification)
+	}
+
 	if verifProxyInitFn != nil {
 		verifProxyInitFn(&c)
 	}
@@ -267,6 +283,8 @@ func (c *Client) getClientUsingCache(chainID uint64) (chain.ClientInterface, err
 
 	client := chain.NewClient(ethClients, chainID, phm)
 	client.SetWalletNotifier(c.walletNotifier)
+	client.SetHedgeDelay(c.providerHedgeDelay)
+	client.SetVerifier(c.verifier)
 	c.rpcClients[chainID] = client
 	return client, nil
 }
@@ -0,0 +1,387 @@
+package multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/contracts/multicall3"
+	"github.com/status-im/status-go/logutils"
+)
+
+const (
+	// batchWindow is how long the first call of a batch waits for the concurrent calls to join it
+	batchWindow = 10 * time.Millisecond
+	// maxBatchSize keeps the aggregated calls below the gas cap of the nodes, bigger batches are split anyway
+	maxBatchSize = 100
+	// batchTimeout bounds the aggregated call, it doesn't depend on the contexts of the batched calls
+	batchTimeout = 30 * time.Second
+
+	latestBlockKey = "latest"
+)
+
+var (
+	errUnexpectedResults = errors.New("unexpected number of aggregate3 results")
+	errInvalidOutput     = errors.New("invalid aggregate3 output")
+)
+
+// executionErrors are the errors of the nodes caused by the execution of the aggregated call, e.g. too many calls
+// for the gas cap, a smaller batch may succeed
+var executionErrors = []string{
+	"execution reverted",
+	"execution aborted",
+	"out of gas",
+	"gas required exceeds",
+	"exceeds block gas limit",
+	"gas cap",
+	"gas limit",
+}
+
+// executionErrorCode is the JSON-RPC error code of the execution errors
+const executionErrorCode = 3
+
+// Caller makes the calls of the batches, the aggregated ones and the ones sent directly
+type Caller interface {
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+type response struct {
+	data []byte
+	err  error
+}
+
+type request struct {
+	ctx    context.Context
+	caller Caller
+	msg    ethereum.CallMsg
+	result chan response
+}
+
+type batch struct {
+	blockNumber *big.Int
+	requests    []*request
+}
+
+// deployment is the Multicall3 deployment of a chain, shared by the copies of an aggregator
+type deployment struct {
+	mutex    sync.Mutex
+	deployed *bool
+}
+
+// Aggregator coalesces the concurrent read-only contract calls of a chain made at the same block into Multicall3
+// aggregate3 calls. A call made while no other call is in flight is sent directly without waiting for a batch. An
+// aggregated call failing in the execution is split and retried, other errors are returned to all its calls. The
+// calls failing inside of it are sent directly so their own error is returned, and all the calls are sent directly on
+// chains without Multicall3.
+type Aggregator struct {
+	window       time.Duration
+	maxBatchSize int
+	abi          abi.ABI
+
+	inFlight atomic.Int64
+
+	pendingMutex sync.Mutex
+	pending      map[string]*batch
+
+	deployment *deployment
+}
+
+func NewAggregator() *Aggregator {
+	return newAggregator(batchWindow, maxBatchSize)
+}
+
+func newAggregator(window time.Duration, maxBatchSize int) *Aggregator {
+	parsed, err := abi.JSON(strings.NewReader(multicall3.Multicall3MetaData.ABI))
+	if err != nil {
+		// the ABI is generated, it can't be invalid
+		panic(err)
+	}
+	return &Aggregator{
+		window:       window,
+		maxBatchSize: maxBatchSize,
+		abi:          parsed,
+		pending:      make(map[string]*batch),
+		deployment:   &deployment{},
+	}
+}
+
+// Copy returns an aggregator batching its own calls, so the calls of differently tagged clients are not made with
+// each other's caller. The Multicall3 deployment is looked up once for all the copies.
+func (a *Aggregator) Copy() *Aggregator {
+	return &Aggregator{
+		window:       a.window,
+		maxBatchSize: a.maxBatchSize,
+		abi:          a.abi,
+		pending:      make(map[string]*batch),
+		deployment:   a.deployment,
+	}
+}
+
+// CallContract returns the result of the call, batched with the concurrent calls at the same block when possible.
+// The calls of a batch are made with the caller of its first call.
+func (a *Aggregator) CallContract(ctx context.Context, caller Caller, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	concurrent := a.inFlight.Add(1) > 1
+	defer a.inFlight.Add(-1)
+
+	if !concurrent || !aggregatable(msg) || a.isDeployed() == notDeployed {
+		return caller.CallContract(ctx, msg, blockNumber)
+	}
+
+	req := &request{
+		ctx:    ctx,
+		caller: caller,
+		msg:    msg,
+		result: make(chan response, 1),
+	}
+	a.enqueue(req, blockNumber)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-req.result:
+		return res.data, res.err
+	}
+}
+
+// aggregatable returns true for the calls whose result doesn't change when made through Multicall3, the calls
+// depending on the sender, the value or the gas are sent directly
+func aggregatable(msg ethereum.CallMsg) bool {
+	return msg.To != nil &&
+		len(msg.Data) > 0 &&
+		msg.From == (common.Address{}) &&
+		(msg.Value == nil || msg.Value.Sign() == 0) &&
+		msg.Gas == 0 &&
+		msg.GasPrice == nil &&
+		msg.GasFeeCap == nil &&
+		msg.GasTipCap == nil &&
+		len(msg.AccessList) == 0
+}
+
+func blockKey(blockNumber *big.Int) string {
+	if blockNumber == nil {
+		return latestBlockKey
+	}
+	return blockNumber.String()
+}
+
+func (a *Aggregator) enqueue(req *request, blockNumber *big.Int) {
+	key := blockKey(blockNumber)
+
+	a.pendingMutex.Lock()
+	b, ok := a.pending[key]
+	if !ok {
+		b = &batch{blockNumber: blockNumber}
+		a.pending[key] = b
+		time.AfterFunc(a.window, func() {
+			defer gocommon.LogOnPanic()
+			a.flush(key, b)
+		})
+	}
+	b.requests = append(b.requests, req)
+	full := len(b.requests) >= a.maxBatchSize
+	if full {
+		delete(a.pending, key)
+	}
+	a.pendingMutex.Unlock()
+
+	if full {
+		go func() {
+			defer gocommon.LogOnPanic()
+			a.execute(b)
+		}()
+	}
+}
+
+// flush executes the batch once its window is over, unless it was already executed when it got full
+func (a *Aggregator) flush(key string, b *batch) {
+	a.pendingMutex.Lock()
+	if a.pending[key] != b {
+		a.pendingMutex.Unlock()
+		return
+	}
+	delete(a.pending, key)
+	a.pendingMutex.Unlock()
+
+	a.execute(b)
+}
+
+func (a *Aggregator) execute(b *batch) {
+	if len(b.requests) == 1 {
+		a.direct(b.requests[0], b.blockNumber)
+		return
+	}
+
+	if a.checkDeployed(b.requests[0].caller) != deployed {
+		a.directAll(b.requests, b.blockNumber)
+		return
+	}
+
+	a.aggregate(b.requests, b.blockNumber)
+}
+
+// aggregate makes the calls with a single aggregate3 call, the halves of the calls are retried on failure
+func (a *Aggregator) aggregate(requests []*request, blockNumber *big.Int) {
+	if len(requests) == 1 {
+		a.direct(requests[0], blockNumber)
+		return
+	}
+
+	results, err := a.aggregate3(requests, blockNumber)
+	if err != nil && !splittable(err) {
+		// e.g. a transport error or a rate limit, the calls would fail the same way on their own
+		for _, req := range requests {
+			req.result <- response{err: err}
+		}
+		return
+	}
+	if err != nil {
+		logutils.ZapLogger().Debug("multicall aggregate3 failed, splitting the batch",
+			zap.Int("calls", len(requests)), zap.Error(err))
+		middle := len(requests) / 2
+		a.aggregate(requests[:middle], blockNumber)
+		a.aggregate(requests[middle:], blockNumber)
+		return
+	}
+
+	failed := make([]*request, 0)
+	for i, result := range results {
+		if !result.Success {
+			failed = append(failed, requests[i])
+			continue
+		}
+		requests[i].result <- response{data: result.ReturnData}
+	}
+	// the failed calls are repeated to return the error of the node instead of the revert data
+	a.directAll(failed, blockNumber)
+}
+
+func (a *Aggregator) aggregate3(requests []*request, blockNumber *big.Int) ([]multicall3.Multicall3Result, error) {
+	calls := make([]multicall3.Multicall3Call3, 0, len(requests))
+	for _, req := range requests {
+		calls = append(calls, multicall3.Multicall3Call3{
+			Target:       *req.msg.To,
+			AllowFailure: true,
+			CallData:     req.msg.Data,
+		})
+	}
+	data, err := a.abi.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+
+	to := multicall3.ContractAddress
+	output, err := requests[0].caller.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	unpacked, err := a.abi.Unpack("aggregate3", output)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidOutput, err)
+	}
+	if len(unpacked) != 1 {
+		return nil, errUnexpectedResults
+	}
+	results := *abi.ConvertType(unpacked[0], new([]multicall3.Multicall3Result)).(*[]multicall3.Multicall3Result)
+	if len(results) != len(requests) {
+		return nil, errUnexpectedResults
+	}
+	return results, nil
+}
+
+// splittable returns true for the errors of an aggregated call which may not happen with fewer calls
+func splittable(err error) bool {
+	if errors.Is(err, errUnexpectedResults) || errors.Is(err, errInvalidOutput) {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == executionErrorCode {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, executionError := range executionErrors {
+		if strings.Contains(message, executionError) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Aggregator) direct(req *request, blockNumber *big.Int) {
+	data, err := req.caller.CallContract(req.ctx, req.msg, blockNumber)
+	req.result <- response{data: data, err: err}
+}
+
+func (a *Aggregator) directAll(requests []*request, blockNumber *big.Int) {
+	wg := sync.WaitGroup{}
+	for _, req := range requests {
+		wg.Add(1)
+		go func(req *request) {
+			defer gocommon.LogOnPanic()
+			defer wg.Done()
+			a.direct(req, blockNumber)
+		}(req)
+	}
+	wg.Wait()
+}
+
+type deploymentStatus int
+
+const (
+	deploymentUnknown deploymentStatus = iota
+	deployed
+	notDeployed
+)
+
+func (a *Aggregator) isDeployed() deploymentStatus {
+	a.deployment.mutex.Lock()
+	defer a.deployment.mutex.Unlock()
+	if a.deployment.deployed == nil {
+		return deploymentUnknown
+	}
+	if *a.deployment.deployed {
+		return deployed
+	}
+	return notDeployed
+}
+
+// checkDeployed looks up the Multicall3 code once per chain, a failed lookup is repeated with the next batch
+func (a *Aggregator) checkDeployed(caller Caller) deploymentStatus {
+	if d := a.isDeployed(); d != deploymentUnknown {
+		return d
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+	code, err := caller.CodeAt(ctx, multicall3.ContractAddress, nil)
+	if err != nil {
+		logutils.ZapLogger().Debug("cannot check the multicall deployment", zap.Error(err))
+		return deploymentUnknown
+	}
+
+	isDeployed := len(code) > 0
+	a.deployment.mutex.Lock()
+	a.deployment.deployed = &isDeployed
+	a.deployment.mutex.Unlock()
+	if isDeployed {
+		return deployed
+	}
+	return notDeployed
+}
@@ -0,0 +1,299 @@
+package multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/contracts/multicall3"
+)
+
+var errReverted = errors.New("execution reverted")
+
+var holdData = []byte("hold")
+
+// testCaller answers the calls with their target followed by their data, the calls with the data "revert" revert and
+// aggregate3 fails with more than maxAggregated calls, or with aggregateErr when set. The calls with the data "hold"
+// are not counted and answered once hold is closed.
+type testCaller struct {
+	t             *testing.T
+	aggregator    *Aggregator
+	deployed      bool
+	maxAggregated int
+	aggregateErr  error
+	hold          chan struct{}
+
+	mutex          sync.Mutex
+	directCalls    int
+	aggregateSizes []int
+}
+
+func answer(target common.Address, data []byte) ([]byte, error) {
+	if string(data) == "revert" {
+		return nil, errReverted
+	}
+	return append(target.Bytes(), data...), nil
+}
+
+func (c *testCaller) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if c.deployed && account == multicall3.ContractAddress {
+		return []byte{1}, nil
+	}
+	return nil, nil
+}
+
+func (c *testCaller) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if string(msg.Data) == string(holdData) {
+		<-c.hold
+		return nil, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if *msg.To != multicall3.ContractAddress {
+		c.directCalls++
+		return answer(*msg.To, msg.Data)
+	}
+
+	method, err := c.aggregator.abi.MethodById(msg.Data[:4])
+	require.NoError(c.t, err)
+	args, err := method.Inputs.Unpack(msg.Data[4:])
+	require.NoError(c.t, err)
+	calls := *abi.ConvertType(args[0], new([]multicall3.Multicall3Call3)).(*[]multicall3.Multicall3Call3)
+
+	c.aggregateSizes = append(c.aggregateSizes, len(calls))
+	if c.aggregateErr != nil {
+		return nil, c.aggregateErr
+	}
+	if len(calls) > c.maxAggregated {
+		return nil, errors.New("out of gas")
+	}
+
+	results := make([]multicall3.Multicall3Result, 0, len(calls))
+	for _, call := range calls {
+		data, err := answer(call.Target, call.CallData)
+		results = append(results, multicall3.Multicall3Result{Success: err == nil, ReturnData: data})
+	}
+	return method.Outputs.Pack(results)
+}
+
+func newTestCaller(t *testing.T, deployed bool, maxAggregated int) (*Aggregator, *testCaller) {
+	aggregator := newAggregator(50*time.Millisecond, 100)
+	return aggregator, &testCaller{
+		t:             t,
+		aggregator:    aggregator,
+		deployed:      deployed,
+		maxAggregated: maxAggregated,
+	}
+}
+
+// callConcurrently makes the calls at the same time and returns their results in order
+func callConcurrently(aggregator *Aggregator, caller *testCaller, calls []ethereum.CallMsg) ([][]byte, []error) {
+	return callConcurrentlyAt(aggregator, caller, calls, func(int) *big.Int { return nil })
+}
+
+// callConcurrentlyAt makes the calls at the same time while another call is in flight, so none of them is sent
+// directly for lack of concurrency
+func callConcurrentlyAt(aggregator *Aggregator, caller *testCaller, calls []ethereum.CallMsg, blockNumber func(i int) *big.Int) ([][]byte, []error) {
+	caller.hold = make(chan struct{})
+	held := make(chan struct{})
+	go func() {
+		defer close(held)
+		to := common.HexToAddress("0xffff")
+		_, _ = aggregator.CallContract(context.Background(), caller, ethereum.CallMsg{To: &to, Data: holdData}, nil)
+	}()
+	waitInFlight(aggregator, 1)
+
+	results := make([][]byte, len(calls))
+	errs := make([]error, len(calls))
+	finished := atomic.Int64{}
+	wg := sync.WaitGroup{}
+	for i, call := range calls {
+		wg.Add(1)
+		go func(i int, call ethereum.CallMsg) {
+			defer wg.Done()
+			results[i], errs[i] = aggregator.CallContract(context.Background(), caller, call, blockNumber(i))
+			finished.Add(1)
+		}(i, call)
+	}
+	// the calls sent directly may be finished already
+	for aggregator.inFlight.Load()-1+finished.Load() < int64(len(calls)) {
+		time.Sleep(time.Millisecond)
+	}
+	close(caller.hold)
+	<-held
+
+	wg.Wait()
+	return results, errs
+}
+
+func waitInFlight(aggregator *Aggregator, count int64) {
+	for aggregator.inFlight.Load() < count {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func testCalls(count int) []ethereum.CallMsg {
+	calls := make([]ethereum.CallMsg, 0, count)
+	for i := 0; i < count; i++ {
+		to := common.BigToAddress(big.NewInt(int64(i + 1)))
+		calls = append(calls, ethereum.CallMsg{To: &to, Data: []byte(fmt.Sprintf("call%d", i))})
+	}
+	return calls
+}
+
+func TestAggregate(t *testing.T) {
+	aggregator, caller := newTestCaller(t, true, 100)
+
+	calls := testCalls(10)
+	calls[3].Data = []byte("revert")
+	results, errs := callConcurrently(aggregator, caller, calls)
+
+	for i, call := range calls {
+		if i == 3 {
+			require.ErrorIs(t, errs[i], errReverted)
+			continue
+		}
+		require.NoError(t, errs[i])
+		require.Equal(t, append(call.To.Bytes(), call.Data...), results[i])
+	}
+	require.Equal(t, []int{10}, caller.aggregateSizes)
+	// only the reverted call is repeated
+	require.Equal(t, 1, caller.directCalls)
+}
+
+func TestAggregateSplitsFailedBatches(t *testing.T) {
+	aggregator, caller := newTestCaller(t, true, 3)
+
+	calls := testCalls(8)
+	results, errs := callConcurrently(aggregator, caller, calls)
+
+	for i, call := range calls {
+		require.NoError(t, errs[i])
+		require.Equal(t, append(call.To.Bytes(), call.Data...), results[i])
+	}
+	require.Equal(t, []int{8, 4, 2, 2, 4, 2, 2}, caller.aggregateSizes)
+	require.Equal(t, 0, caller.directCalls)
+}
+
+func TestAggregateWithoutDeployment(t *testing.T) {
+	aggregator, caller := newTestCaller(t, false, 100)
+
+	calls := testCalls(5)
+	results, errs := callConcurrently(aggregator, caller, calls)
+	for i, call := range calls {
+		require.NoError(t, errs[i])
+		require.Equal(t, append(call.To.Bytes(), call.Data...), results[i])
+	}
+	require.Empty(t, caller.aggregateSizes)
+	require.Equal(t, 5, caller.directCalls)
+
+	// the deployment is checked once, the next calls are sent directly without waiting for a batch
+	wg := sync.WaitGroup{}
+	for _, call := range calls {
+		wg.Add(1)
+		go func(call ethereum.CallMsg) {
+			defer wg.Done()
+			_, err := aggregator.CallContract(context.Background(), caller, call, nil)
+			require.NoError(t, err)
+		}(call)
+	}
+	wg.Wait()
+	require.Equal(t, 10, caller.directCalls)
+}
+
+func TestCallsNotAggregated(t *testing.T) {
+	aggregator, caller := newTestCaller(t, true, 100)
+
+	calls := testCalls(3)
+	calls[0].From = common.HexToAddress("0x1234")
+	calls[1].Value = big.NewInt(1)
+	calls[2].Gas = 100000
+	_, errs := callConcurrently(aggregator, caller, calls)
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Empty(t, caller.aggregateSizes)
+	require.Equal(t, 3, caller.directCalls)
+}
+
+func TestCallsAtDifferentBlocks(t *testing.T) {
+	aggregator, caller := newTestCaller(t, true, 100)
+
+	calls := testCalls(4)
+	_, errs := callConcurrentlyAt(aggregator, caller, calls, func(i int) *big.Int { return big.NewInt(int64(i % 2)) })
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, []int{2, 2}, caller.aggregateSizes)
+}
+
+func TestCallCanceled(t *testing.T) {
+	aggregator, caller := newTestCaller(t, true, 100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := aggregator.CallContract(ctx, caller, testCalls(1)[0], nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCallWithoutConcurrencyNotDelayed(t *testing.T) {
+	aggregator, caller := newTestCaller(t, true, 100)
+
+	start := time.Now()
+	call := testCalls(1)[0]
+	result, err := aggregator.CallContract(context.Background(), caller, call, nil)
+	require.NoError(t, err)
+	require.Equal(t, append(call.To.Bytes(), call.Data...), result)
+	require.Less(t, time.Since(start), aggregator.window)
+	require.Empty(t, caller.aggregateSizes)
+	require.Equal(t, 1, caller.directCalls)
+}
+
+func TestAggregateReturnsTransportErrors(t *testing.T) {
+	aggregator, caller := newTestCaller(t, true, 100)
+	caller.aggregateErr = errors.New("429 Too Many Requests")
+
+	_, errs := callConcurrently(aggregator, caller, testCalls(6))
+	for _, err := range errs {
+		require.ErrorIs(t, err, caller.aggregateErr)
+	}
+	// the batch is neither split nor repeated call by call
+	require.Equal(t, []int{6}, caller.aggregateSizes)
+	require.Equal(t, 0, caller.directCalls)
+}
+
+func TestAggregatorCopy(t *testing.T) {
+	aggregator, caller := newTestCaller(t, true, 100)
+	_, errs := callConcurrently(aggregator, caller, testCalls(2))
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	// the copy has its own batches and knows the deployment
+	aggregatorCopy := aggregator.Copy()
+	require.NotSame(t, aggregator, aggregatorCopy)
+	require.Equal(t, deployed, aggregatorCopy.isDeployed())
+	caller.aggregator = aggregatorCopy
+	_, errs = callConcurrently(aggregatorCopy, caller, testCalls(3))
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, []int{2, 3}, caller.aggregateSizes)
+	require.Empty(t, aggregator.pending)
+}
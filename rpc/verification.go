@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/rpc/chain/ethclient"
+	"github.com/status-im/status-go/rpc/verified"
+)
+
+// newVerifier creates the verifier of the chain clients, the headers trusted by default are the ones of the trusted
+// nodes, then the ones returned by the quorum of the providers of the chain
+func (c *Client) newVerifier(config verified.Config) *verified.Verifier {
+	if config.Headers == nil {
+		config.Headers = verified.NewQuorumHeaders(c.providersHeaderReaders, config.ProvidersQuorum)
+	}
+	if len(config.TrustedNodes) > 0 {
+		config.Headers = verified.NewNodeHeaders(config.TrustedNodes, config.Headers)
+	}
+	return verified.NewVerifier(config)
+}
+
+// providersHeaderReaders returns a reader per enabled provider of the chain, each one is asked independently
+func (c *Client) providersHeaderReaders(chainID uint64) ([]verified.HeaderReader, error) {
+	network := c.NetworkManager.Find(chainID)
+	if network == nil {
+		return nil, fmt.Errorf("could not find network: %d", chainID)
+	}
+
+	ethClients := c.getEthClients(network)
+	readers := make([]verified.HeaderReader, 0, len(ethClients))
+	for _, ethClient := range ethClients {
+		readers = append(readers, providerHeaderReader{ethClient})
+	}
+	return readers, nil
+}
+
+// providerHeaderReader reads the headers of a provider within its RPS limit
+type providerHeaderReader struct {
+	ethClient ethclient.RPSLimitedEthClientInterface
+}
+
+func (r providerHeaderReader) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	res, err := r.ethClient.ExecuteWithRPSLimit(func(client ethclient.EthClientInterface) (interface{}, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*types.Header), nil
+}
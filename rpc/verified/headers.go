@@ -0,0 +1,318 @@
+package verified
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	gocommon "github.com/status-im/status-go/common"
+)
+
+const (
+	// DefaultProvidersQuorum is the number of providers agreeing on a header required by QuorumHeaders by default
+	DefaultProvidersQuorum = 2
+	// latestHeaderMaxAge is how long the latest trusted header is reused before asking the providers again
+	latestHeaderMaxAge = 5 * time.Second
+	// maxCachedHeaders is the number of trusted headers kept per chain, the lowest blocks are dropped first
+	maxCachedHeaders = 256
+)
+
+// HeaderSource provides the headers whose state roots are trusted, a nil number stands for the latest trusted header
+type HeaderSource interface {
+	TrustedHeader(ctx context.Context, chainID uint64, number *big.Int) (*types.Header, error)
+}
+
+// HeaderSourceFunc adapts a function to a HeaderSource, like a light client answering with verified headers
+type HeaderSourceFunc func(ctx context.Context, chainID uint64, number *big.Int) (*types.Header, error)
+
+func (f HeaderSourceFunc) TrustedHeader(ctx context.Context, chainID uint64, number *big.Int) (*types.Header, error) {
+	return f(ctx, chainID, number)
+}
+
+// StaticHeaders trusts the headers added to it, like checkpoints shipped with the app or verified out of band
+type StaticHeaders struct {
+	mu      sync.RWMutex
+	headers map[uint64]map[uint64]*types.Header
+	latest  map[uint64]*types.Header
+}
+
+func NewStaticHeaders() *StaticHeaders {
+	return &StaticHeaders{
+		headers: make(map[uint64]map[uint64]*types.Header),
+		latest:  make(map[uint64]*types.Header),
+	}
+}
+
+func (s *StaticHeaders) Add(chainID uint64, header *types.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.headers[chainID] == nil {
+		s.headers[chainID] = make(map[uint64]*types.Header)
+	}
+	s.headers[chainID][header.Number.Uint64()] = header
+	if latest := s.latest[chainID]; latest == nil || latest.Number.Cmp(header.Number) < 0 {
+		s.latest[chainID] = header
+	}
+}
+
+func (s *StaticHeaders) TrustedHeader(ctx context.Context, chainID uint64, number *big.Int) (*types.Header, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var header *types.Header
+	if number == nil {
+		header = s.latest[chainID]
+	} else if number.IsUint64() {
+		header = s.headers[chainID][number.Uint64()]
+	}
+	if header == nil {
+		return nil, fmt.Errorf("%w: chain %d block %v", ErrNoTrustedHeader, chainID, number)
+	}
+	return header, nil
+}
+
+// NodeHeaders trusts the headers of a node per chain, the chains without a node use the fallback source
+type NodeHeaders struct {
+	urls     map[uint64]string
+	fallback HeaderSource
+
+	mu      sync.Mutex
+	clients map[uint64]*ethclient.Client
+}
+
+func NewNodeHeaders(urls map[uint64]string, fallback HeaderSource) *NodeHeaders {
+	return &NodeHeaders{
+		urls:     urls,
+		fallback: fallback,
+		clients:  make(map[uint64]*ethclient.Client),
+	}
+}
+
+func (n *NodeHeaders) nodeClient(ctx context.Context, chainID uint64) (*ethclient.Client, bool, error) {
+	url, ok := n.urls[chainID]
+	if !ok {
+		return nil, false, nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if client, ok := n.clients[chainID]; ok {
+		return client, true, nil
+	}
+	client, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return nil, true, fmt.Errorf("%w: chain %d: %v", ErrNoTrustedHeader, chainID, err)
+	}
+	n.clients[chainID] = client
+	return client, true, nil
+}
+
+func (n *NodeHeaders) TrustedHeader(ctx context.Context, chainID uint64, number *big.Int) (*types.Header, error) {
+	client, found, err := n.nodeClient(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if n.fallback == nil {
+			return nil, fmt.Errorf("%w: chain %d has no trusted node", ErrNoTrustedHeader, chainID)
+		}
+		return n.fallback.TrustedHeader(ctx, chainID, number)
+	}
+
+	header, err := client.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("%w: chain %d block %v: %v", ErrNoTrustedHeader, chainID, number, err)
+	}
+	return header, nil
+}
+
+// HeaderReader reads the headers of a single provider
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// QuorumHeaders trusts a header when at least quorum independent providers return it. The latest trusted header is
+// the lowest latest block of the providers, so the providers lagging behind can still confirm it. The trusted headers
+// are cached per block number and the latest one is reused for latestMaxAge, so the providers aren't all asked for
+// every read.
+type QuorumHeaders struct {
+	readersFn    func(chainID uint64) ([]HeaderReader, error)
+	quorum       int
+	latestMaxAge time.Duration
+
+	mu      sync.Mutex
+	readers map[uint64][]HeaderReader
+	trusted map[uint64]*trustedHeaders
+}
+
+// trustedHeaders are the headers of a chain confirmed by the quorum
+type trustedHeaders struct {
+	byNumber map[uint64]*types.Header
+	latest   *types.Header
+	latestAt time.Time
+}
+
+// NewQuorumHeaders creates the source, the readers of a chain are created by readersFn on first use
+func NewQuorumHeaders(readersFn func(chainID uint64) ([]HeaderReader, error), quorum int) *QuorumHeaders {
+	if quorum <= 0 {
+		quorum = DefaultProvidersQuorum
+	}
+	return &QuorumHeaders{
+		readersFn:    readersFn,
+		quorum:       quorum,
+		latestMaxAge: latestHeaderMaxAge,
+		readers:      make(map[uint64][]HeaderReader),
+		trusted:      make(map[uint64]*trustedHeaders),
+	}
+}
+
+func (q *QuorumHeaders) chainReaders(chainID uint64) ([]HeaderReader, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if readers, ok := q.readers[chainID]; ok {
+		return readers, nil
+	}
+	readers, err := q.readersFn(chainID)
+	if err != nil {
+		return nil, err
+	}
+	q.readers[chainID] = readers
+	return readers, nil
+}
+
+// cachedHeader returns the trusted header of the block, or the latest one if it's recent enough
+func (q *QuorumHeaders) cachedHeader(chainID uint64, number *big.Int) *types.Header {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	trusted := q.trusted[chainID]
+	if trusted == nil {
+		return nil
+	}
+	if number == nil {
+		if trusted.latest != nil && time.Since(trusted.latestAt) < q.latestMaxAge {
+			return trusted.latest
+		}
+		return nil
+	}
+	if !number.IsUint64() {
+		return nil
+	}
+	return trusted.byNumber[number.Uint64()]
+}
+
+func (q *QuorumHeaders) cacheHeader(chainID uint64, header *types.Header, latest bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	trusted := q.trusted[chainID]
+	if trusted == nil {
+		trusted = &trustedHeaders{byNumber: make(map[uint64]*types.Header)}
+		q.trusted[chainID] = trusted
+	}
+	if latest {
+		trusted.latest = header
+		trusted.latestAt = time.Now()
+	}
+
+	trusted.byNumber[header.Number.Uint64()] = header
+	if len(trusted.byNumber) > maxCachedHeaders {
+		lowest := header.Number.Uint64()
+		for number := range trusted.byNumber {
+			if number < lowest {
+				lowest = number
+			}
+		}
+		delete(trusted.byNumber, lowest)
+	}
+}
+
+func (q *QuorumHeaders) TrustedHeader(ctx context.Context, chainID uint64, number *big.Int) (*types.Header, error) {
+	if header := q.cachedHeader(chainID, number); header != nil {
+		return header, nil
+	}
+
+	header, err := q.quorumHeader(ctx, chainID, number)
+	if err != nil {
+		return nil, err
+	}
+	q.cacheHeader(chainID, header, number == nil)
+	return header, nil
+}
+
+func (q *QuorumHeaders) quorumHeader(ctx context.Context, chainID uint64, number *big.Int) (*types.Header, error) {
+	readers, err := q.chainReaders(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if len(readers) < q.quorum {
+		return nil, fmt.Errorf("%w: chain %d has %d providers, %d required", ErrNoTrustedHeader, chainID, len(readers), q.quorum)
+	}
+
+	headers := headersByNumber(ctx, readers, number)
+	if number == nil {
+		// the providers agree on the lowest of their latest blocks
+		for _, header := range headers {
+			if header != nil && (number == nil || header.Number.Cmp(number) < 0) {
+				number = header.Number
+			}
+		}
+		if number == nil {
+			return nil, fmt.Errorf("%w: chain %d: no provider returned its latest header", ErrNoTrustedHeader, chainID)
+		}
+		if header := q.cachedHeader(chainID, number); header != nil {
+			return header, nil
+		}
+		for i, header := range headers {
+			if header == nil || header.Number.Cmp(number) != 0 {
+				headers[i] = headerByNumber(ctx, readers[i], number)
+			}
+		}
+	}
+
+	votes := make(map[common.Hash]int)
+	for _, header := range headers {
+		if header == nil || header.Number.Cmp(number) != 0 {
+			continue
+		}
+		hash := header.Hash()
+		votes[hash]++
+		if votes[hash] >= q.quorum {
+			return header, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: chain %d block %s: fewer than %d providers agree", ErrNoTrustedHeader, chainID, number, q.quorum)
+}
+
+// headersByNumber reads the header from all the readers at once, the header of a failed reader is nil
+func headersByNumber(ctx context.Context, readers []HeaderReader, number *big.Int) []*types.Header {
+	headers := make([]*types.Header, len(readers))
+	wg := sync.WaitGroup{}
+	for i, reader := range readers {
+		wg.Add(1)
+		go func(i int, reader HeaderReader) {
+			defer gocommon.LogOnPanic()
+			defer wg.Done()
+			headers[i] = headerByNumber(ctx, reader, number)
+		}(i, reader)
+	}
+	wg.Wait()
+	return headers
+}
+
+func headerByNumber(ctx context.Context, reader HeaderReader, number *big.Int) *types.Header {
+	header, err := reader.HeaderByNumber(ctx, number)
+	if err != nil || header == nil || header.Number == nil {
+		return nil
+	}
+	return header
+}
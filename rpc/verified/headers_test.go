@@ -0,0 +1,202 @@
+package verified
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// testHeaderReader answers with the headers of its chain up to its latest block
+type testHeaderReader struct {
+	headers []*types.Header
+	latest  uint64
+	err     error
+	calls   int
+}
+
+func (r *testHeaderReader) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	if number == nil {
+		return r.headers[r.latest], nil
+	}
+	if number.Uint64() > r.latest {
+		return nil, nil
+	}
+	return r.headers[number.Uint64()], nil
+}
+
+func testHeaders(count int, root common.Hash) []*types.Header {
+	headers := make([]*types.Header, 0, count)
+	for i := 0; i < count; i++ {
+		headers = append(headers, &types.Header{Number: big.NewInt(int64(i)), Root: root, Difficulty: big.NewInt(0)})
+	}
+	return headers
+}
+
+func TestQuorumHeaders(t *testing.T) {
+	ctx := context.Background()
+	honest := testHeaders(10, common.HexToHash("0x01"))
+	forged := testHeaders(10, common.HexToHash("0x02"))
+
+	readers := []HeaderReader{
+		&testHeaderReader{headers: honest, latest: 9},
+		&testHeaderReader{headers: forged, latest: 9},
+		&testHeaderReader{headers: honest, latest: 7},
+		&testHeaderReader{err: errors.New("connection refused")},
+	}
+	created := 0
+	source := NewQuorumHeaders(func(chainID uint64) ([]HeaderReader, error) {
+		created++
+		return readers, nil
+	}, 2)
+
+	// the lagging provider confirms the latest block it knows
+	header, err := source.TrustedHeader(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, honest[7].Hash(), header.Hash())
+
+	header, err = source.TrustedHeader(ctx, 1, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, honest[5].Hash(), header.Hash())
+
+	// only one provider knows the block
+	_, err = source.TrustedHeader(ctx, 1, big.NewInt(8))
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+
+	// the readers are created once per chain
+	require.Equal(t, 1, created)
+
+	source = NewQuorumHeaders(func(chainID uint64) ([]HeaderReader, error) {
+		return readers[:1], nil
+	}, 0)
+	_, err = source.TrustedHeader(ctx, 1, nil)
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+
+	// the providers disagree
+	source = NewQuorumHeaders(func(chainID uint64) ([]HeaderReader, error) {
+		return readers[:2], nil
+	}, 2)
+	_, err = source.TrustedHeader(ctx, 1, nil)
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+}
+
+func TestQuorumHeadersCache(t *testing.T) {
+	ctx := context.Background()
+	headers := testHeaders(10, common.HexToHash("0x01"))
+	first := &testHeaderReader{headers: headers, latest: 9}
+	second := &testHeaderReader{headers: headers, latest: 9}
+	source := NewQuorumHeaders(func(chainID uint64) ([]HeaderReader, error) {
+		return []HeaderReader{first, second}, nil
+	}, 2)
+
+	header, err := source.TrustedHeader(ctx, 1, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, headers[5].Hash(), header.Hash())
+	require.Equal(t, 1, first.calls)
+
+	// the trusted headers are not asked again
+	header, err = source.TrustedHeader(ctx, 1, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, headers[5].Hash(), header.Hash())
+	require.Equal(t, 1, first.calls)
+
+	header, err = source.TrustedHeader(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, headers[9].Hash(), header.Hash())
+	require.Equal(t, 2, first.calls)
+	_, err = source.TrustedHeader(ctx, 1, nil)
+	require.NoError(t, err)
+	_, err = source.TrustedHeader(ctx, 1, big.NewInt(9))
+	require.NoError(t, err)
+	require.Equal(t, 2, first.calls)
+
+	// the latest header is asked again once too old, the known block isn't read a second time
+	source.latestMaxAge = 0
+	_, err = source.TrustedHeader(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, first.calls)
+	require.Equal(t, 3, second.calls)
+
+	// the other chains have their own headers
+	_, err = source.TrustedHeader(ctx, 2, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, 4, first.calls)
+}
+
+func TestStaticHeaders(t *testing.T) {
+	ctx := context.Background()
+	headers := testHeaders(3, common.HexToHash("0x01"))
+	source := NewStaticHeaders()
+
+	_, err := source.TrustedHeader(ctx, 1, nil)
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+
+	source.Add(1, headers[2])
+	source.Add(1, headers[1])
+
+	header, err := source.TrustedHeader(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, headers[2], header)
+
+	header, err = source.TrustedHeader(ctx, 1, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, headers[1], header)
+
+	_, err = source.TrustedHeader(ctx, 1, big.NewInt(0))
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+	_, err = source.TrustedHeader(ctx, 2, nil)
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+}
+
+// testNode is the "eth" service of a trusted node
+type testNode struct {
+	headers []*types.Header
+}
+
+func (n *testNode) GetBlockByNumber(ctx context.Context, number gethrpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	if number < 0 {
+		return n.headers[len(n.headers)-1], nil
+	}
+	return n.headers[number], nil
+}
+
+func TestNodeHeaders(t *testing.T) {
+	ctx := context.Background()
+	trusted := testHeaders(3, common.HexToHash("0x01"))
+	server := gethrpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &testNode{headers: trusted}))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	defer server.Stop()
+
+	fallback := NewStaticHeaders()
+	checkpoint := testHeaders(2, common.HexToHash("0x02"))[1]
+	fallback.Add(2, checkpoint)
+	source := NewNodeHeaders(map[uint64]string{1: httpServer.URL}, fallback)
+
+	header, err := source.TrustedHeader(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, trusted[2].Hash(), header.Hash())
+	header, err = source.TrustedHeader(ctx, 1, big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, trusted[1].Hash(), header.Hash())
+
+	// the chains without a node use the fallback
+	header, err = source.TrustedHeader(ctx, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, checkpoint.Hash(), header.Hash())
+
+	_, err = NewNodeHeaders(map[uint64]string{}, nil).TrustedHeader(ctx, 1, nil)
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+}
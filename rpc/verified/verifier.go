@@ -0,0 +1,275 @@
+package verified
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	// ErrProofVerification is returned when the answer of the provider doesn't match the trusted state root
+	ErrProofVerification = errors.New("proof verification failed")
+	// ErrNoTrustedHeader is returned when the header source doesn't trust any header for the requested block
+	ErrNoTrustedHeader = errors.New("no trusted header")
+	// ErrUnknownBalanceSlot is returned for the tokens without a configured balances mapping slot
+	ErrUnknownBalanceSlot = errors.New("unknown ERC20 balances slot")
+)
+
+// balanceOfSelector is the selector of the ERC20 balanceOf(address) function
+var balanceOfSelector = []byte{0x70, 0xa0, 0x82, 0x31}
+
+// ProofReader makes the eth_getProof calls, its answers don't need to be trusted
+type ProofReader interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// AccountResult is the answer of eth_getProof
+type AccountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+}
+
+// StorageResult is the proof of a storage slot in the answer of eth_getProof
+type StorageResult struct {
+	Key   string          `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// Account is the state of an account proven against a trusted state root
+type Account struct {
+	Nonce       uint64
+	Balance     *big.Int
+	StorageRoot common.Hash
+	CodeHash    common.Hash
+}
+
+// Config configures the verification of the state reads
+type Config struct {
+	// Headers provides the trusted headers the proofs are checked against. When it's nil, rpc.Client trusts the
+	// headers returned by at least ProvidersQuorum of the providers of the chain.
+	Headers         HeaderSource
+	ProvidersQuorum int
+	// TrustedNodes are the RPC URLs of the nodes trusted for the headers of each chain, like a node run by the
+	// user. They take precedence over Headers for their chains.
+	TrustedNodes map[uint64]string
+	// ERC20BalanceSlots maps the tokens of each chain to the storage slot of their Solidity balances mapping, the
+	// balanceOf calls of the tokens without a slot are not verified. The balances read in batch through the balance
+	// checker contract are not balanceOf calls of the tokens and stay unverified.
+	ERC20BalanceSlots map[uint64]map[common.Address]common.Hash
+}
+
+// Verifier serves the balances, nonces and storage slots of accounts from untrusted providers, the eth_getProof
+// answers are checked against the state root of a trusted header
+type Verifier struct {
+	headers      HeaderSource
+	balanceSlots map[uint64]map[common.Address]common.Hash
+}
+
+func NewVerifier(config Config) *Verifier {
+	return &Verifier{
+		headers:      config.Headers,
+		balanceSlots: config.ERC20BalanceSlots,
+	}
+}
+
+// BalanceAt returns the verified balance of the account, at the latest trusted block when blockNumber is nil
+func (v *Verifier) BalanceAt(ctx context.Context, reader ProofReader, chainID uint64, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	proven, _, err := v.getProof(ctx, reader, chainID, account, nil, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return proven.Balance, nil
+}
+
+// NonceAt returns the verified nonce of the account, at the latest trusted block when blockNumber is nil
+func (v *Verifier) NonceAt(ctx context.Context, reader ProofReader, chainID uint64, account common.Address, blockNumber *big.Int) (uint64, error) {
+	proven, _, err := v.getProof(ctx, reader, chainID, account, nil, blockNumber)
+	if err != nil {
+		return 0, err
+	}
+	return proven.Nonce, nil
+}
+
+// StorageAt returns the verified value of the storage slot, at the latest trusted block when blockNumber is nil
+func (v *Verifier) StorageAt(ctx context.Context, reader ProofReader, chainID uint64, account common.Address, key common.Hash, blockNumber *big.Int) (common.Hash, error) {
+	_, values, err := v.getProof(ctx, reader, chainID, account, []common.Hash{key}, blockNumber)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return values[0], nil
+}
+
+// ERC20BalanceAt returns the verified token balance of the holder read from the balances mapping of the token
+func (v *Verifier) ERC20BalanceAt(ctx context.Context, reader ProofReader, chainID uint64, token common.Address, holder common.Address, blockNumber *big.Int) (*big.Int, error) {
+	slot, ok := v.balanceSlots[chainID][token]
+	if !ok {
+		return nil, fmt.Errorf("%w: token %s on chain %d", ErrUnknownBalanceSlot, token, chainID)
+	}
+	value, err := v.StorageAt(ctx, reader, chainID, token, BalanceStorageKey(holder, slot), blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return value.Big(), nil
+}
+
+// BalanceOfCall serves the ERC20 balanceOf calls of the tokens with a configured balances slot, handled is false
+// for the other calls which have to be made as usual
+func (v *Verifier) BalanceOfCall(ctx context.Context, reader ProofReader, chainID uint64, msg ethereum.CallMsg, blockNumber *big.Int) (data []byte, handled bool, err error) {
+	if msg.To == nil || len(msg.Data) != 4+common.HashLength || !bytes.Equal(msg.Data[:4], balanceOfSelector) {
+		return nil, false, nil
+	}
+	if msg.Value != nil && msg.Value.Sign() != 0 {
+		return nil, false, nil
+	}
+	if _, ok := v.balanceSlots[chainID][*msg.To]; !ok {
+		return nil, false, nil
+	}
+	arg := msg.Data[4:]
+	// an address argument is padded with zeros, the other values are left to the contract
+	if !bytes.Equal(arg[:common.HashLength-common.AddressLength], make([]byte, common.HashLength-common.AddressLength)) {
+		return nil, false, nil
+	}
+
+	balance, err := v.ERC20BalanceAt(ctx, reader, chainID, *msg.To, common.BytesToAddress(arg), blockNumber)
+	if err != nil {
+		return nil, true, err
+	}
+	return common.BigToHash(balance).Bytes(), true, nil
+}
+
+// BalanceStorageKey returns the storage slot of the holder in a Solidity mapping(address => uint256) stored at
+// mappingSlot
+func BalanceStorageKey(holder common.Address, mappingSlot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(common.LeftPadBytes(holder.Bytes(), common.HashLength), mappingSlot.Bytes())
+}
+
+func (v *Verifier) getProof(ctx context.Context, reader ProofReader, chainID uint64, account common.Address, keys []common.Hash, blockNumber *big.Int) (*Account, []common.Hash, error) {
+	if v.headers == nil {
+		return nil, nil, fmt.Errorf("%w: no header source", ErrNoTrustedHeader)
+	}
+	header, err := v.headers.TrustedHeader(ctx, chainID, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header == nil {
+		return nil, nil, fmt.Errorf("%w: chain %d block %v", ErrNoTrustedHeader, chainID, blockNumber)
+	}
+
+	storageKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		storageKeys = append(storageKeys, key.Hex())
+	}
+	var result AccountResult
+	err = reader.CallContext(ctx, &result, "eth_getProof", account, storageKeys, hexutil.EncodeBig(header.Number))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proven, err := VerifyAccountProof(header.Root, account, &result)
+	if err != nil {
+		return nil, nil, fmt.Errorf("chain %d block %d: %w", chainID, header.Number, err)
+	}
+	if len(result.StorageProof) != len(keys) {
+		return nil, nil, fmt.Errorf("%w: chain %d block %d: %d storage proofs for %d keys", ErrProofVerification,
+			chainID, header.Number, len(result.StorageProof), len(keys))
+	}
+	values := make([]common.Hash, 0, len(keys))
+	for i, key := range keys {
+		value, err := VerifyStorageProof(proven.StorageRoot, key, result.StorageProof[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("chain %d block %d account %s: %w", chainID, header.Number, account, err)
+		}
+		values = append(values, value)
+	}
+	return proven, values, nil
+}
+
+// VerifyAccountProof checks the account proof against the state root and returns the proven account, the values
+// reported by the provider must match the proven ones
+func VerifyAccountProof(stateRoot common.Hash, account common.Address, result *AccountResult) (*Account, error) {
+	value, err := trie.VerifyProof(stateRoot, crypto.Keccak256(account.Bytes()), proofDB(result.AccountProof))
+	if err != nil {
+		return nil, fmt.Errorf("%w: account %s against state root %s: %v", ErrProofVerification, account, stateRoot, err)
+	}
+
+	// a proof of absence proves an empty account
+	proven := &Account{
+		Balance:     new(big.Int),
+		StorageRoot: types.EmptyRootHash,
+		CodeHash:    types.EmptyCodeHash,
+	}
+	if value != nil {
+		var stateAccount types.StateAccount
+		if err := rlp.DecodeBytes(value, &stateAccount); err != nil {
+			return nil, fmt.Errorf("%w: account %s: invalid account: %v", ErrProofVerification, account, err)
+		}
+		proven = &Account{
+			Nonce:       stateAccount.Nonce,
+			Balance:     stateAccount.Balance,
+			StorageRoot: stateAccount.Root,
+			CodeHash:    common.BytesToHash(stateAccount.CodeHash),
+		}
+	}
+
+	switch {
+	case result.Balance == nil || result.Balance.ToInt().Cmp(proven.Balance) != 0:
+		return nil, fmt.Errorf("%w: account %s: reported balance %v, proven %s", ErrProofVerification, account, result.Balance, proven.Balance)
+	case uint64(result.Nonce) != proven.Nonce:
+		return nil, fmt.Errorf("%w: account %s: reported nonce %d, proven %d", ErrProofVerification, account, result.Nonce, proven.Nonce)
+	case result.StorageHash != proven.StorageRoot:
+		return nil, fmt.Errorf("%w: account %s: reported storage hash %s, proven %s", ErrProofVerification, account, result.StorageHash, proven.StorageRoot)
+	case result.CodeHash != proven.CodeHash:
+		return nil, fmt.Errorf("%w: account %s: reported code hash %s, proven %s", ErrProofVerification, account, result.CodeHash, proven.CodeHash)
+	}
+	return proven, nil
+}
+
+// VerifyStorageProof checks the storage proof of the key against the storage root of the account and returns the
+// proven value, the value reported by the provider must match it
+func VerifyStorageProof(storageRoot common.Hash, key common.Hash, result StorageResult) (common.Hash, error) {
+	proven := common.Hash{}
+	// the storage of the accounts without storage has no nodes to prove
+	if storageRoot != types.EmptyRootHash {
+		value, err := trie.VerifyProof(storageRoot, crypto.Keccak256(key.Bytes()), proofDB(result.Proof))
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("%w: storage key %s against storage root %s: %v", ErrProofVerification, key, storageRoot, err)
+		}
+		if value != nil {
+			content, _, err := rlp.SplitString(value)
+			if err != nil {
+				return common.Hash{}, fmt.Errorf("%w: storage key %s: invalid value: %v", ErrProofVerification, key, err)
+			}
+			proven = common.BytesToHash(content)
+		}
+	}
+
+	if result.Value == nil || result.Value.ToInt().Cmp(proven.Big()) != 0 {
+		return common.Hash{}, fmt.Errorf("%w: storage key %s: reported value %v, proven %s", ErrProofVerification, key, result.Value, proven)
+	}
+	return proven, nil
+}
+
+func proofDB(proof []hexutil.Bytes) *memorydb.Database {
+	db := memorydb.New()
+	for _, node := range proof {
+		_ = db.Put(crypto.Keccak256(node), node)
+	}
+	return db
+}
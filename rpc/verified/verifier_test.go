@@ -0,0 +1,316 @@
+package verified
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+const testChainID = uint64(1)
+
+var (
+	testBlock   = big.NewInt(10)
+	holder      = common.HexToAddress("0x1111")
+	token       = common.HexToAddress("0x2222")
+	unknown     = common.HexToAddress("0x3333")
+	balanceSlot = common.BigToHash(big.NewInt(2))
+)
+
+// proofList collects the nodes of a proof in the eth_getProof format
+type proofList []hexutil.Bytes
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	return nil
+}
+
+// testState is the "eth" service of a node answering eth_getProof for a state with an account and a token
+type testState struct {
+	t        *testing.T
+	accounts map[common.Address]types.StateAccount
+	storage  map[common.Address]*trie.Trie
+	state    *trie.Trie
+	root     common.Hash
+
+	mu          sync.Mutex
+	tamper      func(result *AccountResult)
+	blockNumber gethrpc.BlockNumber
+}
+
+func newTestState(t *testing.T) *testState {
+	db := trie.NewDatabase(memorydb.New())
+	s := &testState{
+		t:        t,
+		accounts: make(map[common.Address]types.StateAccount),
+		storage:  make(map[common.Address]*trie.Trie),
+		state:    trie.NewEmpty(db),
+	}
+
+	s.accounts[holder] = types.StateAccount{
+		Nonce:    7,
+		Balance:  big.NewInt(1000),
+		Root:     types.EmptyRootHash,
+		CodeHash: types.EmptyCodeHash.Bytes(),
+	}
+
+	tokenStorage := trie.NewEmpty(db)
+	s.setStorage(tokenStorage, BalanceStorageKey(holder, balanceSlot), common.BigToHash(big.NewInt(500)))
+	s.setStorage(tokenStorage, common.BigToHash(big.NewInt(0)), common.BigToHash(big.NewInt(1)))
+	s.storage[token] = tokenStorage
+	s.accounts[token] = types.StateAccount{
+		Nonce:    1,
+		Balance:  big.NewInt(0),
+		Root:     tokenStorage.Hash(),
+		CodeHash: crypto.Keccak256([]byte("token code")),
+	}
+
+	for address, account := range s.accounts {
+		data, err := rlp.EncodeToBytes(&account)
+		require.NoError(t, err)
+		s.state.Update(crypto.Keccak256(address.Bytes()), data)
+	}
+	s.root = s.state.Hash()
+	return s
+}
+
+func (s *testState) setStorage(storage *trie.Trie, key common.Hash, value common.Hash) {
+	data, err := rlp.EncodeToBytes(common.TrimLeftZeroes(value.Bytes()))
+	require.NoError(s.t, err)
+	storage.Update(crypto.Keccak256(key.Bytes()), data)
+}
+
+func (s *testState) GetProof(ctx context.Context, address common.Address, storageKeys []string, blockNumber gethrpc.BlockNumber) (*AccountResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blockNumber = blockNumber
+
+	account, ok := s.accounts[address]
+	if !ok {
+		account = types.StateAccount{Balance: new(big.Int), Root: types.EmptyRootHash, CodeHash: types.EmptyCodeHash.Bytes()}
+	}
+	result := &AccountResult{
+		Address:     address,
+		Balance:     (*hexutil.Big)(account.Balance),
+		CodeHash:    common.BytesToHash(account.CodeHash),
+		Nonce:       hexutil.Uint64(account.Nonce),
+		StorageHash: account.Root,
+	}
+	proof := proofList{}
+	if err := s.state.Prove(crypto.Keccak256(address.Bytes()), 0, &proof); err != nil {
+		return nil, err
+	}
+	result.AccountProof = proof
+
+	for _, key := range storageKeys {
+		hash := common.HexToHash(key)
+		storageResult := StorageResult{Key: key, Value: (*hexutil.Big)(new(big.Int))}
+		if storage, ok := s.storage[address]; ok {
+			if value := storage.Get(crypto.Keccak256(hash.Bytes())); value != nil {
+				content, _, err := rlp.SplitString(value)
+				if err != nil {
+					return nil, err
+				}
+				storageResult.Value = (*hexutil.Big)(new(big.Int).SetBytes(content))
+			}
+			proof := proofList{}
+			if err := storage.Prove(crypto.Keccak256(hash.Bytes()), 0, &proof); err != nil {
+				return nil, err
+			}
+			storageResult.Proof = proof
+		}
+		result.StorageProof = append(result.StorageProof, storageResult)
+	}
+
+	if s.tamper != nil {
+		s.tamper(result)
+	}
+	return result, nil
+}
+
+func (s *testState) setTamper(tamper func(result *AccountResult)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tamper = tamper
+}
+
+func (s *testState) lastBlockNumber() gethrpc.BlockNumber {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.blockNumber
+}
+
+func setupVerifierTest(t *testing.T) (*Verifier, *testState, *StaticHeaders, *gethrpc.Client) {
+	state := newTestState(t)
+	server := gethrpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", state))
+	client := gethrpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	headers := NewStaticHeaders()
+	headers.Add(testChainID, &types.Header{Number: big.NewInt(9), Root: common.HexToHash("0x1234")})
+	headers.Add(testChainID, &types.Header{Number: testBlock, Root: state.root})
+
+	verifier := NewVerifier(Config{
+		Headers: headers,
+		ERC20BalanceSlots: map[uint64]map[common.Address]common.Hash{
+			testChainID: {token: balanceSlot},
+		},
+	})
+	return verifier, state, headers, client
+}
+
+func balanceOfData(address common.Address) []byte {
+	return append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(address.Bytes(), common.HashLength)...)
+}
+
+func TestVerifiedReads(t *testing.T) {
+	verifier, state, _, client := setupVerifierTest(t)
+	ctx := context.Background()
+
+	// the latest trusted block is read when no block is requested
+	balance, err := verifier.BalanceAt(ctx, client, testChainID, holder, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), balance.Int64())
+	require.Equal(t, gethrpc.BlockNumber(10), state.lastBlockNumber())
+
+	nonce, err := verifier.NonceAt(ctx, client, testChainID, holder, testBlock)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), nonce)
+
+	tokenBalance, err := verifier.ERC20BalanceAt(ctx, client, testChainID, token, holder, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(500), tokenBalance.Int64())
+
+	value, err := verifier.StorageAt(ctx, client, testChainID, token, common.BigToHash(big.NewInt(0)), nil)
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(big.NewInt(1)), value)
+
+	// proofs of absence
+	value, err = verifier.StorageAt(ctx, client, testChainID, token, common.BigToHash(big.NewInt(5)), nil)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}, value)
+
+	tokenBalance, err = verifier.ERC20BalanceAt(ctx, client, testChainID, token, unknown, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), tokenBalance.Int64())
+
+	balance, err = verifier.BalanceAt(ctx, client, testChainID, unknown, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), balance.Int64())
+
+	value, err = verifier.StorageAt(ctx, client, testChainID, holder, common.BigToHash(big.NewInt(0)), nil)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}, value)
+}
+
+func TestBalanceOfCall(t *testing.T) {
+	verifier, _, _, client := setupVerifierTest(t)
+	ctx := context.Background()
+
+	data, handled, err := verifier.BalanceOfCall(ctx, client, testChainID, ethereum.CallMsg{To: &token, Data: balanceOfData(holder)}, nil)
+	require.NoError(t, err)
+	require.True(t, handled)
+	require.Equal(t, common.BigToHash(big.NewInt(500)).Bytes(), data)
+
+	notHandled := []ethereum.CallMsg{
+		{To: &unknown, Data: balanceOfData(holder)},
+		{To: &token, Data: append([]byte{0x18, 0x16, 0x0d, 0xdd}, make([]byte, 32)...)},
+		{To: &token, Data: balanceOfData(holder)[:20]},
+		{To: &token, Data: balanceOfData(holder), Value: big.NewInt(1)},
+		{Data: balanceOfData(holder)},
+	}
+	for _, msg := range notHandled {
+		_, handled, err = verifier.BalanceOfCall(ctx, client, testChainID, msg, nil)
+		require.NoError(t, err)
+		require.False(t, handled)
+	}
+
+	_, handled, err = verifier.BalanceOfCall(ctx, client, 2, ethereum.CallMsg{To: &token, Data: balanceOfData(holder)}, nil)
+	require.NoError(t, err)
+	require.False(t, handled)
+}
+
+func TestVerificationFailures(t *testing.T) {
+	verifier, state, headers, client := setupVerifierTest(t)
+	ctx := context.Background()
+
+	tampers := map[string]func(result *AccountResult){
+		"reported balance": func(result *AccountResult) {
+			result.Balance = (*hexutil.Big)(big.NewInt(2000))
+		},
+		"reported nonce": func(result *AccountResult) {
+			result.Nonce++
+		},
+		"missing account proof": func(result *AccountResult) {
+			result.AccountProof = result.AccountProof[:len(result.AccountProof)-1]
+		},
+		"proof of absence": func(result *AccountResult) {
+			result.AccountProof = nil
+		},
+	}
+	for name, tamper := range tampers {
+		state.setTamper(tamper)
+		_, err := verifier.BalanceAt(ctx, client, testChainID, holder, nil)
+		require.ErrorIs(t, err, ErrProofVerification, name)
+	}
+
+	storageTampers := map[string]func(result *AccountResult){
+		"reported value": func(result *AccountResult) {
+			result.StorageProof[0].Value = (*hexutil.Big)(big.NewInt(5000))
+		},
+		"missing storage proof": func(result *AccountResult) {
+			result.StorageProof[0].Proof = result.StorageProof[0].Proof[:1]
+		},
+		"missing storage result": func(result *AccountResult) {
+			result.StorageProof = nil
+		},
+		"reported storage hash": func(result *AccountResult) {
+			result.StorageHash = types.EmptyRootHash
+		},
+	}
+	for name, tamper := range storageTampers {
+		state.setTamper(tamper)
+		_, err := verifier.ERC20BalanceAt(ctx, client, testChainID, token, holder, nil)
+		require.ErrorIs(t, err, ErrProofVerification, name)
+	}
+	state.setTamper(nil)
+
+	// the proof of another state
+	_, err := verifier.BalanceAt(ctx, client, testChainID, holder, big.NewInt(9))
+	require.ErrorIs(t, err, ErrProofVerification)
+
+	_, err = verifier.BalanceAt(ctx, client, testChainID, holder, big.NewInt(8))
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+
+	_, err = verifier.BalanceAt(ctx, client, 2, holder, nil)
+	require.ErrorIs(t, err, ErrNoTrustedHeader)
+
+	_, err = verifier.ERC20BalanceAt(ctx, client, testChainID, unknown, holder, nil)
+	require.ErrorIs(t, err, ErrUnknownBalanceSlot)
+
+	// a newer header is trusted once its proofs match
+	headers.Add(testChainID, &types.Header{Number: big.NewInt(11), Root: state.root})
+	_, err = verifier.NonceAt(ctx, client, testChainID, holder, nil)
+	require.NoError(t, err)
+	require.Equal(t, gethrpc.BlockNumber(11), state.lastBlockNumber())
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/protocol"
 	"github.com/status-im/status-go/services/accounts/accountsevent"
+	"github.com/status-im/status-go/services/wallet/spam"
 )
 
 func NewAccountsAPI(manager *account.GethManager, config *params.NodeConfig, db *accounts.Database, feed *event.Feed, messenger **protocol.Messenger) *API {
@@ -93,6 +94,15 @@ func (api *API) GetTokenPreferences(ctx context.Context) ([]walletsettings.Token
 	return (*api.messenger).GetTokenPreferences()
 }
 
+// UpdateTokenSpamOverrides replaces the spam verdicts set by the user, they win over the wallet classification
+func (api *API) UpdateTokenSpamOverrides(ctx context.Context, overrides []spam.Override) error {
+	return (*api.messenger).UpdateTokenSpamOverrides(overrides)
+}
+
+func (api *API) GetTokenSpamOverrides(ctx context.Context) ([]spam.Override, error) {
+	return (*api.messenger).GetTokenSpamOverrides()
+}
+
 func (api *API) UpdateCollectiblePreferences(ctx context.Context, preferences []walletsettings.CollectiblePreferences) error {
 	return (*api.messenger).UpdateCollectiblePreferences(preferences)
 }
@@ -251,7 +261,11 @@ func (api *API) checkAccountValidity(account *accounts.Account) error {
 		return errors.New("`ColorID` field of an account must be set")
 	}
 
-	if account.Type != accounts.AccountTypeWatch {
+	if account.Type == accounts.AccountTypeSmart && len(account.PublicKey) == 0 {
+		return errors.New("`PublicKey` field of a smart account must be set to the public key of its owner")
+	}
+
+	if !account.IsKeypairless() {
 
 		if len(account.KeyUID) == 0 {
 			return errors.New("`KeyUID` field of an account must be set")
@@ -306,7 +320,7 @@ func (api *API) AddAccount(ctx context.Context, password string, account *accoun
 		return err
 	}
 
-	if account.Type != accounts.AccountTypeWatch {
+	if !account.IsKeypairless() {
 		kp, err := api.db.GetKeypairByKeyUID(account.KeyUID)
 		if err != nil {
 			if err == accounts.ErrDbKeypairNotFound {
@@ -29,14 +29,32 @@ func NewAPI(s *Service) *API {
 		RpcClient:     s.rpc,
 		Db:            s.db,
 		ClientHandler: c,
+		Previewer:     s.sp,
+	})
+	r.Register("wallet_sendCalls", &commands.SendCallsCommand{
+		RpcClient:        s.rpc,
+		Db:               s.db,
+		ClientHandler:    c,
+		PendingTxTracker: s.pt,
+	})
+	r.Register("wallet_getCallsStatus", &commands.GetCallsStatusCommand{
+		RpcClient:        s.rpc,
+		Db:               s.db,
+		PendingTxTracker: s.pt,
+	})
+	r.Register("wallet_getCapabilities", &commands.GetCapabilitiesCommand{
+		Db:             s.db,
+		NetworkManager: s.nm,
 	})
 	r.Register("personal_sign", &commands.SignCommand{
 		Db:            s.db,
 		ClientHandler: c,
+		Previewer:     s.sp,
 	})
 	r.Register("eth_signTypedData_v4", &commands.SignCommand{
 		Db:            s.db,
 		ClientHandler: c,
+		Previewer:     s.sp,
 	})
 
 	// Accounts query and dapp permissions
@@ -57,6 +75,18 @@ func NewAPI(s *Service) *API {
 		Db:             s.db,
 		NetworkManager: s.nm,
 	})
+	r.Register("wallet_addEthereumChain", &commands.AddEthereumChainCommand{
+		Db:             s.db,
+		NetworkManager: s.nm,
+		ClientHandler:  c,
+	})
+
+	// Assets
+	r.Register("wallet_watchAsset", &commands.WatchAssetCommand{
+		Db:            s.db,
+		TokenManager:  s.tm,
+		ClientHandler: c,
+	})
 
 	// Permissions
 	r.Register("wallet_requestPermissions", &commands.RequestPermissionsCommand{})
@@ -148,6 +178,14 @@ func (api *API) SendTransactionRejected(args commands.RejectedArgs) error {
 	return api.c.SendTransactionRejected(args)
 }
 
+func (api *API) SendCallsAccepted(args commands.SendCallsAcceptedArgs) error {
+	return api.c.SendCallsAccepted(args)
+}
+
+func (api *API) SendCallsRejected(args commands.RejectedArgs) error {
+	return api.c.SendCallsRejected(args)
+}
+
 func (api *API) SignAccepted(args commands.SignAcceptedArgs) error {
 	return api.c.SignAccepted(args)
 }
@@ -155,3 +193,19 @@ func (api *API) SignAccepted(args commands.SignAcceptedArgs) error {
 func (api *API) SignRejected(args commands.RejectedArgs) error {
 	return api.c.SignRejected(args)
 }
+
+func (api *API) AddEthereumChainAccepted(args commands.AddEthereumChainAcceptedArgs) error {
+	return api.c.AddEthereumChainAccepted(args)
+}
+
+func (api *API) AddEthereumChainRejected(args commands.RejectedArgs) error {
+	return api.c.AddEthereumChainRejected(args)
+}
+
+func (api *API) WatchAssetAccepted(args commands.WatchAssetAcceptedArgs) error {
+	return api.c.WatchAssetAccepted(args)
+}
+
+func (api *API) WatchAssetRejected(args commands.RejectedArgs) error {
+	return api.c.WatchAssetRejected(args)
+}
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/pkg/security"
+	"github.com/status-im/status-go/rpc/network"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/signal"
+)
+
+// errors
+var (
+	ErrNoAddEthereumChainParamsFound = errors.New("no chain in params found")
+	ErrAddEthereumChainInvalidName   = errors.New("chain name is required")
+	ErrAddEthereumChainNoRpcUrls     = errors.New("at least one valid rpc url is required")
+	ErrAddEthereumChainInvalidRpcUrl = errors.New("invalid rpc url")
+)
+
+type NativeCurrency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint64 `json:"decimals"`
+}
+
+// AddEthereumChainParams represents the network parameters as defined by EIP-3085
+type AddEthereumChainParams struct {
+	ChainID           string          `json:"chainId"`
+	ChainName         string          `json:"chainName"`
+	NativeCurrency    *NativeCurrency `json:"nativeCurrency,omitempty"`
+	RpcUrls           []string        `json:"rpcUrls"`
+	BlockExplorerUrls []string        `json:"blockExplorerUrls,omitempty"`
+	IconUrls          []string        `json:"iconUrls,omitempty"`
+}
+
+type AddEthereumChainCommand struct {
+	NetworkManager *network.Manager
+	Db             *sql.DB
+	ClientHandler  ClientSideHandlerInterface
+}
+
+func (r *RPCRequest) getAddEthereumChainParams() (*AddEthereumChainParams, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return nil, ErrEmptyRPCParams
+	}
+
+	paramMap, ok := r.Params[0].(map[string]interface{})
+	if !ok {
+		return nil, ErrNoAddEthereumChainParamsFound
+	}
+
+	paramBytes, err := json.Marshal(paramMap)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling first add chain param: %v", err)
+	}
+
+	var chainParams AddEthereumChainParams
+	err = json.Unmarshal(paramBytes, &chainParams)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling first add chain param to AddEthereumChainParams: %v", err)
+	}
+
+	return &chainParams, nil
+}
+
+func (p *AddEthereumChainParams) validate() (uint64, error) {
+	chainID, err := hexStringToUint64(p.ChainID)
+	if err != nil {
+		return 0, err
+	}
+
+	if p.ChainName == "" {
+		return 0, ErrAddEthereumChainInvalidName
+	}
+
+	if len(p.RpcUrls) == 0 {
+		return 0, ErrAddEthereumChainNoRpcUrls
+	}
+
+	for _, rpcUrl := range p.RpcUrls {
+		u, err := url.Parse(rpcUrl)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return 0, ErrAddEthereumChainInvalidRpcUrl
+		}
+	}
+
+	return chainID, nil
+}
+
+func (p *AddEthereumChainParams) toNetwork(chainID uint64) *params.Network {
+	n := &params.Network{
+		ChainID:   chainID,
+		ChainName: p.ChainName,
+		Enabled:   true,
+	}
+
+	for _, rpcUrl := range p.RpcUrls {
+		n.RpcProviders = append(n.RpcProviders, params.RpcProvider{
+			ChainID:  chainID,
+			Name:     p.ChainName,
+			URL:      security.NewSensitiveString(rpcUrl),
+			Type:     params.UserProviderType,
+			Enabled:  true,
+			AuthType: params.NoAuth,
+		})
+	}
+
+	if p.NativeCurrency != nil {
+		n.NativeCurrencyName = p.NativeCurrency.Name
+		n.NativeCurrencySymbol = p.NativeCurrency.Symbol
+		n.NativeCurrencyDecimals = p.NativeCurrency.Decimals
+	}
+
+	if len(p.BlockExplorerUrls) > 0 {
+		n.BlockExplorerURL = p.BlockExplorerUrls[0]
+	}
+
+	if len(p.IconUrls) > 0 {
+		n.IconURL = p.IconUrls[0]
+	}
+
+	return n
+}
+
+func (c *AddEthereumChainCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	chainParams, err := request.getAddEthereumChainParams()
+	if err != nil {
+		return "", err
+	}
+
+	chainID, err := chainParams.validate()
+	if err != nil {
+		return "", err
+	}
+
+	// EIP-3085: adding an already known chain is a no-op
+	if c.NetworkManager.Find(chainID) != nil {
+		return nil, nil
+	}
+
+	err = c.ClientHandler.RequestAddEthereumChain(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, chainID, chainParams)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.NetworkManager.Upsert(chainParams.toNetwork(chainID))
+	if err != nil {
+		return "", err
+	}
+
+	return nil, nil
+}
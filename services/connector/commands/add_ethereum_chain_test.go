@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/signal"
+)
+
+const testCustomChainID = uint64(0x1a343)
+
+func prepareAddEthereumChainRequest(dApp *signal.ConnectorDApp, chainID string, rpcUrls []interface{}) (RPCRequest, error) {
+	params := []interface{}{
+		map[string]interface{}{
+			"chainId":   chainID,
+			"chainName": "Custom Chain",
+			"nativeCurrency": map[string]interface{}{
+				"name":     "Custom Ether",
+				"symbol":   "CETH",
+				"decimals": 18,
+			},
+			"rpcUrls":           rpcUrls,
+			"blockExplorerUrls": []interface{}{"https://explorer.custom.chain"},
+		},
+	}
+
+	return ConstructRPCRequest("wallet_addEthereumChain", params, dApp)
+}
+
+func TestFailToAddEthereumChainWithMissingDAppFields(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	request, err := prepareAddEthereumChainRequest(nil, "0x1a343", []interface{}{"https://rpc.custom.chain"})
+	assert.NoError(t, err)
+
+	result, err := state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrRequestMissingDAppData, err)
+	assert.Empty(t, result)
+}
+
+func TestFailToAddEthereumChainWithoutPermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	request, err := prepareAddEthereumChainRequest(&testDAppData, "0x1a343", []interface{}{"https://rpc.custom.chain"})
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+}
+
+func TestFailToAddEthereumChainWithInvalidParams(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareAddEthereumChainRequest(&testDAppData, "0x1a343", []interface{}{})
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAddEthereumChainNoRpcUrls, err)
+
+	request, err = prepareAddEthereumChainRequest(&testDAppData, "0x1a343", []interface{}{"ws://rpc.custom.chain"})
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAddEthereumChainInvalidRpcUrl, err)
+
+	request, err = prepareAddEthereumChainRequest(&testDAppData, "1a343", []interface{}{"https://rpc.custom.chain"})
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrUnsupportedNetwork, err)
+}
+
+func TestAddEthereumChainAlreadyKnown(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+		assert.NotEqual(t, signal.EventConnectorAddEthereumChain, evt.Type)
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	// Ethereum mainnet is one of the embedded networks
+	request, err := prepareAddEthereumChainRequest(&testDAppData, "0x1", []interface{}{"https://rpc.custom.chain"})
+	assert.NoError(t, err)
+
+	result, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestAddEthereumChainWithSignalAccepted(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorAddEthereumChain:
+			var ev signal.ConnectorAddEthereumChainSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+			assert.Equal(t, testCustomChainID, ev.ChainID)
+
+			err = state.handler.AddEthereumChainAccepted(AddEthereumChainAcceptedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	request, err := prepareAddEthereumChainRequest(&testDAppData, "0x1a343", []interface{}{"https://rpc.custom.chain"})
+	assert.NoError(t, err)
+
+	result, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	network := state.networkManager.Find(testCustomChainID)
+	assert.NotNil(t, network)
+	assert.Equal(t, "Custom Chain", network.ChainName)
+	assert.Equal(t, "CETH", network.NativeCurrencySymbol)
+	assert.Equal(t, uint64(18), network.NativeCurrencyDecimals)
+	assert.Equal(t, "https://explorer.custom.chain", network.BlockExplorerURL)
+	assert.False(t, network.IsActive)
+	assert.Len(t, network.RpcProviders, 1)
+	assert.Equal(t, params.UserProviderType, network.RpcProviders[0].Type)
+	assert.Equal(t, "https://rpc.custom.chain", network.RpcProviders[0].URL.Reveal())
+}
+
+func TestAddEthereumChainWithSignalRejected(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorAddEthereumChain:
+			var ev signal.ConnectorAddEthereumChainSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.AddEthereumChainRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	request, err := prepareAddEthereumChainRequest(&testDAppData, "0x1a343", []interface{}{"https://rpc.custom.chain"})
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAddEthereumChainRejectedByUser, err)
+	assert.Nil(t, state.networkManager.Find(testCustomChainID))
+}
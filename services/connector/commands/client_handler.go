@@ -11,6 +11,7 @@ import (
 
 	"github.com/status-im/status-go/eth-node/types"
 	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/signingpreview"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/signal"
 )
@@ -27,6 +28,10 @@ var (
 	ErrAnotherConnectorOperationIsAwaitingFor = fmt.Errorf("another connector operation is awaiting for user input")
 	ErrEmptyUrl                               = fmt.Errorf("empty URL")
 	ErrDAppDoesNotHavePermissions             = fmt.Errorf("dApp does not have permissions")
+	ErrAddEthereumChainRejectedByUser         = fmt.Errorf("add ethereum chain was rejected by user")
+	ErrWatchAssetRejectedByUser               = fmt.Errorf("watch asset was rejected by user")
+	ErrSendCallsRejectedByUser                = fmt.Errorf("send calls was rejected by user")
+	ErrSendCallsHashesMismatch                = fmt.Errorf("number of sent transactions does not match number of calls")
 )
 
 type MessageType int
@@ -36,6 +41,9 @@ const (
 	SendTransactionAccepted
 	SignAccepted
 	Rejected
+	AddEthereumChainAccepted
+	WatchAssetAccepted
+	SendCallsAccepted
 )
 
 type Message struct {
@@ -57,6 +65,17 @@ func NewClientSideHandler(db *sql.DB) *ClientSideHandler {
 	}
 }
 
+func marshalPreview(preview *signingpreview.Preview) (json.RawMessage, error) {
+	if preview == nil {
+		return nil, nil
+	}
+	previewJson, err := json.Marshal(preview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal preview: %v", err)
+	}
+	return previewJson, nil
+}
+
 func (c *ClientSideHandler) generateRequestID(dApp signal.ConnectorDApp) string {
 	rawID := fmt.Sprintf("%d%s", time.Now().UnixMilli(), dApp.URL)
 	hash := sha256.Sum256([]byte(rawID))
@@ -148,7 +167,7 @@ func (c *ClientSideHandler) RecallDAppPermissions(args RecallDAppPermissionsArgs
 	return nil
 }
 
-func (c *ClientSideHandler) RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *wallettypes.SendTxArgs) (types.Hash, error) {
+func (c *ClientSideHandler) RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *wallettypes.SendTxArgs, preview *signingpreview.Preview) (types.Hash, error) {
 	if !c.setRequestRunning() {
 		return types.Hash{}, ErrAnotherConnectorOperationIsAwaitingFor
 	}
@@ -159,8 +178,13 @@ func (c *ClientSideHandler) RequestSendTransaction(dApp signal.ConnectorDApp, ch
 		return types.Hash{}, fmt.Errorf("failed to marshal txArgs: %v", err)
 	}
 
+	previewJson, err := marshalPreview(preview)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
 	requestID := c.generateRequestID(dApp)
-	signal.SendConnectorSendTransaction(dApp, chainID, string(txArgsJson), requestID)
+	signal.SendConnectorSendTransaction(dApp, chainID, string(txArgsJson), requestID, previewJson)
 
 	timeout := time.After(WalletResponseMaxInterval)
 
@@ -203,14 +227,77 @@ func (c *ClientSideHandler) SendTransactionRejected(args RejectedArgs) error {
 	return nil
 }
 
-func (c *ClientSideHandler) RequestSign(dApp signal.ConnectorDApp, challenge, address string, method string) (string, error) {
+func (c *ClientSideHandler) RequestSendCalls(dApp signal.ConnectorDApp, chainID uint64, txsArgs []*wallettypes.SendTxArgs) ([]types.Hash, error) {
+	if !c.setRequestRunning() {
+		return nil, ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	txsArgsJson, err := json.Marshal(txsArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal txsArgs: %v", err)
+	}
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorSendCalls(dApp, chainID, string(txsArgsJson), requestID)
+
+	timeout := time.After(WalletResponseMaxInterval)
+
+	for {
+		select {
+		case msg := <-c.responseChannel:
+			switch msg.Type {
+			case SendCallsAccepted:
+				response := msg.Data.(SendCallsAcceptedArgs)
+				if response.RequestID == requestID {
+					if len(response.Hashes) != len(txsArgs) {
+						return nil, ErrSendCallsHashesMismatch
+					}
+					return response.Hashes, nil
+				}
+			case Rejected:
+				response := msg.Data.(RejectedArgs)
+				if response.RequestID == requestID {
+					return nil, ErrSendCallsRejectedByUser
+				}
+			}
+		case <-timeout:
+			return nil, ErrWalletResponseTimeout
+		}
+	}
+}
+
+func (c *ClientSideHandler) SendCallsAccepted(args SendCallsAcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: SendCallsAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) SendCallsRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) RequestSign(dApp signal.ConnectorDApp, challenge, address string, method string, preview *signingpreview.Preview) (string, error) {
 	if !c.setRequestRunning() {
 		return "", ErrAnotherConnectorOperationIsAwaitingFor
 	}
 	defer c.clearRequestRunning()
 
+	previewJson, err := marshalPreview(preview)
+	if err != nil {
+		return "", err
+	}
+
 	requestID := c.generateRequestID(dApp)
-	signal.SendConnectorSign(dApp, requestID, challenge, address, method)
+	signal.SendConnectorSign(dApp, requestID, challenge, address, method, previewJson)
 
 	timeout := time.After(WalletResponseMaxInterval)
 
@@ -252,3 +339,113 @@ func (c *ClientSideHandler) SignRejected(args RejectedArgs) error {
 	c.responseChannel <- Message{Type: Rejected, Data: args}
 	return nil
 }
+
+func (c *ClientSideHandler) RequestAddEthereumChain(dApp signal.ConnectorDApp, chainID uint64, chainArgs *AddEthereumChainParams) error {
+	if !c.setRequestRunning() {
+		return ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	chainArgsJson, err := json.Marshal(chainArgs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chainArgs: %v", err)
+	}
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorAddEthereumChain(dApp, chainID, string(chainArgsJson), requestID)
+
+	timeout := time.After(WalletResponseMaxInterval)
+
+	for {
+		select {
+		case msg := <-c.responseChannel:
+			switch msg.Type {
+			case AddEthereumChainAccepted:
+				response := msg.Data.(AddEthereumChainAcceptedArgs)
+				if response.RequestID == requestID {
+					return nil
+				}
+			case Rejected:
+				response := msg.Data.(RejectedArgs)
+				if response.RequestID == requestID {
+					return ErrAddEthereumChainRejectedByUser
+				}
+			}
+		case <-timeout:
+			return ErrWalletResponseTimeout
+		}
+	}
+}
+
+func (c *ClientSideHandler) AddEthereumChainAccepted(args AddEthereumChainAcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: AddEthereumChainAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) AddEthereumChainRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) RequestWatchAsset(dApp signal.ConnectorDApp, chainID uint64, assetArgs *WatchAssetParams) error {
+	if !c.setRequestRunning() {
+		return ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	assetArgsJson, err := json.Marshal(assetArgs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal assetArgs: %v", err)
+	}
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorWatchAsset(dApp, chainID, string(assetArgsJson), requestID)
+
+	timeout := time.After(WalletResponseMaxInterval)
+
+	for {
+		select {
+		case msg := <-c.responseChannel:
+			switch msg.Type {
+			case WatchAssetAccepted:
+				response := msg.Data.(WatchAssetAcceptedArgs)
+				if response.RequestID == requestID {
+					return nil
+				}
+			case Rejected:
+				response := msg.Data.(RejectedArgs)
+				if response.RequestID == requestID {
+					return ErrWatchAssetRejectedByUser
+				}
+			}
+		case <-timeout:
+			return ErrWalletResponseTimeout
+		}
+	}
+}
+
+func (c *ClientSideHandler) WatchAssetAccepted(args WatchAssetAcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: WatchAssetAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) WatchAssetRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
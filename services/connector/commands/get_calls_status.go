@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/rpc"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/transactions"
+)
+
+// Batch status codes as defined by EIP-5792
+const (
+	CallsStatusPending        = 100
+	CallsStatusConfirmed      = 200
+	CallsStatusOffchainFailed = 400
+	CallsStatusReverted       = 500
+	CallsStatusPartialReverts = 600
+)
+
+var (
+	ErrNoCallsBatchIDFound = errors.New("no calls batch id in params found")
+	ErrUnknownCallsBatchID = errors.New("unknown calls batch id")
+)
+
+type CallsReceipt struct {
+	Logs            []*gethTypes.Log `json:"logs"`
+	Status          hexutil.Uint64   `json:"status"`
+	BlockHash       common.Hash      `json:"blockHash"`
+	BlockNumber     *hexutil.Big     `json:"blockNumber"`
+	GasUsed         hexutil.Uint64   `json:"gasUsed"`
+	TransactionHash common.Hash      `json:"transactionHash"`
+}
+
+type GetCallsStatusResult struct {
+	Version  string          `json:"version"`
+	ID       string          `json:"id"`
+	ChainID  string          `json:"chainId"`
+	Status   int             `json:"status"`
+	Atomic   bool            `json:"atomic"`
+	Receipts []*CallsReceipt `json:"receipts,omitempty"`
+}
+
+type GetCallsStatusCommand struct {
+	RpcClient        rpc.ClientInterface
+	Db               *sql.DB
+	PendingTxTracker PendingTxTrackerInterface
+}
+
+func (r *RPCRequest) getCallsBatchID() (string, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return "", ErrEmptyRPCParams
+	}
+
+	batchID, ok := r.Params[0].(string)
+	if !ok || batchID == "" {
+		return "", ErrNoCallsBatchIDFound
+	}
+
+	return batchID, nil
+}
+
+func aggregateCallsStatus(statuses []transactions.TxStatus) int {
+	succeeded, failed := 0, 0
+	for _, status := range statuses {
+		switch status {
+		case transactions.Success:
+			succeeded++
+		case transactions.Failed:
+			failed++
+		default:
+			return CallsStatusPending
+		}
+	}
+
+	if failed == 0 {
+		return CallsStatusConfirmed
+	}
+	if succeeded == 0 {
+		return CallsStatusReverted
+	}
+	return CallsStatusPartialReverts
+}
+
+func (c *GetCallsStatusCommand) fetchReceipts(ctx context.Context, batch *persistence.CallsBatch) ([]*CallsReceipt, error) {
+	ethClient, err := c.RpcClient.EthClient(batch.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*CallsReceipt, 0, len(batch.TxHashes))
+	for _, hash := range batch.TxHashes {
+		receipt, err := ethClient.TransactionReceipt(ctx, common.Hash(hash))
+		if err != nil {
+			return nil, err
+		}
+
+		receipts = append(receipts, &CallsReceipt{
+			Logs:            receipt.Logs,
+			Status:          hexutil.Uint64(receipt.Status),
+			BlockHash:       receipt.BlockHash,
+			BlockNumber:     (*hexutil.Big)(receipt.BlockNumber),
+			GasUsed:         hexutil.Uint64(receipt.GasUsed),
+			TransactionHash: receipt.TxHash,
+		})
+	}
+
+	return receipts, nil
+}
+
+func (c *GetCallsStatusCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	if c.PendingTxTracker == nil {
+		return "", ErrPendingTrackerNotSet
+	}
+
+	batchID, err := request.getCallsBatchID()
+	if err != nil {
+		return "", err
+	}
+
+	// Batches are only visible to the dApp which sent them
+	batch, err := persistence.SelectCallsBatch(c.Db, request.URL, batchID)
+	if err != nil {
+		return "", err
+	}
+
+	if batch == nil {
+		return "", ErrUnknownCallsBatchID
+	}
+
+	statuses := make([]transactions.TxStatus, 0, len(batch.TxHashes))
+	for _, hash := range batch.TxHashes {
+		status, err := c.PendingTxTracker.GetTrackedTxStatus(walletCommon.ChainID(batch.ChainID), common.Hash(hash))
+		if err != nil {
+			if err != sql.ErrNoRows {
+				return "", err
+			}
+			status = transactions.Pending
+		}
+		statuses = append(statuses, status)
+	}
+
+	result := GetCallsStatusResult{
+		Version: SendCallsVersion,
+		ID:      batch.ID,
+		ChainID: hexutil.EncodeUint64(batch.ChainID),
+		Status:  aggregateCallsStatus(statuses),
+		Atomic:  false,
+	}
+
+	if result.Status != CallsStatusPending {
+		result.Receipts, err = c.fetchReceipts(ctx, batch)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return result, nil
+}
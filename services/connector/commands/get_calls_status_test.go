@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/eth-node/types"
+	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/transactions"
+)
+
+var testCallsBatchHashes = []types.Hash{{0x51}, {0x52}}
+
+func insertTestCallsBatch(t *testing.T, state testState) {
+	err := persistence.InsertCallsBatch(state.walletDb, &persistence.CallsBatch{
+		ID:        "test-batch",
+		URL:       testDAppData.URL,
+		ChainID:   1,
+		From:      types.Address{0x01},
+		Timestamp: 1,
+		TxHashes:  testCallsBatchHashes,
+	})
+	assert.NoError(t, err)
+}
+
+func TestFailToGetCallsStatusOfUnknownBatch(t *testing.T) {
+	state, close := setupCommand(t, Method_GetCallsStatus)
+	t.Cleanup(close)
+
+	request, err := ConstructRPCRequest("wallet_getCallsStatus", []interface{}{"test-batch"}, &testDAppData)
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrUnknownCallsBatchID, err)
+
+	insertTestCallsBatch(t, state)
+
+	// Batches sent by other dApps are not exposed
+	otherDApp := testDAppData
+	otherDApp.URL = "http://otherDAppURL"
+	request, err = ConstructRPCRequest("wallet_getCallsStatus", []interface{}{"test-batch"}, &otherDApp)
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrUnknownCallsBatchID, err)
+}
+
+func TestGetCallsStatusPending(t *testing.T) {
+	state, close := setupCommand(t, Method_GetCallsStatus)
+	t.Cleanup(close)
+
+	insertTestCallsBatch(t, state)
+	state.pendingTxTracker.statuses[common.Hash(testCallsBatchHashes[0])] = transactions.Success
+
+	request, err := ConstructRPCRequest("wallet_getCallsStatus", []interface{}{"test-batch"}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+
+	result := response.(GetCallsStatusResult)
+	assert.Equal(t, SendCallsVersion, result.Version)
+	assert.Equal(t, "test-batch", result.ID)
+	assert.Equal(t, "0x1", result.ChainID)
+	assert.Equal(t, CallsStatusPending, result.Status)
+	assert.False(t, result.Atomic)
+	assert.Empty(t, result.Receipts)
+}
+
+func TestGetCallsStatusConfirmed(t *testing.T) {
+	state, close := setupCommand(t, Method_GetCallsStatus)
+	t.Cleanup(close)
+
+	insertTestCallsBatch(t, state)
+	state.pendingTxTracker.statuses[common.Hash(testCallsBatchHashes[0])] = transactions.Success
+	state.pendingTxTracker.statuses[common.Hash(testCallsBatchHashes[1])] = transactions.Failed
+
+	mockedChainClient := mock_client.NewMockClientInterface(state.mockCtrl)
+	state.rpcClient.EXPECT().EthClient(uint64(1)).Times(1).Return(mockedChainClient, nil)
+	for i, hash := range testCallsBatchHashes {
+		mockedChainClient.EXPECT().TransactionReceipt(state.ctx, common.Hash(hash)).Times(1).Return(&gethTypes.Receipt{
+			Status:      uint64(1 - i),
+			TxHash:      common.Hash(hash),
+			BlockHash:   common.Hash{0x10},
+			BlockNumber: big.NewInt(10),
+			GasUsed:     21000,
+		}, nil)
+	}
+
+	request, err := ConstructRPCRequest("wallet_getCallsStatus", []interface{}{"test-batch"}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+
+	result := response.(GetCallsStatusResult)
+	assert.Equal(t, CallsStatusPartialReverts, result.Status)
+	assert.Len(t, result.Receipts, 2)
+	assert.Equal(t, hexutil.Uint64(1), result.Receipts[0].Status)
+	assert.Equal(t, hexutil.Uint64(0), result.Receipts[1].Status)
+	assert.Equal(t, common.Hash(testCallsBatchHashes[1]), result.Receipts[1].TransactionHash)
+}
+
+func TestAggregateCallsStatus(t *testing.T) {
+	assert.Equal(t, CallsStatusPending, aggregateCallsStatus([]transactions.TxStatus{transactions.Success, transactions.Pending}))
+	assert.Equal(t, CallsStatusConfirmed, aggregateCallsStatus([]transactions.TxStatus{transactions.Success, transactions.Success}))
+	assert.Equal(t, CallsStatusReverted, aggregateCallsStatus([]transactions.TxStatus{transactions.Failed, transactions.Failed}))
+	assert.Equal(t, CallsStatusPartialReverts, aggregateCallsStatus([]transactions.TxStatus{transactions.Failed, transactions.Success}))
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/services/connector/chainutils"
+	persistence "github.com/status-im/status-go/services/connector/database"
+)
+
+const AtomicCapabilityUnsupported = "unsupported"
+
+type AtomicCapability struct {
+	Status string `json:"status"`
+}
+
+type Capabilities struct {
+	Atomic AtomicCapability `json:"atomic"`
+}
+
+type GetCapabilitiesCommand struct {
+	NetworkManager *network.Manager
+	Db             *sql.DB
+}
+
+func (r *RPCRequest) getCapabilitiesParams() (types.Address, []string, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return types.Address{}, nil, ErrEmptyRPCParams
+	}
+
+	address, ok := r.Params[0].(string)
+	if !ok || !types.IsHexAddress(address) {
+		return types.Address{}, nil, ErrInvalidParamType
+	}
+
+	var chainIDs []string
+	if len(r.Params) > 1 {
+		rawChainIDs, ok := r.Params[1].([]interface{})
+		if !ok {
+			return types.Address{}, nil, ErrInvalidParamType
+		}
+		for _, rawChainID := range rawChainIDs {
+			chainID, ok := rawChainID.(string)
+			if !ok {
+				return types.Address{}, nil, ErrInvalidParamType
+			}
+			chainIDs = append(chainIDs, strings.ToLower(chainID))
+		}
+	}
+
+	return types.HexToAddress(address), chainIDs, nil
+}
+
+func (c *GetCapabilitiesCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	address, requestedChainIDs, err := request.getCapabilitiesParams()
+	if err != nil {
+		return "", err
+	}
+
+	if address != dApp.SharedAccount {
+		return "", ErrParamsFromAddressIsNotShared
+	}
+
+	supportedChainIDs, err := chainutils.GetSupportedChainIDs(c.NetworkManager)
+	if err != nil {
+		return "", err
+	}
+
+	capabilities := make(map[string]Capabilities)
+	for _, chainID := range supportedChainIDs {
+		capabilities[hexutil.EncodeUint64(chainID)] = Capabilities{
+			Atomic: AtomicCapability{
+				Status: AtomicCapabilityUnsupported,
+			},
+		}
+	}
+
+	// Only the requested chains are reported when the dApp filters them
+	if len(requestedChainIDs) > 0 {
+		filtered := make(map[string]Capabilities)
+		for _, chainID := range requestedChainIDs {
+			if capability, ok := capabilities[chainID]; ok {
+				filtered[chainID] = capability
+			}
+		}
+		capabilities = filtered
+	}
+
+	return capabilities, nil
+}
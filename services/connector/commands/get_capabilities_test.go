@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/status-im/status-go/eth-node/types"
+)
+
+func TestFailToGetCapabilitiesWithoutPermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_GetCapabilities)
+	t.Cleanup(close)
+
+	request, err := ConstructRPCRequest("wallet_getCapabilities", []interface{}{types.Address{0x01}.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+}
+
+func TestFailToGetCapabilitiesForNotSharedAccount(t *testing.T) {
+	state, close := setupCommand(t, Method_GetCapabilities)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := ConstructRPCRequest("wallet_getCapabilities", []interface{}{types.Address{0x02}.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrParamsFromAddressIsNotShared, err)
+}
+
+func TestGetCapabilities(t *testing.T) {
+	state, close := setupCommand(t, Method_GetCapabilities)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := ConstructRPCRequest("wallet_getCapabilities", []interface{}{types.Address{0x01}.Hex()}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+
+	capabilities := response.(map[string]Capabilities)
+	assert.Len(t, capabilities, 2)
+	assert.Equal(t, AtomicCapabilityUnsupported, capabilities["0x1"].Atomic.Status)
+	assert.Equal(t, AtomicCapabilityUnsupported, capabilities["0xa"].Atomic.Status)
+
+	// Filtered by the requested chains
+	request, err = ConstructRPCRequest("wallet_getCapabilities", []interface{}{types.Address{0x01}.Hex(), []interface{}{"0xa", "0x89"}}, &testDAppData)
+	assert.NoError(t, err)
+
+	response, err = state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+
+	capabilities = response.(map[string]Capabilities)
+	assert.Len(t, capabilities, 1)
+	assert.Contains(t, capabilities, "0xa")
+}
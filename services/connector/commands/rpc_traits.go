@@ -6,10 +6,16 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/params"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/signingpreview"
+	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/signal"
+	"github.com/status-im/status-go/transactions"
 )
 
 const (
@@ -22,6 +28,11 @@ const (
 	Method_RequestPermissions  = "wallet_requestPermissions"
 	Method_RevokePermissions   = "wallet_revokePermissions"
 	Method_SwitchEthereumChain = "wallet_switchEthereumChain"
+	Method_AddEthereumChain    = "wallet_addEthereumChain"
+	Method_WatchAsset          = "wallet_watchAsset"
+	Method_SendCalls           = "wallet_sendCalls"
+	Method_GetCallsStatus      = "wallet_getCallsStatus"
+	Method_GetCapabilities     = "wallet_getCapabilities"
 )
 
 // errors
@@ -57,11 +68,24 @@ type SendTransactionAcceptedArgs struct {
 	Hash      types.Hash `json:"hash"`
 }
 
+type SendCallsAcceptedArgs struct {
+	RequestID string       `json:"requestId"`
+	Hashes    []types.Hash `json:"hashes"`
+}
+
 type SignAcceptedArgs struct {
 	RequestID string `json:"requestId"`
 	Signature string `json:"signature"`
 }
 
+type AddEthereumChainAcceptedArgs struct {
+	RequestID string `json:"requestId"`
+}
+
+type WatchAssetAcceptedArgs struct {
+	RequestID string `json:"requestId"`
+}
+
 type RejectedArgs struct {
 	RequestID string `json:"requestId"`
 }
@@ -76,13 +100,25 @@ type ClientSideHandlerInterface interface {
 	RequestAccountsRejected(args RejectedArgs) error
 	RecallDAppPermissions(args RecallDAppPermissionsArgs) error
 
-	RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *wallettypes.SendTxArgs) (types.Hash, error)
+	RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *wallettypes.SendTxArgs, preview *signingpreview.Preview) (types.Hash, error)
 	SendTransactionAccepted(args SendTransactionAcceptedArgs) error
 	SendTransactionRejected(args RejectedArgs) error
 
-	RequestSign(dApp signal.ConnectorDApp, challenge, address string, method string) (string, error)
+	RequestSendCalls(dApp signal.ConnectorDApp, chainID uint64, txsArgs []*wallettypes.SendTxArgs) ([]types.Hash, error)
+	SendCallsAccepted(args SendCallsAcceptedArgs) error
+	SendCallsRejected(args RejectedArgs) error
+
+	RequestSign(dApp signal.ConnectorDApp, challenge, address string, method string, preview *signingpreview.Preview) (string, error)
 	SignAccepted(args SignAcceptedArgs) error
 	SignRejected(args RejectedArgs) error
+
+	RequestAddEthereumChain(dApp signal.ConnectorDApp, chainID uint64, chainArgs *AddEthereumChainParams) error
+	AddEthereumChainAccepted(args AddEthereumChainAcceptedArgs) error
+	AddEthereumChainRejected(args RejectedArgs) error
+
+	RequestWatchAsset(dApp signal.ConnectorDApp, chainID uint64, assetArgs *WatchAssetParams) error
+	WatchAssetAccepted(args WatchAssetAcceptedArgs) error
+	WatchAssetRejected(args RejectedArgs) error
 }
 
 type NetworkManagerInterface interface {
@@ -93,13 +129,41 @@ type RPCClientInterface interface {
 	CallRaw(body string) string
 }
 
+type TokenManagerInterface interface {
+	UpsertCustom(token tokenTypes.Token) error
+}
+
+type SigningPreviewerInterface interface {
+	PreviewMessage(challenge string, chainID uint64) *signingpreview.Preview
+	PreviewTypedData(typedJson string, chainID uint64) (*signingpreview.Preview, error)
+	PreviewTransaction(chainID uint64, args *wallettypes.SendTxArgs) (*signingpreview.Preview, error)
+}
+
+type PendingTxTrackerInterface interface {
+	StoreAndTrackPendingTx(transaction *transactions.PendingTransaction) error
+	GetTrackedTxStatus(chainID walletCommon.ChainID, hash common.Hash) (transactions.TxStatus, error)
+}
+
 func RPCRequestFromJSON(inputJSON string) (RPCRequest, error) {
 	var request RPCRequest
 
 	err := json.Unmarshal([]byte(inputJSON), &request)
-	if err != nil {
+	if err == nil {
+		return request, nil
+	}
+
+	// Some methods (e.g. wallet_watchAsset, EIP-747) pass a single object as params
+	// instead of an array, wrap it so that all commands can handle params the same way
+	var objectParamsRequest struct {
+		RPCRequest
+		Params map[string]interface{} `json:"params"`
+	}
+	if objErr := json.Unmarshal([]byte(inputJSON), &objectParamsRequest); objErr != nil {
 		return RPCRequest{}, fmt.Errorf("error unmarshalling JSON: %v", err)
 	}
+
+	request = objectParamsRequest.RPCRequest
+	request.Params = []interface{}{objectParamsRequest.Params}
 	return request, nil
 }
 
@@ -0,0 +1,261 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/signal"
+	"github.com/status-im/status-go/transactions"
+)
+
+const (
+	// SendCallsVersion is the EIP-5792 version supported by the connector
+	SendCallsVersion = "2.0.0"
+
+	maxCallsBatchIDLength = 4096
+)
+
+var (
+	ErrNoSendCallsParamsFound    = errors.New("no calls in params found")
+	ErrEmptyCalls                = errors.New("calls are empty")
+	ErrAtomicBatchUnsupported    = errors.New("atomic execution of calls is not supported")
+	ErrSendCallsChainIDMismatch  = errors.New("chain id does not match dApp's active chain")
+	ErrCallsBatchIDInvalid       = errors.New("invalid calls batch id")
+	ErrCallsBatchIDAlreadyExists = errors.New("calls batch id already exists")
+	ErrPendingTrackerNotSet      = errors.New("pending transaction tracker is not initialized")
+)
+
+// Call is a single call of an EIP-5792 batch
+type Call struct {
+	To    *types.Address `json:"to,omitempty"`
+	Data  types.HexBytes `json:"data,omitempty"`
+	Value *hexutil.Big   `json:"value,omitempty"`
+}
+
+// SendCallsParams represents the wallet_sendCalls parameters as defined by EIP-5792
+type SendCallsParams struct {
+	Version        string         `json:"version"`
+	ID             string         `json:"id,omitempty"`
+	From           *types.Address `json:"from,omitempty"`
+	ChainID        string         `json:"chainId"`
+	AtomicRequired bool           `json:"atomicRequired"`
+	Calls          []Call         `json:"calls"`
+}
+
+type SendCallsResult struct {
+	ID string `json:"id"`
+}
+
+type SendCallsCommand struct {
+	RpcClient        rpc.ClientInterface
+	Db               *sql.DB
+	ClientHandler    ClientSideHandlerInterface
+	PendingTxTracker PendingTxTrackerInterface
+}
+
+func (r *RPCRequest) getSendCallsParams() (*SendCallsParams, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return nil, ErrEmptyRPCParams
+	}
+
+	paramMap, ok := r.Params[0].(map[string]interface{})
+	if !ok {
+		return nil, ErrNoSendCallsParamsFound
+	}
+
+	paramBytes, err := json.Marshal(paramMap)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling first send calls param: %v", err)
+	}
+
+	var sendCallsParams SendCallsParams
+	err = json.Unmarshal(paramBytes, &sendCallsParams)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling first send calls param to SendCallsParams: %v", err)
+	}
+
+	return &sendCallsParams, nil
+}
+
+func generateCallsBatchID() (string, error) {
+	id := make([]byte, 32)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(id), nil
+}
+
+func (c *SendCallsCommand) toSendTxArgs(ctx context.Context, chainID uint64, from types.Address, calls []Call) ([]*wallettypes.SendTxArgs, error) {
+	fetchedFees, err := fetchSuggestedFees(ctx, c.RpcClient, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := fetchPendingNonce(ctx, c.RpcClient, chainID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	txsArgs := make([]*wallettypes.SendTxArgs, 0, len(calls))
+	for i, call := range calls {
+		txArgs := &wallettypes.SendTxArgs{
+			From:  from,
+			To:    call.To,
+			Value: call.Value,
+			Data:  call.Data,
+		}
+
+		if txArgs.Value == nil {
+			txArgs.Value = (*hexutil.Big)(big.NewInt(0))
+		}
+
+		err = setSuggestedFees(txArgs, fetchedFees)
+		if err != nil {
+			return nil, err
+		}
+
+		// Calls are executed sequentially, one nonce after another
+		callNonce := nonce + uint64(i)
+		txArgs.Nonce = (*hexutil.Uint64)(&callNonce)
+
+		txsArgs = append(txsArgs, txArgs)
+	}
+
+	return txsArgs, nil
+}
+
+func (c *SendCallsCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	if c.PendingTxTracker == nil {
+		return "", ErrPendingTrackerNotSet
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	params, err := request.getSendCallsParams()
+	if err != nil {
+		return "", err
+	}
+
+	if len(params.Calls) == 0 {
+		return "", ErrEmptyCalls
+	}
+
+	if params.AtomicRequired {
+		return "", ErrAtomicBatchUnsupported
+	}
+
+	if params.From != nil && *params.From != dApp.SharedAccount {
+		return "", ErrParamsFromAddressIsNotShared
+	}
+
+	if params.ChainID != "" {
+		chainID, err := hexStringToUint64(params.ChainID)
+		if err != nil {
+			return "", err
+		}
+		if chainID != dApp.ChainID {
+			return "", ErrSendCallsChainIDMismatch
+		}
+	}
+
+	batchID := params.ID
+	if batchID == "" {
+		batchID, err = generateCallsBatchID()
+		if err != nil {
+			return "", err
+		}
+	} else if len(batchID) > maxCallsBatchIDLength {
+		return "", ErrCallsBatchIDInvalid
+	} else {
+		// the IDs are unique per dApp, the batches of the other dApps are not visible
+		existingBatch, err := persistence.SelectCallsBatch(c.Db, dApp.URL, batchID)
+		if err != nil {
+			return "", err
+		}
+		if existingBatch != nil {
+			return "", ErrCallsBatchIDAlreadyExists
+		}
+	}
+
+	txsArgs, err := c.toSendTxArgs(ctx, dApp.ChainID, dApp.SharedAccount, params.Calls)
+	if err != nil {
+		return "", err
+	}
+
+	hashes, err := c.ClientHandler.RequestSendCalls(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, dApp.ChainID, txsArgs)
+	if err != nil {
+		return "", err
+	}
+
+	err = persistence.InsertCallsBatch(c.Db, &persistence.CallsBatch{
+		ID:        batchID,
+		URL:       dApp.URL,
+		ChainID:   dApp.ChainID,
+		From:      dApp.SharedAccount,
+		Timestamp: time.Now().Unix(),
+		TxHashes:  hashes,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	autoDelete := transactions.AutoDelete
+	for i, hash := range hashes {
+		var to common.Address
+		if txsArgs[i].To != nil {
+			to = common.Address(*txsArgs[i].To)
+		}
+
+		err = c.PendingTxTracker.StoreAndTrackPendingTx(&transactions.PendingTransaction{
+			Hash:           common.Hash(hash),
+			Timestamp:      uint64(time.Now().Unix()),
+			Value:          bigint.BigInt{Int: txsArgs[i].Value.ToInt()},
+			From:           common.Address(dApp.SharedAccount),
+			To:             to,
+			Data:           txsArgs[i].Data.String(),
+			Type:           transactions.ConnectorBatchCall,
+			AdditionalData: batchID,
+			ChainID:        walletCommon.ChainID(dApp.ChainID),
+			Nonce:          uint64(*txsArgs[i].Nonce),
+			AutoDelete:     &autoDelete,
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return SendCallsResult{
+		ID: batchID,
+	}, nil
+}
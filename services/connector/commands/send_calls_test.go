@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/status-im/status-go/eth-node/types"
+	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/signal"
+	"github.com/status-im/status-go/transactions"
+)
+
+func prepareSendCallsRequest(dApp signal.ConnectorDApp, from types.Address, batchID string, calls []interface{}) (RPCRequest, error) {
+	sendCallsParams := map[string]interface{}{
+		"version":        SendCallsVersion,
+		"from":           from.Hex(),
+		"chainId":        "0x1",
+		"atomicRequired": false,
+		"calls":          calls,
+	}
+	if batchID != "" {
+		sendCallsParams["id"] = batchID
+	}
+
+	return ConstructRPCRequest("wallet_sendCalls", []interface{}{sendCallsParams}, &dApp)
+}
+
+func testCalls() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"to":    types.Address{0x02}.Hex(),
+			"value": "0x1",
+		},
+		map[string]interface{}{
+			"to":   types.Address{0x03}.Hex(),
+			"data": "0xa9059cbb",
+		},
+	}
+}
+
+func expectSendCallsFeesAndNonce(state testState, accountAddress types.Address) {
+	mockedChainClient := mock_client.NewMockClientInterface(state.mockCtrl)
+	feeHistory := &fees.FeeHistory{}
+	percentiles := []int{fees.RewardPercentiles1, fees.RewardPercentiles2, fees.RewardPercentiles3}
+	state.rpcClient.EXPECT().Call(feeHistory, uint64(1), "eth_feeHistory", uint64(10), "latest", percentiles).Times(1).Return(nil)
+	state.rpcClient.EXPECT().EthClient(uint64(1)).Times(2).Return(mockedChainClient, nil)
+	mockedChainClient.EXPECT().BlockNumber(state.ctx).Times(1).Return(blockNumber, nil)
+	for i := uint64(0); i < uint64(blocksToCheck); i++ {
+		blockNum := big.NewInt(0).SetUint64(blockNumber - i)
+		mockedChainClient.EXPECT().BlockByNumber(state.ctx, blockNum).Times(1).Return(blockToReturn, nil)
+	}
+	mockedChainClient.EXPECT().SuggestGasPrice(state.ctx).Times(1).Return(big.NewInt(1), nil)
+	state.rpcClient.EXPECT().EthClient(uint64(1)).Times(1).Return(mockedChainClient, nil)
+	mockedChainClient.EXPECT().PendingNonceAt(state.ctx, common.Address(accountAddress)).Times(1).Return(uint64(10), nil)
+}
+
+func TestFailToSendCallsWithoutPermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	request, err := prepareSendCallsRequest(testDAppData, types.Address{0x01}, "", testCalls())
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+}
+
+func TestFailToSendCallsWithInvalidParams(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, accountAddress, "", []interface{}{})
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrEmptyCalls, err)
+
+	request, err = prepareSendCallsRequest(testDAppData, types.Address{0x02}, "", testCalls())
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrParamsFromAddressIsNotShared, err)
+
+	request, err = prepareSendCallsRequest(testDAppData, accountAddress, "", testCalls())
+	assert.NoError(t, err)
+	request.Params[0].(map[string]interface{})["atomicRequired"] = true
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAtomicBatchUnsupported, err)
+
+	request, err = prepareSendCallsRequest(testDAppData, accountAddress, "", testCalls())
+	assert.NoError(t, err)
+	request.Params[0].(map[string]interface{})["chainId"] = "0xa"
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrSendCallsChainIDMismatch, err)
+
+	err = persistence.InsertCallsBatch(state.walletDb, &persistence.CallsBatch{
+		ID:       "existing",
+		URL:      testDAppData.URL,
+		ChainID:  1,
+		From:     accountAddress,
+		TxHashes: []types.Hash{{0x01}},
+	})
+	assert.NoError(t, err)
+
+	request, err = prepareSendCallsRequest(testDAppData, accountAddress, "existing", testCalls())
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrCallsBatchIDAlreadyExists, err)
+}
+
+func TestSendCallsWithSignalAccepted(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	fakedHashes := []types.Hash{{0x51}, {0x52}}
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, accountAddress, "test-batch", testCalls())
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSendCalls:
+			var ev signal.ConnectorSendCallsSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(0x1), ev.ChainID)
+
+			var txsArgs []*wallettypes.SendTxArgs
+			err = json.Unmarshal([]byte(ev.TxsArgs), &txsArgs)
+			assert.NoError(t, err)
+			assert.Len(t, txsArgs, 2)
+			assert.Equal(t, uint64(10), uint64(*txsArgs[0].Nonce))
+			assert.Equal(t, uint64(11), uint64(*txsArgs[1].Nonce))
+
+			err = state.handler.SendCallsAccepted(SendCallsAcceptedArgs{
+				RequestID: ev.RequestID,
+				Hashes:    fakedHashes,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	expectSendCallsFeesAndNonce(state, accountAddress)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, SendCallsResult{ID: "test-batch"}, response)
+
+	batch, err := persistence.SelectCallsBatch(state.walletDb, testDAppData.URL, "test-batch")
+	assert.NoError(t, err)
+	assert.NotNil(t, batch)
+	assert.Equal(t, fakedHashes, batch.TxHashes)
+
+	assert.Len(t, state.pendingTxTracker.tracked, 2)
+	for i, tx := range state.pendingTxTracker.tracked {
+		assert.Equal(t, common.Hash(fakedHashes[i]), tx.Hash)
+		assert.Equal(t, transactions.ConnectorBatchCall, tx.Type)
+		assert.Equal(t, "test-batch", tx.AdditionalData)
+		assert.Equal(t, uint64(10+i), tx.Nonce)
+	}
+}
+
+func TestSendCallsWithSignalRejected(t *testing.T) {
+	state, close := setupCommand(t, Method_SendCalls)
+	t.Cleanup(close)
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendCallsRequest(testDAppData, accountAddress, "", testCalls())
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSendCalls:
+			var ev signal.ConnectorSendCallsSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.SendCallsRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	expectSendCallsFeesAndNonce(state, accountAddress)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrSendCallsRejectedByUser, err)
+	assert.Empty(t, state.pendingTxTracker.tracked)
+}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/rpc"
 	persistence "github.com/status-im/status-go/services/connector/database"
 	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/signingpreview"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/signal"
 )
@@ -27,6 +29,7 @@ type SendTransactionCommand struct {
 	RpcClient     rpc.ClientInterface
 	Db            *sql.DB
 	ClientHandler ClientSideHandlerInterface
+	Previewer     SigningPreviewerInterface
 }
 
 func (r *RPCRequest) getSendTransactionParams() (*wallettypes.SendTxArgs, error) {
@@ -86,33 +89,19 @@ func (c *SendTransactionCommand) Execute(ctx context.Context, request RPCRequest
 	}
 
 	if params.GasPrice == nil || (params.MaxFeePerGas == nil && params.MaxPriorityFeePerGas == nil) {
-		feeManager := &fees.FeeManager{
-			RPCClient: c.RpcClient,
-		}
-		fetchedFees, err := feeManager.SuggestedFees(ctx, dApp.ChainID)
+		fetchedFees, err := fetchSuggestedFees(ctx, c.RpcClient, dApp.ChainID)
 		if err != nil {
 			return "", err
 		}
 
-		if !fetchedFees.EIP1559Enabled {
-			params.GasPrice = (*hexutil.Big)(fetchedFees.GasPrice)
-		} else {
-			maxFees, priorityFee, _, err := fetchedFees.FeeFor(fees.GasFeeMedium)
-			if err != nil {
-				return "", err
-			}
-			params.MaxFeePerGas = (*hexutil.Big)(maxFees)
-			params.MaxPriorityFeePerGas = (*hexutil.Big)(priorityFee)
-		}
-	}
-
-	if params.Nonce == nil {
-		ethClient, err := c.RpcClient.EthClient(dApp.ChainID)
+		err = setSuggestedFees(params, fetchedFees)
 		if err != nil {
 			return "", err
 		}
+	}
 
-		nonce, err := ethClient.PendingNonceAt(ctx, common.Address(dApp.SharedAccount))
+	if params.Nonce == nil {
+		nonce, err := fetchPendingNonce(ctx, c.RpcClient, dApp.ChainID, dApp.SharedAccount)
 		if err != nil {
 			return "", err
 		}
@@ -120,13 +109,50 @@ func (c *SendTransactionCommand) Execute(ctx context.Context, request RPCRequest
 		params.Nonce = (*hexutil.Uint64)(&nonce)
 	}
 
+	var preview *signingpreview.Preview
+	if c.Previewer != nil {
+		// the transaction is still requested without a preview if it can't be decoded
+		preview, _ = c.Previewer.PreviewTransaction(dApp.ChainID, params)
+	}
+
 	hash, err := c.ClientHandler.RequestSendTransaction(signal.ConnectorDApp{
 		URL:     request.URL,
 		Name:    request.Name,
 		IconURL: request.IconURL,
-	}, dApp.ChainID, params)
+	}, dApp.ChainID, params, preview)
 	if err != nil {
 		return "", err
 	}
 	return hash.String(), nil
 }
+
+func fetchSuggestedFees(ctx context.Context, rpcClient rpc.ClientInterface, chainID uint64) (*fees.SuggestedFees, error) {
+	feeManager := &fees.FeeManager{
+		RPCClient: rpcClient,
+	}
+	return feeManager.SuggestedFees(ctx, chainID)
+}
+
+func setSuggestedFees(params *wallettypes.SendTxArgs, fetchedFees *fees.SuggestedFees) error {
+	if !fetchedFees.EIP1559Enabled {
+		params.GasPrice = (*hexutil.Big)(fetchedFees.GasPrice)
+		return nil
+	}
+
+	maxFees, priorityFee, _, err := fetchedFees.FeeFor(fees.GasFeeMedium)
+	if err != nil {
+		return err
+	}
+	params.MaxFeePerGas = (*hexutil.Big)(maxFees)
+	params.MaxPriorityFeePerGas = (*hexutil.Big)(priorityFee)
+	return nil
+}
+
+func fetchPendingNonce(ctx context.Context, rpcClient rpc.ClientInterface, chainID uint64, account types.Address) (uint64, error) {
+	ethClient, err := rpcClient.EthClient(chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	return ethClient.PendingNonceAt(ctx, common.Address(account))
+}
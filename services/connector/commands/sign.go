@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/signingpreview"
 	"github.com/status-im/status-go/signal"
 )
 
@@ -18,6 +19,7 @@ var (
 type SignCommand struct {
 	Db            *sql.DB
 	ClientHandler ClientSideHandlerInterface
+	Previewer     SigningPreviewerInterface
 }
 
 type SignParams struct {
@@ -90,5 +92,21 @@ func (c *SignCommand) Execute(ctx context.Context, request RPCRequest) (interfac
 		URL:     request.URL,
 		Name:    request.Name,
 		IconURL: request.IconURL,
-	}, params.Challenge, params.Address, params.Method)
+	}, params.Challenge, params.Address, params.Method, c.preview(params, dApp.ChainID))
+}
+
+// preview returns the summary of the signed data shown to the user, the request is still sent without a preview if
+// the data can't be decoded
+func (c *SignCommand) preview(params *SignParams, chainID uint64) *signingpreview.Preview {
+	if c.Previewer == nil {
+		return nil
+	}
+	if params.Method == Method_PersonalSign {
+		return c.Previewer.PreviewMessage(params.Challenge, chainID)
+	}
+	preview, err := c.Previewer.PreviewTypedData(params.Challenge, chainID)
+	if err != nil {
+		return nil
+	}
+	return preview
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/services/wallet/signingpreview"
 	"github.com/status-im/status-go/signal"
 )
 
@@ -273,3 +274,51 @@ func TestUnsupportedSignMethod(t *testing.T) {
 	assert.Equal(t, ErrInvalidMethod, err)
 	assert.Equal(t, response, "")
 }
+
+func TestTypedDataV4SignRequestWithPreview(t *testing.T) {
+	state, close := setupCommand(t, Method_SignTypedDataV4)
+	t.Cleanup(close)
+	state.cmd.(*SignCommand).Previewer = signingpreview.NewPreviewer(nil)
+
+	fakedSignature := "0x051"
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	// unlimited permit for an unknown spender
+	challenge := "{\"domain\":{\"chainId\":1,\"name\":\"USD Coin\",\"verifyingContract\":\"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48\",\"version\":\"2\"},\"message\":{\"owner\":\"0x4B0897b0513FdBeEc7C469D9aF4fA6C0752aBea7\",\"spender\":\"0x1111111111111111111111111111111111111111\",\"value\":\"115792089237316195423570985008687907853269984665640564039457584007913129639935\",\"nonce\":0,\"deadline\":1700000000},\"primaryType\":\"Permit\",\"types\":{\"EIP712Domain\":[{\"name\":\"name\",\"type\":\"string\"},{\"name\":\"version\",\"type\":\"string\"},{\"name\":\"chainId\",\"type\":\"uint256\"},{\"name\":\"verifyingContract\",\"type\":\"address\"}],\"Permit\":[{\"name\":\"owner\",\"type\":\"address\"},{\"name\":\"spender\",\"type\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\"},{\"name\":\"nonce\",\"type\":\"uint256\"},{\"name\":\"deadline\",\"type\":\"uint256\"}]}}"
+	address := "0x4B0897b0513FdBeEc7C469D9aF4fA6C0752aBea7"
+	request, err := prepareTypedDataV4SignRequest(testDAppData, challenge, address)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSign:
+			var ev signal.ConnectorSignSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			var preview signingpreview.Preview
+			err = json.Unmarshal(ev.Preview, &preview)
+			assert.NoError(t, err)
+			assert.Equal(t, signingpreview.KindPermit, preview.Kind)
+			assert.Equal(t, signingpreview.RiskLevelCritical, preview.RiskLevel)
+			assert.Len(t, preview.Risks, 2)
+
+			err = state.handler.SignAccepted(SignAcceptedArgs{
+				Signature: fakedSignature,
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, response, fakedSignature)
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	mock_rpcclient "github.com/status-im/status-go/rpc/mock/client"
 
 	"github.com/status-im/status-go/appdatabase"
@@ -19,8 +21,10 @@ import (
 	network_testutil "github.com/status-im/status-go/rpc/network/testutil"
 	persistence "github.com/status-im/status-go/services/connector/database"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
 	"github.com/status-im/status-go/signal"
 	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/transactions"
 	"github.com/status-im/status-go/walletdatabase"
 )
 
@@ -57,6 +61,37 @@ type testState struct {
 	handler   *ClientSideHandler
 	mockCtrl  *gomock.Controller
 	rpcClient *mock_rpcclient.MockClientInterface
+
+	networkManager   *network.Manager
+	tokenManager     *testTokenManager
+	pendingTxTracker *testPendingTxTracker
+}
+
+type testTokenManager struct {
+	tokens []tokenTypes.Token
+}
+
+func (m *testTokenManager) UpsertCustom(token tokenTypes.Token) error {
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+type testPendingTxTracker struct {
+	tracked  []*transactions.PendingTransaction
+	statuses map[common.Hash]transactions.TxStatus
+}
+
+func (m *testPendingTxTracker) StoreAndTrackPendingTx(transaction *transactions.PendingTransaction) error {
+	m.tracked = append(m.tracked, transaction)
+	return nil
+}
+
+func (m *testPendingTxTracker) GetTrackedTxStatus(chainID walletCommon.ChainID, hash common.Hash) (transactions.TxStatus, error) {
+	status, ok := m.statuses[hash]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return status, nil
 }
 
 func setupCommand(t *testing.T, method string) (state testState, close func()) {
@@ -82,6 +117,7 @@ func setupCommand(t *testing.T, method string) (state testState, close func()) {
 	}
 	err := networkManager.InitEmbeddedNetworks(initNetworks)
 	require.NoError(t, err)
+	state.networkManager = networkManager
 
 	state.handler = NewClientSideHandler(state.db)
 
@@ -130,6 +166,41 @@ func setupCommand(t *testing.T, method string) (state testState, close func()) {
 			Db:             state.walletDb,
 			NetworkManager: networkManager,
 		}
+	case Method_AddEthereumChain:
+		state.cmd = &AddEthereumChainCommand{
+			Db:             state.walletDb,
+			NetworkManager: networkManager,
+			ClientHandler:  state.handler,
+		}
+	case Method_WatchAsset:
+		state.tokenManager = &testTokenManager{}
+		state.cmd = &WatchAssetCommand{
+			Db:            state.walletDb,
+			TokenManager:  state.tokenManager,
+			ClientHandler: state.handler,
+		}
+	case Method_SendCalls:
+		state.pendingTxTracker = &testPendingTxTracker{}
+		state.cmd = &SendCallsCommand{
+			Db:               state.walletDb,
+			ClientHandler:    state.handler,
+			RpcClient:        state.rpcClient,
+			PendingTxTracker: state.pendingTxTracker,
+		}
+	case Method_GetCallsStatus:
+		state.pendingTxTracker = &testPendingTxTracker{
+			statuses: make(map[common.Hash]transactions.TxStatus),
+		}
+		state.cmd = &GetCallsStatusCommand{
+			Db:               state.walletDb,
+			RpcClient:        state.rpcClient,
+			PendingTxTracker: state.pendingTxTracker,
+		}
+	case Method_GetCapabilities:
+		state.cmd = &GetCapabilitiesCommand{
+			Db:             state.walletDb,
+			NetworkManager: networkManager,
+		}
 	}
 
 	return state, func() {
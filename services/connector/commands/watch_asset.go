@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
+	"github.com/status-im/status-go/signal"
+)
+
+const (
+	AssetTypeERC20 = "ERC20"
+
+	maxTokenDecimals = 36
+)
+
+// errors
+var (
+	ErrNoWatchAssetParamsFound    = errors.New("no asset in params found")
+	ErrUnsupportedAssetType       = errors.New("unsupported asset type")
+	ErrWatchAssetInvalidAddress   = errors.New("invalid asset address")
+	ErrWatchAssetInvalidSymbol    = errors.New("invalid asset symbol")
+	ErrWatchAssetInvalidDecimals  = errors.New("invalid asset decimals")
+	ErrTokenManagerNotInitialized = errors.New("token manager is not initialized")
+)
+
+type WatchAssetOptions struct {
+	Address  string      `json:"address"`
+	Symbol   string      `json:"symbol"`
+	Decimals interface{} `json:"decimals"`
+	Image    string      `json:"image,omitempty"`
+}
+
+// WatchAssetParams represents the asset parameters as defined by EIP-747
+type WatchAssetParams struct {
+	Type    string            `json:"type"`
+	Options WatchAssetOptions `json:"options"`
+}
+
+type WatchAssetCommand struct {
+	TokenManager  TokenManagerInterface
+	Db            *sql.DB
+	ClientHandler ClientSideHandlerInterface
+}
+
+func (r *RPCRequest) getWatchAssetParams() (*WatchAssetParams, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return nil, ErrEmptyRPCParams
+	}
+
+	paramMap, ok := r.Params[0].(map[string]interface{})
+	if !ok {
+		return nil, ErrNoWatchAssetParamsFound
+	}
+
+	paramBytes, err := json.Marshal(paramMap)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling first watch asset param: %v", err)
+	}
+
+	var assetParams WatchAssetParams
+	err = json.Unmarshal(paramBytes, &assetParams)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling first watch asset param to WatchAssetParams: %v", err)
+	}
+
+	return &assetParams, nil
+}
+
+// Decimals are sent either as a number or as a string by different dApps
+func (o *WatchAssetOptions) decimals() (uint, error) {
+	var decimals uint64
+	switch v := o.Decimals.(type) {
+	case float64:
+		if v < 0 || v != float64(uint64(v)) {
+			return 0, ErrWatchAssetInvalidDecimals
+		}
+		decimals = uint64(v)
+	case string:
+		parsed, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return 0, ErrWatchAssetInvalidDecimals
+		}
+		decimals = parsed
+	default:
+		return 0, ErrWatchAssetInvalidDecimals
+	}
+
+	if decimals > maxTokenDecimals {
+		return 0, ErrWatchAssetInvalidDecimals
+	}
+	return uint(decimals), nil
+}
+
+func (p *WatchAssetParams) toToken(chainID uint64) (*tokenTypes.Token, error) {
+	if !strings.EqualFold(p.Type, AssetTypeERC20) {
+		return nil, ErrUnsupportedAssetType
+	}
+
+	if !common.IsHexAddress(p.Options.Address) {
+		return nil, ErrWatchAssetInvalidAddress
+	}
+
+	if p.Options.Symbol == "" || len(p.Options.Symbol) > 11 {
+		return nil, ErrWatchAssetInvalidSymbol
+	}
+
+	decimals, err := p.Options.decimals()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenTypes.Token{
+		Address:  common.HexToAddress(p.Options.Address),
+		Name:     p.Options.Symbol,
+		Symbol:   p.Options.Symbol,
+		Decimals: decimals,
+		ChainID:  chainID,
+		Image:    p.Options.Image,
+	}, nil
+}
+
+func (c *WatchAssetCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	if c.TokenManager == nil {
+		return "", ErrTokenManagerNotInitialized
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	assetParams, err := request.getWatchAssetParams()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := assetParams.toToken(dApp.ChainID)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.ClientHandler.RequestWatchAsset(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, dApp.ChainID, assetParams)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.TokenManager.UpsertCustom(*token)
+	if err != nil {
+		return "", err
+	}
+
+	return true, nil
+}
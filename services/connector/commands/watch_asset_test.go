@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/signal"
+)
+
+var testAssetAddress = common.HexToAddress("0x744d70fdbe2ba4cf95131626614a1763df805b9e")
+
+func prepareWatchAssetRequest(dApp *signal.ConnectorDApp, assetType string, decimals interface{}) (RPCRequest, error) {
+	params := []interface{}{
+		map[string]interface{}{
+			"type": assetType,
+			"options": map[string]interface{}{
+				"address":  testAssetAddress.Hex(),
+				"symbol":   "SNT",
+				"decimals": decimals,
+				"image":    "https://status.im/snt.png",
+			},
+		},
+	}
+
+	return ConstructRPCRequest("wallet_watchAsset", params, dApp)
+}
+
+func TestFailToWatchAssetWithoutPermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	request, err := prepareWatchAssetRequest(&testDAppData, AssetTypeERC20, 18)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+}
+
+func TestFailToWatchAssetWithInvalidParams(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareWatchAssetRequest(&testDAppData, "ERC721", 18)
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrUnsupportedAssetType, err)
+
+	request, err = prepareWatchAssetRequest(&testDAppData, AssetTypeERC20, 100)
+	assert.NoError(t, err)
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrWatchAssetInvalidDecimals, err)
+
+	assert.Empty(t, state.tokenManager.tokens)
+}
+
+func TestWatchAssetWithSignalAccepted(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorWatchAsset:
+			var ev signal.ConnectorWatchAssetSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(0x1), ev.ChainID)
+
+			err = state.handler.WatchAssetAccepted(WatchAssetAcceptedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	// Decimals passed as a string are accepted as well
+	request, err := prepareWatchAssetRequest(&testDAppData, AssetTypeERC20, "18")
+	assert.NoError(t, err)
+
+	result, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, true, result)
+
+	assert.Len(t, state.tokenManager.tokens, 1)
+	token := state.tokenManager.tokens[0]
+	assert.Equal(t, testAssetAddress, token.Address)
+	assert.Equal(t, "SNT", token.Symbol)
+	assert.Equal(t, uint(18), token.Decimals)
+	assert.Equal(t, uint64(0x1), token.ChainID)
+}
+
+func TestWatchAssetWithSignalRejected(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorWatchAsset:
+			var ev signal.ConnectorWatchAssetSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.WatchAssetRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	request, err := prepareWatchAssetRequest(&testDAppData, AssetTypeERC20, 18)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrWatchAssetRejectedByUser, err)
+	assert.Empty(t, state.tokenManager.tokens)
+}
+
+func TestRPCRequestFromJSONWithObjectParams(t *testing.T) {
+	request, err := RPCRequestFromJSON(`{"jsonrpc":"2.0","id":1,"method":"wallet_watchAsset","params":{"type":"ERC20","options":{"address":"0x744d70fdbe2ba4cf95131626614a1763df805b9e","symbol":"SNT","decimals":18}},"url":"http://testDAppURL","name":"testDAppName"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, Method_WatchAsset, request.Method)
+	assert.Equal(t, "http://testDAppURL", request.URL)
+
+	params, err := request.getWatchAssetParams()
+	assert.NoError(t, err)
+	assert.Equal(t, AssetTypeERC20, params.Type)
+	assert.Equal(t, "SNT", params.Options.Symbol)
+}
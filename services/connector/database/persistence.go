@@ -10,6 +10,10 @@ const upsertDAppQuery = "INSERT INTO connector_dapps (url, name, icon_url, share
 const selectDAppByUrlQuery = "SELECT name, icon_url, shared_account, chain_id FROM connector_dapps WHERE url = ?"
 const selectDAppsQuery = "SELECT url, name, icon_url, shared_account, chain_id FROM connector_dapps"
 const deleteDAppQuery = "DELETE FROM connector_dapps WHERE url = ?"
+const insertCallsBatchQuery = "INSERT INTO connector_calls_batches (id, url, chain_id, from_address, timestamp) VALUES (?, ?, ?, ?, ?)"
+const insertCallsBatchTxQuery = "INSERT INTO connector_calls_batches_transactions (batch_url, batch_id, call_index, tx_hash) VALUES (?, ?, ?, ?)"
+const selectCallsBatchQuery = "SELECT chain_id, from_address, timestamp FROM connector_calls_batches WHERE url = ? AND id = ?"
+const selectCallsBatchTxsQuery = "SELECT tx_hash FROM connector_calls_batches_transactions WHERE batch_url = ? AND batch_id = ? ORDER BY call_index"
 
 type DApp struct {
 	URL           string        `json:"url"`
@@ -19,6 +23,16 @@ type DApp struct {
 	ChainID       uint64        `json:"chainId"`
 }
 
+// CallsBatch is a set of transactions sent at once by a dApp through wallet_sendCalls (EIP-5792)
+type CallsBatch struct {
+	ID        string        `json:"id"`
+	URL       string        `json:"url"`
+	ChainID   uint64        `json:"chainId"`
+	From      types.Address `json:"from"`
+	Timestamp int64         `json:"timestamp"`
+	TxHashes  []types.Hash  `json:"txHashes"`
+}
+
 func UpsertDApp(db *sql.DB, dApp *DApp) error {
 	_, err := db.Exec(upsertDAppQuery, dApp.URL, dApp.Name, dApp.IconURL, dApp.SharedAccount, dApp.ChainID)
 	return err
@@ -58,3 +72,61 @@ func DeleteDApp(db *sql.DB, url string) error {
 	_, err := db.Exec(deleteDAppQuery, url)
 	return err
 }
+
+func InsertCallsBatch(db *sql.DB, batch *CallsBatch) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(insertCallsBatchQuery, batch.ID, batch.URL, batch.ChainID, batch.From, batch.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	for i, hash := range batch.TxHashes {
+		_, err = tx.Exec(insertCallsBatchTxQuery, batch.URL, batch.ID, i, hash.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SelectCallsBatch returns the batch sent by the dApp with the id, the batches of the other dApps are not visible
+func SelectCallsBatch(db *sql.DB, url string, id string) (*CallsBatch, error) {
+	batch := &CallsBatch{
+		ID:  id,
+		URL: url,
+	}
+	err := db.QueryRow(selectCallsBatchQuery, url, id).Scan(&batch.ChainID, &batch.From, &batch.Timestamp)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(selectCallsBatchTxsQuery, url, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hash []byte
+		err = rows.Scan(&hash)
+		if err != nil {
+			return nil, err
+		}
+		batch.TxHashes = append(batch.TxHashes, types.BytesToHash(hash))
+	}
+	return batch, rows.Err()
+}
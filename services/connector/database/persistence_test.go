@@ -93,3 +93,50 @@ func TestSelectAllDApps(t *testing.T) {
 	require.Len(t, dApps, 1)
 	require.Equal(t, testDApp, dApps[0])
 }
+
+func TestInsertAndSelectCallsBatch(t *testing.T) {
+	db, close := setupTestDB(t)
+	defer close()
+
+	batch := CallsBatch{
+		ID:        "0x01",
+		URL:       testDApp.URL,
+		ChainID:   testDApp.ChainID,
+		From:      testDApp.SharedAccount,
+		Timestamp: 1700000000,
+		TxHashes:  []types.Hash{{0x01}, {0x02}, {0x03}},
+	}
+
+	err := InsertCallsBatch(db, &batch)
+	require.NoError(t, err)
+
+	batchBack, err := SelectCallsBatch(db, batch.URL, batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, &batch, batchBack)
+
+	// IDs are unique per dApp
+	err = InsertCallsBatch(db, &batch)
+	require.Error(t, err)
+
+	missingBatch, err := SelectCallsBatch(db, batch.URL, "0x02")
+	require.NoError(t, err)
+	require.Nil(t, missingBatch)
+
+	// the other dApps can use the same ID and don't see the batch
+	otherBatch := batch
+	otherBatch.URL = "http://other.test"
+	otherBatch.TxHashes = []types.Hash{{0x04}}
+	err = InsertCallsBatch(db, &otherBatch)
+	require.NoError(t, err)
+
+	batchBack, err = SelectCallsBatch(db, otherBatch.URL, batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, &otherBatch, batchBack)
+	batchBack, err = SelectCallsBatch(db, batch.URL, batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, &batch, batchBack)
+
+	missingBatch, err = SelectCallsBatch(db, "http://unknown.test", batch.ID)
+	require.NoError(t, err)
+	require.Nil(t, missingBatch)
+}
@@ -6,13 +6,18 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/status-im/status-go/rpc"
 	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/services/connector/commands"
 )
 
-func NewService(db *sql.DB, rpc rpc.ClientInterface, nm *network.Manager) *Service {
+func NewService(db *sql.DB, rpc rpc.ClientInterface, nm *network.Manager, tm commands.TokenManagerInterface, pt commands.PendingTxTrackerInterface,
+	sp commands.SigningPreviewerInterface) *Service {
 	return &Service{
 		db:  db,
 		rpc: rpc,
 		nm:  nm,
+		tm:  tm,
+		pt:  pt,
+		sp:  sp,
 	}
 }
 
@@ -20,6 +25,9 @@ type Service struct {
 	db  *sql.DB
 	rpc rpc.ClientInterface
 	nm  *network.Manager
+	tm  commands.TokenManagerInterface
+	pt  commands.PendingTxTrackerInterface
+	sp  commands.SigningPreviewerInterface
 }
 
 func (s *Service) Start() error {
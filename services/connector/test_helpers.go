@@ -89,7 +89,7 @@ func setupTests(t *testing.T) (state testState, close func()) {
 
 	state.rpcClient.EXPECT().GetNetworkManager().AnyTimes().Return(networkManager)
 
-	state.service = NewService(state.walletDb, state.rpcClient, state.rpcClient.GetNetworkManager())
+	state.service = NewService(state.walletDb, state.rpcClient, state.rpcClient.GetNetworkManager(), nil, nil, nil)
 
 	state.api = NewAPI(state.service)
 
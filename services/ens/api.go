@@ -16,8 +16,11 @@ import (
 	"github.com/wealdtech/go-multicodec"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/multiaccounts/accounts"
+	"github.com/status-im/status-go/multiaccounts/settings"
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/rpc"
 	"github.com/status-im/status-go/services/ens/ensresolver"
@@ -26,7 +29,7 @@ import (
 
 func NewAPI(rpcClient *rpc.Client, accountsManager *account.GethManager, pendingTracker *transactions.PendingTxTracker, config *params.NodeConfig, appDb *sql.DB, timeSource func() time.Time, syncUserDetailFunc *syncUsernameDetail) *API {
 	return &API{
-		ensResolver: ensresolver.NewEnsResolver(rpcClient),
+		ensResolver: ensresolver.NewEnsResolver(rpcClient, URLUnfurlingEnabled(appDb)),
 
 		accountsManager: accountsManager,
 		pendingTracker:  pendingTracker,
@@ -38,6 +41,8 @@ func NewAPI(rpcClient *rpc.Client, accountsManager *account.GethManager, pending
 	}
 }
 
+const profileCacheTTL = 24 * time.Hour
+
 type URI struct {
 	Scheme string
 	Host   string
@@ -125,6 +130,73 @@ func (api *API) AddressOf(ctx context.Context, chainID uint64, username string)
 	return api.ensResolver.AddressOf(ctx, chainID, username)
 }
 
+func (api *API) TextRecord(ctx context.Context, chainID uint64, username string, key string) (string, error) {
+	return api.ensResolver.TextOf(ctx, chainID, username, key)
+}
+
+// MultichainAddressOf returns the hex encoded ENSIP-9 address of the name for the given coin type
+func (api *API) MultichainAddressOf(ctx context.Context, chainID uint64, username string, coinType uint64) (string, error) {
+	addr, err := api.ensResolver.MultichainAddressOf(ctx, chainID, username, coinType)
+	if err != nil {
+		return "", err
+	}
+	if len(addr) == 0 {
+		return "", nil
+	}
+	return hexutil.Encode(addr), nil
+}
+
+func (api *API) AvatarURL(ctx context.Context, chainID uint64, username string) (string, error) {
+	return api.ensResolver.AvatarURL(ctx, chainID, username)
+}
+
+// Profile returns the avatar, text records and addresses of the name. Profiles are cached in the database
+// for profileCacheTTL, a stale profile is returned if the name can't be resolved.
+func (api *API) Profile(ctx context.Context, chainID uint64, username string) (*ensresolver.Profile, error) {
+	return CachedProfile(ctx, api.db, api.ensResolver, chainID, username, api.unixTime())
+}
+
+// CachedProfile returns the profile of the name stored in db if it was resolved less than profileCacheTTL before
+// now, otherwise the name is resolved and its profile stored
+func CachedProfile(ctx context.Context, db *Database, ensResolver *ensresolver.EnsResolver, chainID uint64, username string, now uint64) (*ensresolver.Profile, error) {
+	cached, err := db.GetProfile(username, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil && cached.UpdatedAt+uint64(profileCacheTTL.Seconds()) > now {
+		return cached, nil
+	}
+
+	profile, err := ensResolver.Profile(ctx, chainID, username)
+	if err != nil {
+		if cached != nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	profile.UpdatedAt = now
+	err = db.SaveProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// URLUnfurlingEnabled returns whether the user allowed loading the content of the links, the remote media of the
+// profiles is loaded from its host only then since the request reveals the user IP to the host
+func URLUnfurlingEnabled(appDb *sql.DB) func() bool {
+	return func() bool {
+		accountsDB, err := accounts.NewDB(appDb)
+		if err != nil {
+			return false
+		}
+		mode, err := accountsDB.URLUnfurlingMode()
+		return err == nil && settings.URLUnfurlingModeType(mode) == settings.URLUnfurlingEnableAll
+	}
+}
+
 func (api *API) ExpireAt(ctx context.Context, chainID uint64, username string) (string, error) {
 	return api.ensResolver.ExpireAt(ctx, chainID, username)
 }
@@ -13,6 +13,7 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/status-im/status-go/appdatabase"
 	statusRPC "github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/ens/ensresolver"
 	"github.com/status-im/status-go/t/helpers"
 	"github.com/status-im/status-go/t/utils"
 	"github.com/status-im/status-go/transactions/fake"
@@ -158,3 +159,47 @@ func TestResourceURL(t *testing.T) {
 	require.Equal(t, "noahzinsmeister.com", uri.Host)
 	require.Equal(t, "", uri.Path)
 }
+
+func TestProfileCache(t *testing.T) {
+	db, cancel := createDB(t)
+	defer cancel()
+
+	rpcClient, err := statusRPC.NewClient(statusRPC.ClientConfig{UpstreamChainID: 1, DB: db})
+	require.NoError(t, err)
+
+	now := time.Now()
+	api := NewAPI(rpcClient, nil, nil, nil, db, func() time.Time { return now }, nil)
+
+	profile := &ensresolver.Profile{
+		Username:    "vitalik.eth",
+		ChainID:     1,
+		Avatar:      "https://example.com/avatar.png",
+		TextRecords: map[string]string{"url": "https://vitalik.ca", "com.twitter": "VitalikButerin"},
+		Addresses:   map[uint64]string{ensresolver.EthereumCoinType: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"},
+		UpdatedAt:   uint64(now.Unix()),
+	}
+	require.NoError(t, api.db.SaveProfile(profile))
+
+	cached, err := api.db.GetProfile("vitalik.eth", 1)
+	require.NoError(t, err)
+	require.Equal(t, profile, cached)
+
+	missing, err := api.db.GetProfile("vitalik.eth", 11155111)
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	// a fresh profile is served from the cache without resolving the name
+	result, err := api.Profile(context.Background(), 1, "vitalik.eth")
+	require.NoError(t, err)
+	require.Equal(t, profile, result)
+
+	// a stale profile is still served when the name can't be resolved
+	now = now.Add(profileCacheTTL + time.Minute)
+	result, err = api.Profile(context.Background(), 1, "vitalik.eth")
+	require.NoError(t, err)
+	require.Equal(t, profile, result)
+
+	// without a cached profile the resolution error is returned
+	_, err = api.Profile(context.Background(), 11155111, "vitalik.eth")
+	require.Error(t, err)
+}
@@ -2,6 +2,9 @@ package ens
 
 import (
 	"database/sql"
+	"encoding/json"
+
+	"github.com/status-im/status-go/services/ens/ensresolver"
 )
 
 type Database struct {
@@ -79,3 +82,43 @@ WHERE NOT EXISTS (SELECT 1 FROM ens_usernames WHERE username = ? AND chain_id =
 	_, err := db.db.Exec(sqlQuery, details.Username, details.ChainID, details.Clock, details.Removed, details.Username, details.ChainID, details.Clock)
 	return err
 }
+
+// GetProfile returns the cached profile of the name, nil if it was never resolved
+func (db *Database) GetProfile(username string, chainID uint64) (*ensresolver.Profile, error) {
+	const sqlQuery = `SELECT avatar, text_records, addresses, updated_at
+					  FROM ens_profiles WHERE username = ? AND chain_id = ?`
+
+	profile := &ensresolver.Profile{Username: username, ChainID: chainID}
+	var textRecords, addresses []byte
+	err := db.db.QueryRow(sqlQuery, username, chainID).Scan(&profile.Avatar, &textRecords, &addresses, &profile.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(textRecords, &profile.TextRecords); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(addresses, &profile.Addresses); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (db *Database) SaveProfile(profile *ensresolver.Profile) error {
+	textRecords, err := json.Marshal(profile.TextRecords)
+	if err != nil {
+		return err
+	}
+	addresses, err := json.Marshal(profile.Addresses)
+	if err != nil {
+		return err
+	}
+
+	const sqlQuery = `INSERT OR REPLACE INTO ens_profiles(username, chain_id, avatar, text_records, addresses, updated_at)
+					  VALUES (?, ?, ?, ?, ?, ?)`
+	_, err = db.db.Exec(sqlQuery, profile.Username, profile.ChainID, profile.Avatar, textRecords, addresses, profile.UpdatedAt)
+	return err
+}
@@ -0,0 +1,181 @@
+package ensresolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty/collectibles/onchain"
+)
+
+const metadataRequestTimeout = 10 * time.Second
+
+const (
+	erc721Standard  = "erc721"
+	erc1155Standard = "erc1155"
+)
+
+// Minimal ABI of the ERC721 and ERC1155 methods needed to verify and load NFT avatars
+const nftAvatarABI = `[
+	{"inputs":[{"name":"tokenId","type":"uint256"}],"name":"ownerOf","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"tokenId","type":"uint256"}],"name":"tokenURI","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"account","type":"address"},{"name":"id","type":"uint256"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"id","type":"uint256"}],"name":"uri","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"}
+]`
+
+var (
+	ErrUnsupportedAvatarURI = errors.New("unsupported avatar URI")
+	ErrAvatarNotOwned       = errors.New("avatar NFT is not owned by the name")
+)
+
+// nftAvatar is an ENSIP-12 NFT reference, e.g. `eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1`
+type nftAvatar struct {
+	ChainID  uint64
+	Standard string
+	Contract common.Address
+	TokenID  *big.Int
+}
+
+func parseNFTAvatar(record string) (*nftAvatar, error) {
+	record = strings.TrimSpace(record)
+	if !strings.HasPrefix(strings.ToLower(record), "eip155:") {
+		return nil, ErrUnsupportedAvatarURI
+	}
+
+	parts := strings.Split(record[len("eip155:"):], "/")
+	if len(parts) != 3 {
+		return nil, ErrUnsupportedAvatarURI
+	}
+
+	chainID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrUnsupportedAvatarURI
+	}
+
+	standard, contract, found := strings.Cut(parts[1], ":")
+	standard = strings.ToLower(standard)
+	if !found || (standard != erc721Standard && standard != erc1155Standard) || !common.IsHexAddress(contract) {
+		return nil, ErrUnsupportedAvatarURI
+	}
+
+	tokenID, ok := new(big.Int).SetString(parts[2], 10)
+	if !ok || tokenID.Sign() < 0 {
+		return nil, ErrUnsupportedAvatarURI
+	}
+
+	return &nftAvatar{
+		ChainID:  chainID,
+		Standard: standard,
+		Contract: common.HexToAddress(contract),
+		TokenID:  tokenID,
+	}, nil
+}
+
+// AvatarURL resolves the `avatar` text record of the name to an image URL following ENSIP-12. NFT avatars
+// are only returned if the NFT is owned by the address the name resolves to.
+func (e *EnsResolver) AvatarURL(ctx context.Context, chainID uint64, username string) (string, error) {
+	record, err := e.TextOf(ctx, chainID, username, "avatar")
+	if err != nil {
+		return "", err
+	}
+	if record == "" {
+		return "", nil
+	}
+
+	return e.resolveAvatar(ctx, chainID, username, record, nil)
+}
+
+// resolveAvatar resolves the avatar record, owner is the address of the name when it is already known
+func (e *EnsResolver) resolveAvatar(ctx context.Context, chainID uint64, username string, record string, owner *common.Address) (string, error) {
+	record = strings.TrimSpace(record)
+	lowerRecord := strings.ToLower(record)
+	switch {
+	case strings.HasPrefix(lowerRecord, "https://"), strings.HasPrefix(lowerRecord, "http://"),
+		strings.HasPrefix(lowerRecord, "ipfs://"), strings.HasPrefix(lowerRecord, "ar://"),
+		strings.HasPrefix(lowerRecord, "data:"):
+		return e.metadata.ResolveURI(record), nil
+	}
+
+	avatar, err := parseNFTAvatar(record)
+	if err != nil {
+		return "", err
+	}
+
+	if owner == nil {
+		owner, err = e.AddressOf(ctx, chainID, username)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return e.nftAvatarURL(ctx, avatar, *owner)
+}
+
+func (e *EnsResolver) nftAvatarURL(ctx context.Context, avatar *nftAvatar, owner common.Address) (string, error) {
+	backend, err := e.contractMaker.RPCClient.EthClient(avatar.ChainID)
+	if err != nil {
+		return "", err
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(nftAvatarABI))
+	if err != nil {
+		return "", err
+	}
+	contract := bind.NewBoundContract(avatar.Contract, parsedABI, backend, nil, nil)
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	var tokenURI string
+	switch avatar.Standard {
+	case erc721Standard:
+		var out []interface{}
+		if err := contract.Call(callOpts, &out, "ownerOf", avatar.TokenID); err != nil {
+			return "", err
+		}
+		if tokenOwner := *abi.ConvertType(out[0], new(common.Address)).(*common.Address); tokenOwner != owner {
+			return "", ErrAvatarNotOwned
+		}
+
+		out = nil
+		if err := contract.Call(callOpts, &out, "tokenURI", avatar.TokenID); err != nil {
+			return "", err
+		}
+		tokenURI = *abi.ConvertType(out[0], new(string)).(*string)
+	case erc1155Standard:
+		var out []interface{}
+		if err := contract.Call(callOpts, &out, "balanceOf", owner, avatar.TokenID); err != nil {
+			return "", err
+		}
+		if balance := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int); balance.Sign() <= 0 {
+			return "", ErrAvatarNotOwned
+		}
+
+		out = nil
+		if err := contract.Call(callOpts, &out, "uri", avatar.TokenID); err != nil {
+			return "", err
+		}
+		tokenURI = onchain.ERC1155URI(*abi.ConvertType(out[0], new(string)).(*string), avatar.TokenID)
+	}
+
+	metadata, err := e.metadata.Fetch(ctx, tokenURI)
+	if err != nil {
+		return "", err
+	}
+
+	image := metadata.Image
+	if image == "" {
+		image = metadata.ImageURL
+	}
+	if image == "" {
+		return "", fmt.Errorf("no image in the metadata of %s", tokenURI)
+	}
+
+	return e.metadata.ResolveURI(image), nil
+}
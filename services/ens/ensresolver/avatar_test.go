@@ -0,0 +1,39 @@
+package ensresolver
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseNFTAvatar(t *testing.T) {
+	avatar, err := parseNFTAvatar("eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1")
+	require.NoError(t, err)
+	require.Equal(t, &nftAvatar{
+		ChainID:  1,
+		Standard: erc721Standard,
+		Contract: common.HexToAddress("0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB"),
+		TokenID:  big.NewInt(1),
+	}, avatar)
+
+	avatar, err = parseNFTAvatar("eip155:10/ERC1155:0x495f947276749ce646f68ac8c248420045cb7b5e/8112316025873927737505937898915153732580103913704334048512380490797008551937")
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), avatar.ChainID)
+	require.Equal(t, erc1155Standard, avatar.Standard)
+	require.Equal(t, "8112316025873927737505937898915153732580103913704334048512380490797008551937", avatar.TokenID.String())
+
+	for _, record := range []string{
+		"",
+		"eip155:1/erc20:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1",
+		"eip155:1/erc721:0xinvalid/1",
+		"eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB",
+		"eip155:mainnet/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1",
+		"eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/one",
+	} {
+		_, err = parseNFTAvatar(record)
+		require.ErrorIs(t, err, ErrUnsupportedAvatarURI, record)
+	}
+}
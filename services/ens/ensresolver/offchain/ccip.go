@@ -0,0 +1,212 @@
+package offchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// EIP-3668 recommends a limit on the number of redirects a single call can go through
+const maxLookups = 4
+
+// maxGatewayResponseSize bounds the gateway responses, they are expected to be a few KB
+const maxGatewayResponseSize = 1024 * 1024
+
+// offchainLookupSelector is the selector of
+// `OffchainLookup(address sender, string[] urls, bytes callData, bytes4 callbackFunction, bytes extraData)`
+var offchainLookupSelector = []byte{0x55, 0x6f, 0x18, 0x30}
+
+var (
+	ErrTooManyLookups     = errors.New("too many CCIP-Read lookups")
+	ErrSenderMismatch     = errors.New("OffchainLookup sender doesn't match the called contract")
+	ErrNoGatewayURLs      = errors.New("OffchainLookup without gateway URLs")
+	ErrGatewayUnavailable = errors.New("no CCIP-Read gateway could serve the request")
+)
+
+var offchainLookupArguments, callbackArguments = mustLookupArguments()
+
+func mustLookupArguments() (abi.Arguments, abi.Arguments) {
+	newType := func(t string) abi.Type {
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			// the types are constants, this can't happen
+			panic(err)
+		}
+		return typ
+	}
+
+	lookup := abi.Arguments{
+		{Name: "sender", Type: newType("address")},
+		{Name: "urls", Type: newType("string[]")},
+		{Name: "callData", Type: newType("bytes")},
+		{Name: "callbackFunction", Type: newType("bytes4")},
+		{Name: "extraData", Type: newType("bytes")},
+	}
+	callback := abi.Arguments{
+		{Name: "response", Type: newType("bytes")},
+		{Name: "extraData", Type: newType("bytes")},
+	}
+	return lookup, callback
+}
+
+type offchainLookup struct {
+	Sender           common.Address
+	URLs             []string
+	CallData         []byte
+	CallbackFunction [4]byte
+	ExtraData        []byte
+}
+
+// parseOffchainLookup extracts the OffchainLookup revert from the error of an `eth_call`
+func parseOffchainLookup(err error) (*offchainLookup, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+
+	var revertData []byte
+	switch data := dataErr.ErrorData().(type) {
+	case string:
+		var decodeErr error
+		revertData, decodeErr = hexutil.Decode(data)
+		if decodeErr != nil {
+			return nil, false
+		}
+	case []byte:
+		revertData = data
+	default:
+		return nil, false
+	}
+
+	if len(revertData) < len(offchainLookupSelector) || !bytes.Equal(revertData[:len(offchainLookupSelector)], offchainLookupSelector) {
+		return nil, false
+	}
+
+	values, err := offchainLookupArguments.Unpack(revertData[len(offchainLookupSelector):])
+	if err != nil || len(values) != len(offchainLookupArguments) {
+		return nil, false
+	}
+
+	lookup := &offchainLookup{}
+	var ok bool
+	if lookup.Sender, ok = values[0].(common.Address); !ok {
+		return nil, false
+	}
+	if lookup.URLs, ok = values[1].([]string); !ok {
+		return nil, false
+	}
+	if lookup.CallData, ok = values[2].([]byte); !ok {
+		return nil, false
+	}
+	if lookup.CallbackFunction, ok = values[3].([4]byte); !ok {
+		return nil, false
+	}
+	if lookup.ExtraData, ok = values[4].([]byte); !ok {
+		return nil, false
+	}
+	return lookup, true
+}
+
+// callbackData encodes the call of the callback function with the gateway response
+func (l *offchainLookup) callbackData(response []byte) ([]byte, error) {
+	args, err := callbackArguments.Pack(response, l.ExtraData)
+	if err != nil {
+		return nil, err
+	}
+	return append(l.CallbackFunction[:], args...), nil
+}
+
+type gatewayRequest struct {
+	Data   string `json:"data"`
+	Sender string `json:"sender"`
+}
+
+type gatewayResponse struct {
+	Data string `json:"data"`
+}
+
+// queryGateways tries the gateway URLs in order. As specified by EIP-3668, URLs containing `{data}` are
+// queried with GET and the others with POST, a 4xx response aborts the lookup while other failures move
+// on to the next URL.
+func (r *Resolver) queryGateways(ctx context.Context, lookup *offchainLookup) ([]byte, error) {
+	if len(lookup.URLs) == 0 {
+		return nil, ErrNoGatewayURLs
+	}
+
+	sender := strings.ToLower(lookup.Sender.Hex())
+	callData := hexutil.Encode(lookup.CallData)
+
+	errs := make([]error, 0, len(lookup.URLs))
+	for _, url := range lookup.URLs {
+		response, retry, err := r.queryGateway(ctx, url, sender, callData)
+		if err == nil {
+			return response, nil
+		}
+		if !retry || ctx.Err() != nil {
+			return nil, err
+		}
+		errs = append(errs, err)
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrGatewayUnavailable, errs)
+}
+
+func (r *Resolver) queryGateway(ctx context.Context, url, sender, callData string) (response []byte, retry bool, err error) {
+	url = strings.ReplaceAll(url, "{sender}", sender)
+
+	var req *http.Request
+	if strings.Contains(url, "{data}") {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, strings.ReplaceAll(url, "{data}", callData), nil)
+		if err != nil {
+			return nil, true, err
+		}
+	} else {
+		body, err := json.Marshal(gatewayRequest{Data: callData, Sender: sender})
+		if err != nil {
+			return nil, false, err
+		}
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, true, err
+		}
+		req.Header.Set("content-type", "application/json")
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxGatewayResponseSize))
+	if err != nil {
+		return nil, true, err
+	}
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return nil, false, fmt.Errorf("gateway error %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, true, fmt.Errorf("gateway error %d", resp.StatusCode)
+	}
+
+	var parsed gatewayResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, true, err
+	}
+	response, err = hexutil.Decode(parsed.Data)
+	if err != nil {
+		return nil, true, err
+	}
+	return response, false, nil
+}
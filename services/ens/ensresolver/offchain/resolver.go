@@ -0,0 +1,320 @@
+// Package offchain resolves ENS names served by wildcard (ENSIP-10) and offchain (EIP-3668, CCIP-Read)
+// resolvers, on top of the regular resolution of names with their own resolver.
+package offchain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wealdtech/go-ens/v3"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/contracts/resolver"
+)
+
+const gatewayRequestTimeout = 10 * time.Second
+
+// ExtendedResolverInterfaceID is the ERC165 ID of `resolve(bytes,bytes)`
+var ExtendedResolverInterfaceID = [4]byte{0x90, 0x61, 0xb9, 0x23}
+
+const extendedResolverABI = `[{"inputs":[{"name":"name","type":"bytes"},{"name":"data","type":"bytes"}],"name":"resolve","outputs":[{"name":"","type":"bytes"}],"stateMutability":"view","type":"function"}]`
+
+var (
+	ErrNoResolver    = errors.New("no resolver set for the name or its parents")
+	ErrInvalidName   = errors.New("invalid ENS name")
+	ErrEmptyResponse = errors.New("resolver returned no data")
+)
+
+// Resolver resolves records of any ENS name, following the ENSIP-10 wildcard resolution and the
+// CCIP-Read gateway redirects
+type Resolver struct {
+	caller      bind.ContractCaller
+	registry    common.Address
+	httpClient  *http.Client
+	extendedABI abi.ABI
+	resolverABI abi.ABI
+}
+
+func NewResolver(caller bind.ContractCaller, registry common.Address) *Resolver {
+	return NewResolverWithHTTPClient(caller, registry, &http.Client{Timeout: gatewayRequestTimeout})
+}
+
+func NewResolverWithHTTPClient(caller bind.ContractCaller, registry common.Address, httpClient *http.Client) *Resolver {
+	extendedABI, err := abi.JSON(strings.NewReader(extendedResolverABI))
+	if err != nil {
+		// the ABI is a constant, this can't happen
+		panic(err)
+	}
+	resolverABI, err := abi.JSON(strings.NewReader(resolver.PublicResolverABI))
+	if err != nil {
+		panic(err)
+	}
+
+	return &Resolver{
+		caller:      caller,
+		registry:    registry,
+		httpClient:  httpClient,
+		extendedABI: extendedABI,
+		resolverABI: resolverABI,
+	}
+}
+
+// FindResolver returns the resolver of the name or, as described in ENSIP-10, the resolver of its closest
+// parent. `exact` is false when the resolver was inherited from a parent.
+func (r *Resolver) FindResolver(ctx context.Context, name string) (resolverAddress common.Address, exact bool, err error) {
+	registry, err := resolver.NewENSRegistryWithFallbackCaller(r.registry, r.caller)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+	current := name
+	for current != "" {
+		node, err := ens.NameHash(current)
+		if err != nil {
+			return common.Address{}, false, err
+		}
+
+		resolverAddress, err = registry.Resolver(callOpts, node)
+		if err != nil {
+			return common.Address{}, false, err
+		}
+		if resolverAddress != (common.Address{}) {
+			return resolverAddress, current == name, nil
+		}
+
+		_, parent, found := strings.Cut(current, ".")
+		if !found {
+			break
+		}
+		current = parent
+	}
+
+	return common.Address{}, false, ErrNoResolver
+}
+
+// Resolve calls the resolver of the name with `data`, the ABI encoded call of a resolver method
+// (e.g. `addr(bytes32)`) for the namehash of the full name, and returns the ABI encoded result
+func (r *Resolver) Resolve(ctx context.Context, name string, data []byte) ([]byte, error) {
+	if name == "" || strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") || strings.Contains(name, "..") {
+		return nil, ErrInvalidName
+	}
+
+	resolverAddress, exact, err := r.FindResolver(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	extended, err := r.supportsExtendedResolver(ctx, resolverAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if !extended {
+		// only the extended resolvers can resolve names they are not directly set for
+		if !exact {
+			return nil, ErrNoResolver
+		}
+		return r.callNonEmpty(ctx, resolverAddress, data)
+	}
+
+	input, err := r.extendedABI.Pack("resolve", ens.DNSWireFormat(name), data)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := r.callNonEmpty(ctx, resolverAddress, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []byte
+	err = r.extendedABI.UnpackIntoInterface(&result, "resolve", output)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return result, nil
+}
+
+// ResolveRecord resolves a record of the name through the PublicResolver `method` (e.g. "addr", "pubkey"),
+// `args` are the method arguments following the node
+func (r *Resolver) ResolveRecord(ctx context.Context, name string, method string, args ...interface{}) ([]interface{}, error) {
+	node, err := ens.NameHash(name)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := r.resolverABI.Pack(method, append([]interface{}{node}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := r.Resolve(ctx, name, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.resolverABI.Unpack(method, output)
+}
+
+// RecordCall is a record read by ResolveRecords, see ResolveRecord for the method and args
+type RecordCall struct {
+	Method string
+	Args   []interface{}
+}
+
+// ResolveRecords resolves several records of the name with a single call to the resolver `multicall`, the records
+// are resolved one by one when the resolver doesn't support it. The result of a record that can't be resolved is nil.
+func (r *Resolver) ResolveRecords(ctx context.Context, name string, calls []RecordCall) ([][]interface{}, error) {
+	node, err := ens.NameHash(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([][]byte, 0, len(calls))
+	for _, call := range calls {
+		input, err := r.resolverABI.Pack(call.Method, append([]interface{}{node}, call.Args...)...)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, input)
+	}
+
+	input, err := r.resolverABI.Pack("multicall", data)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]interface{}, len(calls))
+	output, err := r.Resolve(ctx, name, input)
+	if err == nil {
+		var outputs [][]byte
+		err = r.resolverABI.UnpackIntoInterface(&outputs, "multicall", output)
+		if err == nil && len(outputs) == len(calls) {
+			for i, call := range calls {
+				if len(outputs[i]) == 0 {
+					continue
+				}
+				if result, err := r.resolverABI.Unpack(call.Method, outputs[i]); err == nil {
+					results[i] = result
+				}
+			}
+			return results, nil
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if errors.Is(err, ErrNoResolver) || errors.Is(err, ErrInvalidName) {
+		return nil, err
+	}
+
+	for i, call := range calls {
+		result, err := r.ResolveRecord(ctx, name, call.Method, call.Args...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// ResolvePubKey resolves the pubkey record of the name on the registry of the backend chain, it follows the
+// wildcard and offchain resolvers and can be given to the eth-node ENS verifier
+func ResolvePubKey(ctx context.Context, backend bind.ContractBackend, name string) (x [32]byte, y [32]byte, err error) {
+	registry, err := ens.RegistryContractAddress(backend)
+	if err != nil {
+		return x, y, err
+	}
+
+	result, err := NewResolver(backend, registry).ResolveRecord(ctx, name, "pubkey")
+	if err != nil {
+		return x, y, err
+	}
+	if len(result) != 2 {
+		return x, y, errors.New("unexpected pubkey record")
+	}
+	x, okX := result[0].([32]byte)
+	y, okY := result[1].([32]byte)
+	if !okX || !okY {
+		return x, y, errors.New("unexpected pubkey record")
+	}
+	return x, y, nil
+}
+
+func (r *Resolver) supportsExtendedResolver(ctx context.Context, resolverAddress common.Address) (bool, error) {
+	caller, err := resolver.NewPublicResolverCaller(resolverAddress, r.caller)
+	if err != nil {
+		return false, err
+	}
+
+	supported, err := caller.SupportsInterface(&bind.CallOpts{Context: ctx}, ExtendedResolverInterfaceID)
+	if err != nil {
+		// resolvers predating ERC165 revert or return nothing
+		if isRevert(err) || errors.Is(err, bind.ErrNoCode) || strings.Contains(err.Error(), "abi: attempting to unmarshall an empty string") {
+			return false, nil
+		}
+		return false, err
+	}
+	return supported, nil
+}
+
+func (r *Resolver) callNonEmpty(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
+	output, err := r.Call(ctx, to, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(output) == 0 {
+		return nil, ErrEmptyResponse
+	}
+	return output, nil
+}
+
+// Call executes a read-only call against the contract, following the OffchainLookup redirects
+func (r *Resolver) Call(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
+	for lookups := 0; ; lookups++ {
+		output, err := r.caller.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+		if err == nil {
+			return output, nil
+		}
+
+		lookup, ok := parseOffchainLookup(err)
+		if !ok {
+			return nil, err
+		}
+		if lookups >= maxLookups {
+			return nil, ErrTooManyLookups
+		}
+		if lookup.Sender != to {
+			return nil, ErrSenderMismatch
+		}
+
+		response, err := r.queryGateways(ctx, lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err = lookup.callbackData(response)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func isRevert(err error) bool {
+	return strings.Contains(err.Error(), "revert")
+}
@@ -0,0 +1,376 @@
+package offchain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-ens/v3"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/contracts/resolver"
+)
+
+var (
+	testRegistry         = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+	testPublicResolver   = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testOffchainResolver = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testAddress          = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	testOffchainAddress  = common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	testCallbackSelector = crypto.Keccak256([]byte("resolveWithProof(bytes,bytes)"))[:4]
+	testExtraData        = []byte("extra")
+)
+
+// revertError mimics the errors of the RPC clients for reverted calls
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string {
+	return "execution reverted"
+}
+
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
+// testChain fakes the registry, a regular public resolver and an offchain resolver with wildcard support
+type testChain struct {
+	t           *testing.T
+	registryABI abi.ABI
+	resolverABI abi.ABI
+	extendedABI abi.ABI
+	resolvers   map[common.Hash]common.Address
+	addresses   map[common.Hash]common.Address
+	texts       map[string]string
+	gatewayURLs []string
+	// the public resolver doesn't implement `multicall`
+	noMulticall bool
+	// number of calls made to the public resolver
+	resolverCalls int
+	// contract reported by the OffchainLookup reverts
+	lookupSender common.Address
+	// the callback keeps redirecting to the gateway
+	endlessLookups  bool
+	resolveCallData []byte
+}
+
+func newTestChain(t *testing.T, gatewayURLs []string) *testChain {
+	registryABI, err := abi.JSON(strings.NewReader(resolver.ENSRegistryWithFallbackABI))
+	require.NoError(t, err)
+	resolverABI, err := abi.JSON(strings.NewReader(resolver.PublicResolverABI))
+	require.NoError(t, err)
+	extendedABI, err := abi.JSON(strings.NewReader(extendedResolverABI))
+	require.NoError(t, err)
+
+	chain := &testChain{
+		t:            t,
+		registryABI:  registryABI,
+		resolverABI:  resolverABI,
+		extendedABI:  extendedABI,
+		resolvers:    make(map[common.Hash]common.Address),
+		addresses:    make(map[common.Hash]common.Address),
+		texts:        make(map[string]string),
+		gatewayURLs:  gatewayURLs,
+		lookupSender: testOffchainResolver,
+	}
+	chain.resolvers[nameHash(t, "alice.eth")] = testPublicResolver
+	chain.addresses[nameHash(t, "alice.eth")] = testAddress
+	// subnames of a name with a regular resolver can't be resolved
+	chain.resolvers[nameHash(t, "status.eth")] = testPublicResolver
+	chain.resolvers[nameHash(t, "cb.id")] = testOffchainResolver
+
+	return chain
+}
+
+func nameHash(t *testing.T, name string) common.Hash {
+	hash, err := ens.NameHash(name)
+	require.NoError(t, err)
+	return hash
+}
+
+func (c *testChain) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (c *testChain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	switch *call.To {
+	case testRegistry:
+		method, err := c.registryABI.MethodById(call.Data)
+		require.NoError(c.t, err)
+		require.Equal(c.t, "resolver", method.Name)
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		require.NoError(c.t, err)
+		return method.Outputs.Pack(c.resolvers[args[0].([32]byte)])
+
+	case testPublicResolver:
+		c.resolverCalls++
+		method, err := c.resolverABI.MethodById(call.Data)
+		require.NoError(c.t, err)
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		require.NoError(c.t, err)
+		switch method.Name {
+		case "supportsInterface":
+			return method.Outputs.Pack(false)
+		case "addr":
+			return method.Outputs.Pack(c.addresses[args[0].([32]byte)])
+		case "text":
+			return method.Outputs.Pack(c.texts[args[1].(string)])
+		case "multicall":
+			if c.noMulticall {
+				break
+			}
+			// the calls made by the resolver itself are not RPC calls
+			defer func(calls int) { c.resolverCalls = calls }(c.resolverCalls)
+			var results [][]byte
+			for _, data := range args[0].([][]byte) {
+				result, err := c.CallContract(ctx, ethereum.CallMsg{To: call.To, Data: data}, blockNumber)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, result)
+			}
+			return method.Outputs.Pack(results)
+		}
+
+	case testOffchainResolver:
+		if bytes.Equal(call.Data[:4], testCallbackSelector) {
+			if c.endlessLookups {
+				return nil, c.offchainLookup(c.resolveCallData)
+			}
+			args, err := callbackArguments.Unpack(call.Data[4:])
+			require.NoError(c.t, err)
+			require.Equal(c.t, testExtraData, args[1])
+			// the gateway already returns the ABI encoded `resolve` result
+			return args[0].([]byte), nil
+		}
+
+		if method, err := c.resolverABI.MethodById(call.Data); err == nil && method.Name == "supportsInterface" {
+			args, err := method.Inputs.Unpack(call.Data[4:])
+			require.NoError(c.t, err)
+			return method.Outputs.Pack(args[0].([4]byte) == ExtendedResolverInterfaceID)
+		}
+
+		method, err := c.extendedABI.MethodById(call.Data)
+		require.NoError(c.t, err)
+		require.Equal(c.t, "resolve", method.Name)
+		c.resolveCallData = call.Data[4:]
+		return nil, c.offchainLookup(c.resolveCallData)
+	}
+
+	return nil, &revertError{data: "0x"}
+}
+
+func (c *testChain) offchainLookup(callData []byte) error {
+	args, err := offchainLookupArguments.Pack(c.lookupSender, c.gatewayURLs, callData, [4]byte(testCallbackSelector), testExtraData)
+	require.NoError(c.t, err)
+	// errors are wrapped by the RPC client
+	return fmt.Errorf("eth_call failed: %w", &revertError{data: hexutil.Encode(append(offchainLookupSelector, args...))})
+}
+
+// testGateway answers the `resolve(bytes,bytes)` calls forwarded by the offchain resolver
+func testGateway(t *testing.T, chain *testChain) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var sender, data string
+		if r.Method == http.MethodPost {
+			var req gatewayRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			sender, data = req.Sender, req.Data
+		} else {
+			parts := strings.Split(strings.TrimSuffix(r.URL.Path, ".json"), "/")
+			sender, data = parts[len(parts)-2], parts[len(parts)-1]
+		}
+		require.Equal(t, strings.ToLower(testOffchainResolver.Hex()), sender)
+
+		callData, err := hexutil.Decode(data)
+		require.NoError(t, err)
+		args, err := chain.extendedABI.Methods["resolve"].Inputs.Unpack(callData)
+		require.NoError(t, err)
+		require.Equal(t, ens.DNSWireFormat("alice.cb.id"), args[0])
+
+		method, err := chain.resolverABI.MethodById(args[1].([]byte))
+		require.NoError(t, err)
+		require.Equal(t, "addr", method.Name)
+
+		result, err := method.Outputs.Pack(testOffchainAddress)
+		require.NoError(t, err)
+		output, err := chain.extendedABI.Methods["resolve"].Outputs.Pack(result)
+		require.NoError(t, err)
+
+		require.NoError(t, json.NewEncoder(w).Encode(gatewayResponse{Data: hexutil.Encode(output)}))
+	}
+}
+
+func setupTest(t *testing.T, urls func(gatewayURL string) []string) (*Resolver, *testChain) {
+	chain := newTestChain(t, nil)
+	server := httptest.NewServer(testGateway(t, chain))
+	t.Cleanup(server.Close)
+	chain.gatewayURLs = urls(server.URL)
+
+	return NewResolverWithHTTPClient(chain, testRegistry, server.Client()), chain
+}
+
+func resolveAddress(r *Resolver, name string) (common.Address, error) {
+	result, err := r.ResolveRecord(context.Background(), name, "addr")
+	if err != nil {
+		return common.Address{}, err
+	}
+	return result[0].(common.Address), nil
+}
+
+func TestResolveOnchain(t *testing.T) {
+	r, _ := setupTest(t, func(string) []string { return nil })
+
+	addr, err := resolveAddress(r, "alice.eth")
+	require.NoError(t, err)
+	require.Equal(t, testAddress, addr)
+
+	resolverAddress, exact, err := r.FindResolver(context.Background(), "bob.status.eth")
+	require.NoError(t, err)
+	require.Equal(t, testPublicResolver, resolverAddress)
+	require.False(t, exact)
+
+	// the parent resolver doesn't support ENSIP-10
+	_, err = resolveAddress(r, "bob.status.eth")
+	require.ErrorIs(t, err, ErrNoResolver)
+
+	_, err = resolveAddress(r, "unknown.xyz")
+	require.ErrorIs(t, err, ErrNoResolver)
+
+	_, err = r.Resolve(context.Background(), "alice..eth", nil)
+	require.ErrorIs(t, err, ErrInvalidName)
+}
+
+func TestResolveRecords(t *testing.T) {
+	r, chain := setupTest(t, func(string) []string { return nil })
+	chain.texts["url"] = "https://example.com"
+	calls := []RecordCall{
+		{Method: "text", Args: []interface{}{"url"}},
+		{Method: "text", Args: []interface{}{"email"}},
+		{Method: "addr"},
+		// not implemented by the resolver
+		{Method: "pubkey"},
+	}
+	expected := [][]interface{}{{"https://example.com"}, {""}, {testAddress}, nil}
+
+	results, err := r.ResolveRecords(context.Background(), "alice.eth", calls[:3])
+	require.NoError(t, err)
+	require.Equal(t, expected[:3], results)
+	// the resolver is found with supportsInterface then all the records are read at once
+	require.Equal(t, 2, chain.resolverCalls)
+
+	// a failing record reverts the whole multicall, the records are then read one by one
+	results, err = r.ResolveRecords(context.Background(), "alice.eth", calls)
+	require.NoError(t, err)
+	require.Equal(t, expected, results)
+
+	chain.noMulticall = true
+	results, err = r.ResolveRecords(context.Background(), "alice.eth", calls)
+	require.NoError(t, err)
+	require.Equal(t, expected, results)
+
+	_, err = r.ResolveRecords(context.Background(), "unknown.xyz", calls)
+	require.ErrorIs(t, err, ErrNoResolver)
+}
+
+func TestResolveWildcardOffchain(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		urls func(gatewayURL string) []string
+	}{
+		{
+			name: "GET",
+			urls: func(gatewayURL string) []string { return []string{gatewayURL + "/gateway/{sender}/{data}.json"} },
+		},
+		{
+			name: "POST",
+			urls: func(gatewayURL string) []string { return []string{gatewayURL + "/gateway"} },
+		},
+		{
+			name: "fallback after server error",
+			urls: func(gatewayURL string) []string {
+				failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				}))
+				t.Cleanup(failing.Close)
+				return []string{failing.URL + "/{sender}/{data}", gatewayURL + "/gateway"}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, _ := setupTest(t, tc.urls)
+
+			resolverAddress, exact, err := r.FindResolver(context.Background(), "alice.cb.id")
+			require.NoError(t, err)
+			require.Equal(t, testOffchainResolver, resolverAddress)
+			require.False(t, exact)
+
+			addr, err := resolveAddress(r, "alice.cb.id")
+			require.NoError(t, err)
+			require.Equal(t, testOffchainAddress, addr)
+		})
+	}
+}
+
+func TestResolveOffchainErrors(t *testing.T) {
+	r, chain := setupTest(t, func(gatewayURL string) []string {
+		clientError := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unknown name", http.StatusNotFound)
+		}))
+		t.Cleanup(clientError.Close)
+		// a client error stops the lookup even if other gateways are available
+		return []string{clientError.URL + "/{sender}/{data}", gatewayURL + "/gateway"}
+	})
+	_, err := resolveAddress(r, "alice.cb.id")
+	require.ErrorContains(t, err, "gateway error 404")
+
+	chain.gatewayURLs = nil
+	_, err = resolveAddress(r, "alice.cb.id")
+	require.ErrorIs(t, err, ErrNoGatewayURLs)
+
+	r, chain = setupTest(t, func(gatewayURL string) []string { return []string{gatewayURL + "/gateway"} })
+	chain.endlessLookups = true
+	_, err = resolveAddress(r, "alice.cb.id")
+	require.ErrorIs(t, err, ErrTooManyLookups)
+
+	// the lookup must come from the called contract
+	chain.endlessLookups = false
+	chain.lookupSender = testPublicResolver
+	_, err = resolveAddress(r, "alice.cb.id")
+	require.ErrorIs(t, err, ErrSenderMismatch)
+}
+
+func TestParseOffchainLookup(t *testing.T) {
+	chain := newTestChain(t, []string{"https://gateway.example/{sender}/{data}.json"})
+
+	lookup, ok := parseOffchainLookup(chain.offchainLookup([]byte{0x1, 0x2}))
+	require.True(t, ok)
+	require.Equal(t, testOffchainResolver, lookup.Sender)
+	require.Equal(t, []string{"https://gateway.example/{sender}/{data}.json"}, lookup.URLs)
+	require.Equal(t, []byte{0x1, 0x2}, lookup.CallData)
+	require.Equal(t, testCallbackSelector, lookup.CallbackFunction[:])
+	require.Equal(t, testExtraData, lookup.ExtraData)
+
+	_, ok = parseOffchainLookup(errors.New("execution reverted"))
+	require.False(t, ok)
+	_, ok = parseOffchainLookup(&revertError{data: "0x08c379a0"})
+	require.False(t, ok)
+}
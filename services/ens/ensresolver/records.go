@@ -0,0 +1,179 @@
+package ensresolver
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/services/ens/ensresolver/offchain"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+// ENSIP-9 coin type of Ethereum
+const EthereumCoinType uint64 = 60
+
+// ProfileTextKeys are the ENSIP-5 text records shown in profiles
+var ProfileTextKeys = []string{
+	"avatar",
+	"url",
+	"description",
+	"email",
+	"com.twitter",
+	"com.github",
+	"org.telegram",
+	"com.discord",
+}
+
+// ProfileCoinTypes are the coin types of the chains supported by the wallet
+var ProfileCoinTypes = []uint64{
+	EthereumCoinType,
+	EVMCoinType(walletCommon.OptimismMainnet),
+	EVMCoinType(walletCommon.ArbitrumMainnet),
+	EVMCoinType(walletCommon.BaseMainnet),
+}
+
+// EVMCoinType returns the ENSIP-11 coin type of an EVM chain
+func EVMCoinType(chainID uint64) uint64 {
+	return 0x80000000 | chainID
+}
+
+type Profile struct {
+	Username string `json:"username"`
+	ChainID  uint64 `json:"chainId"`
+	// Avatar is the URL of the avatar image, resolved according to ENSIP-12
+	Avatar      string            `json:"avatar"`
+	TextRecords map[string]string `json:"textRecords"`
+	// Addresses are the hex encoded addresses by coin type
+	Addresses map[uint64]string `json:"addresses"`
+	UpdatedAt uint64            `json:"updatedAt"`
+}
+
+func (e *EnsResolver) TextOf(ctx context.Context, chainID uint64, username string, key string) (string, error) {
+	err := walletCommon.ValidateENSName(username)
+	if err != nil {
+		return "", err
+	}
+
+	offchainResolver, err := e.offchainResolver(chainID)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := offchainResolver.ResolveRecord(ctx, username, "text", key)
+	if err != nil {
+		return "", err
+	}
+
+	text, ok := result[0].(string)
+	if !ok {
+		return "", errors.New("unexpected text record")
+	}
+
+	return text, nil
+}
+
+// MultichainAddressOf returns the ENSIP-9 address of the name for the coin type, empty if not set
+func (e *EnsResolver) MultichainAddressOf(ctx context.Context, chainID uint64, username string, coinType uint64) ([]byte, error) {
+	err := walletCommon.ValidateENSName(username)
+	if err != nil {
+		return nil, err
+	}
+
+	offchainResolver, err := e.offchainResolver(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// `addr(bytes32,uint256)` is the second overload of `addr`
+	result, err := offchainResolver.ResolveRecord(ctx, username, "addr0", new(big.Int).SetUint64(coinType))
+	if err != nil {
+		return nil, err
+	}
+
+	addr, ok := result[0].([]byte)
+	if !ok {
+		return nil, errors.New("unexpected addr record")
+	}
+
+	return addr, nil
+}
+
+// Profile resolves the avatar, the profile text records and the addresses of the name, the records are read with a
+// single call when the resolver supports `multicall`. Missing records are left out, the call fails only if the name
+// can't be resolved at all.
+func (e *EnsResolver) Profile(ctx context.Context, chainID uint64, username string) (*Profile, error) {
+	err := walletCommon.ValidateENSName(username)
+	if err != nil {
+		return nil, err
+	}
+
+	offchainResolver, err := e.offchainResolver(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]offchain.RecordCall, 0, len(ProfileTextKeys)+len(ProfileCoinTypes))
+	for _, key := range ProfileTextKeys {
+		calls = append(calls, offchain.RecordCall{Method: "text", Args: []interface{}{key}})
+	}
+	for _, coinType := range ProfileCoinTypes {
+		// `addr(bytes32,uint256)` is the second overload of `addr`
+		calls = append(calls, offchain.RecordCall{Method: "addr0", Args: []interface{}{new(big.Int).SetUint64(coinType)}})
+	}
+
+	results, err := offchainResolver.ResolveRecords(ctx, username, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{
+		Username:    username,
+		ChainID:     chainID,
+		TextRecords: make(map[string]string),
+		Addresses:   make(map[uint64]string),
+	}
+
+	for i, key := range ProfileTextKeys {
+		if len(results[i]) == 0 {
+			logutils.ZapLogger().Debug("failed to resolve ENS text record", zap.String("name", username), zap.String("key", key))
+			continue
+		}
+		if text, ok := results[i][0].(string); ok && text != "" {
+			profile.TextRecords[key] = text
+		}
+	}
+
+	var owner *common.Address
+	for i, coinType := range ProfileCoinTypes {
+		result := results[len(ProfileTextKeys)+i]
+		if len(result) == 0 {
+			logutils.ZapLogger().Debug("failed to resolve ENS address", zap.String("name", username), zap.Uint64("coinType", coinType))
+			continue
+		}
+		addr, ok := result[0].([]byte)
+		if !ok || len(addr) == 0 {
+			continue
+		}
+		profile.Addresses[coinType] = hexutil.Encode(addr)
+		if coinType == EthereumCoinType && len(addr) == common.AddressLength {
+			address := common.BytesToAddress(addr)
+			owner = &address
+		}
+	}
+
+	if avatarRecord, ok := profile.TextRecords["avatar"]; ok {
+		avatar, err := e.resolveAvatar(ctx, chainID, username, avatarRecord, owner)
+		if err != nil {
+			logutils.ZapLogger().Debug("failed to resolve ENS avatar", zap.String("name", username), zap.Error(err))
+		}
+		profile.Avatar = avatar
+	}
+
+	return profile, nil
+}
@@ -3,7 +3,9 @@ package ensresolver
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/http"
 	"sync"
 
 	"github.com/wealdtech/go-ens/v3"
@@ -15,15 +17,20 @@ import (
 	"github.com/status-im/status-go/contracts/resolver"
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/ens/ensresolver/offchain"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty/collectibles/onchain"
 )
 
-func NewEnsResolver(rpcClient *rpc.Client) *EnsResolver {
+// NewEnsResolver creates the resolver, the NFT metadata of the avatars hosted outside of IPFS is loaded only when
+// allowDirectFetch returns true
+func NewEnsResolver(rpcClient *rpc.Client, allowDirectFetch func() bool) *EnsResolver {
 	return &EnsResolver{
 		contractMaker: &contracts.ContractMaker{
 			RPCClient: rpcClient,
 		},
 		addrPerChain: make(map[uint64]common.Address),
+		metadata:     onchain.NewMetadataFetcher(&http.Client{Timeout: metadataRequestTimeout}, onchain.DefaultIPFSGateways, allowDirectFetch),
 
 		quit: make(chan struct{}),
 	}
@@ -35,6 +42,9 @@ type EnsResolver struct {
 	addrPerChain      map[uint64]common.Address
 	addrPerChainMutex sync.Mutex
 
+	// metadata loads the NFT metadata of the avatars
+	metadata *onchain.MetadataFetcher
+
 	quitOnce sync.Once
 	quit     chan struct{}
 }
@@ -97,75 +107,88 @@ func (e *EnsResolver) OwnerOf(ctx context.Context, chainID uint64, username stri
 	return &owner, nil
 }
 
-func (e *EnsResolver) ContentHash(ctx context.Context, chainID uint64, username string) ([]byte, error) {
-	err := walletCommon.ValidateENSUsername(username)
+// offchainResolver returns a resolver supporting the wildcard and offchain names on top of the regular ones
+func (e *EnsResolver) offchainResolver(chainID uint64) (*offchain.Resolver, error) {
+	backend, err := e.contractMaker.RPCClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	registryAddr, err := resolver.ContractAddress(chainID)
 	if err != nil {
 		return nil, err
 	}
 
-	resolverAddress, err := e.Resolver(ctx, chainID, username)
+	return offchain.NewResolver(backend, registryAddr), nil
+}
+
+func (e *EnsResolver) ContentHash(ctx context.Context, chainID uint64, username string) ([]byte, error) {
+	err := walletCommon.ValidateENSName(username)
 	if err != nil {
 		return nil, err
 	}
 
-	resolver, err := e.contractMaker.NewPublicResolver(chainID, resolverAddress)
+	offchainResolver, err := e.offchainResolver(chainID)
 	if err != nil {
 		return nil, err
 	}
 
-	callOpts := &bind.CallOpts{Context: ctx, Pending: false}
-	contentHash, err := resolver.Contenthash(callOpts, walletCommon.NameHash(username))
+	result, err := offchainResolver.ResolveRecord(ctx, username, "contenthash")
 	if err != nil {
 		return nil, nil
 	}
 
+	contentHash, ok := result[0].([]byte)
+	if !ok {
+		return nil, nil
+	}
+
 	return contentHash, nil
 }
 
 func (e *EnsResolver) PublicKeyOf(ctx context.Context, chainID uint64, username string) (string, error) {
-	err := walletCommon.ValidateENSUsername(username)
+	err := walletCommon.ValidateENSName(username)
 	if err != nil {
 		return "", err
 	}
 
-	resolverAddress, err := e.Resolver(ctx, chainID, username)
+	offchainResolver, err := e.offchainResolver(chainID)
 	if err != nil {
 		return "", err
 	}
 
-	resolver, err := e.contractMaker.NewPublicResolver(chainID, resolverAddress)
+	result, err := offchainResolver.ResolveRecord(ctx, username, "pubkey")
 	if err != nil {
 		return "", err
 	}
 
-	callOpts := &bind.CallOpts{Context: ctx, Pending: false}
-	pubKey, err := resolver.Pubkey(callOpts, walletCommon.NameHash(username))
-	if err != nil {
-		return "", err
+	x, okX := result[0].([32]byte)
+	y, okY := result[1].([32]byte)
+	if !okX || !okY {
+		return "", errors.New("unexpected pubkey record")
 	}
-	return "0x04" + hex.EncodeToString(pubKey.X[:]) + hex.EncodeToString(pubKey.Y[:]), nil
+	return "0x04" + hex.EncodeToString(x[:]) + hex.EncodeToString(y[:]), nil
 }
 
 func (e *EnsResolver) AddressOf(ctx context.Context, chainID uint64, username string) (*common.Address, error) {
-	err := walletCommon.ValidateENSUsername(username)
+	err := walletCommon.ValidateENSName(username)
 	if err != nil {
 		return nil, err
 	}
 
-	resolverAddress, err := e.Resolver(ctx, chainID, username)
+	offchainResolver, err := e.offchainResolver(chainID)
 	if err != nil {
 		return nil, err
 	}
 
-	resolver, err := e.contractMaker.NewPublicResolver(chainID, resolverAddress)
+	result, err := offchainResolver.ResolveRecord(ctx, username, "addr")
 	if err != nil {
 		return nil, err
 	}
 
-	callOpts := &bind.CallOpts{Context: ctx, Pending: false}
-	addr, err := resolver.Addr(callOpts, walletCommon.NameHash(username))
-	if err != nil {
-		return nil, err
+	addr, ok := result[0].(common.Address)
+	if !ok {
+		return nil, errors.New("unexpected addr record")
 	}
 
 	return &addr, nil
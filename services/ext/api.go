@@ -340,6 +340,11 @@ func (api *PublicAPI) GetContactByID(parent context.Context, id string) *protoco
 	return api.service.messenger.GetContactByID(id)
 }
 
+// FetchContactENSProfile resolves the avatar and links of the ENS name of the contact when its profile is viewed
+func (api *PublicAPI) FetchContactENSProfile(ctx context.Context, publicKey string) (*protocol.Contact, error) {
+	return api.service.messenger.FetchContactENSProfile(ctx, publicKey)
+}
+
 func (api *PublicAPI) RequestContactInfoFromMailserver(pubkey string) (*protocol.Contact, error) {
 	return api.service.messenger.FetchContact(pubkey, true)
 }
@@ -5,6 +5,8 @@ const (
 	CategoryGroupInvite            PushCategory = "groupInvite"
 	CategoryCommunityRequestToJoin              = "communityRequestToJoin"
 	CategoryCommunityJoined                     = "communityJoined"
+	CategoryPriceAlert             PushCategory = "priceAlert"
 
-	TypeMessage NotificationType = "message"
+	TypeMessage    NotificationType = "message"
+	TypePriceAlert NotificationType = "priceAlert"
 )
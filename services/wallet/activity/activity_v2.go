@@ -3,6 +3,8 @@ package activity
 import (
 	"context"
 	"database/sql"
+	"math"
+	"sort"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -16,9 +18,11 @@ import (
 	"github.com/status-im/status-go/logutils"
 	ac "github.com/status-im/status-go/services/wallet/activity/common"
 	wCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/poisoning"
 	"github.com/status-im/status-go/services/wallet/requests"
 	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
 	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/spam"
 	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/sqlite"
@@ -35,7 +39,8 @@ type FilterDependencies struct {
 	currentTimestamp func() int64
 }
 
-// getActivityEntriesV2 queries the route_* and tracked_transactions based on filter parameters and arguments
+// getActivityEntriesV2 merges the sent transactions from the route_* and tracked_transactions tables with the
+// received transfers from the transfers table based on filter parameters and arguments
 // it returns metadata for all entries ordered by timestamp column
 func getActivityEntriesV2(ctx context.Context, deps FilterDependencies, addresses []eth.Address, allAddresses bool, chainIDs []wCommon.ChainID, filter Filter, offset int, limit int) ([]Entry, error) {
 	if len(addresses) == 0 {
@@ -45,6 +50,163 @@ func getActivityEntriesV2(ctx context.Context, deps FilterDependencies, addresse
 		return nil, ErrNoChainIDsProvided
 	}
 
+	spamTokens := make(map[string]bool)
+	if filter.HideSpam {
+		ids, err := spam.NewDB(deps.db).GetSpamTokens()
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			spamTokens[id.HashKey()] = true
+		}
+	}
+
+	sources := []*entriesSource{
+		{fetch: func(ctx context.Context, offset int, limit int) ([]Entry, bool, error) {
+			return getSentEntriesV2(ctx, deps, addresses, chainIDs, filter.Period, offset, limit)
+		}},
+		{fetch: func(ctx context.Context, offset int, limit int) ([]Entry, bool, error) {
+			return getIncomingEntriesV2(ctx, deps, addresses, chainIDs, filter.Period, offset, limit)
+		}},
+	}
+
+	// Each source has to provide all the entries up to the end of the requested page to be able to merge them.
+	// Filter fields not handled by the queries are matched against the entries, in that case the sources are read in
+	// chunks until the page is filled with matching entries.
+	matchEntries := filter.requiresEntryMatching() || filter.HideSpam
+	requiredEntries := offset + limit
+	chunkSize := ac.NoLimit
+	if limit != ac.NoLimit {
+		chunkSize = requiredEntries
+		if matchEntries && chunkSize < entriesChunkSize {
+			chunkSize = entriesChunkSize
+		}
+	}
+
+	var entries []Entry
+	for {
+		for _, source := range sources {
+			if source.complete {
+				continue
+			}
+			chunk, complete, err := source.fetch(ctx, source.offset, chunkSize)
+			if err != nil {
+				return nil, err
+			}
+			source.offset += chunkSize
+			source.complete = complete
+			if len(chunk) > 0 {
+				source.oldestTimestamp = chunk[len(chunk)-1].timestamp
+				source.hasEntries = true
+			}
+
+			for i := range chunk {
+				if filter.matches(&chunk[i]) && !hasSpamToken(&chunk[i], spamTokens) {
+					entries = append(entries, chunk[i])
+				}
+			}
+		}
+
+		// Stable to keep the query order (e.g. approval before the transaction) for the same timestamp
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].timestamp > entries[j].timestamp
+		})
+
+		if limit == ac.NoLimit || !matchEntries || allSourcesComplete(sources) ||
+			completeEntriesCount(entries, sources) >= requiredEntries {
+			break
+		}
+	}
+
+	if limit != ac.NoLimit {
+		if offset >= len(entries) {
+			return []Entry{}, nil
+		}
+		end := offset + limit
+		if end > len(entries) {
+			end = len(entries)
+		}
+		entries = entries[offset:end]
+	}
+
+	err := flagPoisoningTransfers(deps.db, entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// entriesChunkSize is the minimal number of rows read at once from a source when the entries are matched in memory
+const entriesChunkSize = 100
+
+// entriesSource reads the entries of one of the activity sources in chunks ordered by timestamp, complete is set once
+// all the rows have been read
+type entriesSource struct {
+	fetch           func(ctx context.Context, offset int, limit int) (entries []Entry, complete bool, err error)
+	offset          int
+	complete        bool
+	hasEntries      bool
+	oldestTimestamp int64
+}
+
+func allSourcesComplete(sources []*entriesSource) bool {
+	for _, source := range sources {
+		if !source.complete {
+			return false
+		}
+	}
+	return true
+}
+
+// completeEntriesCount returns the number of sorted entries which can't be preceded by the entries not read yet, all
+// the entries newer than the oldest entry read from every source which still has rows to read
+func completeEntriesCount(entries []Entry, sources []*entriesSource) int {
+	cutoff := int64(math.MinInt64)
+	for _, source := range sources {
+		if source.complete {
+			continue
+		}
+		if !source.hasEntries {
+			return 0
+		}
+		if source.oldestTimestamp > cutoff {
+			cutoff = source.oldestTimestamp
+		}
+	}
+	return sort.Search(len(entries), func(i int) bool {
+		return entries[i].timestamp <= cutoff
+	})
+}
+
+// flagPoisoningTransfers sets the warnings of the zero value transfers received from addresses looking like the
+// addresses known by the user, attackers make them appear in the activity to have their address copied
+func flagPoisoningTransfers(db *sql.DB, entries []Entry) error {
+	var known *poisoning.KnownAddresses
+	for i := range entries {
+		entry := &entries[i]
+		if entry.activityType != ac.ReceiveAT || entry.sender == nil || entry.tokenIn == nil ||
+			(entry.tokenIn.TokenType != ac.Native && entry.tokenIn.TokenType != ac.Erc20) ||
+			entry.amountIn == nil || entry.amountIn.ToInt().Sign() != 0 {
+			continue
+		}
+
+		if known == nil {
+			var err error
+			known, err = poisoning.NewDetector(db).KnownAddresses()
+			if err != nil {
+				return err
+			}
+		}
+		if warnings := known.Check(*entry.sender); len(warnings) > 0 {
+			entry.poisoningWarnings = warnings
+		}
+	}
+	return nil
+}
+
+// getSentEntriesV2 returns the entries of the transactions sent by the given addresses using the router, complete is
+// set when there are no rows after the requested ones
+func getSentEntriesV2(ctx context.Context, deps FilterDependencies, addresses []eth.Address, chainIDs []wCommon.ChainID, period Period, offset int, limit int) (entries []Entry, complete bool, err error) {
 	q := sq.Select(`
 		st.tx_json,
 		rpt.tx_args_json,
@@ -72,6 +234,7 @@ func getActivityEntriesV2(ctx context.Context, deps FilterDependencies, addresse
 
 	qConditions = append(qConditions, sq.Eq{"rpt.chain_id": chainIDs})
 	qConditions = append(qConditions, sq.Eq{"rip.from_address": addresses})
+	qConditions = append(qConditions, periodConditions("tt.timestamp", period)...)
 
 	q = q.Where(qConditions)
 
@@ -82,27 +245,37 @@ func getActivityEntriesV2(ctx context.Context, deps FilterDependencies, addresse
 
 	query, args, err := q.ToSql()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	stmt, err := deps.db.Prepare(query)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer stmt.Close()
 
 	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer rows.Close()
 
-	data, err := rowsToDataV2(rows)
+	data, rowsCount, err := rowsToDataV2(rows)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+
+	for _, d := range data {
+		if d.Status == transactions.Replaced {
+			d.Status = resolveReplacedStatus(deps.db, transactions.TxIdentity{
+				ChainID: wCommon.ChainID(d.Path.FromChain.ChainID),
+				Hash:    d.Tx.Hash(),
+			})
+		}
 	}
 
-	return dataToEntriesV2(deps, data)
+	entries, err = dataToEntriesV2(deps, data)
+	return entries, limit == ac.NoLimit || rowsCount < limit, err
 }
 
 type entryDataV2 struct {
@@ -124,9 +297,12 @@ func newEntryDataV2() *entryDataV2 {
 	}
 }
 
-func rowsToDataV2(rows *sql.Rows) ([]*entryDataV2, error) {
+// rowsToDataV2 returns the data of the valid rows and the number of rows read
+func rowsToDataV2(rows *sql.Rows) ([]*entryDataV2, int, error) {
 	var ret []*entryDataV2
+	rowsCount := 0
 	for rows.Next() {
+		rowsCount++
 		data := newEntryDataV2()
 
 		nullableTx := sqlite.JSONBlob{Data: data.Tx}
@@ -147,7 +323,7 @@ func rowsToDataV2(rows *sql.Rows) ([]*entryDataV2, error) {
 			&nullableTimestamp,
 		)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		// Check all necessary fields are not null
@@ -169,7 +345,7 @@ func rowsToDataV2(rows *sql.Rows) ([]*entryDataV2, error) {
 		ret = append(ret, data)
 	}
 
-	return ret, nil
+	return ret, rowsCount, nil
 }
 
 func dataToEntriesV2(deps FilterDependencies, data []*entryDataV2) ([]Entry, error) {
@@ -249,6 +425,36 @@ func getActivityTypeV2(processorName string, isApproval bool) ac.Type {
 	return ac.UnknownAT
 }
 
+// resolveReplacedStatus returns the status of the speed-up transaction mined in place of the replaced one,
+// a cancelled transaction is reported as failed
+func resolveReplacedStatus(db *sql.DB, txID transactions.TxIdentity) transactions.TxStatus {
+	trackedTxDB := transactions.NewDB(db)
+	group, err := trackedTxDB.GetReplacementGroup(txID)
+	if err != nil {
+		logutils.ZapLogger().Error("failed to get replacement group", zap.Stringer("hash", txID.Hash), zap.Error(err))
+		return transactions.Replaced
+	}
+
+	for _, hash := range group {
+		id := transactions.TxIdentity{
+			ChainID: txID.ChainID,
+			Hash:    hash,
+		}
+		trackedTx, err := trackedTxDB.GetTx(id)
+		if err != nil || trackedTx.Status == transactions.Pending || trackedTx.Status == transactions.Replaced {
+			continue
+		}
+
+		replacement, err := trackedTxDB.GetReplacement(id)
+		if err != nil || replacement.Type != transactions.SpeedUp {
+			return transactions.Failed
+		}
+		return trackedTx.Status
+	}
+
+	return transactions.Replaced
+}
+
 func getActivityStatusV2(status transactions.TxStatus, timestamp int64, now int64, finalizationDuration int64) ac.Status {
 	switch status {
 	case transactions.Pending:
@@ -258,7 +464,7 @@ func getActivityStatusV2(status transactions.TxStatus, timestamp int64, now int6
 			return ac.FinalizedAS
 		}
 		return ac.CompleteAS
-	case transactions.Failed:
+	case transactions.Failed, transactions.Replaced:
 		return ac.FailedAS
 	}
 
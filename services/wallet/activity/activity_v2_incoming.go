@@ -0,0 +1,185 @@
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	eth "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"go.uber.org/zap"
+
+	"github.com/status-im/status-go/logutils"
+	ac "github.com/status-im/status-go/services/wallet/activity/common"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	wCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/transactions"
+)
+
+// getIncomingEntriesV2 returns the entries of the transfers received by the given addresses, as downloaded by the
+// transfer package. Transfers between the given addresses sent using the router are skipped, they are already
+// represented by the sent entries. complete is set when there are no rows after the requested ones.
+func getIncomingEntriesV2(ctx context.Context, deps FilterDependencies, addresses []eth.Address, chainIDs []wCommon.ChainID, period Period, offset int, limit int) (entries []Entry, complete bool, err error) {
+	q := sq.Select(`
+		t.hash,
+		t.network_id,
+		t.address,
+		t.type,
+		t.timestamp,
+		t.status,
+		t.amount_padded128hex,
+		t.token_address,
+		t.token_id,
+		t.tx_from_address
+		`).
+		From("transfers t").
+		OrderBy("t.timestamp DESC", "t.hash ASC")
+
+	qConditions := sq.And{
+		sq.Eq{"t.network_id": chainIDs},
+		sq.Eq{"t.address": addresses},
+		sq.Eq{"t.loaded": true},
+		sq.Expr("t.tx_to_address = t.address"),
+		sq.Or{
+			sq.NotEq{"t.tx_from_address": addresses},
+			sq.Expr(`NOT EXISTS (SELECT 1 FROM sent_transactions st WHERE
+				st.chain_id = t.network_id AND
+				st.tx_hash = t.tx_hash)`),
+		},
+	}
+	qConditions = append(qConditions, periodConditions("t.timestamp", period)...)
+
+	q = q.Where(qConditions)
+
+	if limit != ac.NoLimit {
+		q = q.Limit(uint64(limit))
+		q = q.Offset(uint64(offset))
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := deps.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	var ret []Entry
+	rowsCount := 0
+
+	now := time.Now().Unix()
+
+	for rows.Next() {
+		rowsCount++
+		var (
+			id              eth.Hash
+			chainID         wCommon.ChainID
+			address         eth.Address
+			transferType    wCommon.Type
+			timestamp       int64
+			receiptStatus   sql.NullInt64
+			amountHex       sql.NullString
+			tokenAddress    []byte
+			tokenID         = new(big.Int)
+			fromAddressData []byte
+		)
+		err := rows.Scan(&id, &chainID, &address, &transferType, &timestamp, &receiptStatus, &amountHex,
+			&tokenAddress, (*bigint.SQLBigIntBytes)(tokenID), &fromAddressData)
+		if err != nil {
+			return nil, false, err
+		}
+
+		token := transferToken(transferType, chainID, eth.BytesToAddress(tokenAddress), tokenID)
+		if token == nil {
+			logutils.ZapLogger().Warn("unsupported transfer type", zap.String("type", string(transferType)))
+			continue
+		}
+
+		status := transactions.Failed
+		if receiptStatus.Valid && uint64(receiptStatus.Int64) == 1 {
+			status = transactions.Success
+		}
+
+		amount := new(big.Int)
+		if amountHex.Valid {
+			if _, ok := amount.SetString(amountHex.String, 16); !ok {
+				logutils.ZapLogger().Warn("malformed transfer amount", zap.String("amount", amountHex.String))
+			}
+		}
+
+		sender := eth.BytesToAddress(fromAddressData)
+		recipient := address
+		entry := Entry{
+			payloadType: ac.SimpleTransactionPT,
+			transaction: &ac.TransactionIdentity{
+				ChainID: chainID,
+				Hash:    id,
+				Address: address,
+			},
+			timestamp:      timestamp,
+			activityType:   ac.ReceiveAT,
+			activityStatus: getActivityStatusV2(status, timestamp, now, getFinalizationPeriod(chainID)),
+			amountIn:       (*hexutil.Big)(amount),
+			tokenIn:        token,
+			sender:         &sender,
+			recipient:      &recipient,
+			chainIDIn:      &chainID,
+			transferType:   transferTypeFromTokenType(token.TokenType),
+		}
+
+		_, entry.symbolIn = lookupAndFillInTokens(deps, nil, entry.tokenIn)
+
+		ret = append(ret, entry)
+	}
+
+	return ret, limit == ac.NoLimit || rowsCount < limit, rows.Err()
+}
+
+func transferToken(transferType wCommon.Type, chainID wCommon.ChainID, tokenAddress eth.Address, tokenID *big.Int) *ac.Token {
+	token := &ac.Token{
+		ChainID: chainID,
+	}
+
+	switch transferType {
+	case wCommon.EthTransfer:
+		token.TokenType = ac.Native
+	case wCommon.Erc20Transfer:
+		token.TokenType = ac.Erc20
+		token.Address = tokenAddress
+	case wCommon.Erc721Transfer, wCommon.Erc1155Transfer:
+		token.TokenType = ac.Erc721
+		if transferType == wCommon.Erc1155Transfer {
+			token.TokenType = ac.Erc1155
+		}
+		token.Address = tokenAddress
+		token.TokenID = (*hexutil.Big)(tokenID)
+	default:
+		return nil
+	}
+
+	return token
+}
+
+func transferTypeFromTokenType(tokenType ac.TokenType) *ac.TransferType {
+	ret := new(ac.TransferType)
+	switch tokenType {
+	case ac.Native:
+		*ret = ac.TransferTypeEth
+	case ac.Erc20:
+		*ret = ac.TransferTypeErc20
+	case ac.Erc721:
+		*ret = ac.TransferTypeErc721
+	case ac.Erc1155:
+		*ret = ac.TransferTypeErc1155
+	default:
+		return nil
+	}
+	return ret
+}
@@ -0,0 +1,353 @@
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	eth "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	ac "github.com/status-im/status-go/services/wallet/activity/common"
+	"github.com/status-im/status-go/services/wallet/common"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	"github.com/status-im/status-go/services/wallet/transfer"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+var (
+	testAccount      = eth.HexToAddress("0xAC")
+	testOtherAccount = eth.HexToAddress("0xAD")
+	testCounterparty = eth.HexToAddress("0xCC")
+	testTokenAddress = eth.HexToAddress("0x70")
+	testNFTAddress   = eth.HexToAddress("0xAF7")
+)
+
+func testDeps(db *sql.DB) FilterDependencies {
+	return FilterDependencies{
+		db: db,
+		tokenSymbol: func(token ac.Token) string {
+			if token.TokenType == ac.Native {
+				return "ETH"
+			}
+			return "TKN"
+		},
+		tokenFromSymbol: func(chainID *common.ChainID, symbol string) *ac.Token {
+			return nil
+		},
+		currentTimestamp: func() int64 {
+			return time.Now().Unix()
+		},
+	}
+}
+
+func insertTestIncomingTransfer(t *testing.T, db *sql.DB, hash int64, chainID common.ChainID, from eth.Address, to eth.Address, timestamp int64, success bool, opt *transfer.TestTransferOptions) {
+	tr := transfer.TestTransfer{
+		TestTransaction: transfer.TestTransaction{
+			Hash:      eth.BigToHash(big.NewInt(hash)),
+			ChainID:   chainID,
+			From:      from,
+			Timestamp: timestamp,
+			BlkNumber: timestamp,
+			Success:   success,
+		},
+		To:    to,
+		Value: hash,
+	}
+	if opt == nil {
+		opt = &transfer.TestTransferOptions{}
+	}
+	transfer.InsertTestTransferWithOptions(t, db, to, &tr, opt)
+}
+
+// setupActivityV2TestDB creates, from the newest to the oldest:
+// 0: received ERC721 on chain 10, 1: sent ETH, 2: received ERC20, 3: internal transfer sent with the router,
+// 4: received ETH, failed
+func setupActivityV2TestDB(t *testing.T) *sql.DB {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+
+	insertTestIncomingTransfer(t, db, 100, 10, testCounterparty, testAccount, 500, true, &transfer.TestTransferOptions{
+		TokenAddress: testNFTAddress,
+		TokenID:      big.NewInt(7),
+	})
+	insertTestRouteTx(t, db, testRouteTx{uuid: "1", chainID: 1, hash: eth.BigToHash(big.NewInt(101)), from: testAccount, to: testCounterparty,
+		processor: pathProcessorCommon.ProcessorTransferName, tokenSymbol: "ETH", timestamp: 400})
+	insertTestIncomingTransfer(t, db, 102, 1, testOtherAccount, testAccount, 300, true, &transfer.TestTransferOptions{
+		TokenAddress: testTokenAddress,
+	})
+
+	internalHash := eth.BigToHash(big.NewInt(103))
+	insertTestRouteTx(t, db, testRouteTx{uuid: "2", chainID: 1, hash: internalHash, from: testOtherAccount, to: testAccount,
+		processor: pathProcessorCommon.ProcessorTransferName, tokenSymbol: "ETH", timestamp: 200})
+	insertTestIncomingTransfer(t, db, 103, 1, testOtherAccount, testAccount, 200, true, nil)
+
+	insertTestIncomingTransfer(t, db, 104, 1, testCounterparty, testAccount, 100, false, nil)
+
+	// the sent transaction as seen by the transfer downloader must not be reported as received
+	insertTestIncomingTransfer(t, db, 101, 1, testAccount, testCounterparty, 400, true, nil)
+
+	return db
+}
+
+func entriesTimestamps(entries []Entry) []int64 {
+	res := make([]int64, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, e.timestamp)
+	}
+	return res
+}
+
+func TestGetActivityEntriesV2_IncomingTransfers(t *testing.T) {
+	db := setupActivityV2TestDB(t)
+	defer db.Close()
+	deps := testDeps(db)
+	chainIDs := []common.ChainID{1, 10}
+
+	entries, err := getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, chainIDs, Filter{}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []int64{500, 400, 300, 200, 100}, entriesTimestamps(entries))
+
+	nft := entries[0]
+	require.Equal(t, ac.SimpleTransactionPT, nft.payloadType)
+	require.Equal(t, &ac.TransactionIdentity{ChainID: 10, Hash: eth.BigToHash(big.NewInt(100)), Address: testAccount}, nft.transaction)
+	require.Equal(t, ac.ReceiveAT, nft.activityType)
+	require.Equal(t, ac.CompleteAS, nft.activityStatus)
+	require.Equal(t, testCounterparty, *nft.sender)
+	require.Equal(t, testAccount, *nft.recipient)
+	require.Equal(t, common.ChainID(10), *nft.chainIDIn)
+	require.Equal(t, ac.TransferTypeErc721, *nft.transferType)
+	require.Equal(t, &ac.Token{TokenType: ac.Erc721, ChainID: 10, Address: testNFTAddress, TokenID: (*hexutil.Big)(big.NewInt(7))}, nft.tokenIn)
+	require.Nil(t, nft.symbolIn)
+
+	require.Equal(t, ac.SendAT, entries[1].activityType)
+
+	erc20 := entries[2]
+	require.Equal(t, ac.ReceiveAT, erc20.activityType)
+	require.Equal(t, &ac.Token{TokenType: ac.Erc20, ChainID: 1, Address: testTokenAddress}, erc20.tokenIn)
+	require.Equal(t, "TKN", *erc20.symbolIn)
+	require.Equal(t, int64(102), erc20.amountIn.ToInt().Int64())
+
+	// the internal transfer isn't sent by the requested address, it is reported as received
+	require.Equal(t, ac.ReceiveAT, entries[3].activityType)
+	require.Equal(t, ac.FailedAS, entries[4].activityStatus)
+	require.Equal(t, "ETH", *entries[4].symbolIn)
+
+	// the internal transfer is reported only as sent when both accounts are requested
+	entries, err = getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount, testOtherAccount}, true, chainIDs, Filter{}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []int64{500, 400, 300, 200, 100}, entriesTimestamps(entries))
+	require.Equal(t, ac.SendAT, entries[3].activityType)
+
+	// pages are taken from the merged entries
+	entries, err = getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, chainIDs, Filter{}, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{400, 300}, entriesTimestamps(entries))
+
+	entries, err = getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, chainIDs, Filter{}, 4, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{100}, entriesTimestamps(entries))
+
+	entries, err = getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, chainIDs, Filter{}, 5, 2)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	entries, err = getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, []common.ChainID{10}, Filter{}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []int64{500}, entriesTimestamps(entries))
+}
+
+func TestGetActivityEntriesV2_Filter(t *testing.T) {
+	db := setupActivityV2TestDB(t)
+	defer db.Close()
+	deps := testDeps(db)
+	chainIDs := []common.ChainID{1, 10}
+
+	testCases := []struct {
+		name     string
+		filter   Filter
+		offset   int
+		limit    int
+		expected []int64
+	}{
+		{
+			name:     "period",
+			filter:   Filter{Period: Period{StartTimestamp: 200, EndTimestamp: 400}},
+			limit:    10,
+			expected: []int64{400, 300, 200},
+		},
+		{
+			name:     "types",
+			filter:   Filter{Types: []ac.Type{ac.ReceiveAT}},
+			limit:    10,
+			expected: []int64{500, 300, 200, 100},
+		},
+		{
+			name:     "statuses",
+			filter:   Filter{Statuses: []ac.Status{ac.FailedAS}},
+			limit:    10,
+			expected: []int64{100},
+		},
+		{
+			name:     "counterparty",
+			filter:   Filter{CounterpartyAddresses: []eth.Address{testCounterparty}},
+			limit:    10,
+			expected: []int64{500, 400, 100},
+		},
+		{
+			name:     "native asset",
+			filter:   Filter{Assets: []ac.Token{{TokenType: ac.Native, ChainID: 10}}},
+			limit:    10,
+			expected: []int64{400, 200, 100},
+		},
+		{
+			name:     "erc20 asset",
+			filter:   Filter{Assets: []ac.Token{{TokenType: ac.Erc20, ChainID: 1, Address: testTokenAddress}}},
+			limit:    10,
+			expected: []int64{300},
+		},
+		{
+			name:     "collectible",
+			filter:   Filter{Collectibles: []ac.Token{{TokenType: ac.Erc721, ChainID: 10, Address: testNFTAddress, TokenID: (*hexutil.Big)(big.NewInt(7))}}},
+			limit:    10,
+			expected: []int64{500},
+		},
+		{
+			name:     "other collectible",
+			filter:   Filter{Collectibles: []ac.Token{{TokenType: ac.Erc721, ChainID: 10, Address: testNFTAddress, TokenID: (*hexutil.Big)(big.NewInt(8))}}},
+			limit:    10,
+			expected: []int64{},
+		},
+		{
+			name:     "filter out assets",
+			filter:   Filter{FilterOutAssets: true},
+			limit:    10,
+			expected: []int64{500},
+		},
+		{
+			name:     "filter out collectibles",
+			filter:   Filter{FilterOutCollectibles: true},
+			limit:    10,
+			expected: []int64{400, 300, 200, 100},
+		},
+		{
+			name:     "paginated with entry matching",
+			filter:   Filter{Types: []ac.Type{ac.ReceiveAT}},
+			offset:   1,
+			limit:    2,
+			expected: []int64{300, 200},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, chainIDs, tc.filter, tc.offset, tc.limit)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, entriesTimestamps(entries))
+		})
+	}
+}
+
+func TestGetActivityEntriesV2_FilterChunks(t *testing.T) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	defer db.Close()
+	deps := testDeps(db)
+
+	// the matching entries are older than the first chunks read from the sources
+	count := 2*entriesChunkSize + 50
+	for i := 0; i < count; i++ {
+		insertTestIncomingTransfer(t, db, int64(1000+i), 1, testCounterparty, testAccount, int64(1000+i), true, nil)
+	}
+	insertTestIncomingTransfer(t, db, 10, 1, testCounterparty, testAccount, 10, false, nil)
+	insertTestIncomingTransfer(t, db, 5, 1, testCounterparty, testAccount, 5, false, nil)
+	insertTestRouteTx(t, db, testRouteTx{uuid: "1", chainID: 1, hash: eth.BigToHash(big.NewInt(1)), from: testAccount, to: testCounterparty,
+		processor: pathProcessorCommon.ProcessorTransferName, tokenSymbol: "ETH", timestamp: 7})
+
+	filter := Filter{Statuses: []ac.Status{ac.FailedAS}}
+	entries, err := getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, []common.ChainID{1}, filter, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 5}, entriesTimestamps(entries))
+
+	entries, err = getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, []common.ChainID{1}, filter, 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, []int64{5}, entriesTimestamps(entries))
+
+	// the entries of both sources are merged across the chunks
+	filter = Filter{CounterpartyAddresses: []eth.Address{testCounterparty}}
+	entries, err = getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, []common.ChainID{1}, filter, count-1, 4)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1000, 10, 7, 5}, entriesTimestamps(entries))
+}
+
+func TestGetActivityEntriesV2_PoisoningWarnings(t *testing.T) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	defer db.Close()
+	deps := testDeps(db)
+
+	counterparty := eth.HexToAddress("0x1a2b000000000000000000000000000000003c4d")
+	lookalike := eth.HexToAddress("0x1a2bffffffffffffffffffffffffffffffff3c4d")
+	now := time.Now().Unix()
+
+	sent := transfer.TestTransfer{
+		TestTransaction: transfer.TestTransaction{Hash: eth.BigToHash(big.NewInt(1)), ChainID: 1, From: testAccount, Timestamp: now - 2, BlkNumber: 1, Success: true},
+		To:              counterparty,
+		Value:           100,
+	}
+	transfer.InsertTestTransferWithOptions(t, db, testAccount, &sent, &transfer.TestTransferOptions{})
+
+	zeroValue := transfer.TestTransfer{
+		TestTransaction: transfer.TestTransaction{Hash: eth.BigToHash(big.NewInt(2)), ChainID: 1, From: lookalike, Timestamp: now - 1, BlkNumber: 2, Success: true},
+		To:              testAccount,
+		Value:           0,
+	}
+	transfer.InsertTestTransferWithOptions(t, db, testAccount, &zeroValue, &transfer.TestTransferOptions{TokenAddress: testTokenAddress})
+
+	// a transfer with value from the same address is not flagged
+	insertTestIncomingTransfer(t, db, 3, 1, lookalike, testAccount, now, true, nil)
+
+	entries, err := getActivityEntriesV2(context.Background(), deps, []eth.Address{testAccount}, false, []common.ChainID{1}, Filter{}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Empty(t, entries[0].poisoningWarnings)
+	require.Len(t, entries[1].poisoningWarnings, 1)
+	require.Equal(t, lookalike, entries[1].poisoningWarnings[0].Address)
+	require.Equal(t, counterparty, entries[1].poisoningWarnings[0].LookalikeOf)
+}
+
+func TestService_IncomingTransferNewOnTop(t *testing.T) {
+	state := setupTestService(t)
+	defer state.close()
+
+	ch := make(chan walletevent.Event, 4)
+	sub := state.eventFeed.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	state.tokenMock.EXPECT().LookupTokenIdentity(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	insertTestIncomingTransfer(t, state.service.db, 1, 1, testCounterparty, testAccount, 100, true, nil)
+
+	sessionID := state.service.StartFilterSession([]eth.Address{testAccount}, []common.ChainID{1}, Filter{}, 5, V2)
+	require.Greater(t, sessionID, SessionID(0))
+	defer state.service.StopFilterSession(sessionID)
+
+	filterResponseCount := validateFilteringDone(t, ch, 1, nil, nil)
+
+	insertTestIncomingTransfer(t, state.service.db, 2, 1, testCounterparty, testAccount, 200, true, nil)
+	state.eventFeed.Send(walletevent.Event{
+		Type:     transfer.EventNewTransfers,
+		Accounts: []eth.Address{testAccount},
+		ChainID:  1,
+	})
+
+	_, sessionUpdatesCount := validateSessionUpdateEvent(t, ch, &filterResponseCount, 1, getValidateSessionUpdateHasNewOnTopFn(t))
+	require.Equal(t, 1, sessionUpdatesCount)
+}
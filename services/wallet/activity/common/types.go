@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/poisoning"
 )
 
 type PayloadType = int
@@ -113,6 +114,7 @@ type EntryData struct {
 	CommunityID               *string                        `json:"communityId,omitempty"`
 	InteractedContractAddress *eth.Address                   `json:"interactedContractAddress,omitempty"`
 	ApprovalSpender           *eth.Address                   `json:"approvalSpender,omitempty"`
+	PoisoningWarnings         []*poisoning.Warning           `json:"poisoningWarnings,omitempty"`
 
 	IsNew *bool `json:"isNew,omitempty"`
 
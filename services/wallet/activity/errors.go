@@ -6,4 +6,6 @@ var (
 	ErrNoAddressesProvided = errors.New("no addresses provided")
 	ErrNoChainIDsProvided  = errors.New("no chainIDs provided")
 	ErrSessionNotFound     = errors.New("session not found")
+
+	ErrUnsupportedExportFormat = errors.New("unsupported export format")
 )
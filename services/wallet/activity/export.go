@@ -0,0 +1,488 @@
+package activity
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	eth "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/logutils"
+	ac "github.com/status-im/status-go/services/wallet/activity/common"
+	"github.com/status-im/status-go/services/wallet/async"
+	w_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/sqlite"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatJSON ExportFormat = "json"
+
+	// exportProgressInterval is the number of rows written between two progress events
+	exportProgressInterval = 100
+)
+
+// ExchangeRates provides the cached daily token prices, implemented by history.Exchange
+type ExchangeRates interface {
+	GetExchangeRateForDay(token string, currency string, date time.Time) (float32, error)
+	FetchAndCacheMissingRates(token string, currency string) error
+}
+
+type ExportParams struct {
+	Addresses []eth.Address      `json:"addresses"`
+	ChainIDs  []w_common.ChainID `json:"chainIds"`
+	Period    Period             `json:"period"`
+	Format    ExportFormat       `json:"format"`
+	Currency  string             `json:"currency"`
+	FilePath  string             `json:"filePath"`
+}
+
+// ExportRow is a single activity entry as written to the export file. Amount and fee are formatted
+// using the token decimals, fiat values are empty if the historical rate is not available. The fee is empty for the
+// received transfers and the transactions without a downloaded receipt
+type ExportRow struct {
+	Timestamp    int64            `json:"timestamp"`
+	ChainID      w_common.ChainID `json:"chainId"`
+	Hash         eth.Hash         `json:"hash"`
+	Type         string           `json:"type"`
+	Status       string           `json:"status"`
+	Account      eth.Address      `json:"account"`
+	Counterparty *eth.Address     `json:"counterparty,omitempty"`
+	Token        string           `json:"token"`
+	TokenAddress *eth.Address     `json:"tokenAddress,omitempty"`
+	TokenID      *hexutil.Big     `json:"tokenId,omitempty"`
+	Amount       string           `json:"amount"`
+	AmountFiat   *float64         `json:"amountFiat,omitempty"`
+	Fee          string           `json:"fee,omitempty"`
+	FeeToken     string           `json:"feeToken,omitempty"`
+	FeeFiat      *float64         `json:"feeFiat,omitempty"`
+	Currency     string           `json:"currency"`
+}
+
+type ExportProgress struct {
+	FilePath string `json:"filePath"`
+	Exported int    `json:"exported"`
+	Total    int    `json:"total"`
+}
+
+type ExportResponse struct {
+	FilePath  string    `json:"filePath"`
+	Exported  int       `json:"exported"`
+	ErrorCode ErrorCode `json:"errorCode"`
+}
+
+var exportTypeNames = map[ac.Type]string{
+	ac.SendAT:               "send",
+	ac.ReceiveAT:            "receive",
+	ac.BuyAT:                "buy",
+	ac.SwapAT:               "swap",
+	ac.BridgeAT:             "bridge",
+	ac.ContractDeploymentAT: "contract-deployment",
+	ac.MintAT:               "mint",
+	ac.ApproveAT:            "approve",
+}
+
+var exportStatusNames = map[ac.Status]string{
+	ac.FailedAS:    "failed",
+	ac.PendingAS:   "pending",
+	ac.CompleteAS:  "complete",
+	ac.FinalizedAS: "finalized",
+}
+
+var exportCSVHeader = []string{
+	"timestamp", "chain_id", "hash", "type", "status", "account", "counterparty", "token", "token_address",
+	"token_id", "amount", "amount_fiat", "fee", "fee_token", "fee_fiat", "currency",
+}
+
+// ExportActivityAsync writes the activity of the given addresses and chains over the period to a file.
+// EventActivityExportProgress events are sent while writing and EventActivityExportDone when finished
+func (s *Service) ExportActivityAsync(requestID int32, params ExportParams) {
+	s.scheduler.Enqueue(requestID, exportTask, func(ctx context.Context) (interface{}, error) {
+		return exportActivity(ctx, s.getExportDeps(), params, func(progress ExportProgress) {
+			sendResponseEvent(s.eventFeed, &requestID, EventActivityExportProgress, progress, nil)
+		})
+	}, func(result interface{}, taskType async.TaskType, err error) {
+		res := ExportResponse{
+			FilePath:  params.FilePath,
+			ErrorCode: ErrorCodeFailed,
+		}
+		if exported, ok := result.(int); ok {
+			res.Exported = exported
+		}
+
+		if errors.Is(err, context.Canceled) || errors.Is(err, async.ErrTaskOverwritten) {
+			res.ErrorCode = ErrorCodeTaskCanceled
+		} else if err == nil {
+			res.ErrorCode = ErrorCodeSuccess
+		}
+
+		sendResponseEvent(s.eventFeed, &requestID, EventActivityExportDone, res, err)
+	})
+}
+
+type exportDependencies struct {
+	FilterDependencies
+	// use token.TokenType, token.ChainID and token.Address to find the token decimals, false if not found
+	tokenDecimals func(token ac.Token) (uint, bool)
+	rates         ExchangeRates
+}
+
+func (s *Service) getExportDeps() exportDependencies {
+	return exportDependencies{
+		FilterDependencies: s.getDeps(),
+		tokenDecimals: func(t ac.Token) (uint, bool) {
+			info := s.tokenManager.LookupTokenIdentity(uint64(t.ChainID), t.Address, t.TokenType == ac.Native)
+			if info == nil {
+				return 0, false
+			}
+			return info.Decimals, true
+		},
+		rates: s.exchangeRates,
+	}
+}
+
+// exportActivity returns the number of exported rows
+func exportActivity(ctx context.Context, deps exportDependencies, params ExportParams, progressFn func(ExportProgress)) (int, error) {
+	writerFactory, ok := exportWriters[params.Format]
+	if !ok {
+		return 0, ErrUnsupportedExportFormat
+	}
+
+	entries, err := getActivityEntriesV2(ctx, deps.FilterDependencies, params.Addresses, true, params.ChainIDs, Filter{Period: params.Period}, 0, ac.NoLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	// the rows are written to a temporary file renamed once complete, a failed or canceled export doesn't leave a
+	// partial file behind nor overwrites a previous export
+	file, err := os.CreateTemp(filepath.Dir(params.FilePath), filepath.Base(params.FilePath)+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	exported, err := writeExportFile(ctx, deps, params, entries, file, writerFactory, progressFn)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), params.FilePath)
+	}
+	if err != nil {
+		if removeErr := os.Remove(file.Name()); removeErr != nil && !os.IsNotExist(removeErr) {
+			logutils.ZapLogger().Warn("failed to remove the temporary export file", zap.String("file", file.Name()), zap.Error(removeErr))
+		}
+		return exported, err
+	}
+	progressFn(ExportProgress{FilePath: params.FilePath, Exported: exported, Total: len(entries)})
+
+	return exported, nil
+}
+
+// writeExportFile writes the entries to the file and returns the number of written rows
+func writeExportFile(ctx context.Context, deps exportDependencies, params ExportParams, entries []Entry, file *os.File,
+	writerFactory func(w io.Writer) (exportWriter, error), progressFn func(ExportProgress)) (int, error) {
+	buffered := bufio.NewWriter(file)
+	writer, err := writerFactory(buffered)
+	if err != nil {
+		return 0, err
+	}
+
+	rates := newExportRates(deps.rates, params.Currency)
+	for i := range entries {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+
+		row, err := newExportRow(ctx, deps, rates, &entries[i])
+		if err != nil {
+			return i, err
+		}
+		if err := writer.writeRow(row); err != nil {
+			return i, err
+		}
+
+		if (i+1)%exportProgressInterval == 0 && i+1 < len(entries) {
+			progressFn(ExportProgress{FilePath: params.FilePath, Exported: i + 1, Total: len(entries)})
+		}
+	}
+
+	if err := writer.close(); err != nil {
+		return len(entries), err
+	}
+	return len(entries), buffered.Flush()
+}
+
+func newExportRow(ctx context.Context, deps exportDependencies, rates *exportRates, entry *Entry) (*ExportRow, error) {
+	row := &ExportRow{
+		Timestamp:    entry.timestamp,
+		Type:         exportTypeNames[entry.activityType],
+		Status:       exportStatusNames[entry.activityStatus],
+		Counterparty: entry.counterparty(),
+		Currency:     rates.currency,
+	}
+
+	var fee *big.Int
+	var err error
+	if entry.payloadType == ac.MultiTransactionPT {
+		if len(entry.transactions) == 0 {
+			return nil, errors.New("entry without transactions")
+		}
+		tx := entry.transactions[0]
+		row.ChainID, row.Hash, row.Account = tx.ChainID, tx.Hash, tx.Address
+		// the account sent the transaction, so it paid the fee
+		fee, err = getTransactionFee(ctx, deps.db, tx.ChainID, tx.Hash)
+	} else {
+		row.ChainID, row.Account = entry.transaction.ChainID, entry.transaction.Address
+		// the transfer was received, the fee was paid by the sender and is left empty
+		row.Hash, err = getTransferTxHash(ctx, deps.db, entry.transaction)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, amount, symbol := entry.tokenOut, entry.amountOut, entry.symbolOut
+	if entry.activityType == ac.ReceiveAT || token == nil {
+		token, amount, symbol = entry.tokenIn, entry.amountIn, entry.symbolIn
+	}
+	if symbol != nil {
+		row.Token = *symbol
+	}
+	if token != nil && token.TokenType != ac.Native {
+		address := token.Address
+		row.TokenAddress = &address
+		row.TokenID = token.TokenID
+	}
+	if token != nil && amount != nil {
+		if token.TokenID != nil {
+			// collectibles amount is the number of items
+			row.Amount = amount.ToInt().String()
+		} else if decimals, ok := deps.tokenDecimals(*token); ok {
+			row.Amount = formatUnits(amount.ToInt(), decimals)
+			row.AmountFiat = rates.fiatValue(row.Token, amount.ToInt(), decimals, entry.timestamp)
+		} else {
+			// unknown token, the raw amount is exported
+			row.Amount = amount.ToInt().String()
+		}
+	}
+
+	if fee != nil {
+		nativeToken := ac.Token{TokenType: ac.Native, ChainID: row.ChainID}
+		row.FeeToken = deps.tokenSymbol(nativeToken)
+		decimals, ok := deps.tokenDecimals(nativeToken)
+		if !ok {
+			decimals = 18
+		}
+		row.Fee = formatUnits(fee, decimals)
+		if fee.Sign() > 0 {
+			row.FeeFiat = rates.fiatValue(row.FeeToken, fee, decimals, entry.timestamp)
+		}
+	}
+
+	return row, nil
+}
+
+// getTransactionFee returns nil if the transaction receipt was not downloaded yet
+func getTransactionFee(ctx context.Context, db *sql.DB, chainID w_common.ChainID, txHash eth.Hash) (*big.Int, error) {
+	receipt := new(ethTypes.Receipt)
+	nullableReceipt := sqlite.JSONBlob{Data: receipt}
+	err := db.QueryRowContext(ctx, `SELECT receipt FROM transfers WHERE network_id = ? AND tx_hash = ? AND receipt IS NOT NULL LIMIT 1`,
+		chainID, txHash).Scan(&nullableReceipt)
+	if err == sql.ErrNoRows || (err == nil && !nullableReceipt.Valid) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.EffectiveGasPrice == nil {
+		return nil, nil
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	if receipt.L1Fee != nil {
+		fee.Add(fee, receipt.L1Fee)
+	}
+	return fee, nil
+}
+
+// getTransferTxHash returns the hash of the transaction which contains the transfer
+func getTransferTxHash(ctx context.Context, db *sql.DB, transfer *ac.TransactionIdentity) (eth.Hash, error) {
+	var txHash eth.Hash
+	err := db.QueryRowContext(ctx, `SELECT tx_hash FROM transfers WHERE network_id = ? AND hash = ? AND address = ?`,
+		transfer.ChainID, transfer.Hash, transfer.Address).Scan(&txHash)
+	if err == sql.ErrNoRows {
+		return transfer.Hash, nil
+	}
+	return txHash, err
+}
+
+// formatUnits formats the amount as a decimal number with the given number of decimals, without trailing zeros
+func formatUnits(amount *big.Int, decimals uint) string {
+	if decimals == 0 {
+		return amount.String()
+	}
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	str := new(big.Rat).SetFrac(amount, divisor).FloatString(int(decimals))
+	for str[len(str)-1] == '0' {
+		str = str[:len(str)-1]
+	}
+	if str[len(str)-1] == '.' {
+		str = str[:len(str)-1]
+	}
+	return str
+}
+
+// exportRates fetches the missing rates at most once per token for the whole export
+type exportRates struct {
+	rates    ExchangeRates
+	currency string
+	fetched  map[string]bool
+}
+
+func newExportRates(rates ExchangeRates, currency string) *exportRates {
+	return &exportRates{
+		rates:    rates,
+		currency: currency,
+		fetched:  make(map[string]bool),
+	}
+}
+
+func (r *exportRates) fiatValue(symbol string, amount *big.Int, decimals uint, timestamp int64) *float64 {
+	if r.rates == nil || r.currency == "" || symbol == "" {
+		return nil
+	}
+
+	date := time.Unix(timestamp, 0).UTC()
+	rate, err := r.rates.GetExchangeRateForDay(symbol, r.currency, date)
+	if err != nil && !r.fetched[symbol] {
+		r.fetched[symbol] = true
+		if fetchErr := r.rates.FetchAndCacheMissingRates(symbol, r.currency); fetchErr != nil {
+			logutils.ZapLogger().Warn("failed to fetch exchange rates", zap.String("symbol", symbol), zap.Error(fetchErr))
+			return nil
+		}
+		rate, err = r.rates.GetExchangeRateForDay(symbol, r.currency, date)
+	}
+	if err != nil || rate == 0 {
+		return nil
+	}
+
+	divisor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	fiat := new(big.Float).Mul(new(big.Float).SetInt(amount), big.NewFloat(float64(rate)))
+	value, _ := fiat.Quo(fiat, divisor).Float64()
+	return &value
+}
+
+type exportWriter interface {
+	writeRow(row *ExportRow) error
+	close() error
+}
+
+var exportWriters = map[ExportFormat]func(w io.Writer) (exportWriter, error){
+	ExportFormatCSV:  newCSVExportWriter,
+	ExportFormatJSON: newJSONExportWriter,
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer) (exportWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportCSVHeader); err != nil {
+		return nil, err
+	}
+	return &csvExportWriter{writer: writer}, nil
+}
+
+func (c *csvExportWriter) writeRow(row *ExportRow) error {
+	optionalAddress := func(address *eth.Address) string {
+		if address == nil {
+			return ""
+		}
+		return address.Hex()
+	}
+	optionalFloat := func(value *float64) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*value, 'f', -1, 64)
+	}
+	tokenID := ""
+	if row.TokenID != nil {
+		tokenID = row.TokenID.ToInt().String()
+	}
+
+	err := c.writer.Write([]string{
+		strconv.FormatInt(row.Timestamp, 10),
+		strconv.FormatUint(uint64(row.ChainID), 10),
+		row.Hash.Hex(),
+		row.Type,
+		row.Status,
+		row.Account.Hex(),
+		optionalAddress(row.Counterparty),
+		row.Token,
+		optionalAddress(row.TokenAddress),
+		tokenID,
+		row.Amount,
+		optionalFloat(row.AmountFiat),
+		row.Fee,
+		row.FeeToken,
+		optionalFloat(row.FeeFiat),
+		row.Currency,
+	})
+	return err
+}
+
+func (c *csvExportWriter) close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// jsonExportWriter writes the rows as a JSON array, one row per line
+type jsonExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONExportWriter(w io.Writer) (exportWriter, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+	return &jsonExportWriter{w: w}, nil
+}
+
+func (j *jsonExportWriter) writeRow(row *ExportRow) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	separator := "\n"
+	if j.count > 0 {
+		separator = ",\n"
+	}
+	if _, err := io.WriteString(j.w, separator); err != nil {
+		return err
+	}
+	_, err = j.w.Write(data)
+	j.count++
+	return err
+}
+
+func (j *jsonExportWriter) close() error {
+	_, err := io.WriteString(j.w, "\n]\n")
+	return err
+}
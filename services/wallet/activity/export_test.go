@@ -0,0 +1,219 @@
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	eth "github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+
+	ac "github.com/status-im/status-go/services/wallet/activity/common"
+	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/transfer"
+)
+
+type testExchangeRates struct {
+	rates      map[string]float32
+	fetchCalls map[string]int
+}
+
+func (r *testExchangeRates) GetExchangeRateForDay(token string, currency string, date time.Time) (float32, error) {
+	rate, ok := r.rates[token+currency]
+	if !ok {
+		return 0, errors.New("missing token")
+	}
+	return rate, nil
+}
+
+func (r *testExchangeRates) FetchAndCacheMissingRates(token string, currency string) error {
+	r.fetchCalls[token]++
+	return nil
+}
+
+func setupExportTest(t *testing.T) (*sql.DB, exportDependencies, *testExchangeRates) {
+	db := setupActivityV2TestDB(t)
+
+	rates := &testExchangeRates{
+		rates:      map[string]float32{"ETHUSD": 2000},
+		fetchCalls: make(map[string]int),
+	}
+	deps := exportDependencies{
+		FilterDependencies: testDeps(db),
+		tokenDecimals: func(token ac.Token) (uint, bool) {
+			if token.TokenType == ac.Native {
+				return 18, true
+			}
+			return 6, true
+		},
+		rates: rates,
+	}
+
+	// the receipt of the sent transaction, as stored by the transfer downloader
+	entries, err := getActivityEntriesV2(context.Background(), deps.FilterDependencies, []eth.Address{testAccount}, true, []common.ChainID{1}, Filter{}, 0, ac.NoLimit)
+	require.NoError(t, err)
+	require.Equal(t, ac.SendAT, entries[0].activityType)
+	sentHash := entries[0].transactions[0].Hash
+
+	transfer.InsertTestTransferWithOptions(t, db, testAccount, &transfer.TestTransfer{
+		TestTransaction: transfer.TestTransaction{
+			Hash:      sentHash,
+			ChainID:   1,
+			From:      testAccount,
+			Timestamp: 400,
+			BlkNumber: 400,
+			Success:   true,
+		},
+		To: testCounterparty,
+	}, &transfer.TestTransferOptions{
+		Receipt: &ethTypes.Receipt{
+			Status:            ethTypes.ReceiptStatusSuccessful,
+			GasUsed:           21000,
+			EffectiveGasPrice: big.NewInt(1000000000),
+			Logs:              []*ethTypes.Log{},
+		},
+	})
+
+	return db, deps, rates
+}
+
+func TestExportActivity_CSV(t *testing.T) {
+	db, deps, rates := setupExportTest(t)
+	defer db.Close()
+
+	filePath := filepath.Join(t.TempDir(), "activity.csv")
+	var progress []ExportProgress
+	exported, err := exportActivity(context.Background(), deps, ExportParams{
+		Addresses: []eth.Address{testAccount},
+		ChainIDs:  []common.ChainID{1, 10},
+		Format:    ExportFormatCSV,
+		Currency:  "USD",
+		FilePath:  filePath,
+	}, func(p ExportProgress) {
+		progress = append(progress, p)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, exported)
+	require.Equal(t, []ExportProgress{{FilePath: filePath, Exported: 5, Total: 5}}, progress)
+
+	file, err := os.Open(filePath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+	require.Equal(t, exportCSVHeader, records[0])
+
+	// received collectible, amount is the number of items, the fee was paid by the sender
+	require.Equal(t, []string{"500", "10", eth.BigToHash(big.NewInt(100)).Hex(), "receive", "complete", testAccount.Hex(),
+		testCounterparty.Hex(), "", testNFTAddress.Hex(), "7", "100", "", "", "", "", "USD"}, records[1])
+
+	// sent ETH, the fee comes from the receipt
+	sent := records[2]
+	require.Equal(t, "send", sent[3])
+	require.Equal(t, testCounterparty.Hex(), sent[6])
+	require.Equal(t, "ETH", sent[7])
+	require.Equal(t, "0.000021", sent[12])
+	require.Equal(t, "0.042", sent[14])
+
+	// received ERC20 without historical rate
+	require.Equal(t, []string{"300", "1", eth.BigToHash(big.NewInt(102)).Hex(), "receive", "complete", testAccount.Hex(),
+		testOtherAccount.Hex(), "TKN", testTokenAddress.Hex(), "", "0.000102", "", "", "", "", "USD"}, records[3])
+	require.Equal(t, 1, rates.fetchCalls["TKN"])
+
+	require.Equal(t, "failed", records[5][4])
+}
+
+func TestExportActivity_JSON(t *testing.T) {
+	db, deps, _ := setupExportTest(t)
+	defer db.Close()
+
+	filePath := filepath.Join(t.TempDir(), "activity.json")
+	exported, err := exportActivity(context.Background(), deps, ExportParams{
+		Addresses: []eth.Address{testAccount},
+		ChainIDs:  []common.ChainID{1},
+		Period:    Period{StartTimestamp: 200},
+		Format:    ExportFormatJSON,
+		Currency:  "USD",
+		FilePath:  filePath,
+	}, func(ExportProgress) {})
+	require.NoError(t, err)
+	require.Equal(t, 3, exported)
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	var rows []ExportRow
+	require.NoError(t, json.Unmarshal(data, &rows))
+	require.Len(t, rows, 3)
+	require.Equal(t, []int64{400, 300, 200}, []int64{rows[0].Timestamp, rows[1].Timestamp, rows[2].Timestamp})
+
+	require.Equal(t, "send", rows[0].Type)
+	require.Equal(t, "0.000021", rows[0].Fee)
+	require.NotNil(t, rows[0].FeeFiat)
+	require.InDelta(t, 0.042, *rows[0].FeeFiat, 1e-9)
+
+	require.Equal(t, "receive", rows[2].Type)
+	require.Equal(t, "ETH", rows[2].Token)
+	require.Equal(t, "0.000000000000000103", rows[2].Amount)
+	require.NotNil(t, rows[2].AmountFiat)
+	require.Empty(t, rows[2].Fee)
+	require.Empty(t, rows[2].FeeToken)
+}
+
+func TestExportActivity_Canceled(t *testing.T) {
+	db, deps, _ := setupExportTest(t)
+	defer db.Close()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "activity.csv")
+	require.NoError(t, os.WriteFile(filePath, []byte("previous export"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := exportActivity(ctx, deps, ExportParams{
+		Addresses: []eth.Address{testAccount},
+		ChainIDs:  []common.ChainID{1},
+		Format:    ExportFormatCSV,
+		FilePath:  filePath,
+	}, func(ExportProgress) {})
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the previous export is kept and no temporary file is left
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, "previous export", string(data))
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestExportActivity_UnsupportedFormat(t *testing.T) {
+	db, deps, _ := setupExportTest(t)
+	defer db.Close()
+
+	_, err := exportActivity(context.Background(), deps, ExportParams{
+		Addresses: []eth.Address{testAccount},
+		ChainIDs:  []common.ChainID{1},
+		Format:    "xml",
+		FilePath:  filepath.Join(t.TempDir(), "activity.xml"),
+	}, func(ExportProgress) {})
+	require.ErrorIs(t, err, ErrUnsupportedExportFormat)
+}
+
+func TestFormatUnits(t *testing.T) {
+	require.Equal(t, "0", formatUnits(big.NewInt(0), 18))
+	require.Equal(t, "1", formatUnits(big.NewInt(1000000), 6))
+	require.Equal(t, "1.5", formatUnits(big.NewInt(1500000), 6))
+	require.Equal(t, "42", formatUnits(big.NewInt(42), 0))
+}
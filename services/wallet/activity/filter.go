@@ -1,15 +1,20 @@
 package activity
 
 import (
-
-	// used for embedding the sql query in the binary
 	"context"
 	"database/sql"
-	_ "embed"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"go.uber.org/zap"
 
 	eth "github.com/ethereum/go-ethereum/common"
+	"github.com/status-im/status-go/logutils"
 	ac "github.com/status-im/status-go/services/wallet/activity/common"
+	"github.com/status-im/status-go/services/wallet/bigint"
 	"github.com/status-im/status-go/services/wallet/common"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
 
@@ -47,6 +52,9 @@ type Filter struct {
 	Collectibles          []ac.Token `json:"collectibles"`
 	FilterOutAssets       bool       `json:"filterOutAssets"`
 	FilterOutCollectibles bool       `json:"filterOutCollectibles"`
+
+	// HideSpam hides the entries of tokens and collectibles considered spam, see the spam package
+	HideSpam bool `json:"hideSpam"`
 }
 
 func (f *Filter) IsEmpty() bool {
@@ -58,20 +66,285 @@ func (f *Filter) IsEmpty() bool {
 		len(f.Assets) == 0 &&
 		len(f.Collectibles) == 0 &&
 		!f.FilterOutAssets &&
-		!f.FilterOutCollectibles
+		!f.FilterOutCollectibles &&
+		!f.HideSpam
+}
+
+// requiresEntryMatching returns true if the filter has fields which are not handled by the queries and have to be
+// matched against the entries, see matches
+func (f *Filter) requiresEntryMatching() bool {
+	return len(f.Types) > 0 ||
+		len(f.Statuses) > 0 ||
+		len(f.CounterpartyAddresses) > 0 ||
+		len(f.Assets) > 0 ||
+		len(f.Collectibles) > 0 ||
+		f.FilterOutAssets ||
+		f.FilterOutCollectibles ||
+		f.HideSpam
+}
+
+// matches checks the entry against all the filter fields except the period, which is handled by the queries
+func (f *Filter) matches(entry *Entry) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, entry.activityType) {
+		return false
+	}
+
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, entry.activityStatus) {
+		return false
+	}
+
+	if len(f.CounterpartyAddresses) > 0 {
+		counterparty := entry.counterparty()
+		if counterparty == nil || !slices.Contains(f.CounterpartyAddresses, *counterparty) {
+			return false
+		}
+	}
+
+	tokens := make([]*ac.Token, 0, 2)
+	for _, token := range []*ac.Token{entry.tokenOut, entry.tokenIn} {
+		if token == nil {
+			continue
+		}
+		isCollectible := token.TokenID != nil
+		if (isCollectible && f.FilterOutCollectibles) || (!isCollectible && f.FilterOutAssets) {
+			return false
+		}
+		tokens = append(tokens, token)
+	}
+
+	if len(f.Assets) > 0 || len(f.Collectibles) > 0 {
+		for _, token := range tokens {
+			if slices.ContainsFunc(f.Assets, func(asset ac.Token) bool { return assetMatches(asset, token) }) ||
+				slices.ContainsFunc(f.Collectibles, func(collectible ac.Token) bool { return collectibleMatches(collectible, token) }) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// hasSpamToken returns true if a token of the entry is in `spamTokens`, the set of thirdparty.ContractID hash keys
+func hasSpamToken(entry *Entry, spamTokens map[string]bool) bool {
+	for _, token := range []*ac.Token{entry.tokenOut, entry.tokenIn} {
+		if token == nil || token.TokenType == ac.Native {
+			continue
+		}
+		id := thirdparty.ContractID{ChainID: token.ChainID, Address: token.Address}
+		if spamTokens[id.HashKey()] {
+			return true
+		}
+	}
+	return false
+}
+
+// assetMatches ignores the chain of the native token, it is included for all chains
+func assetMatches(asset ac.Token, token *ac.Token) bool {
+	if token.TokenID != nil || asset.TokenType != token.TokenType {
+		return false
+	}
+	if asset.TokenType == ac.Native {
+		return true
+	}
+	return asset.ChainID == token.ChainID && asset.Address == token.Address
+}
+
+func collectibleMatches(collectible ac.Token, token *ac.Token) bool {
+	return token.TokenID != nil && collectible.TokenID != nil &&
+		collectible.ChainID == token.ChainID &&
+		collectible.Address == token.Address &&
+		collectible.TokenID.ToInt().Cmp(token.TokenID.ToInt()) == 0
+}
+
+func periodConditions(timestampColumn string, period Period) sq.And {
+	conditions := sq.And{}
+	if period.StartTimestamp != NoLimitTimestampForPeriod {
+		conditions = append(conditions, sq.GtOrEq{timestampColumn: period.StartTimestamp})
+	}
+	if period.EndTimestamp != NoLimitTimestampForPeriod {
+		conditions = append(conditions, sq.LtOrEq{timestampColumn: period.EndTimestamp})
+	}
+	return conditions
 }
 
-// Kept for API compatibility, to be reimplemented
+// selectRouteTransactions selects the sent route transactions, the same way getActivityEntriesV2 does
+func selectRouteTransactions(columns ...string) sq.SelectBuilder {
+	return sq.Select(columns...).
+		From("sent_transactions st").
+		Join(`route_path_transactions rpt ON
+			st.chain_id = rpt.chain_id AND
+			st.tx_hash = rpt.tx_hash`).
+		Join(`tracked_transactions tt ON
+			st.chain_id = tt.chain_id AND
+			st.tx_hash = tt.tx_hash`).
+		Join(`route_paths rp ON
+			rpt.uuid = rp.uuid AND
+			rpt.path_idx = rp.path_idx`).
+		Join(`route_input_parameters rip ON
+			rpt.uuid = rip.uuid`)
+}
+
+// GetRecipients returns the addresses the given addresses sent to, the most recently used first.
+// Empty chainIDs or addresses means no filtering by chain or sender.
 func GetRecipients(ctx context.Context, db *sql.DB, chainIDs []common.ChainID, addresses []eth.Address, offset int, limit int) (recipients []eth.Address, hasMore bool, err error) {
-	return []eth.Address{}, false, nil
+	q := selectRouteTransactions("rip.to_address", "MAX(tt.timestamp) AS last_used").
+		GroupBy("rip.to_address").
+		OrderBy("last_used DESC", "rip.to_address ASC")
+
+	qConditions := sq.And{
+		sq.Eq{"rpt.is_approval": false},
+		sq.Expr("rip.to_address != rip.from_address"),
+	}
+	if len(chainIDs) > 0 {
+		qConditions = append(qConditions, sq.Eq{"rpt.chain_id": chainIDs})
+	}
+	if len(addresses) > 0 {
+		qConditions = append(qConditions, sq.Eq{"rip.from_address": addresses})
+	}
+	q = q.Where(qConditions)
+
+	if limit != ac.NoLimit {
+		// fetch one more to know if there are more entries
+		q = q.Limit(uint64(limit + 1))
+		q = q.Offset(uint64(offset))
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	recipients = make([]eth.Address, 0)
+	for rows.Next() {
+		var recipient eth.Address
+		var lastUsed int64
+		err = rows.Scan(&recipient, &lastUsed)
+		if err != nil {
+			return nil, false, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	if limit != ac.NoLimit && len(recipients) > limit {
+		recipients = recipients[:limit]
+		hasMore = true
+	}
+
+	return recipients, hasMore, nil
 }
 
-// Kept for API compatibility, to be reimplemented
+// GetOldestTimestamp returns the timestamp of the oldest transaction sent from or to the given addresses,
+// 0 if there is none. Empty addresses means all addresses.
 func GetOldestTimestamp(ctx context.Context, db *sql.DB, addresses []eth.Address) (timestamp uint64, err error) {
-	return 0, nil
+	q := selectRouteTransactions("MIN(tt.timestamp)")
+	if len(addresses) > 0 {
+		q = q.Where(sq.Or{
+			sq.Eq{"rip.from_address": addresses},
+			sq.Eq{"rip.to_address": addresses},
+		})
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var nullableTimestamp sql.NullInt64
+	err = db.QueryRowContext(ctx, query, args...).Scan(&nullableTimestamp)
+	if err != nil {
+		return 0, err
+	}
+
+	if !nullableTimestamp.Valid {
+		return 0, nil
+	}
+	return uint64(nullableTimestamp.Int64), nil
 }
 
-// Kept for API compatibility, to be reimplemented
+// GetActivityCollectibles returns the collectibles sent from or to the given owners, the most recently transferred first.
+// Empty chainIDs or owners means no filtering by chain or owner.
 func GetActivityCollectibles(ctx context.Context, db *sql.DB, chainIDs []common.ChainID, owners []eth.Address, offset int, limit int) ([]thirdparty.CollectibleUniqueID, error) {
-	return []thirdparty.CollectibleUniqueID{}, nil
+	q := selectRouteTransactions(
+		"rpt.chain_id",
+		"json_extract(rp.path_json, '$.FromToken.address') AS contract_address",
+		"json_extract(rp.path_json, '$.FromToken.symbol') AS token_id",
+		"MAX(tt.timestamp) AS last_transferred",
+	).
+		GroupBy("rpt.chain_id", "contract_address", "token_id").
+		OrderBy("last_transferred DESC", "rpt.chain_id ASC", "contract_address ASC", "token_id ASC")
+
+	qConditions := sq.And{
+		sq.Eq{"rpt.is_approval": false},
+		// the token ID is stored as the symbol of the collectible token
+		sq.Eq{"json_extract(rp.path_json, '$.ProcessorName')": []string{
+			pathProcessorCommon.ProcessorERC721Name,
+			pathProcessorCommon.ProcessorERC1155Name,
+		}},
+	}
+	if len(chainIDs) > 0 {
+		qConditions = append(qConditions, sq.Eq{"rpt.chain_id": chainIDs})
+	}
+	if len(owners) > 0 {
+		qConditions = append(qConditions, sq.Or{
+			sq.Eq{"rip.from_address": owners},
+			sq.Eq{"rip.to_address": owners},
+		})
+	}
+	q = q.Where(qConditions)
+
+	if limit != ac.NoLimit {
+		q = q.Limit(uint64(limit))
+		q = q.Offset(uint64(offset))
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collectibles := make([]thirdparty.CollectibleUniqueID, 0)
+	for rows.Next() {
+		var chainID common.ChainID
+		var contractAddress, tokenIDSymbol string
+		var lastTransferred int64
+		err = rows.Scan(&chainID, &contractAddress, &tokenIDSymbol, &lastTransferred)
+		if err != nil {
+			return nil, err
+		}
+
+		tokenID, err := common.GetTokenIdFromSymbol(tokenIDSymbol)
+		if err != nil {
+			logutils.ZapLogger().Warn("malformed collectible token symbol", zap.Error(err))
+			continue
+		}
+
+		collectibles = append(collectibles, thirdparty.CollectibleUniqueID{
+			ContractID: thirdparty.ContractID{
+				ChainID: chainID,
+				Address: eth.HexToAddress(contractAddress),
+			},
+			TokenID: &bigint.BigInt{Int: tokenID},
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collectibles, nil
 }
@@ -0,0 +1,178 @@
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	eth "github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	"github.com/status-im/status-go/services/wallet/common"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/transactions"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+type testRouteTx struct {
+	uuid         string
+	isApproval   bool
+	chainID      common.ChainID
+	hash         eth.Hash
+	from         eth.Address
+	to           eth.Address
+	processor    string
+	tokenAddress eth.Address
+	tokenSymbol  string
+	timestamp    int64
+}
+
+func insertTestRouteTx(t *testing.T, db *sql.DB, tx testRouteTx) {
+	_, err := db.Exec(`INSERT OR IGNORE INTO route_input_parameters (route_input_params_json) VALUES (?)`,
+		fmt.Sprintf(`{"uuid":"%s","addrFrom":"%s","addrTo":"%s"}`, tx.uuid, tx.from.Hex(), tx.to.Hex()))
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT OR IGNORE INTO route_paths (uuid, path_idx, path_json) VALUES (?, 0, ?)`, tx.uuid,
+		fmt.Sprintf(`{"ProcessorName":"%s","FromChain":{"chainId":%d},"FromToken":{"address":"%s","symbol":"%s"}}`,
+			tx.processor, tx.chainID, tx.tokenAddress.Hex(), tx.tokenSymbol))
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO route_path_transactions (uuid, path_idx, is_approval, chain_id, tx_hash, tx_args_json, hash_to_sign, sig)
+		VALUES (?, 0, ?, ?, ?, '{}', ?, ?)`, tx.uuid, tx.isApproval, tx.chainID, tx.hash, []byte{}, []byte{})
+	require.NoError(t, err)
+
+	txJSON, err := json.Marshal(ethTypes.NewTx(&ethTypes.DynamicFeeTx{
+		ChainID: new(big.Int).SetUint64(uint64(tx.chainID)),
+		To:      &tx.to,
+		Value:   big.NewInt(0),
+	}))
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO sent_transactions (chain_id, tx_hash, tx_json) VALUES (?, ?, ?)`, tx.chainID, tx.hash, string(txJSON))
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO tracked_transactions (chain_id, tx_hash, tx_status, timestamp) VALUES (?, ?, ?, ?)`,
+		tx.chainID, tx.hash, transactions.Success, tx.timestamp)
+	require.NoError(t, err)
+}
+
+func setupFilterTestDB(t *testing.T) (*sql.DB, []testRouteTx) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+
+	sender, otherSender := eth.HexToAddress("0x1"), eth.HexToAddress("0x2")
+	recipientA, recipientB, recipientC := eth.HexToAddress("0xA"), eth.HexToAddress("0xB"), eth.HexToAddress("0xC")
+	collectibleAddress := eth.HexToAddress("0xC011EC7AB1E")
+
+	txs := []testRouteTx{
+		{uuid: "1", chainID: 1, from: sender, to: recipientA, processor: pathProcessorCommon.ProcessorTransferName, tokenSymbol: "ETH", timestamp: 100},
+		{uuid: "2", chainID: 10, from: sender, to: recipientB, processor: pathProcessorCommon.ProcessorTransferName, tokenSymbol: "ETH", timestamp: 200},
+		{uuid: "3", chainID: 1, from: sender, to: recipientA, processor: pathProcessorCommon.ProcessorTransferName, tokenSymbol: "ETH", timestamp: 300},
+		{uuid: "4", chainID: 1, from: otherSender, to: recipientC, processor: pathProcessorCommon.ProcessorERC721Name, tokenAddress: collectibleAddress, tokenSymbol: "7", timestamp: 50},
+		{uuid: "5", chainID: 1, from: sender, to: sender, processor: pathProcessorCommon.ProcessorSwapParaswapName, tokenSymbol: "ETH", timestamp: 400},
+		{uuid: "6", chainID: 10, from: sender, to: recipientC, processor: pathProcessorCommon.ProcessorERC1155Name, tokenAddress: collectibleAddress, tokenSymbol: "0x10", timestamp: 250},
+		{uuid: "6", chainID: 10, from: sender, to: recipientC, processor: pathProcessorCommon.ProcessorERC1155Name, tokenAddress: collectibleAddress, tokenSymbol: "0x10", timestamp: 240, isApproval: true},
+	}
+	for i := range txs {
+		txs[i].hash = eth.BigToHash(big.NewInt(int64(i + 1)))
+		insertTestRouteTx(t, db, txs[i])
+	}
+
+	return db, txs
+}
+
+func TestGetRecipients(t *testing.T) {
+	db, txs := setupFilterTestDB(t)
+	defer db.Close()
+
+	sender := txs[0].from
+	recipientA, recipientB, recipientC := txs[0].to, txs[1].to, txs[3].to
+
+	// the most recent recipient first, approvals and self transfers are skipped
+	recipients, hasMore, err := GetRecipients(context.Background(), db, nil, nil, 0, 10)
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Equal(t, []eth.Address{recipientA, recipientC, recipientB}, recipients)
+
+	recipients, hasMore, err = GetRecipients(context.Background(), db, nil, []eth.Address{sender}, 0, 2)
+	require.NoError(t, err)
+	require.True(t, hasMore)
+	require.Equal(t, []eth.Address{recipientA, recipientC}, recipients)
+
+	recipients, hasMore, err = GetRecipients(context.Background(), db, nil, []eth.Address{sender}, 2, 2)
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Equal(t, []eth.Address{recipientB}, recipients)
+
+	recipients, _, err = GetRecipients(context.Background(), db, []common.ChainID{1}, []eth.Address{sender}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []eth.Address{recipientA}, recipients)
+
+	recipients, _, err = GetRecipients(context.Background(), db, []common.ChainID{5}, nil, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, recipients)
+}
+
+func TestGetOldestTimestamp(t *testing.T) {
+	db, txs := setupFilterTestDB(t)
+	defer db.Close()
+
+	timestamp, err := GetOldestTimestamp(context.Background(), db, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(50), timestamp)
+
+	timestamp, err = GetOldestTimestamp(context.Background(), db, []eth.Address{txs[0].from})
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), timestamp)
+
+	// received transactions are taken into account
+	timestamp, err = GetOldestTimestamp(context.Background(), db, []eth.Address{txs[1].to})
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), timestamp)
+
+	timestamp, err = GetOldestTimestamp(context.Background(), db, []eth.Address{eth.HexToAddress("0x1234")})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), timestamp)
+}
+
+func TestGetActivityCollectibles(t *testing.T) {
+	db, txs := setupFilterTestDB(t)
+	defer db.Close()
+
+	erc1155 := thirdparty.CollectibleUniqueID{
+		ContractID: thirdparty.ContractID{ChainID: 10, Address: txs[5].tokenAddress},
+		TokenID:    &bigint.BigInt{Int: big.NewInt(16)},
+	}
+	erc721 := thirdparty.CollectibleUniqueID{
+		ContractID: thirdparty.ContractID{ChainID: 1, Address: txs[3].tokenAddress},
+		TokenID:    &bigint.BigInt{Int: big.NewInt(7)},
+	}
+
+	collectibles, err := GetActivityCollectibles(context.Background(), db, nil, nil, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []thirdparty.CollectibleUniqueID{erc1155, erc721}, collectibles)
+
+	collectibles, err = GetActivityCollectibles(context.Background(), db, nil, nil, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []thirdparty.CollectibleUniqueID{erc721}, collectibles)
+
+	collectibles, err = GetActivityCollectibles(context.Background(), db, []common.ChainID{1}, nil, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []thirdparty.CollectibleUniqueID{erc721}, collectibles)
+
+	// both the sender and the recipient are owners in the activity
+	collectibles, err = GetActivityCollectibles(context.Background(), db, nil, []eth.Address{txs[3].to}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []thirdparty.CollectibleUniqueID{erc1155, erc721}, collectibles)
+
+	collectibles, err = GetActivityCollectibles(context.Background(), db, nil, []eth.Address{txs[0].from}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []thirdparty.CollectibleUniqueID{erc1155}, collectibles)
+}
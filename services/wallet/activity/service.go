@@ -33,6 +33,10 @@ const (
 	EventActivityGetRecipientsDone      walletevent.EventType = "wallet-activity-get-recipients-result"
 	EventActivityGetOldestTimestampDone walletevent.EventType = "wallet-activity-get-oldest-timestamp-result"
 	EventActivityGetCollectibles        walletevent.EventType = "wallet-activity-get-collectibles"
+	// EventActivityExportProgress contains an ExportProgress payload
+	EventActivityExportProgress walletevent.EventType = "wallet-activity-export-progress"
+	// EventActivityExportDone contains an ExportResponse payload
+	EventActivityExportDone walletevent.EventType = "wallet-activity-export-done"
 
 	// EventActivitySessionUpdated contains a SessionUpdate payload
 	EventActivitySessionUpdated walletevent.EventType = "wallet-activity-session-updated"
@@ -55,6 +59,10 @@ var (
 		ID:     4,
 		Policy: async.ReplacementPolicyCancelOld,
 	}
+	exportTask = async.TaskType{
+		ID:     5,
+		Policy: async.ReplacementPolicyCancelOld,
+	}
 )
 
 // Service provides an async interface, ensuring only one filter request, of each type, is running at a time. It also provides lazy load of NFT info and token mapping
@@ -64,6 +72,8 @@ type Service struct {
 	tokenManager token.ManagerInterface
 	collectibles collectibles.ManagerInterface
 	eventFeed    *event.Feed
+	// exchangeRates provides the historical fiat values for exports
+	exchangeRates ExchangeRates
 
 	scheduler *async.MultiClientScheduler
 
@@ -81,14 +91,15 @@ func (s *Service) nextSessionID() SessionID {
 	return SessionID(s.lastSessionID.Add(1))
 }
 
-func NewService(db *sql.DB, accountsDB *accounts.Database, tokenManager token.ManagerInterface, collectibles collectibles.ManagerInterface, eventFeed *event.Feed) *Service {
+func NewService(db *sql.DB, accountsDB *accounts.Database, tokenManager token.ManagerInterface, collectibles collectibles.ManagerInterface, eventFeed *event.Feed, exchangeRates ExchangeRates) *Service {
 	return &Service{
-		db:           db,
-		accountsDB:   accountsDB,
-		tokenManager: tokenManager,
-		collectibles: collectibles,
-		eventFeed:    eventFeed,
-		scheduler:    async.NewMultiClientScheduler(),
+		db:            db,
+		accountsDB:    accountsDB,
+		tokenManager:  tokenManager,
+		collectibles:  collectibles,
+		eventFeed:     eventFeed,
+		exchangeRates: exchangeRates,
+		scheduler:     async.NewMultiClientScheduler(),
 
 		sessions: make(map[SessionID]*Session),
 		// here to be overwritten by tests
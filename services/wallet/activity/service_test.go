@@ -88,7 +88,7 @@ func setupTestService(tb testing.TB) (state testState) {
 	pendingCheckInterval := time.Second
 	state.pendingTracker = transactions.NewPendingTxTracker(db, state.rpcClient, state.eventFeed, pendingCheckInterval)
 
-	state.service = NewService(db, accountsDB, state.tokenMock, state.collectiblesMock, state.eventFeed)
+	state.service = NewService(db, accountsDB, state.tokenMock, state.collectiblesMock, state.eventFeed, nil)
 	state.service.debounceDuration = 0
 	state.close = func() {
 		require.NoError(tb, state.pendingTracker.Stop())
@@ -14,6 +14,7 @@ import (
 	ac "github.com/status-im/status-go/services/wallet/activity/common"
 	"github.com/status-im/status-go/services/wallet/bigint"
 	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/poisoning"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
 
@@ -40,6 +41,7 @@ type Entry struct {
 	communityID               *string
 	interactedContractAddress *eth.Address
 	approvalSpender           *eth.Address
+	poisoningWarnings         []*poisoning.Warning // Set for zero value transfers received from lookalike addresses
 
 	isNew bool // isNew is used to indicate if the entry is newer than session start (changed state also)
 }
@@ -78,6 +80,7 @@ func (e *Entry) MarshalJSON() ([]byte, error) {
 		CommunityID:               e.communityID,
 		InteractedContractAddress: e.interactedContractAddress,
 		ApprovalSpender:           e.approvalSpender,
+		PoisoningWarnings:         e.poisoningWarnings,
 	}
 
 	if e.payloadType == ac.MultiTransactionPT {
@@ -129,6 +132,7 @@ func (e *Entry) UnmarshalJSON(data []byte) error {
 	e.communityID = aux.CommunityID
 	e.interactedContractAddress = aux.InteractedContractAddress
 	e.approvalSpender = aux.ApprovalSpender
+	e.poisoningWarnings = aux.PoisoningWarnings
 
 	e.isNew = aux.IsNew != nil && *aux.IsNew
 
@@ -139,6 +143,14 @@ func (e *Entry) PayloadType() ac.PayloadType {
 	return e.payloadType
 }
 
+// counterparty is the sender for received entries and the recipient for all the others
+func (e *Entry) counterparty() *eth.Address {
+	if e.activityType == ac.ReceiveAT {
+		return e.sender
+	}
+	return e.recipient
+}
+
 func (e *Entry) isNFT() bool {
 	tt := e.transferType
 	return tt != nil && (*tt == ac.TransferTypeErc721 || *tt == ac.TransferTypeErc1155) && ((e.tokenIn != nil && e.tokenIn.TokenID != nil) || (e.tokenOut != nil && e.tokenOut.TokenID != nil))
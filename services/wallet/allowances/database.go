@@ -0,0 +1,109 @@
+package allowances
+
+import (
+	"database/sql"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Database struct {
+	db *sql.DB
+}
+
+func NewDB(db *sql.DB) *Database {
+	return &Database{db: db}
+}
+
+func (db *Database) SaveAllowance(allowance *Allowance) error {
+	var amount sql.NullString
+	if allowance.Amount != nil {
+		amount = sql.NullString{String: allowance.Amount.ToInt().String(), Valid: true}
+	}
+
+	_, err := db.db.Exec(`INSERT OR REPLACE INTO token_allowances (chain_id, owner, token, spender, type, amount, block_number, tx_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, allowance.ChainID, allowance.Owner, allowance.Token, allowance.Spender, allowance.Type,
+		amount, allowance.BlockNumber, allowance.TxHash)
+	return err
+}
+
+func (db *Database) DeleteAllowance(allowance *Allowance) error {
+	_, err := db.db.Exec(`DELETE FROM token_allowances WHERE chain_id = ? AND owner = ? AND token = ? AND spender = ? AND type = ?`,
+		allowance.ChainID, allowance.Owner, allowance.Token, allowance.Spender, allowance.Type)
+	return err
+}
+
+// GetAllowances returns the allowances given by the owner on the chain
+func (db *Database) GetAllowances(chainID uint64, owner common.Address) ([]*Allowance, error) {
+	rows, err := db.db.Query(`SELECT token, spender, type, amount, block_number, tx_hash FROM token_allowances
+		WHERE chain_id = ? AND owner = ? ORDER BY block_number DESC`, chainID, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allowances := make([]*Allowance, 0)
+	for rows.Next() {
+		allowance := &Allowance{ChainID: chainID, Owner: owner}
+		var amount sql.NullString
+		err = rows.Scan(&allowance.Token, &allowance.Spender, &allowance.Type, &amount, &allowance.BlockNumber, &allowance.TxHash)
+		if err != nil {
+			return nil, err
+		}
+
+		if amount.Valid {
+			value, ok := new(big.Int).SetString(amount.String, 10)
+			if ok {
+				allowance.setAmount(value)
+			}
+		} else {
+			allowance.Unlimited = true
+		}
+		allowances = append(allowances, allowance)
+	}
+	return allowances, rows.Err()
+}
+
+// GetScannedBlock returns the last block the approval logs of the owner were scanned to, false if never scanned
+func (db *Database) GetScannedBlock(chainID uint64, owner common.Address) (uint64, bool, error) {
+	var blockNumber uint64
+	err := db.db.QueryRow(`SELECT block_number FROM token_allowances_scanned_blocks WHERE chain_id = ? AND owner = ?`,
+		chainID, owner).Scan(&blockNumber)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return blockNumber, true, nil
+}
+
+// GetHistoryStart returns the first block of the ETH or tokens history of the owner found by the transfers downloader,
+// false if it's not found yet
+func (db *Database) GetHistoryStart(chainID uint64, owner common.Address) (uint64, bool, error) {
+	var ethStart, tokensStart sql.NullInt64
+	err := db.db.QueryRow(`SELECT blk_start, token_blk_start FROM blocks_ranges_sequential WHERE network_id = ? AND address = ?`,
+		chainID, owner).Scan(&ethStart, &tokensStart)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	var start uint64
+	found := false
+	for _, blockNumber := range []sql.NullInt64{ethStart, tokensStart} {
+		if blockNumber.Valid && blockNumber.Int64 > 0 && (!found || uint64(blockNumber.Int64) < start) {
+			start = uint64(blockNumber.Int64)
+			found = true
+		}
+	}
+	return start, found, nil
+}
+
+func (db *Database) SaveScannedBlock(chainID uint64, owner common.Address, blockNumber uint64) error {
+	_, err := db.db.Exec(`INSERT OR REPLACE INTO token_allowances_scanned_blocks (chain_id, owner, block_number) VALUES (?, ?, ?)`,
+		chainID, owner, blockNumber)
+	return err
+}
@@ -0,0 +1,391 @@
+package allowances
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/status-im/status-go/contracts/erc721"
+	"github.com/status-im/status-go/contracts/ierc20"
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/rpc/chain"
+	"github.com/status-im/status-go/services/wallet/async"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	// maxLogsBlockRange is the widest range of blocks the approval logs are requested for at once
+	maxLogsBlockRange = uint64(500000)
+	// minLogsBlockRange is the range below which a failing logs request is not split anymore
+	minLogsBlockRange = uint64(1000)
+)
+
+var (
+	approvalEventSignatureHash       = walletCommon.GetEventSignatureHash(walletCommon.Erc20_721ApprovalEventSignature)
+	approvalForAllEventSignatureHash = walletCommon.GetEventSignatureHash(walletCommon.ApprovalForAllEventSignature)
+)
+
+const (
+	// EventAllowancesRefreshProgress contains a RefreshProgress payload, it's sent after each scanned range of blocks
+	EventAllowancesRefreshProgress walletevent.EventType = "wallet-allowances-refresh-progress"
+	// EventAllowancesRefreshDone contains a RefreshResponse payload
+	EventAllowancesRefreshDone walletevent.EventType = "wallet-allowances-refresh-done"
+)
+
+type ErrorCode = int
+
+const (
+	ErrorCodeSuccess      ErrorCode = 1
+	ErrorCodeTaskCanceled ErrorCode = 2
+	ErrorCodeFailed       ErrorCode = 3
+)
+
+var refreshTask = async.TaskType{
+	ID:     1,
+	Policy: async.ReplacementPolicyCancelOld,
+}
+
+// RefreshProgress is the state of the scan of the approval logs of an owner
+type RefreshProgress struct {
+	ChainID      uint64         `json:"chainId"`
+	Owner        common.Address `json:"owner"`
+	FromBlock    uint64         `json:"fromBlock"`
+	ScannedBlock uint64         `json:"scannedBlock"`
+	ToBlock      uint64         `json:"toBlock"`
+}
+
+type RefreshResponse struct {
+	Allowances []*Allowance `json:"allowances"`
+	ErrorCode  ErrorCode    `json:"errorCode"`
+}
+
+type Manager struct {
+	db        *Database
+	rpcClient rpc.ClientInterface
+	feed      *event.Feed
+	scheduler *async.Scheduler
+}
+
+func NewManager(walletDB *sql.DB, rpcClient rpc.ClientInterface, feed *event.Feed) *Manager {
+	return &Manager{
+		db:        NewDB(walletDB),
+		rpcClient: rpcClient,
+		feed:      feed,
+		scheduler: async.NewScheduler(),
+	}
+}
+
+func (m *Manager) Stop() {
+	m.scheduler.Stop()
+}
+
+// GetAllowances returns the allowances known for the owners on the chains, without scanning for new ones
+func (m *Manager) GetAllowances(chainIDs []uint64, owners []common.Address) ([]*Allowance, error) {
+	allowances := make([]*Allowance, 0)
+	for _, chainID := range chainIDs {
+		for _, owner := range owners {
+			ownerAllowances, err := m.db.GetAllowances(chainID, owner)
+			if err != nil {
+				return nil, err
+			}
+			allowances = append(allowances, ownerAllowances...)
+		}
+	}
+	return allowances, nil
+}
+
+// RefreshAsync scans the approval logs in the background, EventAllowancesRefreshProgress events are sent while
+// scanning and EventAllowancesRefreshDone with all the allowances of the owners when finished. A new refresh cancels
+// the running one, the scanned ranges are kept.
+func (m *Manager) RefreshAsync(chainIDs []uint64, owners []common.Address) {
+	m.scheduler.Enqueue(refreshTask, func(ctx context.Context) (interface{}, error) {
+		return m.Refresh(ctx, chainIDs, owners, m.sendProgress)
+	}, func(result interface{}, taskType async.TaskType, err error) {
+		res := RefreshResponse{
+			ErrorCode: ErrorCodeFailed,
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, async.ErrTaskOverwritten) {
+			res.ErrorCode = ErrorCodeTaskCanceled
+		} else if err == nil {
+			res.ErrorCode = ErrorCodeSuccess
+			res.Allowances = result.([]*Allowance)
+		}
+		m.sendEvent(EventAllowancesRefreshDone, 0, owners, res)
+	})
+}
+
+func (m *Manager) sendProgress(progress RefreshProgress) {
+	m.sendEvent(EventAllowancesRefreshProgress, progress.ChainID, []common.Address{progress.Owner}, progress)
+}
+
+func (m *Manager) sendEvent(eventType walletevent.EventType, chainID uint64, owners []common.Address, payloadObj interface{}) {
+	if m.feed == nil {
+		return
+	}
+	payload, err := json.Marshal(payloadObj)
+	if err != nil {
+		logutils.ZapLogger().Error("Error marshalling allowances event", zap.Error(err))
+		return
+	}
+	m.feed.Send(walletevent.Event{
+		Type:     eventType,
+		ChainID:  chainID,
+		Accounts: owners,
+		Message:  string(payload),
+	})
+}
+
+// Refresh scans the approval logs of the owners since the last scan and checks all the known allowances on chain,
+// the allowances not valid anymore are removed. `onProgress` is called after each scanned range, it may be nil.
+func (m *Manager) Refresh(ctx context.Context, chainIDs []uint64, owners []common.Address, onProgress func(RefreshProgress)) ([]*Allowance, error) {
+	for _, chainID := range chainIDs {
+		client, err := m.rpcClient.EthClient(chainID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, owner := range owners {
+			if err := m.scan(ctx, client, chainID, owner, onProgress); err != nil {
+				return nil, err
+			}
+			if err := m.verify(ctx, client, chainID, owner); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return m.GetAllowances(chainIDs, owners)
+}
+
+// firstActivityBlock returns the block the history of the owner starts at, no approval can be older. The start found
+// by the transfers downloader is used if known, otherwise the block of the first transaction of the owner. False is
+// returned for an owner which never sent a transaction.
+func (m *Manager) firstActivityBlock(ctx context.Context, client chain.ClientInterface, chainID uint64, owner common.Address, head uint64) (uint64, bool, error) {
+	start, found, err := m.db.GetHistoryStart(chainID, owner)
+	if err != nil || found {
+		return start, found, err
+	}
+
+	nonce, err := client.NonceAt(ctx, owner, new(big.Int).SetUint64(head))
+	if err != nil {
+		return 0, false, err
+	}
+	if nonce == 0 {
+		return 0, false, nil
+	}
+
+	low, high := uint64(0), head
+	for low < high {
+		middle := low + (high-low)/2
+		nonce, err := client.NonceAt(ctx, owner, new(big.Int).SetUint64(middle))
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, false, ctx.Err()
+			}
+			// the provider doesn't keep the historical state, the whole history is scanned
+			logutils.ZapLogger().Warn("cannot find the first transaction block",
+				zap.Uint64("chainID", chainID), zap.Stringer("owner", owner), zap.Error(err))
+			return 0, true, nil
+		}
+		if nonce > 0 {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+	return low, true, nil
+}
+
+func (m *Manager) scan(ctx context.Context, client chain.ClientInterface, chainID uint64, owner common.Address, onProgress func(RefreshProgress)) error {
+	scannedBlock, scanned, err := m.db.GetScannedBlock(chainID, owner)
+	if err != nil {
+		return err
+	}
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	start := scannedBlock + 1
+	if !scanned {
+		var active bool
+		start, active, err = m.firstActivityBlock(ctx, client, chainID, owner, head)
+		if err != nil {
+			return err
+		}
+		if !active {
+			// nothing is stored, the owner's history is looked up again by the next refresh
+			return nil
+		}
+	}
+
+	for from := start; from <= head; from += maxLogsBlockRange {
+		to := from + maxLogsBlockRange - 1
+		if to > head {
+			to = head
+		}
+
+		logs, err := m.fetchLogs(ctx, client, owner, from, to)
+		if err != nil {
+			return err
+		}
+		for i := range logs {
+			if err := m.applyLog(chainID, &logs[i]); err != nil {
+				return err
+			}
+		}
+
+		if err := m.db.SaveScannedBlock(chainID, owner, to); err != nil {
+			return err
+		}
+		if onProgress != nil {
+			onProgress(RefreshProgress{ChainID: chainID, Owner: owner, FromBlock: start, ScannedBlock: to, ToBlock: head})
+		}
+	}
+	return nil
+}
+
+// fetchLogs returns the approval logs of the owner in the range ordered as on chain, the range is split in halves
+// when the provider refuses it because of its size or of the number of logs
+func (m *Manager) fetchLogs(ctx context.Context, client chain.ClientInterface, owner common.Address, from uint64, to uint64) ([]types.Log, error) {
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Topics: [][]common.Hash{
+			{approvalEventSignatureHash, approvalForAllEventSignatureHash},
+			{common.BytesToHash(owner.Bytes())},
+		},
+	})
+	if err == nil {
+		return logs, nil
+	}
+	if ctx.Err() != nil || to-from < minLogsBlockRange {
+		return nil, err
+	}
+
+	middle := from + (to-from)/2
+	logs, err = m.fetchLogs(ctx, client, owner, from, middle)
+	if err != nil {
+		return nil, err
+	}
+	upperLogs, err := m.fetchLogs(ctx, client, owner, middle+1, to)
+	if err != nil {
+		return nil, err
+	}
+	return append(logs, upperLogs...), nil
+}
+
+// applyLog stores the allowance set by the event, allowances set to zero are removed. ERC721 approvals of a single
+// token are ignored, they are reset when the token is transferred.
+func (m *Manager) applyLog(chainID uint64, log *types.Log) error {
+	if log.Removed {
+		return nil
+	}
+
+	allowance := &Allowance{
+		ChainID:     chainID,
+		Token:       log.Address,
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+	}
+
+	switch walletCommon.GetEventType(log) {
+	case walletCommon.Erc20ApprovalEventType:
+		owner, spender, amount, err := walletCommon.ParseErc20ApprovalLog(log)
+		if err != nil {
+			logutils.ZapLogger().Warn("invalid approval log", zap.Stringer("txHash", log.TxHash), zap.Error(err))
+			return nil
+		}
+		allowance.Owner, allowance.Spender, allowance.Type = owner, spender, TypeErc20
+		if amount.Sign() == 0 {
+			return m.db.DeleteAllowance(allowance)
+		}
+		allowance.setAmount(amount)
+	case walletCommon.ApprovalForAllEventType:
+		owner, operator, approved, err := walletCommon.ParseApprovalForAllLog(log)
+		if err != nil {
+			logutils.ZapLogger().Warn("invalid approval for all log", zap.Stringer("txHash", log.TxHash), zap.Error(err))
+			return nil
+		}
+		allowance.Owner, allowance.Spender, allowance.Type = owner, operator, TypeApprovalForAll
+		if !approved {
+			return m.db.DeleteAllowance(allowance)
+		}
+	default:
+		return nil
+	}
+
+	return m.db.SaveAllowance(allowance)
+}
+
+// verify reads the current value of the known allowances, they change without an approval event when the spender
+// uses them and some tokens don't emit the events at all
+func (m *Manager) verify(ctx context.Context, client chain.ClientInterface, chainID uint64, owner common.Address) error {
+	allowances, err := m.db.GetAllowances(chainID, owner)
+	if err != nil {
+		return err
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+	for _, allowance := range allowances {
+		valid, err := m.verifyAllowance(callOpts, client, allowance)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// the allowance is kept, the contract may not implement the getter
+			logutils.ZapLogger().Warn("cannot verify allowance",
+				zap.Uint64("chainID", chainID),
+				zap.Stringer("token", allowance.Token),
+				zap.Stringer("spender", allowance.Spender),
+				zap.Error(err))
+			continue
+		}
+
+		if !valid {
+			err = m.db.DeleteAllowance(allowance)
+		} else {
+			err = m.db.SaveAllowance(allowance)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) verifyAllowance(callOpts *bind.CallOpts, client chain.ClientInterface, allowance *Allowance) (bool, error) {
+	switch allowance.Type {
+	case TypeErc20:
+		caller, err := ierc20.NewIERC20Caller(allowance.Token, client)
+		if err != nil {
+			return false, err
+		}
+		amount, err := caller.Allowance(callOpts, allowance.Owner, allowance.Spender)
+		if err != nil {
+			return false, err
+		}
+		allowance.setAmount(amount)
+		return amount.Sign() > 0, nil
+	case TypeApprovalForAll:
+		// ERC1155 `isApprovedForAll` has the same signature as the ERC721 one
+		caller, err := erc721.NewErc721Caller(allowance.Token, client)
+		if err != nil {
+			return false, err
+		}
+		return caller.IsApprovedForAll(callOpts, allowance.Owner, allowance.Spender)
+	}
+	return false, nil
+}
@@ -0,0 +1,234 @@
+package allowances
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+
+	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	mock_rpcclient "github.com/status-im/status-go/rpc/mock/client"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+const testChainID = uint64(1)
+
+var (
+	owner       = common.Address{0x01}
+	spender     = common.Address{0x02}
+	usdc        = common.Address{0x10}
+	dai         = common.Address{0x11}
+	collection  = common.Address{0x20}
+	collection2 = common.Address{0x21}
+)
+
+func approvalLog(token common.Address, spender common.Address, amount *big.Int, blockNumber uint64) types.Log {
+	return types.Log{
+		Address:     token,
+		Topics:      []common.Hash{approvalEventSignatureHash, common.BytesToHash(owner.Bytes()), common.BytesToHash(spender.Bytes())},
+		Data:        common.BigToHash(amount).Bytes(),
+		BlockNumber: blockNumber,
+	}
+}
+
+func approvalForAllLog(token common.Address, operator common.Address, approved bool, blockNumber uint64) types.Log {
+	data := common.Hash{}
+	if approved {
+		data[31] = 1
+	}
+	return types.Log{
+		Address:     token,
+		Topics:      []common.Hash{approvalForAllEventSignatureHash, common.BytesToHash(owner.Bytes()), common.BytesToHash(operator.Bytes())},
+		Data:        data.Bytes(),
+		BlockNumber: blockNumber,
+	}
+}
+
+func setupTestManager(t *testing.T) (*Manager, *mock_client.MockClientInterface) {
+	walletDB, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, walletDB.Close()) })
+
+	ctrl := gomock.NewController(t)
+	rpcClient := mock_rpcclient.NewMockClientInterface(ctrl)
+	chainClient := mock_client.NewMockClientInterface(ctrl)
+	rpcClient.EXPECT().EthClient(testChainID).Return(chainClient, nil).AnyTimes()
+
+	return NewManager(walletDB, rpcClient, nil), chainClient
+}
+
+func TestRefreshAllowances(t *testing.T) {
+	manager, chainClient := setupTestManager(t)
+	ctx := context.Background()
+
+	logs := []types.Log{
+		approvalLog(usdc, spender, walletCommon.MaxUint256, 10),
+		approvalLog(dai, spender, big.NewInt(1000), 20),
+		approvalLog(dai, spender, big.NewInt(0), 30),
+		approvalForAllLog(collection, spender, true, 600000),
+		approvalForAllLog(collection2, spender, true, 700000),
+		approvalForAllLog(collection2, spender, false, 1100000),
+	}
+
+	head := uint64(1200000)
+	chainClient.EXPECT().BlockNumber(ctx).Return(head, nil).Times(2)
+	// the first transaction of the owner is found once, the next scan starts from the last scanned block
+	firstTxBlock := uint64(9)
+	chainClient.EXPECT().NonceAt(ctx, owner, gomock.Any()).DoAndReturn(func(_ context.Context, _ common.Address, blockNumber *big.Int) (uint64, error) {
+		if blockNumber.Uint64() >= firstTxBlock {
+			return 3, nil
+		}
+		return 0, nil
+	}).MinTimes(1).MaxTimes(25)
+	var scanFrom []uint64
+	chainClient.EXPECT().FilterLogs(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+		from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+		scanFrom = append(scanFrom, from)
+		// the provider refuses the widest ranges, they are split in halves
+		if to-from >= 300000 {
+			return nil, errors.New("query returned more than 10000 results")
+		}
+		res := make([]types.Log, 0)
+		for _, log := range logs {
+			if log.BlockNumber >= from && log.BlockNumber <= to {
+				res = append(res, log)
+			}
+		}
+		return res, nil
+	}).AnyTimes()
+
+	usdcAllowance := walletCommon.MaxUint256
+	chainClient.EXPECT().CallContract(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+		switch *msg.To {
+		case usdc:
+			return common.BigToHash(usdcAllowance).Bytes(), nil
+		case collection:
+			return common.BigToHash(big.NewInt(1)).Bytes(), nil
+		}
+		return nil, errors.New("unexpected call")
+	}).AnyTimes()
+
+	var progress []RefreshProgress
+	allowances, err := manager.Refresh(ctx, []uint64{testChainID}, []common.Address{owner}, func(p RefreshProgress) {
+		progress = append(progress, p)
+	})
+	require.NoError(t, err)
+	require.Len(t, allowances, 2)
+	require.Equal(t, firstTxBlock, scanFrom[0])
+	require.Len(t, progress, 3)
+	require.Equal(t, RefreshProgress{ChainID: testChainID, Owner: owner, FromBlock: firstTxBlock, ScannedBlock: firstTxBlock + maxLogsBlockRange - 1, ToBlock: head}, progress[0])
+	require.Equal(t, head, progress[2].ScannedBlock)
+
+	require.Equal(t, collection, allowances[0].Token)
+	require.Equal(t, TypeApprovalForAll, allowances[0].Type)
+	require.True(t, allowances[0].Unlimited)
+	require.Nil(t, allowances[0].Amount)
+
+	require.Equal(t, usdc, allowances[1].Token)
+	require.Equal(t, spender, allowances[1].Spender)
+	require.Equal(t, TypeErc20, allowances[1].Type)
+	require.True(t, allowances[1].Unlimited)
+	require.Equal(t, walletCommon.MaxUint256, allowances[1].Amount.ToInt())
+
+	scannedBlock, scanned, err := manager.db.GetScannedBlock(testChainID, owner)
+	require.NoError(t, err)
+	require.True(t, scanned)
+	require.Equal(t, head, scannedBlock)
+
+	// the spender used part of the allowance, then all of it without any approval event
+	usdcAllowance = big.NewInt(500)
+	allowances, err = manager.Refresh(ctx, []uint64{testChainID}, []common.Address{owner}, nil)
+	require.NoError(t, err)
+	require.Len(t, allowances, 2)
+	require.Equal(t, big.NewInt(500), allowances[1].Amount.ToInt())
+	require.False(t, allowances[1].Unlimited)
+
+	usdcAllowance = big.NewInt(0)
+	require.NoError(t, manager.verify(ctx, chainClient, testChainID, owner))
+	allowances, err = manager.GetAllowances([]uint64{testChainID}, []common.Address{owner})
+	require.NoError(t, err)
+	require.Len(t, allowances, 1)
+	require.Equal(t, collection, allowances[0].Token)
+}
+
+func TestRefreshAllowancesFromHistoryStart(t *testing.T) {
+	manager, chainClient := setupTestManager(t)
+	ctx := context.Background()
+
+	// the start of the history found by the transfers downloader is used without looking for the first transaction
+	_, err := manager.db.db.Exec(`INSERT INTO blocks_ranges_sequential (network_id, address, blk_start, token_blk_start) VALUES (?, ?, ?, ?)`,
+		testChainID, owner, 1000, 800)
+	require.NoError(t, err)
+
+	head := uint64(5000)
+	chainClient.EXPECT().BlockNumber(ctx).Return(head, nil).Times(1)
+	chainClient.EXPECT().FilterLogs(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+		require.Equal(t, uint64(800), q.FromBlock.Uint64())
+		require.Equal(t, head, q.ToBlock.Uint64())
+		return nil, nil
+	}).Times(1)
+
+	allowances, err := manager.Refresh(ctx, []uint64{testChainID}, []common.Address{owner}, nil)
+	require.NoError(t, err)
+	require.Empty(t, allowances)
+}
+
+func TestRefreshAllowancesWithoutTransactions(t *testing.T) {
+	manager, chainClient := setupTestManager(t)
+	ctx := context.Background()
+
+	// an owner which never sent a transaction has no approval to scan for, the next refresh looks up its history again
+	chainClient.EXPECT().BlockNumber(ctx).Return(uint64(5000), nil).Times(2)
+	chainClient.EXPECT().NonceAt(ctx, owner, big.NewInt(5000)).Return(uint64(0), nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, err := manager.Refresh(ctx, []uint64{testChainID}, []common.Address{owner}, nil)
+		require.NoError(t, err)
+		_, scanned, err := manager.db.GetScannedBlock(testChainID, owner)
+		require.NoError(t, err)
+		require.False(t, scanned)
+	}
+}
+
+func TestRefreshAllowancesAsync(t *testing.T) {
+	manager, chainClient := setupTestManager(t)
+	manager.feed = &event.Feed{}
+	t.Cleanup(manager.Stop)
+	ch := make(chan walletevent.Event, 4)
+	sub := manager.feed.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	_, err := manager.db.db.Exec(`INSERT INTO blocks_ranges_sequential (network_id, address, blk_start) VALUES (?, ?, ?)`,
+		testChainID, owner, 100)
+	require.NoError(t, err)
+	chainClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(200), nil).Times(1)
+	chainClient.EXPECT().FilterLogs(gomock.Any(), gomock.Any()).Return([]types.Log{approvalForAllLog(collection, spender, true, 150)}, nil).Times(1)
+	chainClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).Return(common.BigToHash(big.NewInt(1)).Bytes(), nil).Times(1)
+
+	manager.RefreshAsync([]uint64{testChainID}, []common.Address{owner})
+
+	progressEvent := <-ch
+	require.Equal(t, EventAllowancesRefreshProgress, progressEvent.Type)
+	progress, err := walletevent.GetPayload[RefreshProgress](progressEvent)
+	require.NoError(t, err)
+	require.Equal(t, RefreshProgress{ChainID: testChainID, Owner: owner, FromBlock: 100, ScannedBlock: 200, ToBlock: 200}, *progress)
+
+	doneEvent := <-ch
+	require.Equal(t, EventAllowancesRefreshDone, doneEvent.Type)
+	res, err := walletevent.GetPayload[RefreshResponse](doneEvent)
+	require.NoError(t, err)
+	require.Equal(t, ErrorCodeSuccess, res.ErrorCode)
+	require.Len(t, res.Allowances, 1)
+	require.Equal(t, collection, res.Allowances[0].Token)
+}
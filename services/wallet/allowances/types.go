@@ -0,0 +1,42 @@
+package allowances
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type Type string
+
+const (
+	// TypeErc20 is an ERC20 allowance given with `approve`
+	TypeErc20 Type = "erc20"
+	// TypeApprovalForAll is an ERC721 or ERC1155 operator allowed to move all the collectibles of the collection
+	TypeApprovalForAll Type = "approvalForAll"
+)
+
+// Allowance is the right of a spender to move tokens of the owner, it's kept until revoked on chain
+type Allowance struct {
+	ChainID uint64         `json:"chainId"`
+	Owner   common.Address `json:"owner"`
+	Token   common.Address `json:"token"`
+	Spender common.Address `json:"spender"`
+	Type    Type           `json:"type"`
+	// Amount is not set for operator approvals
+	Amount    *hexutil.Big `json:"amount,omitempty"`
+	Unlimited bool         `json:"unlimited"`
+	// BlockNumber and TxHash are the ones of the last approval event
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+}
+
+func (a *Allowance) setAmount(amount *big.Int) {
+	a.Amount = (*hexutil.Big)(amount)
+	a.Unlimited = isUnlimited(amount)
+}
+
+// isUnlimited returns true for amounts no account could hold, dApps approve the max uint256 or a value close to it
+func isUnlimited(amount *big.Int) bool {
+	return amount.Cmp(new(big.Int).Lsh(big.NewInt(1), 255)) >= 0
+}
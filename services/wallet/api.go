@@ -28,15 +28,22 @@ import (
 	"github.com/status-im/status-go/rpc/network"
 	"github.com/status-im/status-go/services/typeddata"
 	"github.com/status-im/status-go/services/wallet/activity"
+	"github.com/status-im/status-go/services/wallet/allowances"
 	"github.com/status-im/status-go/services/wallet/collectibles"
 	wcommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/currency"
+	"github.com/status-im/status-go/services/wallet/delegation"
 	"github.com/status-im/status-go/services/wallet/history"
 	"github.com/status-im/status-go/services/wallet/leaderboard"
 	"github.com/status-im/status-go/services/wallet/onramp"
+	"github.com/status-im/status-go/services/wallet/pricealerts"
 	"github.com/status-im/status-go/services/wallet/requests"
 	"github.com/status-im/status-go/services/wallet/router"
 	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/safe"
+	"github.com/status-im/status-go/services/wallet/signingpreview"
+	"github.com/status-im/status-go/services/wallet/smartaccount"
+	"github.com/status-im/status-go/services/wallet/spam"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/token"
 	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
@@ -99,7 +106,37 @@ func (api *API) FetchOrGetCachedWalletBalances(ctx context.Context, addresses []
 		return nil, err
 	}
 
-	return api.reader.FetchOrGetCachedWalletBalances(ctx, clients, addresses, forceRefresh)
+	balances, err := api.reader.FetchOrGetCachedWalletBalances(ctx, clients, addresses, forceRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	// the prices are not critical, the balances are returned without them on failure
+	if api.s.marketManager != nil && api.s.accountsDB != nil {
+		currency, err := api.s.accountsDB.GetCurrency()
+		if err != nil {
+			logutils.ZapLogger().Error("failed to get currency", zap.Error(err))
+		} else if err := fillPrices(api.s.marketManager, balances, []string{strings.ToUpper(currency)}); err != nil {
+			logutils.ZapLogger().Error("failed to fill prices", zap.Error(err))
+		}
+	}
+
+	// the spam classification is not critical, the balances are returned unmarked on failure
+	if api.s.spamManager != nil {
+		if err := api.s.spamManager.MarkSpam(balances); err != nil {
+			logutils.ZapLogger().Error("failed to mark spam tokens", zap.Error(err))
+		}
+	}
+	return balances, nil
+}
+
+// FetchOrGetCachedWalletBalancesWithoutSpam is FetchOrGetCachedWalletBalances without the tokens considered spam
+func (api *API) FetchOrGetCachedWalletBalancesWithoutSpam(ctx context.Context, addresses []common.Address, forceRefresh bool) (map[common.Address][]tokenTypes.StorageToken, error) {
+	balances, err := api.FetchOrGetCachedWalletBalances(ctx, addresses, forceRefresh)
+	if err != nil {
+		return nil, err
+	}
+	return spam.FilterSpam(balances), nil
 }
 
 type DerivedAddress struct {
@@ -691,6 +728,41 @@ func (api *API) SendTransactionWithSignature(ctx context.Context, chainID uint64
 	return api.s.transactionManager.SendTransactionWithSignature(chainID, params, sig)
 }
 
+// BuildSpeedUpTransaction builds a transaction with bumped fees replacing the pending transaction identified by (chainID, hash)
+func (api *API) BuildSpeedUpTransaction(ctx context.Context, chainID uint64, hash common.Hash) (response *transfer.TxResponse, err error) {
+	logutils.ZapLogger().Debug("[WalletAPI::BuildSpeedUpTransaction]", zap.Uint64("chainID", chainID), zap.Stringer("hash", hash))
+	return api.s.transactionManager.BuildReplacementTransaction(chainID, hash, transactions.SpeedUp)
+}
+
+// BuildCancelTransaction builds a 0-value transfer to self with bumped fees replacing the pending transaction identified by (chainID, hash)
+func (api *API) BuildCancelTransaction(ctx context.Context, chainID uint64, hash common.Hash) (response *transfer.TxResponse, err error) {
+	logutils.ZapLogger().Debug("[WalletAPI::BuildCancelTransaction]", zap.Uint64("chainID", chainID), zap.Stringer("hash", hash))
+	return api.s.transactionManager.BuildReplacementTransaction(chainID, hash, transactions.Cancel)
+}
+
+// SendReplacementTransactionWithSignature sends a speed-up or a cancel transaction built by BuildSpeedUpTransaction or BuildCancelTransaction
+func (api *API) SendReplacementTransactionWithSignature(ctx context.Context, chainID uint64, hash common.Hash, replacementType transactions.ReplacementType,
+	sendTxArgsJSON string, signature string) (newHash types.Hash, err error) {
+	logutils.ZapLogger().Debug("[WalletAPI::SendReplacementTransactionWithSignature]",
+		zap.Uint64("chainID", chainID),
+		zap.Stringer("hash", hash),
+		zap.String("replacementType", string(replacementType)),
+		zap.String("sendTxArgsJSON", sendTxArgsJSON),
+		zap.String("signature", signature),
+	)
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return newHash, err
+	}
+
+	var params wallettypes.SendTxArgs
+	err = json.Unmarshal([]byte(sendTxArgsJSON), &params)
+	if err != nil {
+		return newHash, err
+	}
+	return api.s.transactionManager.SendReplacementTransactionWithSignature(chainID, hash, replacementType, params, sig)
+}
+
 func (api *API) BuildTransactionsFromRoute(ctx context.Context, uuid string) {
 	logutils.ZapLogger().Debug("[WalletAPI::BuildTransactionsFromRoute] builds transactions from the generated best route", zap.String("uuid", uuid))
 	api.s.routeExecutionManager.BuildTransactionsFromRoute(ctx, uuid)
@@ -811,6 +883,27 @@ func (api *API) GetActivityCollectiblesAsync(requestID int32, chainIDs []wcommon
 	return nil
 }
 
+// ExportActivityAsync writes the activity to params.FilePath, progress and completion are reported through the
+// `wallet-activity-export-progress` and `wallet-activity-export-done` events
+func (api *API) ExportActivityAsync(requestID int32, params activity.ExportParams) error {
+	logutils.ZapLogger().Debug("wallet.api.ExportActivityAsync",
+		zap.Int("addresses.len", len(params.Addresses)),
+		zap.Int("chainIDs.len", len(params.ChainIDs)),
+		zap.String("format", string(params.Format)),
+	)
+
+	if len(params.Addresses) == 0 {
+		return activity.ErrNoAddressesProvided
+	}
+	if len(params.ChainIDs) == 0 {
+		return activity.ErrNoChainIDsProvided
+	}
+
+	api.s.activity.ExportActivityAsync(requestID, params)
+
+	return nil
+}
+
 func (api *API) FetchChainIDForURL(ctx context.Context, rpcURL string) (*big.Int, error) {
 	logutils.ZapLogger().Debug("wallet.api.VerifyURL", zap.String("rpcURL", rpcURL))
 
@@ -934,6 +1027,31 @@ func (api *API) SafeSignTypedDataForDApps(typedJson string, address string, pass
 	return walletconnect.SafeSignTypedDataForDApps(typedJson, account.AccountKey.PrivateKey, chainID, legacy)
 }
 
+// GetMessageSigningPreview returns the human readable preview of a "personal_sign" challenge
+func (api *API) GetMessageSigningPreview(ctx context.Context, challenge string, chainID uint64) (*signingpreview.Preview, error) {
+	logutils.ZapLogger().Debug("wallet.api.GetMessageSigningPreview", zap.Uint64("chainID", chainID))
+
+	return api.s.signingPreviewer.PreviewMessage(challenge, chainID), nil
+}
+
+// GetTypedDataSigningPreview returns the human readable preview and the risks of typed data requested to be signed
+// on `chainID`
+func (api *API) GetTypedDataSigningPreview(ctx context.Context, typedJson string, chainID uint64) (*signingpreview.Preview, error) {
+	logutils.ZapLogger().Debug("wallet.api.GetTypedDataSigningPreview",
+		zap.Int("len(typedJson)", len(typedJson)),
+		zap.Uint64("chainID", chainID),
+	)
+
+	return api.s.signingPreviewer.PreviewTypedData(typedJson, chainID)
+}
+
+// GetTransactionSigningPreview returns the human readable preview and the risks of a transaction requested by a dApp
+func (api *API) GetTransactionSigningPreview(ctx context.Context, chainID uint64, sendTxArgs wallettypes.SendTxArgs) (*signingpreview.Preview, error) {
+	logutils.ZapLogger().Debug("wallet.api.GetTransactionSigningPreview", zap.Uint64("chainID", chainID))
+
+	return api.s.signingPreviewer.PreviewTransaction(chainID, &sendTxArgs)
+}
+
 func (api *API) RestartWalletReloadTimer(ctx context.Context) error {
 	return api.s.reader.Restart()
 }
@@ -962,3 +1080,136 @@ func (api *API) UnsubscribeFromLeaderboard() error {
 	logutils.ZapLogger().Debug("call to UnsubscribeFromLeaderboard")
 	return api.s.leaderboardService.UnsubscribeFromLeaderboard()
 }
+
+// DiscoverSafe reads the owners and the threshold of the Safe on chain, it must be called before adding the Safe as an account
+func (api *API) DiscoverSafe(ctx context.Context, chainID uint64, address common.Address) (*safe.Info, error) {
+	logutils.ZapLogger().Debug("call to DiscoverSafe", zap.Uint64("chainID", chainID), zap.Stringer("address", address))
+	return api.s.safeManager.Discover(ctx, chainID, address)
+}
+
+// ProposeSafeTransaction returns the pending Safe transaction making the given call, it's created if needed
+func (api *API) ProposeSafeTransaction(ctx context.Context, chainID uint64, safeAddress common.Address, to common.Address, value *hexutil.Big, data hexutil.Bytes) (*safe.Transaction, error) {
+	logutils.ZapLogger().Debug("call to ProposeSafeTransaction", zap.Uint64("chainID", chainID), zap.Stringer("safe", safeAddress))
+	return api.s.safeManager.ProposeTransaction(ctx, chainID, safeAddress, to, value.ToInt(), data)
+}
+
+// GetSafePendingTransactions returns the Safe transactions not executed yet, with the collected signatures
+func (api *API) GetSafePendingTransactions(ctx context.Context, chainID uint64, safeAddress common.Address) ([]*safe.Transaction, error) {
+	logutils.ZapLogger().Debug("call to GetSafePendingTransactions", zap.Uint64("chainID", chainID), zap.Stringer("safe", safeAddress))
+	return api.s.safeManager.PendingTransactions(ctx, chainID, safeAddress)
+}
+
+// GetSafeTransactionTypedData returns the EIP-712 message to be signed by the owners using another wallet
+func (api *API) GetSafeTransactionTypedData(ctx context.Context, safeTxHash common.Hash) (typeddata.TypedData, error) {
+	tx, err := api.s.safeManager.GetTransaction(safeTxHash)
+	if err != nil {
+		return typeddata.TypedData{}, err
+	}
+	return tx.TypedData()
+}
+
+// SignSafeTransaction signs the Safe transaction with a local owner account
+func (api *API) SignSafeTransaction(ctx context.Context, safeTxHash common.Hash, owner string, password string) (*safe.Transaction, error) {
+	logutils.ZapLogger().Debug("call to SignSafeTransaction", zap.Stringer("safeTxHash", safeTxHash), zap.String("owner", owner))
+
+	account, err := api.getVerifiedWalletAccount(owner, password)
+	if err != nil {
+		return nil, err
+	}
+	return api.s.safeManager.SignTransaction(safeTxHash, account.AccountKey.PrivateKey)
+}
+
+// AddSafeSignature adds the signature of an owner collected outside of the app, the transaction can be sent
+// through the router once the threshold is reached
+func (api *API) AddSafeSignature(ctx context.Context, safeTxHash common.Hash, signature hexutil.Bytes) (*safe.Transaction, error) {
+	logutils.ZapLogger().Debug("call to AddSafeSignature", zap.Stringer("safeTxHash", safeTxHash))
+	return api.s.safeManager.AddSignature(safeTxHash, signature)
+}
+
+// CreateSmartAccount returns the ERC-4337 smart account owned by `owner`, it's deployed with its first transaction.
+// The client adds it as a "smart" account without a keypair, holding the public key of the owner.
+func (api *API) CreateSmartAccount(ctx context.Context, chainID uint64, owner common.Address) (*smartaccount.Account, error) {
+	logutils.ZapLogger().Debug("call to CreateSmartAccount", zap.Uint64("chainID", chainID), zap.Stringer("owner", owner))
+	return api.s.smartAccountManager.CreateAccount(ctx, chainID, owner)
+}
+
+// GetSmartAccount returns nil if the address is not a smart account
+func (api *API) GetSmartAccount(ctx context.Context, address common.Address) (*smartaccount.Account, error) {
+	logutils.ZapLogger().Debug("call to GetSmartAccount", zap.Stringer("address", address))
+	return api.s.smartAccountManager.GetAccount(address)
+}
+
+// GetAccountsDelegationStates returns the EIP-7702 delegation of the wallet accounts on the given chains
+func (api *API) GetAccountsDelegationStates(ctx context.Context, chainIDs []uint64) ([]*delegation.State, error) {
+	logutils.ZapLogger().Debug("call to GetAccountsDelegationStates", zap.Uint64s("chainIDs", chainIDs))
+	return api.s.delegationManager.GetAccountsStates(chainIDs)
+}
+
+// BuildBatchExecutorAuthorization returns the authorization delegating the account to the batch executor of the chain,
+// its signature is passed as `delegationSignature` to the router to batch the transactions of a route
+func (api *API) BuildBatchExecutorAuthorization(ctx context.Context, chainID uint64, address common.Address) (*delegation.AuthorizationForSigning, error) {
+	logutils.ZapLogger().Debug("call to BuildBatchExecutorAuthorization", zap.Uint64("chainID", chainID), zap.Stringer("address", address))
+	return api.s.delegationManager.BuildBatchExecutorAuthorization(chainID, address)
+}
+
+// SignBatchExecutorAuthorization returns the authorization delegating the account to the batch executor of the chain,
+// signed by the account key
+func (api *API) SignBatchExecutorAuthorization(ctx context.Context, chainID uint64, address string, password string) (*wallettypes.SetCodeAuthorization, error) {
+	logutils.ZapLogger().Debug("call to SignBatchExecutorAuthorization", zap.Uint64("chainID", chainID), zap.String("address", address))
+
+	account, err := api.getVerifiedWalletAccount(address, password)
+	if err != nil {
+		return nil, err
+	}
+	return api.s.delegationManager.SignBatchExecutorAuthorization(chainID, account.AccountKey.PrivateKey)
+}
+
+// GetTokenAllowances returns the ERC20 allowances and the collectibles operator approvals given by the accounts, as
+// known since the last refresh
+func (api *API) GetTokenAllowances(ctx context.Context, chainIDs []uint64, addresses []common.Address) ([]*allowances.Allowance, error) {
+	logutils.ZapLogger().Debug("call to GetTokenAllowances", zap.Uint64s("chainIDs", chainIDs), zap.Stringers("addresses", addresses))
+	return api.s.allowancesManager.GetAllowances(chainIDs, addresses)
+}
+
+// RefreshTokenAllowancesAsync scans the approval logs of the accounts and checks the allowances on chain in the
+// background, the result is sent with the `wallet-allowances-refresh-done` event. The allowances are revoked through
+// the router with the `RevokeAllowances` send type.
+func (api *API) RefreshTokenAllowancesAsync(ctx context.Context, chainIDs []uint64, addresses []common.Address) error {
+	logutils.ZapLogger().Debug("call to RefreshTokenAllowancesAsync", zap.Uint64s("chainIDs", chainIDs), zap.Stringers("addresses", addresses))
+	api.s.allowancesManager.RefreshAsync(chainIDs, addresses)
+	return nil
+}
+
+// GetTokenSpamVerdicts classifies the tokens transferred from or to the accounts and returns the spam verdicts of the
+// tokens on the chains. The verdicts are overridden with `accounts_updateTokenSpamOverrides`.
+func (api *API) GetTokenSpamVerdicts(ctx context.Context, chainIDs []uint64) ([]*spam.Verdict, error) {
+	logutils.ZapLogger().Debug("call to GetTokenSpamVerdicts", zap.Uint64s("chainIDs", chainIDs))
+
+	if err := api.s.spamManager.ClassifyTransferredTokens(chainIDs); err != nil {
+		return nil, err
+	}
+	return api.s.spamManager.GetVerdicts(chainIDs)
+}
+
+// GetPriceAlerts returns the price alerts set by the user
+func (api *API) GetPriceAlerts(ctx context.Context) ([]*pricealerts.Alert, error) {
+	logutils.ZapLogger().Debug("call to GetPriceAlerts")
+	return api.s.priceAlertsManager.GetAlerts()
+}
+
+// AddPriceAlert adds an alert notified with a local notification and a `wallet-price-alerts-triggered` signal when its
+// condition starts holding
+func (api *API) AddPriceAlert(ctx context.Context, alert *pricealerts.Alert) (*pricealerts.Alert, error) {
+	logutils.ZapLogger().Debug("call to AddPriceAlert", zap.String("symbol", alert.Symbol), zap.String("type", string(alert.Type)))
+	return api.s.priceAlertsManager.AddAlert(alert)
+}
+
+func (api *API) UpdatePriceAlert(ctx context.Context, alert *pricealerts.Alert) error {
+	logutils.ZapLogger().Debug("call to UpdatePriceAlert", zap.Int64("id", alert.ID))
+	return api.s.priceAlertsManager.UpdateAlert(alert)
+}
+
+func (api *API) DeletePriceAlert(ctx context.Context, id int64) error {
+	logutils.ZapLogger().Debug("call to DeletePriceAlert", zap.Int64("id", id))
+	return api.s.priceAlertsManager.DeleteAlert(id)
+}
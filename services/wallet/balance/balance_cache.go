@@ -34,6 +34,7 @@ type CacheIface interface {
 	AddNonce(account common.Address, chainID uint64, blockNumber *big.Int, nonce *int64)
 	BalanceSize(account common.Address, chainID uint64) int
 	NonceSize(account common.Address, chainID uint64) int
+	RemoveAfter(chainID uint64, blockNumber *big.Int)
 	Clear()
 }
 
@@ -44,6 +45,7 @@ type cacheIface[K comparable, V any] interface {
 	set(K, V)
 	len() int
 	keys() []K
+	delete(K)
 	clear()
 	init()
 }
@@ -168,6 +170,35 @@ func (b *genericCache[_, N, _]) NonceSize(account common.Address, chainID uint64
 	return b.nonces[account][chainID].len()
 }
 
+// RemoveAfter removes the balances and nonces cached on the chain for the blocks after blockNumber,
+// e.g. the blocks of a reorganized branch
+func (b *genericCache[_, _, _]) RemoveAfter(chainID uint64, blockNumber *big.Int) {
+	b.rw.Lock()
+	defer b.rw.Unlock()
+
+	for _, chainCache := range b.balances {
+		if cache, exists := chainCache[chainID]; exists {
+			for _, key := range cache.keys() {
+				if key > blockNumber.Uint64() {
+					cache.delete(key)
+				}
+			}
+		}
+	}
+
+	for _, chainCache := range b.nonces {
+		if cache, exists := chainCache[chainID]; exists {
+			for _, key := range cache.keys() {
+				if key > blockNumber.Uint64() {
+					cache.delete(key)
+				}
+			}
+		}
+	}
+
+	b.nonceRangeCache.removeChain(chainID)
+}
+
 // implements Cacher interface that caches balance and nonce in memory.
 type cacherImpl struct {
 	cache CacheIface
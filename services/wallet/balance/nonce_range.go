@@ -116,6 +116,19 @@ func (b *nonceRangeCache[T]) clear() {
 	b.sortedRanges = make(sortedNonceRangesCacheType)
 }
 
+// removeChain removes the nonce ranges of the chain, they are built again from the next added nonces
+func (b *nonceRangeCache[T]) removeChain(chainID uint64) {
+	b.rw.Lock()
+	defer b.rw.Unlock()
+
+	for _, chainRanges := range b.nonceRanges {
+		delete(chainRanges, chainID)
+	}
+	for _, chainRanges := range b.sortedRanges {
+		delete(chainRanges, chainID)
+	}
+}
+
 func (b *nonceRangeCache[T]) size(account common.Address, chainID uint64) int {
 	b.rw.RLock()
 	defer b.rw.RUnlock()
@@ -34,6 +34,10 @@ func (c *mapCache[K, V]) keys() []K {
 	return keys
 }
 
+func (c *mapCache[K, V]) delete(key K) {
+	delete(c.cache, key)
+}
+
 func (c *mapCache[K, V]) init() {
 	c.cache = make(map[K]V)
 }
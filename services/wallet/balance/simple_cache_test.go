@@ -42,3 +42,29 @@ func Test_simpleCacheAll(t *testing.T) {
 	require.Equal(t, 1, cache.nonceRangeCache.size(account, chainID))
 	require.Equal(t, 1, len(cache.nonceRangeCache.sortedRanges))
 }
+
+func Test_simpleCacheRemoveAfter(t *testing.T) {
+	cache := newSimpleCache()
+
+	account := common.Address{1}
+	chainID := uint64(1)
+	otherChainID := uint64(2)
+	nonce := int64(2)
+	for _, block := range []int64{1, 2, 3} {
+		cache.AddBalance(account, chainID, big.NewInt(block), big.NewInt(block))
+		cache.AddNonce(account, chainID, big.NewInt(block), &nonce)
+		cache.AddBalance(account, otherChainID, big.NewInt(block), big.NewInt(block))
+	}
+
+	cache.RemoveAfter(chainID, big.NewInt(1))
+
+	require.Equal(t, 1, cache.BalanceSize(account, chainID))
+	require.Equal(t, 1, cache.NonceSize(account, chainID))
+	require.Equal(t, big.NewInt(1), cache.GetBalance(account, chainID, big.NewInt(1)))
+	require.Nil(t, cache.GetBalance(account, chainID, big.NewInt(2)))
+	// the nonce ranges could span the removed blocks
+	require.Nil(t, cache.GetNonce(account, chainID, big.NewInt(3)))
+	require.Equal(t, 0, cache.nonceRangeCache.size(account, chainID))
+
+	require.Equal(t, 3, cache.BalanceSize(account, otherChainID))
+}
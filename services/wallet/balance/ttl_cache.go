@@ -50,6 +50,11 @@ func (c *ttlCache[K, V]) keys() []K {
 	return c.cache.Keys()
 }
 
+//nolint:golint,unused // linter does not detect using it via reflect
+func (c *ttlCache[K, V]) delete(key K) {
+	c.cache.Delete(key)
+}
+
 //nolint:golint,unused // linter does not detect using it via reflect
 func (c *ttlCache[K, V]) init() {
 	c.cache = ttlcache.New[K, V](
@@ -0,0 +1,234 @@
+package blockchainstate
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/logutils"
+	w_common "github.com/status-im/status-go/services/wallet/common"
+)
+
+const (
+	// minPollInterval bounds the polling of the chains with short blocks
+	minPollInterval = 5 * time.Second
+	// resubscribeInterval is how long the chain is polled before subscribing again
+	resubscribeInterval = 5 * time.Minute
+	headsBufferSize     = 10
+)
+
+var errSubscriptionClosed = errors.New("new heads subscription closed")
+
+// HeadsReader is the part of the chain client used to follow the new blocks
+type HeadsReader interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// HeadListener is called with every new block of a chain, from the goroutine following the chain
+type HeadListener func(chainID uint64, header *types.Header)
+
+// HeadsTracker follows the new blocks of the chains and sets their exact number in BlockChainState.
+// It subscribes to the new heads when a provider supports subscriptions and polls the latest header
+// only while a live subscription is down, the subscription is retried after resubscribeInterval.
+// The chains without subscriptions keep the estimated block numbers and aren't polled.
+type HeadsTracker struct {
+	state               *BlockChainState
+	pollIntervalFn      func(chainID uint64) time.Duration
+	resubscribeInterval time.Duration
+
+	listenersMu sync.RWMutex
+	listeners   []HeadListener
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	logger *zap.Logger
+}
+
+func NewHeadsTracker(state *BlockChainState) *HeadsTracker {
+	return &HeadsTracker{
+		state:               state,
+		pollIntervalFn:      pollInterval,
+		resubscribeInterval: resubscribeInterval,
+		logger:              logutils.ZapLogger().Named("HeadsTracker"),
+	}
+}
+
+// pollInterval polls once per block, but not more often than minPollInterval
+func pollInterval(chainID uint64) time.Duration {
+	blockDuration, found := w_common.AverageBlockDurationForChain[w_common.ChainID(chainID)]
+	if !found {
+		blockDuration = w_common.AverageBlockDurationForChain[w_common.ChainID(w_common.UnknownChainID)]
+	}
+	if blockDuration < minPollInterval {
+		return minPollInterval
+	}
+	return blockDuration
+}
+
+func (t *HeadsTracker) AddListener(listener HeadListener) {
+	t.listenersMu.Lock()
+	defer t.listenersMu.Unlock()
+	t.listeners = append(t.listeners, listener)
+}
+
+// Start follows the chains until Stop is called, the chains followed already are restarted
+func (t *HeadsTracker) Start(chainClients map[uint64]HeadsReader) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	for chainID, client := range chainClients {
+		t.wg.Add(1)
+		go func(chainID uint64, client HeadsReader) {
+			defer gocommon.LogOnPanic()
+			defer t.wg.Done()
+			t.track(ctx, chainID, client)
+		}(chainID, client)
+	}
+}
+
+func (t *HeadsTracker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stop()
+}
+
+// stop cancels the chains followed and waits for them, it's called with mu held
+func (t *HeadsTracker) stop() {
+	if t.cancel == nil {
+		return
+	}
+	t.cancel()
+	t.wg.Wait()
+	t.cancel = nil
+}
+
+// chainHeads keeps the last block of a chain to notify the listeners once per block
+type chainHeads struct {
+	chainID    uint64
+	client     HeadsReader
+	lastHash   common.Hash
+	subscribed bool // a subscription was live at least once
+}
+
+func (t *HeadsTracker) track(ctx context.Context, chainID uint64, client HeadsReader) {
+	heads := &chainHeads{chainID: chainID, client: client}
+	for {
+		err := t.follow(ctx, heads)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if !heads.subscribed {
+			t.logger.Debug("new heads subscription not supported",
+				zap.Uint64("chainID", chainID), zap.Error(err))
+			if !wait(ctx, t.resubscribeInterval) {
+				return
+			}
+			continue
+		}
+
+		t.logger.Debug("new heads subscription dropped, polling",
+			zap.Uint64("chainID", chainID), zap.Error(err))
+		t.poll(ctx, heads)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// wait returns false when the context is done before the duration elapsed
+func wait(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// follow receives the new heads until the subscription fails
+func (t *HeadsTracker) follow(ctx context.Context, heads *chainHeads) error {
+	headers := make(chan *types.Header, headsBufferSize)
+	sub, err := heads.client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	heads.subscribed = true
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				err = errSubscriptionClosed
+			}
+			return err
+		case header := <-headers:
+			t.onHead(heads, header)
+		}
+	}
+}
+
+// poll fetches the latest header at the poll interval of the chain until it's time to subscribe again
+func (t *HeadsTracker) poll(ctx context.Context, heads *chainHeads) {
+	ticker := time.NewTicker(t.pollIntervalFn(heads.chainID))
+	defer ticker.Stop()
+	resubscribe := time.NewTimer(t.resubscribeInterval)
+	defer resubscribe.Stop()
+
+	for {
+		header, err := heads.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			t.logger.Debug("cannot fetch the latest header", zap.Uint64("chainID", heads.chainID), zap.Error(err))
+		} else {
+			t.onHead(heads, header)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-resubscribe.C:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *HeadsTracker) onHead(heads *chainHeads, header *types.Header) {
+	if header == nil || header.Number == nil {
+		return
+	}
+	hash := header.Hash()
+	if hash == heads.lastHash {
+		return
+	}
+	heads.lastHash = hash
+
+	t.state.SetLastBlockNumber(heads.chainID, header.Number.Uint64())
+
+	t.listenersMu.RLock()
+	defer t.listenersMu.RUnlock()
+	for _, listener := range t.listeners {
+		listener(heads.chainID, header)
+	}
+}
@@ -0,0 +1,264 @@
+package blockchainstate
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// testChain is the "eth" service of an in-process node producing blocks on demand
+type testChain struct {
+	mu          sync.Mutex
+	headers     []*types.Header
+	subscribers map[gethrpc.ID]*gethrpc.Notifier
+	latestCalls int
+}
+
+func (c *testChain) NewHeads(ctx context.Context) (*gethrpc.Subscription, error) {
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return &gethrpc.Subscription{}, gethrpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+
+	c.mu.Lock()
+	c.subscribers[sub.ID] = notifier
+	c.mu.Unlock()
+
+	go func() {
+		<-sub.Err()
+		c.mu.Lock()
+		delete(c.subscribers, sub.ID)
+		c.mu.Unlock()
+	}()
+	return sub, nil
+}
+
+func (c *testChain) GetBlockByNumber(ctx context.Context, number gethrpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.latestCalls++
+	if len(c.headers) == 0 {
+		return nil, nil
+	}
+	return c.headers[len(c.headers)-1], nil
+}
+
+func (c *testChain) mine() *types.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := &types.Header{
+		Number:     big.NewInt(int64(len(c.headers) + 1)),
+		Difficulty: big.NewInt(0),
+	}
+	if len(c.headers) > 0 {
+		header.ParentHash = c.headers[len(c.headers)-1].Hash()
+	}
+	c.headers = append(c.headers, header)
+	for id, notifier := range c.subscribers {
+		_ = notifier.Notify(id, header)
+	}
+	return header
+}
+
+func (c *testChain) subscribersCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.subscribers)
+}
+
+func (c *testChain) latestCallsCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.latestCalls
+}
+
+// testHeadsReader reads the test chain, it refuses the subscriptions like an HTTP provider when unsupported is set
+type testHeadsReader struct {
+	*ethclient.Client
+
+	mu          sync.Mutex
+	unsupported bool
+	subs        []ethereum.Subscription
+}
+
+func (r *testHeadsReader) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.unsupported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+	sub, err := r.Client.SubscribeNewHead(ctx, ch)
+	if err == nil {
+		r.subs = append(r.subs, sub)
+	}
+	return sub, err
+}
+
+func (r *testHeadsReader) setUnsupported(unsupported bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.unsupported = unsupported
+}
+
+func (r *testHeadsReader) dropSubscriptions() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, sub := range r.subs {
+		sub.Unsubscribe()
+	}
+	r.subs = nil
+}
+
+// headsRecorder records the block numbers received by the listener
+type headsRecorder struct {
+	mu      sync.Mutex
+	numbers []uint64
+}
+
+func (r *headsRecorder) listener(chainID uint64, header *types.Header) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.numbers = append(r.numbers, header.Number.Uint64())
+}
+
+func (r *headsRecorder) get() []uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]uint64{}, r.numbers...)
+}
+
+func setupHeadsTrackerTest(t *testing.T, unsupported bool) (*HeadsTracker, *BlockChainState, *testChain, *testHeadsReader, *headsRecorder) {
+	chain := &testChain{subscribers: make(map[gethrpc.ID]*gethrpc.Notifier)}
+	server := gethrpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", chain))
+	client := gethrpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	state := NewBlockChainState()
+	// the numbers are exact, nothing is estimated
+	state.sinceFn = func(time.Time) time.Duration { return 0 }
+
+	tracker := NewHeadsTracker(state)
+	tracker.pollIntervalFn = func(chainID uint64) time.Duration { return 10 * time.Millisecond }
+	tracker.resubscribeInterval = 100 * time.Millisecond
+	recorder := &headsRecorder{}
+	tracker.AddListener(recorder.listener)
+
+	reader := &testHeadsReader{Client: ethclient.NewClient(client), unsupported: unsupported}
+	return tracker, state, chain, reader, recorder
+}
+
+func requireLatestBlock(t *testing.T, state *BlockChainState, chainID uint64, number uint64) {
+	require.Eventually(t, func() bool {
+		latest, _ := state.GetEstimatedLatestBlockNumber(context.Background(), chainID)
+		return latest == number
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestHeadsTracker_Subscription(t *testing.T) {
+	tracker, state, chain, reader, recorder := setupHeadsTrackerTest(t, false)
+
+	tracker.Start(map[uint64]HeadsReader{1: reader})
+	defer tracker.Stop()
+	require.Eventually(t, func() bool { return chain.subscribersCount() == 1 }, 5*time.Second, 5*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		chain.mine()
+	}
+	requireLatestBlock(t, state, 1, 3)
+	require.Eventually(t, func() bool { return len(recorder.get()) == 3 }, 5*time.Second, 5*time.Millisecond)
+	require.Equal(t, []uint64{1, 2, 3}, recorder.get())
+
+	// the chain is not polled while subscribed
+	require.Equal(t, 0, chain.latestCallsCount())
+
+	tracker.Stop()
+	require.Eventually(t, func() bool { return chain.subscribersCount() == 0 }, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestHeadsTracker_NotPolledWithoutSubscription(t *testing.T) {
+	tracker, state, chain, reader, recorder := setupHeadsTrackerTest(t, true)
+	chain.mine()
+
+	tracker.Start(map[uint64]HeadsReader{1: reader})
+	defer tracker.Stop()
+
+	// subscriptions not supported, the chain is not polled
+	time.Sleep(3 * tracker.resubscribeInterval)
+	require.Equal(t, 0, chain.latestCallsCount())
+	latest, _ := state.GetEstimatedLatestBlockNumber(context.Background(), 1)
+	require.Equal(t, uint64(0), latest)
+	require.Empty(t, recorder.get())
+
+	// subscribed once supported
+	reader.setUnsupported(false)
+	require.Eventually(t, func() bool { return chain.subscribersCount() == 1 }, 5*time.Second, 5*time.Millisecond)
+	chain.mine()
+	requireLatestBlock(t, state, 1, 2)
+	require.Equal(t, 0, chain.latestCallsCount())
+}
+
+func TestHeadsTracker_FallbackToPolling(t *testing.T) {
+	tracker, state, chain, reader, recorder := setupHeadsTrackerTest(t, false)
+
+	tracker.Start(map[uint64]HeadsReader{1: reader})
+	defer tracker.Stop()
+	require.Eventually(t, func() bool { return chain.subscribersCount() == 1 }, 5*time.Second, 5*time.Millisecond)
+	chain.mine()
+	requireLatestBlock(t, state, 1, 1)
+
+	// polled when the subscription drops
+	reader.setUnsupported(true)
+	reader.dropSubscriptions()
+	require.Eventually(t, func() bool { return chain.latestCallsCount() > 0 }, 5*time.Second, 5*time.Millisecond)
+	chain.mine()
+	requireLatestBlock(t, state, 1, 2)
+	require.Equal(t, 0, chain.subscribersCount())
+
+	// subscribed again once supported
+	reader.setUnsupported(false)
+	require.Eventually(t, func() bool { return chain.subscribersCount() == 1 }, 5*time.Second, 5*time.Millisecond)
+	chain.mine()
+	requireLatestBlock(t, state, 1, 3)
+
+	// the listeners are called once per block, not once per poll
+	require.Equal(t, []uint64{1, 2, 3}, recorder.get())
+}
+
+func TestHeadsTracker_ConcurrentStarts(t *testing.T) {
+	tracker, state, chain, reader, recorder := setupHeadsTrackerTest(t, false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tracker.Start(map[uint64]HeadsReader{1: reader})
+		}()
+	}
+	wg.Wait()
+
+	require.Eventually(t, func() bool { return chain.subscribersCount() > 0 }, 5*time.Second, 5*time.Millisecond)
+	chain.mine()
+	requireLatestBlock(t, state, 1, 1)
+
+	// no chain is followed after Stop, none of the starts is leaked
+	tracker.Stop()
+	chain.mine()
+	time.Sleep(3 * tracker.resubscribeInterval)
+	require.Equal(t, []uint64{1}, recorder.get())
+}
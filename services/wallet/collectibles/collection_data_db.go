@@ -28,7 +28,7 @@ func NewCollectionDataDB(sqlDb *sql.DB) *CollectionDataDB {
 	}
 }
 
-const collectionDataColumns = "chain_id, contract_address, provider, name, slug, image_url, image_payload, community_id"
+const collectionDataColumns = "chain_id, contract_address, provider, name, slug, image_url, image_payload, community_id, is_spam"
 const collectionTraitsColumns = "chain_id, contract_address, trait_type, min, max"
 const selectCollectionTraitsColumns = "trait_type, min, max"
 const collectionSocialsColumns = "chain_id, contract_address, provider, website, twitter_handle"
@@ -112,7 +112,7 @@ func upsertCollectionTraits(creator sqlite.StatementCreator, id thirdparty.Contr
 
 func setCollectionsData(creator sqlite.StatementCreator, collections []thirdparty.CollectionData, allowUpdate bool) error {
 	insertCollection, err := creator.Prepare(fmt.Sprintf(`%s INTO collection_data_cache (%s) 
-																				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, insertStatement(allowUpdate), collectionDataColumns))
+																				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, insertStatement(allowUpdate), collectionDataColumns))
 	if err != nil {
 		return err
 	}
@@ -127,6 +127,7 @@ func setCollectionsData(creator sqlite.StatementCreator, collections []thirdpart
 			c.ImageURL,
 			c.ImagePayload,
 			c.CommunityID,
+			c.IsSpam,
 		)
 		if err != nil {
 			return err
@@ -190,6 +191,7 @@ func scanCollectionsDataRow(row *sql.Row) (*thirdparty.CollectionData, error) {
 		&c.ImageURL,
 		&c.ImagePayload,
 		&c.CommunityID,
+		&c.IsSpam,
 	)
 	if err != nil {
 		return nil, err
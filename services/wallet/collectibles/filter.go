@@ -50,6 +50,9 @@ type Filter struct {
 	CommunityPrivilegesLevels []token.PrivilegesLevel          `json:"community_privileges_levels"`
 
 	FilterCommunity FilterCommunityType `json:"filter_community"`
+
+	// HideSpam hides the collectibles of the collections considered spam, see the spam_tokens view
+	HideSpam bool `json:"hide_spam"`
 }
 
 func filterOwnedCollectibles(ctx context.Context, db *sql.DB, chainIDs []wcommon.ChainID, addresses []common.Address, filter Filter, offset int, limit int) ([]thirdparty.CollectibleUniqueID, error) {
@@ -101,6 +104,12 @@ func filterOwnedCollectibles(ctx context.Context, db *sql.DB, chainIDs []wcommon
 		qConditions = append(qConditions, sq.Eq{"data.community_privileges_level": filter.CommunityPrivilegesLevels})
 	}
 
+	if filter.HideSpam {
+		qConditions = append(qConditions, sq.Expr(`NOT EXISTS (SELECT 1 FROM spam_tokens spam WHERE
+			spam.chain_id = ownership.chain_id AND
+			spam.address = ownership.contract_address)`))
+	}
+
 	q = q.Where(qConditions)
 
 	q = q.Limit(uint64(limit))
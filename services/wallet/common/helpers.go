@@ -83,6 +83,22 @@ func ValidateENSUsername(username string) error {
 	return nil
 }
 
+// ValidateENSName accepts any name that can be resolved through ENS, including subnames and DNS names
+// served by wildcard resolvers, e.g. "alice.cb.id"
+func ValidateENSName(name string) error {
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("name must have at least two labels")
+	}
+	for _, label := range labels {
+		if label == "" {
+			return fmt.Errorf("name must not have empty labels")
+		}
+	}
+
+	return nil
+}
+
 func UsernameToLabel(username string) [32]byte {
 	usernameHashed := crypto.Keccak256([]byte(username))
 	var label [32]byte
@@ -60,3 +60,35 @@ func TestLogHopBridgeTransferFromL1Completed(t *testing.T) {
 	eventType := GetEventType(&eventLog)
 	require.Equal(t, HopBridgeTransferFromL1CompletedEventType, eventType)
 }
+
+func TestLogErc20Approval(t *testing.T) {
+	eventLogTestData := `{"address":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","topics":["0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925","0x000000000000000000000000d6255ae13ac335b347aa846802ad6ac39dd2543a","0x000000000000000000000000000000000022d473030f116ddee9f6b43ac78ba3"],"data":"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff","blockNumber":"0x10b4baf","transactionHash":"0xfc4687f8d985ef0cc86d79a0c7abc582552b8bae0e13a90c59eeadf7e64ed569","transactionIndex":"0x48","blockHash":"0xea7f003d02a43be4dfec836803a033c0ce22ecf9a48d4a3ed3700db9c722d994","logIndex":"0xfa","removed":false}`
+	var eventLog types.Log
+	err := json.Unmarshal([]byte(eventLogTestData), &eventLog)
+	require.NoError(t, err)
+
+	eventType := GetEventType(&eventLog)
+	require.Equal(t, Erc20ApprovalEventType, eventType)
+
+	owner, spender, amount, err := ParseErc20ApprovalLog(&eventLog)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xd6255ae13ac335b347aa846802ad6ac39dd2543a"), owner)
+	require.Equal(t, common.HexToAddress("0x000000000022d473030f116ddee9f6b43ac78ba3"), spender)
+	require.Equal(t, MaxUint256, amount)
+}
+
+func TestLogApprovalForAll(t *testing.T) {
+	eventLogTestData := `{"address":"0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d","topics":["0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31","0x000000000000000000000000d6255ae13ac335b347aa846802ad6ac39dd2543a","0x0000000000000000000000001e0049783f008a0085193e00003d00cd54003c71"],"data":"0x0000000000000000000000000000000000000000000000000000000000000001","blockNumber":"0x10b4baf","transactionHash":"0xfc4687f8d985ef0cc86d79a0c7abc582552b8bae0e13a90c59eeadf7e64ed569","transactionIndex":"0x48","blockHash":"0xea7f003d02a43be4dfec836803a033c0ce22ecf9a48d4a3ed3700db9c722d994","logIndex":"0xfa","removed":false}`
+	var eventLog types.Log
+	err := json.Unmarshal([]byte(eventLogTestData), &eventLog)
+	require.NoError(t, err)
+
+	eventType := GetEventType(&eventLog)
+	require.Equal(t, ApprovalForAllEventType, eventType)
+
+	owner, operator, approved, err := ParseApprovalForAllLog(&eventLog)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xd6255ae13ac335b347aa846802ad6ac39dd2543a"), owner)
+	require.Equal(t, common.HexToAddress("0x1e0049783f008a0085193e00003d00cd54003c71"), operator)
+	require.True(t, approved)
+}
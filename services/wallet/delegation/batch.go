@@ -0,0 +1,87 @@
+package delegation
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/params"
+)
+
+const erc7821ABI = `[
+	{"type":"function","name":"execute","stateMutability":"payable","inputs":[{"name":"mode","type":"bytes32"},{"name":"executionData","type":"bytes"}],"outputs":[]}
+]`
+
+// batchExecutionMode is the ERC-7821 mode of a batch of calls reverting all of them if one fails
+var batchExecutionMode = [32]byte{0x01}
+
+var (
+	parsedERC7821ABI abi.ABI
+	executionsArgs   abi.Arguments
+)
+
+func init() {
+	var err error
+	parsedERC7821ABI, err = abi.JSON(strings.NewReader(erc7821ABI))
+	if err != nil {
+		panic(err)
+	}
+
+	executionsType, err := abi.NewType("tuple[]", "", []abi.ArgumentMarshaling{
+		{Name: "target", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "callData", Type: "bytes"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	executionsArgs = abi.Arguments{{Type: executionsType}}
+}
+
+// Call is one of the calls executed by the account in a batch
+type Call struct {
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+}
+
+type execution struct {
+	Target   common.Address
+	Value    *big.Int
+	CallData []byte
+}
+
+// PackBatchExecute returns the ERC-7821 `execute` call data making the delegated account execute the calls atomically
+func PackBatchExecute(calls []Call) ([]byte, error) {
+	executions := make([]execution, 0, len(calls))
+	for _, call := range calls {
+		value := call.Value
+		if value == nil {
+			value = new(big.Int)
+		}
+		data := call.Data
+		if data == nil {
+			data = []byte{}
+		}
+		executions = append(executions, execution{Target: call.To, Value: value, CallData: data})
+	}
+
+	executionData, err := executionsArgs.Pack(executions)
+	if err != nil {
+		return nil, err
+	}
+	return parsedERC7821ABI.Pack("execute", batchExecutionMode, executionData)
+}
+
+// BatchExecutors returns the configured batch executor per chain
+func BatchExecutors(config params.WalletConfig) map[uint64]common.Address {
+	executors := make(map[uint64]common.Address, len(config.BatchExecutorAddresses))
+	for chainID, address := range config.BatchExecutorAddresses {
+		if common.IsHexAddress(address) {
+			executors[chainID] = common.HexToAddress(address)
+		}
+	}
+	return executors
+}
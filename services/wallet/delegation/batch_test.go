@@ -0,0 +1,51 @@
+package delegation
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/params"
+)
+
+func TestPackBatchExecute(t *testing.T) {
+	calls := []Call{
+		{To: common.HexToAddress("0x01"), Data: []byte{0xaa}},
+		{To: common.HexToAddress("0x02"), Value: big.NewInt(10)},
+	}
+
+	data, err := PackBatchExecute(calls)
+	require.NoError(t, err)
+	require.Equal(t, parsedERC7821ABI.Methods["execute"].ID, data[:4])
+
+	args, err := parsedERC7821ABI.Methods["execute"].Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, batchExecutionMode, args[0].([32]byte))
+
+	unpacked, err := executionsArgs.Unpack(args[1].([]byte))
+	require.NoError(t, err)
+
+	executions := []execution{}
+	require.NoError(t, executionsArgs.Copy(&executions, unpacked))
+	require.Len(t, executions, 2)
+	require.Equal(t, calls[0].To, executions[0].Target)
+	require.Equal(t, int64(0), executions[0].Value.Int64())
+	require.Equal(t, []byte{0xaa}, executions[0].CallData)
+	require.Equal(t, calls[1].To, executions[1].Target)
+	require.Equal(t, int64(10), executions[1].Value.Int64())
+	require.Empty(t, executions[1].CallData)
+}
+
+func TestBatchExecutors(t *testing.T) {
+	executors := BatchExecutors(params.WalletConfig{
+		BatchExecutorAddresses: map[uint64]string{
+			1:  "0x63c0c19a282a1b52b07dd5a65b58948a07dae32b",
+			10: "invalid",
+		},
+	})
+	require.Len(t, executors, 1)
+	require.Equal(t, common.HexToAddress("0x63c0c19a282a1b52b07dd5a65b58948a07dae32b"), executors[1])
+}
@@ -0,0 +1,124 @@
+package delegation
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/multiaccounts/accounts"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/transactions"
+)
+
+var ErrNoBatchExecutor = errors.New("no batch executor configured for the chain")
+
+// State is the EIP-7702 delegation of an account on a chain
+type State struct {
+	ChainID         uint64         `json:"chainId"`
+	Address         common.Address `json:"address"`
+	Delegated       bool           `json:"delegated"`
+	Delegate        common.Address `json:"delegate"`
+	IsBatchExecutor bool           `json:"isBatchExecutor"`
+}
+
+// AuthorizationForSigning is an unsigned authorization and the hash the account signs
+type AuthorizationForSigning struct {
+	Authorization *wallettypes.SetCodeAuthorization `json:"authorization"`
+	HashToSign    common.Hash                       `json:"hashToSign"`
+}
+
+type Manager struct {
+	transactor transactions.TransactorIface
+	accountsDB *accounts.Database
+	executors  map[uint64]common.Address
+}
+
+func NewManager(transactor transactions.TransactorIface, accountsDB *accounts.Database, executors map[uint64]common.Address) *Manager {
+	return &Manager{
+		transactor: transactor,
+		accountsDB: accountsDB,
+		executors:  executors,
+	}
+}
+
+func (m *Manager) BatchExecutor(chainID uint64) (common.Address, bool) {
+	executor, ok := m.executors[chainID]
+	return executor, ok
+}
+
+func (m *Manager) GetState(chainID uint64, address common.Address) (*State, error) {
+	delegate, delegated, err := m.transactor.GetDelegation(chainID, address)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{
+		ChainID:   chainID,
+		Address:   address,
+		Delegated: delegated,
+		Delegate:  delegate,
+	}
+	if executor, ok := m.executors[chainID]; ok && delegated {
+		state.IsBatchExecutor = delegate == executor
+	}
+	return state, nil
+}
+
+// GetAccountsStates returns the delegation of the wallet accounts controlled by a local key, other accounts can't be
+// delegated
+func (m *Manager) GetAccountsStates(chainIDs []uint64) ([]*State, error) {
+	walletAccounts, err := m.accountsDB.GetActiveAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*State, 0)
+	for _, acc := range walletAccounts {
+		if acc.Chat || acc.IsKeypairless() {
+			continue
+		}
+		for _, chainID := range chainIDs {
+			state, err := m.GetState(chainID, common.Address(acc.Address))
+			if err != nil {
+				return nil, err
+			}
+			states = append(states, state)
+		}
+	}
+	return states, nil
+}
+
+// BuildBatchExecutorAuthorization returns the authorization delegating the account to the batch executor of the
+// chain, it's valid for the next transaction sent by the account
+func (m *Manager) BuildBatchExecutorAuthorization(chainID uint64, address common.Address) (*AuthorizationForSigning, error) {
+	executor, ok := m.executors[chainID]
+	if !ok {
+		return nil, ErrNoBatchExecutor
+	}
+
+	auth, err := m.transactor.BuildSetCodeAuthorization(chainID, address, executor, -1)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorizationForSigning{
+		Authorization: auth,
+		HashToSign:    auth.SigHash(),
+	}, nil
+}
+
+// SignBatchExecutorAuthorization returns the authorization delegating the account to the batch executor, signed by the
+// account key
+func (m *Manager) SignBatchExecutorAuthorization(chainID uint64, key *ecdsa.PrivateKey) (*wallettypes.SetCodeAuthorization, error) {
+	forSigning, err := m.BuildBatchExecutorAuthorization(chainID, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(forSigning.HashToSign.Bytes(), key)
+	if err != nil {
+		return nil, err
+	}
+	return forSigning.Authorization.WithSignature(sig)
+}
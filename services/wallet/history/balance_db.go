@@ -157,3 +157,14 @@ func (b *BalanceDB) removeBalanceHistory(address common.Address) error {
 	_, err := b.db.Exec("DELETE FROM balance_history WHERE address = ?", address)
 	return err
 }
+
+// removeBalanceHistoryAfter removes the entries of the blocks after block, e.g. the blocks dropped by a reorganization
+func (b *BalanceDB) removeBalanceHistoryAfter(chainID uint64, addresses []common.Address, block *big.Int) error {
+	for _, address := range addresses {
+		_, err := b.db.Exec("DELETE FROM balance_history WHERE chain_id = ? AND address = ? AND block > ?", chainID, address, (*bigint.SQLBigInt)(block))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
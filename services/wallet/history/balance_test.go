@@ -529,3 +529,34 @@ func TestBalance_addEdgePoints(t *testing.T) {
 		})
 	}
 }
+
+func TestBalance_removeBalanceHistoryAfter(t *testing.T) {
+	address := common.Address{1}
+	otherAddress := common.Address{2}
+	entries := []*entry{}
+	for _, a := range []common.Address{address, otherAddress} {
+		for block := int64(1); block <= 3; block++ {
+			entries = append(entries, &entry{
+				chainID:     1,
+				address:     a,
+				tokenSymbol: "ETH",
+				block:       big.NewInt(block),
+				timestamp:   block,
+				balance:     big.NewInt(block),
+			})
+		}
+	}
+	db := dbWithEntries(t, entries)
+
+	err := db.removeBalanceHistoryAfter(1, []common.Address{address}, big.NewInt(1))
+	require.NoError(t, err)
+
+	res, err := db.getNewerThan(&assetIdentity{1, []common.Address{address}, "ETH"}, 0)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, int64(1), res[0].block.Int64())
+
+	res, err = db.getNewerThan(&assetIdentity{1, []common.Address{otherAddress}, "ETH"}, 0)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+}
@@ -6,12 +6,18 @@ import (
 	"time"
 
 	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
 
 type tokenType = string
 type currencyType = string
 type yearType = int
 
+// contractRatesRetryInterval is the time before fetching again the rates of a contract unknown to the providers
+const contractRatesRetryInterval = time.Hour
+
+var errContractRatesUnavailable = errors.New("contract rates unavailable")
+
 type allTimeEntry struct {
 	value          float32
 	startTimestamp int64
@@ -25,14 +31,17 @@ type Exchange struct {
 	// special case for all time information
 	allTimeCache map[tokenType]map[currencyType][]allTimeEntry
 	fetchMutex   sync.Mutex
+	// time of the last failed fetch of the rates by contract
+	contractFailures map[tokenType]time.Time
 
 	marketManager *market.Manager
 }
 
 func NewExchange(marketManager *market.Manager) *Exchange {
 	return &Exchange{
-		cache:         make(map[tokenType]map[currencyType]map[yearType][]float32),
-		marketManager: marketManager,
+		cache:            make(map[tokenType]map[currencyType]map[yearType][]float32),
+		contractFailures: make(map[tokenType]time.Time),
+		marketManager:    marketManager,
 	}
 }
 
@@ -82,6 +91,43 @@ func (e *Exchange) GetExchangeRateForDay(token tokenType, currency currencyType,
 
 // fetchAndCacheRates fetches and in memory cache exchange rates for this and last year
 func (e *Exchange) FetchAndCacheMissingRates(token tokenType, currency currencyType) error {
+	return e.fetchAndCacheMissingRates(token, currency, func(limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+		return e.marketManager.FetchHistoricalDailyPrices(token, currency, limit, allData, aggregate)
+	})
+}
+
+// FetchAndCacheMissingRatesByContract fetches and in memory cache exchange rates for this and last year of the token
+// identified by its contract
+func (e *Exchange) FetchAndCacheMissingRatesByContract(contract thirdparty.ContractID, currency currencyType) error {
+	key := contract.HashKey()
+	e.fetchMutex.Lock()
+	failedAt, failed := e.contractFailures[key]
+	e.fetchMutex.Unlock()
+	if failed && time.Since(failedAt) < contractRatesRetryInterval {
+		return errContractRatesUnavailable
+	}
+
+	err := e.fetchAndCacheMissingRates(key, currency, func(limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+		return e.marketManager.FetchHistoricalDailyPricesByContract(contract, currency, limit, allData, aggregate)
+	})
+
+	e.fetchMutex.Lock()
+	defer e.fetchMutex.Unlock()
+	if err != nil {
+		e.contractFailures[key] = time.Now()
+	} else {
+		delete(e.contractFailures, key)
+	}
+	return err
+}
+
+// GetExchangeRateForDayByContract returns the exchange rate from the token identified by its contract to currency in
+// the day of the given date
+func (e *Exchange) GetExchangeRateForDayByContract(contract thirdparty.ContractID, currency currencyType, date time.Time) (float32, error) {
+	return e.GetExchangeRateForDay(contract.HashKey(), currency, date)
+}
+
+func (e *Exchange) fetchAndCacheMissingRates(token tokenType, currency currencyType, fetch func(limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error)) error {
 	// Protect REST calls also to prevent fetching the same token/currency twice
 	e.fetchMutex.Lock()
 	defer e.fetchMutex.Unlock()
@@ -110,8 +156,13 @@ func (e *Exchange) FetchAndCacheMissingRates(token tokenType, currency currencyT
 		return nil
 	}
 
-	res, err := e.marketManager.FetchHistoricalDailyPrices(token, currency, daysToFetch, false, 1)
+	res, err := fetch(daysToFetch, false, 1)
+	if err == nil && len(res) == 0 {
+		err = errors.New("no prices")
+	}
 	if err != nil {
+		// Drop the allocated days so they are fetched again instead of read as zero
+		delete(currencyMap, currency)
 		return err
 	}
 
@@ -125,7 +176,7 @@ func (e *Exchange) FetchAndCacheMissingRates(token tokenType, currency currencyT
 	}
 
 	// Fetch all time
-	allTime, err := e.marketManager.FetchHistoricalDailyPrices(token, currency, 1, true, 30)
+	allTime, err := fetch(1, true, 30)
 	if err != nil {
 		return err
 	}
@@ -174,3 +225,13 @@ func extendDaysSliceForYear(yearsMap map[yearType][]float32, untilTime time.Time
 	yearsMap[year] = append(yearsMap[year], make([]float32, missingDays)...)
 	return missingDays
 }
+
+// GetCurrentRateByContract returns the current exchange rate from the token identified by its contract to currency,
+// zero if the contract is unknown to the market providers
+func (e *Exchange) GetCurrentRateByContract(contract thirdparty.ContractID, currency currencyType) (float32, error) {
+	prices, err := e.marketManager.GetOrFetchPricesByContract([]thirdparty.ContractID{contract}, []string{currency}, market.MaxAgeInSecondsForBalances)
+	if err != nil {
+		return 0, err
+	}
+	return float32(prices[contract][currency].Price), nil
+}
@@ -0,0 +1,50 @@
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	mock_thirdparty "github.com/status-im/status-go/services/wallet/thirdparty/mock"
+)
+
+func TestExchangeRatesByContract(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	provider := mock_thirdparty.NewMockMarketDataProvider(ctrl)
+	provider.EXPECT().ID().Return("exchange-test-provider").AnyTimes()
+	exchange := NewExchange(market.NewManager([]thirdparty.MarketDataProvider{provider}, nil, &event.Feed{}))
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+	known := thirdparty.ContractID{ChainID: 1, Address: common.HexToAddress("0x01")}
+	unknown := thirdparty.ContractID{ChainID: 10, Address: common.HexToAddress("0x02")}
+
+	provider.EXPECT().FetchHistoricalDailyPricesByContract(known, "usd", gomock.Any(), false, 1).Return([]thirdparty.HistoricalPrice{
+		{Timestamp: yesterday.Unix(), Value: 0.99},
+		{Timestamp: today.Unix(), Value: 0.99},
+	}, nil)
+	provider.EXPECT().FetchHistoricalDailyPricesByContract(known, "usd", 1, true, 30).Return([]thirdparty.HistoricalPrice{}, nil)
+	// the unknown contract isn't fetched again before the retry interval
+	provider.EXPECT().FetchHistoricalDailyPricesByContract(unknown, "usd", gomock.Any(), false, 1).Return(nil, errors.New("contract not found")).Times(1)
+
+	require.NoError(t, exchange.FetchAndCacheMissingRatesByContract(known, "usd"))
+	rate, err := exchange.GetExchangeRateForDayByContract(known, "usd", yesterday)
+	require.NoError(t, err)
+	require.Equal(t, float32(0.99), rate)
+
+	// the rates are cached
+	require.NoError(t, exchange.FetchAndCacheMissingRatesByContract(known, "usd"))
+
+	require.Error(t, exchange.FetchAndCacheMissingRatesByContract(unknown, "usd"))
+	require.ErrorIs(t, exchange.FetchAndCacheMissingRatesByContract(unknown, "usd"), errContractRatesUnavailable)
+	_, err = exchange.GetExchangeRateForDayByContract(unknown, "usd", yesterday)
+	require.Error(t, err)
+}
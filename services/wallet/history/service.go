@@ -28,8 +28,11 @@ import (
 	gocommon "github.com/status-im/status-go/common"
 	"github.com/status-im/status-go/services/accounts/accountsevent"
 	"github.com/status-im/status-go/services/wallet/balance"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/token"
+	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
 	"github.com/status-im/status-go/services/wallet/transfer"
 	"github.com/status-im/status-go/services/wallet/walletevent"
 )
@@ -84,6 +87,11 @@ func NewService(db *sql.DB, accountsDB *accounts.Database, accountFeed *event.Fe
 	}
 }
 
+// Exchange returns the cache of daily exchange rates used for the balance history
+func (s *Service) Exchange() *Exchange {
+	return s.exchange
+}
+
 func (s *Service) Stop() {
 	if s.cancelFn != nil {
 		s.cancelFn()
@@ -301,8 +309,17 @@ func (s *Service) dataPointsToValuePoints(chainIDs []uint64, tokenSymbol string,
 		lastDayTime = lastDayTime.AddDate(0, 0, -1)
 	}
 
-	lastDayValue, err := s.exchange.GetExchangeRateForDay(tokenSymbol, currencySymbol, lastDayTime)
+	token, contracts, err := s.findTokenContracts(tokenSymbol, chainIDs)
 	if err != nil {
+		return nil, err
+	}
+	weisInOneMain := big.NewFloat(math.Pow(10, float64(token.Decimals)))
+
+	// The price by symbol is shared by the tokens with the same symbol, prefer the rates of the contract when known
+	rateContract, hasContractRates := s.contractWithRates(contracts, currencySymbol)
+
+	hasSymbolRates := true
+	if _, err := s.exchange.GetExchangeRateForDay(tokenSymbol, currencySymbol, lastDayTime); err != nil {
 		err := s.exchange.FetchAndCacheMissingRates(tokenSymbol, currencySymbol)
 		if err != nil {
 			logutils.ZapLogger().Error("Error fetching exchange rates",
@@ -310,26 +327,50 @@ func (s *Service) dataPointsToValuePoints(chainIDs []uint64, tokenSymbol string,
 				zap.String("currencySymbol", currencySymbol),
 				zap.Error(err),
 			)
-			return nil, err
+			if !hasContractRates {
+				return nil, err
+			}
+			hasSymbolRates = false
 		}
+	}
 
-		lastDayValue, err = s.exchange.GetExchangeRateForDay(tokenSymbol, currencySymbol, lastDayTime)
-		if err != nil {
-			logutils.ZapLogger().Error("Exchange rate missing for",
-				zap.String("tokenSymbol", tokenSymbol),
-				zap.String("currencySymbol", currencySymbol),
-				zap.Time("lastDayTime", lastDayTime),
-				zap.Error(err),
-			)
-			return nil, err
+	rateForDay := func(dayTime time.Time) (float32, error) {
+		if hasContractRates {
+			value, err := s.exchange.GetExchangeRateForDayByContract(rateContract, currencySymbol, dayTime)
+			if err == nil && value > 0 {
+				return value, nil
+			}
 		}
+		if !hasSymbolRates {
+			return 0, errors.New("missing rate")
+		}
+		return s.exchange.GetExchangeRateForDay(tokenSymbol, currencySymbol, dayTime)
 	}
 
-	decimals, err := s.decimalsForToken(tokenSymbol, chainIDs[0])
+	lastDayValue, err := rateForDay(lastDayTime)
 	if err != nil {
+		logutils.ZapLogger().Error("Exchange rate missing for",
+			zap.String("tokenSymbol", tokenSymbol),
+			zap.String("currencySymbol", currencySymbol),
+			zap.Time("lastDayTime", lastDayTime),
+			zap.Error(err),
+		)
 		return nil, err
 	}
-	weisInOneMain := big.NewFloat(math.Pow(10, float64(decimals)))
+
+	// Prefer the current price of the contract for today
+	if hasContractRates {
+		contracts = []thirdparty.ContractID{rateContract}
+	}
+	for _, contract := range contracts {
+		contractValue, err := s.exchange.GetCurrentRateByContract(contract, currencySymbol)
+		if err != nil {
+			logutils.ZapLogger().Warn("Error fetching price by contract", zap.Stringer("contract", contract.Address), zap.Error(err))
+		} else if contractValue > 0 {
+			lastDayValue = contractValue
+			break
+		}
+	}
 
 	var res []*ValuePoint
 	for _, d := range data {
@@ -347,7 +388,7 @@ func (s *Service) dataPointsToValuePoints(chainIDs []uint64, tokenSymbol string,
 				continue
 			}
 		} else {
-			dayValue, err = s.exchange.GetExchangeRateForDay(tokenSymbol, currencySymbol, dayTime)
+			dayValue, err = rateForDay(dayTime)
 			if err != nil {
 				logutils.ZapLogger().Warn(
 					"Exchange rate missing for",
@@ -368,16 +409,52 @@ func (s *Service) dataPointsToValuePoints(chainIDs []uint64, tokenSymbol string,
 	return res, nil
 }
 
-func (s *Service) decimalsForToken(tokenSymbol string, chainID uint64) (int, error) {
+// findTokenContracts returns the token with the symbol and its contracts on the chains it's listed on, in the order
+// of the chains
+func (s *Service) findTokenContracts(tokenSymbol string, chainIDs []uint64) (*tokenTypes.Token, []thirdparty.ContractID, error) {
+	var found *tokenTypes.Token
+	contracts := make([]thirdparty.ContractID, 0, len(chainIDs))
+	for _, chainID := range chainIDs {
+		token, err := s.findToken(tokenSymbol, chainID)
+		if err != nil {
+			continue
+		}
+		if found == nil {
+			found = token
+		}
+		if !token.IsNative() {
+			contracts = append(contracts, thirdparty.ContractID{ChainID: walletCommon.ChainID(token.ChainID), Address: token.Address})
+		}
+	}
+	if found == nil {
+		return nil, nil, errors.New("token not found")
+	}
+	return found, contracts, nil
+}
+
+// contractWithRates returns the first of the contracts with historical rates
+func (s *Service) contractWithRates(contracts []thirdparty.ContractID, currencySymbol string) (thirdparty.ContractID, bool) {
+	for _, contract := range contracts {
+		err := s.exchange.FetchAndCacheMissingRatesByContract(contract, currencySymbol)
+		if err != nil {
+			logutils.ZapLogger().Debug("Error fetching exchange rates by contract", zap.Stringer("contract", contract.Address), zap.Error(err))
+			continue
+		}
+		return contract, true
+	}
+	return thirdparty.ContractID{}, false
+}
+
+func (s *Service) findToken(tokenSymbol string, chainID uint64) (*tokenTypes.Token, error) {
 	network := s.networkManager.Find(chainID)
 	if network == nil {
-		return 0, errors.New("network not found")
+		return nil, errors.New("network not found")
 	}
 	token := s.tokenManager.FindToken(network, tokenSymbol)
 	if token == nil {
-		return 0, errors.New("token not found")
+		return nil, errors.New("token not found")
 	}
-	return int(token.Decimals), nil
+	return token, nil
 }
 
 func tokenToValue(tokenCount *big.Int, mainDenominationValue float32, weisInOneMain *big.Float) float64 {
@@ -611,7 +688,24 @@ func (s *Service) startTransfersWatcher() {
 		}
 	}
 
-	s.transferWatcher = NewWatcher(s.eventFeed, transferLoadedCb)
+	chainReorganizedCb := func(chainID uint64, addresses []common.Address, block *big.Int) {
+		logutils.ZapLogger().Debug("Balance history watcher: chain reorganized",
+			zap.Uint64("chainID", chainID),
+			zap.Stringers("addresses", addresses),
+			zap.Stringer("block", block),
+		)
+
+		// the entries of the new blocks are added again with their transfers
+		err := s.balance.db.removeBalanceHistoryAfter(chainID, addresses, block)
+		if err != nil {
+			logutils.ZapLogger().Error("Error removing reorganized balance history",
+				zap.Uint64("chainID", chainID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	s.transferWatcher = NewWatcher(s.eventFeed, transferLoadedCb, chainReorganizedCb)
 	s.transferWatcher.Start()
 }
 
@@ -16,17 +16,22 @@ import (
 
 type TransfersLoadedCb func(chainID uint64, addresses []common.Address, block *big.Int)
 
+// ChainReorganizedCb is called with the last block still in the chain after a reorganization
+type ChainReorganizedCb func(chainID uint64, addresses []common.Address, block *big.Int)
+
 // Watcher executes a given callback whenever an account gets added/removed
 type Watcher struct {
-	feed     *event.Feed
-	group    *async.Group
-	callback TransfersLoadedCb
+	feed          *event.Feed
+	group         *async.Group
+	callback      TransfersLoadedCb
+	reorgCallback ChainReorganizedCb
 }
 
-func NewWatcher(feed *event.Feed, callback TransfersLoadedCb) *Watcher {
+func NewWatcher(feed *event.Feed, callback TransfersLoadedCb, reorgCallback ChainReorganizedCb) *Watcher {
 	return &Watcher{
-		feed:     feed,
-		callback: callback,
+		feed:          feed,
+		callback:      callback,
+		reorgCallback: reorgCallback,
 	}
 }
 
@@ -37,7 +42,7 @@ func (w *Watcher) Start() {
 
 	w.group = async.NewGroup(context.Background())
 	w.group.Add(func(ctx context.Context) error {
-		return watch(ctx, w.feed, w.callback)
+		return watch(ctx, w.feed, w.callback, w.reorgCallback)
 	})
 }
 
@@ -55,7 +60,13 @@ func onTransfersLoaded(callback TransfersLoadedCb, chainID uint64, addresses []c
 	}
 }
 
-func watch(ctx context.Context, feed *event.Feed, callback TransfersLoadedCb) error {
+func onChainReorganized(callback ChainReorganizedCb, chainID uint64, addresses []common.Address, blockNum *big.Int) {
+	if callback != nil {
+		callback(chainID, addresses, blockNum)
+	}
+}
+
+func watch(ctx context.Context, feed *event.Feed, callback TransfersLoadedCb, reorgCallback ChainReorganizedCb) error {
 	ch := make(chan walletevent.Event, 100)
 	sub := feed.Subscribe(ch)
 	defer sub.Unsubscribe()
@@ -69,8 +80,11 @@ func watch(ctx context.Context, feed *event.Feed, callback TransfersLoadedCb) er
 				logutils.ZapLogger().Error("history: transfers watcher subscription failed", zap.Error(err))
 			}
 		case ev := <-ch:
-			if ev.Type == transfer.EventNewTransfers {
+			switch ev.Type {
+			case transfer.EventNewTransfers:
 				onTransfersLoaded(callback, ev.ChainID, ev.Accounts, ev.BlockNumber)
+			case transfer.EventChainReorganized:
+				onChainReorganized(reorgCallback, ev.ChainID, ev.Accounts, ev.BlockNumber)
 			}
 		}
 	}
@@ -2,6 +2,7 @@ package market
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"sync"
 	"time"
@@ -21,6 +22,8 @@ import (
 
 const (
 	EventMarketStatusChanged walletevent.EventType = "wallet-market-status-changed"
+	// EventMarketPricesUpdated contains the []string symbols whose price was refreshed in the cache
+	EventMarketPricesUpdated walletevent.EventType = "wallet-market-prices-updated"
 )
 
 const (
@@ -47,6 +50,7 @@ type Manager struct {
 	tokenManager    *token.Manager
 	feed            *event.Feed
 	priceCache      MarketCache[TokenPriceCache]
+	contractCache   MarketCache[ContractPriceCache]
 	marketCache     MarketCache[TokenMarketCache]
 	IsConnected     bool
 	LastCheckedAt   int64
@@ -67,6 +71,7 @@ func NewManager(providers []thirdparty.MarketDataProvider, tokenManager *token.M
 		tokenManager:   tokenManager,
 		feed:           feed,
 		priceCache:     *NewCache(make(TokenPriceCache)),
+		contractCache:  *NewCache(make(ContractPriceCache)),
 		marketCache:    *NewCache(make(TokenMarketCache)),
 		IsConnected:    true,
 		LastCheckedAt:  time.Now().Unix(),
@@ -94,12 +99,26 @@ func (pm *Manager) setIsConnected(value bool) {
 	pm.IsConnected = value
 }
 
-func (pm *Manager) makeCall(providers []thirdparty.MarketDataProvider, f func(provider thirdparty.MarketDataProvider) (interface{}, error)) (interface{}, error) {
+// makeCall tries the providers in order until one succeeds. Each method of a provider has its own circuit, the
+// failures of an endpoint, or an endpoint the provider doesn't support, don't block the other endpoints.
+func (pm *Manager) makeCall(method string, providers []thirdparty.MarketDataProvider, f func(provider thirdparty.MarketDataProvider) (interface{}, error)) (interface{}, error) {
+	result, err := pm.execute(method, providers, f)
+	pm.setIsConnected(err == nil)
+
+	if err != nil {
+		logutils.ZapLogger().Error("Error fetching prices", zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// execute is makeCall without the update of the connection status
+func (pm *Manager) execute(method string, providers []thirdparty.MarketDataProvider, f func(provider thirdparty.MarketDataProvider) (interface{}, error)) (interface{}, error) {
 	cmd := circuitbreaker.NewCommand(context.Background(), nil)
 	for _, provider := range providers {
 		provider := provider
-		// FIXME: we might want a different circuitName. See other uses of NewFunctor
-		circuitName := provider.ID()
+		circuitName := getCircuitName(provider, method)
 		cmd.Add(circuitbreaker.NewFunctor(func() ([]interface{}, error) {
 			result, err := f(provider)
 			return []interface{}{result}, err
@@ -107,15 +126,17 @@ func (pm *Manager) makeCall(providers []thirdparty.MarketDataProvider, f func(pr
 	}
 
 	result := pm.circuitbreaker.Execute(cmd)
-	pm.setIsConnected(result.Error() == nil)
-
 	if result.Error() != nil {
-		logutils.ZapLogger().Error("Error fetching prices", zap.Error(result.Error()))
 		return nil, result.Error()
 	}
 
 	return result.Result()[0], nil
 }
+
+func getCircuitName(provider thirdparty.MarketDataProvider, method string) string {
+	return provider.ID() + "_" + method
+}
+
 func (pm *Manager) symbolProviderSymbolMaps(symbols []string) (symbolsToProviderSymbols map[string]string, providerSymbolsToSymbols map[string][]string, err error) {
 	symbolsToProviderSymbols = make(map[string]string)
 	providerSymbolsToSymbols = make(map[string][]string)
@@ -149,7 +170,7 @@ func (pm *Manager) FetchHistoricalDailyPrices(symbol string, currency string, li
 		return nil, err
 	}
 
-	result, err := pm.makeCall(pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
+	result, err := pm.makeCall("FetchHistoricalDailyPrices", pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
 		return provider.FetchHistoricalDailyPrices(symbolsToProviderSymbols[symbol], currency, limit, allData, aggregate)
 	})
 
@@ -162,6 +183,22 @@ func (pm *Manager) FetchHistoricalDailyPrices(symbol string, currency string, li
 	return prices, nil
 }
 
+// FetchHistoricalDailyPricesByContract fetches the daily prices of the token identified by its contract, an error is
+// returned if no provider knows the contract
+func (pm *Manager) FetchHistoricalDailyPricesByContract(contract thirdparty.ContractID, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	result, err := pm.makeCall("FetchHistoricalDailyPricesByContract", pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
+		return provider.FetchHistoricalDailyPricesByContract(contract, currency, limit, allData, aggregate)
+	})
+
+	if err != nil {
+		logutils.ZapLogger().Error("Error fetching prices by contract", zap.Error(err))
+		return nil, err
+	}
+
+	prices := result.([]thirdparty.HistoricalPrice)
+	return prices, nil
+}
+
 func (pm *Manager) FetchHistoricalHourlyPrices(symbol string, currency string, limit int, aggregate int) ([]thirdparty.HistoricalPrice, error) {
 	symbolsToProviderSymbols, _, err := pm.symbolProviderSymbolMaps([]string{symbol})
 	if err != nil {
@@ -169,7 +206,7 @@ func (pm *Manager) FetchHistoricalHourlyPrices(symbol string, currency string, l
 		return nil, err
 	}
 
-	result, err := pm.makeCall(pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
+	result, err := pm.makeCall("FetchHistoricalHourlyPrices", pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
 		return provider.FetchHistoricalHourlyPrices(symbolsToProviderSymbols[symbol], currency, limit, aggregate)
 	})
 
@@ -189,7 +226,7 @@ func (pm *Manager) FetchTokenMarketValues(symbols []string, currency string) (ma
 		return nil, err
 	}
 
-	result, err := pm.makeCall(pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
+	result, err := pm.makeCall("FetchTokenMarketValues", pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
 		return provider.FetchTokenMarketValues(maps.Values(symbolsToProviderSymbols), currency)
 	})
 
@@ -291,7 +328,7 @@ func (pm *Manager) FetchTokenDetails(symbols []string) (map[string]thirdparty.To
 		return nil, err
 	}
 
-	result, err := pm.makeCall(pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
+	result, err := pm.makeCall("FetchTokenDetails", pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
 		return provider.FetchTokenDetails(maps.Values(symbolsToProviderSymbols))
 	})
 
@@ -331,7 +368,7 @@ func (pm *Manager) FetchPrices(symbols []string, currencies []string) (map[strin
 		return nil, err
 	}
 
-	response, err := pm.makeCall(pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
+	response, err := pm.makeCall("FetchPrices", pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
 		return provider.FetchPrices(maps.Values(symbolsToProviderSymbols), currencies)
 	})
 
@@ -350,10 +387,28 @@ func (pm *Manager) FetchPrices(symbols []string, currencies []string) (map[strin
 	}
 
 	pm.updatePriceCache(mappedPrices)
+	pm.notifyPricesUpdated(mappedPrices)
 
 	return mappedPrices, nil
 }
 
+func (pm *Manager) notifyPricesUpdated(prices map[string]map[string]float64) {
+	if len(prices) == 0 {
+		return
+	}
+	payload, err := json.Marshal(maps.Keys(prices))
+	if err != nil {
+		logutils.ZapLogger().Error("Error marshalling updated symbols", zap.Error(err))
+		return
+	}
+	pm.feed.Send(walletevent.Event{
+		Type:     EventMarketPricesUpdated,
+		Accounts: []common.Address{},
+		Message:  string(payload),
+		At:       time.Now().Unix(),
+	})
+}
+
 func (pm *Manager) getCachedPricesFor(symbols []string, currencies []string) DataPerTokenAndCurrency {
 	return Read(&pm.priceCache, func(tokenPriceCache TokenPriceCache) DataPerTokenAndCurrency {
 		prices := make(DataPerTokenAndCurrency)
@@ -428,3 +483,91 @@ func (pm *Manager) GetOrFetchPrices(symbols []string, currencies []string, maxAg
 
 	return prices, nil
 }
+
+// FetchPricesByContract fetches the prices of the tokens identified by their contract. The providers often know
+// only part of the contracts, e.g. the ones deployed on Ethereum, the contracts still missing a price are asked to
+// the next providers. The tokens unknown to all the providers are left out.
+func (pm *Manager) FetchPricesByContract(contracts []thirdparty.ContractID, currencies []string) (map[thirdparty.ContractID]map[string]float64, error) {
+	prices := make(map[thirdparty.ContractID]map[string]float64)
+	missing := contracts
+	providers := pm.providers
+	answered := false
+	var lastErr error
+	for len(missing) > 0 && len(providers) > 0 {
+		var answeredBy thirdparty.MarketDataProvider
+		response, err := pm.execute("FetchPricesByContract", providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
+			result, err := provider.FetchPricesByContract(missing, currencies)
+			if err == nil {
+				answeredBy = provider
+			}
+			return result, err
+		})
+		if err != nil {
+			// all the remaining providers failed
+			lastErr = err
+			break
+		}
+		answered = true
+
+		for contract, contractPrices := range response.(map[thirdparty.ContractID]map[string]float64) {
+			if hasAllPrices(contractPrices, currencies) {
+				prices[contract] = contractPrices
+			}
+		}
+		missing = missingContracts(missing, prices)
+		providers = providersAfter(providers, answeredBy)
+	}
+
+	pm.setIsConnected(answered)
+	if !answered {
+		logutils.ZapLogger().Error("Error fetching prices by contract", zap.Error(lastErr))
+		return nil, lastErr
+	}
+
+	updateContractPriceCache(&pm.contractCache, contracts, currencies, prices)
+
+	return prices, nil
+}
+
+func hasAllPrices(prices map[string]float64, currencies []string) bool {
+	for _, currency := range currencies {
+		if prices[currency] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func missingContracts(contracts []thirdparty.ContractID, prices map[thirdparty.ContractID]map[string]float64) []thirdparty.ContractID {
+	missing := make([]thirdparty.ContractID, 0, len(contracts))
+	for _, contract := range contracts {
+		if _, ok := prices[contract]; !ok {
+			missing = append(missing, contract)
+		}
+	}
+	return missing
+}
+
+// providersAfter returns the providers following the given one
+func providersAfter(providers []thirdparty.MarketDataProvider, provider thirdparty.MarketDataProvider) []thirdparty.MarketDataProvider {
+	for i, p := range providers {
+		if p == provider {
+			return providers[i+1:]
+		}
+	}
+	return nil
+}
+
+// GetOrFetchPricesByContract returns the cached prices of the contracts if younger than maxAgeInSeconds, fetches them
+// otherwise. The contracts without a price are left out, callers fall back to the prices by symbol for them.
+func (pm *Manager) GetOrFetchPricesByContract(contracts []thirdparty.ContractID, currencies []string, maxAgeInSeconds int64) (DataPerContractAndCurrency, error) {
+	toFetch := contractsToFetch(&pm.contractCache, contracts, currencies, maxAgeInSeconds)
+	if len(toFetch) > 0 {
+		_, err := pm.FetchPricesByContract(toFetch, currencies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cachedPricesByContract(&pm.contractCache, contracts, currencies), nil
+}
@@ -2,6 +2,9 @@ package market
 
 import (
 	"sync"
+	"time"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
 
 type MarketCache[T any] struct {
@@ -33,3 +36,74 @@ func (cache *MarketCache[T]) Get() T {
 	defer cache.lock.RUnlock()
 	return cache.store
 }
+
+// DataPerContractAndCurrency holds the prices of the tokens identified by their contract, the tokens sharing a symbol
+// (bridged variants, community tokens) have their own price
+type DataPerContractAndCurrency = map[thirdparty.ContractID]map[string]DataPoint
+type ContractPriceCache DataPerContractAndCurrency
+
+// contractsToFetch returns the contracts without a price for one of the currencies or with a price older than
+// maxAgeInSeconds
+func contractsToFetch(cache *MarketCache[ContractPriceCache], contracts []thirdparty.ContractID, currencies []string, maxAgeInSeconds int64) []thirdparty.ContractID {
+	return Read(cache, func(contractPriceCache ContractPriceCache) []thirdparty.ContractID {
+		toFetchMap := make(map[thirdparty.ContractID]bool)
+		toFetch := make([]thirdparty.ContractID, 0, len(contracts))
+
+		now := time.Now().Unix()
+		for _, contract := range contracts {
+			if toFetchMap[contract] {
+				continue
+			}
+			prices, ok := contractPriceCache[contract]
+			for _, currency := range currencies {
+				dataPoint, found := prices[currency]
+				if !ok || !found || now-dataPoint.UpdatedAt > maxAgeInSeconds {
+					toFetchMap[contract] = true
+					toFetch = append(toFetch, contract)
+					break
+				}
+			}
+		}
+		return toFetch
+	})
+}
+
+// updateContractPriceCache stores the fetched prices, the contracts requested but unknown to the providers are stored
+// with a zero price to not request them again before maxAgeInSeconds
+func updateContractPriceCache(cache *MarketCache[ContractPriceCache], requested []thirdparty.ContractID, currencies []string, prices map[thirdparty.ContractID]map[string]float64) {
+	Write(cache, func(contractPriceCache ContractPriceCache) ContractPriceCache {
+		now := time.Now().Unix()
+		for _, contract := range requested {
+			if _, present := contractPriceCache[contract]; !present {
+				contractPriceCache[contract] = make(map[string]DataPoint)
+			}
+			for _, currency := range currencies {
+				contractPriceCache[contract][currency] = DataPoint{
+					Price:     prices[contract][currency],
+					UpdatedAt: now,
+				}
+			}
+		}
+		return contractPriceCache
+	})
+}
+
+// cachedPricesByContract returns the cached prices of the contracts, the contracts without a price are left out
+func cachedPricesByContract(cache *MarketCache[ContractPriceCache], contracts []thirdparty.ContractID, currencies []string) DataPerContractAndCurrency {
+	return Read(cache, func(contractPriceCache ContractPriceCache) DataPerContractAndCurrency {
+		prices := make(DataPerContractAndCurrency)
+		for _, contract := range contracts {
+			for _, currency := range currencies {
+				dataPoint, ok := contractPriceCache[contract][currency]
+				if !ok || dataPoint.Price == 0 {
+					continue
+				}
+				if _, present := prices[contract]; !present {
+					prices[contract] = make(map[string]DataPoint)
+				}
+				prices[contract][currency] = dataPoint
+			}
+		}
+		return prices
+	})
+}
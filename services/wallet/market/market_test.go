@@ -7,12 +7,15 @@ import (
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/status-im/status-go/appdatabase"
+	"github.com/status-im/status-go/circuitbreaker"
 	"github.com/status-im/status-go/rpc/network"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	mock_market "github.com/status-im/status-go/services/wallet/market/mock"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	mock_thirdparty "github.com/status-im/status-go/services/wallet/thirdparty/mock"
@@ -329,3 +332,115 @@ func TestSymbolsMapping(t *testing.T) {
 	require.Equal(t, expectedSymbolsToProviderSymbols, symbolsToProviderSymbols)
 	require.Equal(t, expectedProviderSymbolsToSymbols, providerSymbolsToSymbols)
 }
+
+func TestGetOrFetchPricesByContract(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mock_thirdparty.NewMockMarketDataProvider(ctrl)
+	provider.EXPECT().ID().Return("MockMarketProvider").AnyTimes()
+	manager, close := setupMarketManager(t, []thirdparty.MarketDataProvider{provider}, &event.Feed{})
+	t.Cleanup(close)
+
+	known := thirdparty.ContractID{ChainID: walletCommon.ChainID(walletCommon.EthereumMainnet), Address: common.HexToAddress("0x1")}
+	bridged := thirdparty.ContractID{ChainID: walletCommon.ChainID(walletCommon.OptimismMainnet), Address: common.HexToAddress("0x1")}
+	unknown := thirdparty.ContractID{ChainID: walletCommon.ChainID(walletCommon.EthereumMainnet), Address: common.HexToAddress("0x2")}
+	contracts := []thirdparty.ContractID{known, bridged, unknown}
+	currencies := []string{"USD"}
+
+	provider.EXPECT().FetchPricesByContract(contracts, currencies).Return(map[thirdparty.ContractID]map[string]float64{
+		known:   {"USD": 1.5},
+		bridged: {"USD": 1.4},
+	}, nil).Times(1)
+
+	prices, err := manager.GetOrFetchPricesByContract(contracts, currencies, MaxAgeInSecondsForBalances)
+	require.NoError(t, err)
+	require.Equal(t, 1.5, prices[known]["USD"].Price)
+	require.Equal(t, 1.4, prices[bridged]["USD"].Price)
+	require.NotContains(t, prices, unknown)
+
+	// the unknown contract is cached too and not fetched again
+	prices, err = manager.GetOrFetchPricesByContract(contracts, currencies, MaxAgeInSecondsForBalances)
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+}
+
+func TestFetchPricesByContractAsksNextProvidersForMissingContracts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mainnetProvider := mock_thirdparty.NewMockMarketDataProvider(ctrl)
+	mainnetProvider.EXPECT().ID().Return("mainnet-provider").AnyTimes()
+	contractsProvider := mock_thirdparty.NewMockMarketDataProvider(ctrl)
+	contractsProvider.EXPECT().ID().Return("contracts-provider").AnyTimes()
+	manager, close := setupMarketManager(t, []thirdparty.MarketDataProvider{mainnetProvider, contractsProvider}, &event.Feed{})
+	t.Cleanup(close)
+
+	mainnet := thirdparty.ContractID{ChainID: walletCommon.ChainID(walletCommon.EthereumMainnet), Address: common.HexToAddress("0x1")}
+	bridged := thirdparty.ContractID{ChainID: walletCommon.ChainID(walletCommon.OptimismMainnet), Address: common.HexToAddress("0x3")}
+	currencies := []string{"USD"}
+
+	// the first provider knows only the mainnet contracts, the bridged one is asked to the next provider
+	mainnetProvider.EXPECT().FetchPricesByContract([]thirdparty.ContractID{mainnet, bridged}, currencies).Return(map[thirdparty.ContractID]map[string]float64{
+		mainnet: {"USD": 1.5},
+	}, nil).Times(1)
+	contractsProvider.EXPECT().FetchPricesByContract([]thirdparty.ContractID{bridged}, currencies).Return(map[thirdparty.ContractID]map[string]float64{
+		bridged: {"USD": 1.4},
+	}, nil).Times(1)
+
+	prices, err := manager.GetOrFetchPricesByContract([]thirdparty.ContractID{mainnet, bridged}, currencies, MaxAgeInSecondsForBalances)
+	require.NoError(t, err)
+	require.Equal(t, 1.5, prices[mainnet]["USD"].Price)
+	require.Equal(t, 1.4, prices[bridged]["USD"].Price)
+
+	// the first provider doesn't support any of the contracts
+	mainnetProvider.EXPECT().FetchPricesByContract([]thirdparty.ContractID{bridged}, currencies).Return(nil, thirdparty.ErrEndpointNotSupported).Times(1)
+	contractsProvider.EXPECT().FetchPricesByContract([]thirdparty.ContractID{bridged}, currencies).Return(map[thirdparty.ContractID]map[string]float64{
+		bridged: {"USD": 1.3},
+	}, nil).Times(1)
+
+	fetched, err := manager.FetchPricesByContract([]thirdparty.ContractID{bridged}, currencies)
+	require.NoError(t, err)
+	require.Equal(t, 1.3, fetched[bridged]["USD"])
+
+	// the prices found before a provider fails are kept
+	mainnetProvider.EXPECT().FetchPricesByContract([]thirdparty.ContractID{mainnet, bridged}, currencies).Return(map[thirdparty.ContractID]map[string]float64{
+		mainnet: {"USD": 1.6},
+	}, nil).Times(1)
+	contractsProvider.EXPECT().FetchPricesByContract([]thirdparty.ContractID{bridged}, currencies).Return(nil, errors.New("rate limited")).Times(1)
+
+	fetched, err = manager.FetchPricesByContract([]thirdparty.ContractID{mainnet, bridged}, currencies)
+	require.NoError(t, err)
+	require.Equal(t, map[thirdparty.ContractID]map[string]float64{mainnet: {"USD": 1.6}}, fetched)
+}
+
+func TestUnsupportedEndpointDoesNotBlockProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	contract := thirdparty.ContractID{ChainID: walletCommon.ChainID(walletCommon.EthereumMainnet), Address: common.HexToAddress("0x1")}
+	currencies := []string{"USD"}
+
+	partialProvider := mock_thirdparty.NewMockMarketDataProvider(ctrl)
+	partialProvider.EXPECT().ID().Return("partial-provider").AnyTimes()
+	partialProvider.EXPECT().FetchHistoricalDailyPricesByContract(contract, "USD", 30, false, 1).Return(nil, thirdparty.ErrEndpointNotSupported).AnyTimes()
+	fallbackProvider := mock_thirdparty.NewMockMarketDataProvider(ctrl)
+	fallbackProvider.EXPECT().ID().Return("fallback-provider").AnyTimes()
+	fallbackProvider.EXPECT().FetchHistoricalDailyPricesByContract(contract, "USD", 30, false, 1).Return([]thirdparty.HistoricalPrice{{Value: 1}}, nil).AnyTimes()
+
+	manager := NewManager([]thirdparty.MarketDataProvider{partialProvider, fallbackProvider}, nil, &event.Feed{})
+
+	// enough failures to open the circuit of the unsupported endpoint
+	for i := 0; i < 30; i++ {
+		_, err := manager.FetchHistoricalDailyPricesByContract(contract, "USD", 30, false, 1)
+		require.NoError(t, err)
+	}
+	require.True(t, circuitbreaker.IsCircuitOpen(getCircuitName(partialProvider, "FetchHistoricalDailyPricesByContract")))
+
+	// the other endpoints of the provider are still used
+	partialProvider.EXPECT().FetchPricesByContract([]thirdparty.ContractID{contract}, currencies).Return(
+		map[thirdparty.ContractID]map[string]float64{contract: {"USD": 2}}, nil)
+	prices, err := manager.FetchPricesByContract([]thirdparty.ContractID{contract}, currencies)
+	require.NoError(t, err)
+	require.Equal(t, 2.0, prices[contract]["USD"])
+}
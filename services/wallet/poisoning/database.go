@@ -0,0 +1,61 @@
+package poisoning
+
+import (
+	"database/sql"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// zeroAmountPadded128Hex is the amount of zero value transfers in the transfers table
+const zeroAmountPadded128Hex = "00000000000000000000000000000000"
+
+type Database struct {
+	db *sql.DB
+}
+
+func NewDB(db *sql.DB) *Database {
+	return &Database{db: db}
+}
+
+// GetCounterparties returns the addresses the accounts sent value to or received value from since the timestamp,
+// zero value transfers are left out as anyone can make them appear in the history of an account
+func (db *Database) GetCounterparties(since int64) (map[common.Address]bool, error) {
+	rows, err := db.db.Query(`SELECT DISTINCT CASE WHEN tx_from_address = address THEN tx_to_address ELSE tx_from_address END
+		FROM transfers
+		WHERE timestamp >= ? AND amount_padded128hex != ? AND tx_from_address IS NOT NULL AND tx_to_address IS NOT NULL`,
+		since, zeroAmountPadded128Hex)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counterparties := make(map[common.Address]bool)
+	for rows.Next() {
+		var address common.Address
+		if err = rows.Scan(&address); err != nil {
+			return nil, err
+		}
+		counterparties[address] = true
+	}
+	return counterparties, rows.Err()
+}
+
+// GetSavedAddressNames returns the names of the saved addresses not removed
+func (db *Database) GetSavedAddressNames() (map[common.Address]string, error) {
+	rows, err := db.db.Query(`SELECT address, name FROM saved_addresses WHERE removed != 1`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[common.Address]string)
+	for rows.Next() {
+		var address common.Address
+		var name string
+		if err = rows.Scan(&address, &name); err != nil {
+			return nil, err
+		}
+		names[address] = name
+	}
+	return names, rows.Err()
+}
@@ -0,0 +1,79 @@
+package poisoning
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// counterpartiesMaxAge is how far back the counterparties are looked for, the attacks target the recent ones
+const counterpartiesMaxAge = 90 * 24 * time.Hour
+
+// Detector finds the addresses impersonating the recent counterparties of the accounts and the saved addresses
+type Detector struct {
+	db *Database
+}
+
+func NewDetector(walletDB *sql.DB) *Detector {
+	return &Detector{db: NewDB(walletDB)}
+}
+
+// KnownAddresses are the addresses the user already trusts
+type KnownAddresses struct {
+	counterparties map[common.Address]bool
+	savedAddresses map[common.Address]string
+}
+
+func (d *Detector) KnownAddresses() (*KnownAddresses, error) {
+	counterparties, err := d.db.GetCounterparties(time.Now().Add(-counterpartiesMaxAge).Unix())
+	if err != nil {
+		return nil, err
+	}
+	savedAddresses, err := d.db.GetSavedAddressNames()
+	if err != nil {
+		return nil, err
+	}
+	return &KnownAddresses{counterparties: counterparties, savedAddresses: savedAddresses}, nil
+}
+
+// CheckRecipient returns the warnings for the recipient of a transaction
+func (d *Detector) CheckRecipient(recipient common.Address) ([]*Warning, error) {
+	known, err := d.KnownAddresses()
+	if err != nil {
+		return nil, err
+	}
+	return known.Check(recipient), nil
+}
+
+// Check returns a warning for each known address the address looks like, none when the address is a saved address
+func (k *KnownAddresses) Check(address common.Address) []*Warning {
+	warnings := make([]*Warning, 0)
+	if _, ok := k.savedAddresses[address]; ok {
+		return warnings
+	}
+
+	for savedAddress, name := range k.savedAddresses {
+		if isLookalike(address, savedAddress) {
+			warnings = append(warnings, &Warning{
+				Address:     address,
+				LookalikeOf: savedAddress,
+				Source:      SourceSavedAddress,
+				Name:        name,
+			})
+		}
+	}
+	for counterparty := range k.counterparties {
+		if _, ok := k.savedAddresses[counterparty]; ok {
+			continue
+		}
+		if isLookalike(address, counterparty) {
+			warnings = append(warnings, &Warning{
+				Address:     address,
+				LookalikeOf: counterparty,
+				Source:      SourceCounterparty,
+			})
+		}
+	}
+	return warnings
+}
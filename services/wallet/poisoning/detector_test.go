@@ -0,0 +1,81 @@
+package poisoning
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+var (
+	account      = common.HexToAddress("0x00000000000000000000000000000000000000ac")
+	counterparty = common.HexToAddress("0x1a2b000000000000000000000000000000003c4d")
+	savedAddress = common.HexToAddress("0x5e6f000000000000000000000000000000007a8b")
+)
+
+func setupTestDB(t *testing.T) *sql.DB {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	return db
+}
+
+func insertTransfer(t *testing.T, db *sql.DB, hash int64, from common.Address, to common.Address, value int64, timestamp int64) {
+	_, err := db.Exec(`INSERT OR IGNORE INTO blocks (network_id, address, blk_number, blk_hash) VALUES (1, ?, 1, ?)`,
+		account, common.Hash{})
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO transfers (network_id, hash, address, blk_hash, type, blk_number, timestamp, amount_padded128hex,
+		tx_from_address, tx_to_address) VALUES (1, ?, ?, ?, 'eth', 1, ?, ?, ?, ?)`,
+		common.BigToHash(big.NewInt(hash)), account, common.Hash{}, timestamp, fmt.Sprintf("%032x", value), from, to)
+	require.NoError(t, err)
+}
+
+func TestIsLookalike(t *testing.T) {
+	require.True(t, isLookalike(common.HexToAddress("0x1A2Bffffffffffffffffffffffffffffffff3C4D"), counterparty))
+	require.False(t, isLookalike(counterparty, counterparty))
+	require.False(t, isLookalike(common.HexToAddress("0x1a2cffffffffffffffffffffffffffffffff3c4d"), counterparty))
+	require.False(t, isLookalike(common.HexToAddress("0x1a2bffffffffffffffffffffffffffffffff3c4e"), counterparty))
+}
+
+func TestCheckRecipient(t *testing.T) {
+	db := setupTestDB(t)
+	detector := NewDetector(db)
+
+	now := time.Now().Unix()
+	insertTransfer(t, db, 1, account, counterparty, 100, now)
+	// zero value transfers and old transfers don't make counterparties
+	insertTransfer(t, db, 2, common.HexToAddress("0x9999000000000000000000000000000000009999"), account, 0, now)
+	insertTransfer(t, db, 3, common.HexToAddress("0x8888000000000000000000000000000000008888"), account, 100, now-int64(counterpartiesMaxAge.Seconds())-1)
+
+	_, err := db.Exec(`INSERT INTO saved_addresses (address, name) VALUES (?, 'Alice')`, savedAddress)
+	require.NoError(t, err)
+
+	lookalikeCounterparty := common.HexToAddress("0x1a2bffffffffffffffffffffffffffffffff3c4d")
+	warnings, err := detector.CheckRecipient(lookalikeCounterparty)
+	require.NoError(t, err)
+	require.Equal(t, []*Warning{{Address: lookalikeCounterparty, LookalikeOf: counterparty, Source: SourceCounterparty}}, warnings)
+
+	lookalikeSavedAddress := common.HexToAddress("0x5e6fffffffffffffffffffffffffffffffff7a8b")
+	warnings, err = detector.CheckRecipient(lookalikeSavedAddress)
+	require.NoError(t, err)
+	require.Equal(t, []*Warning{{Address: lookalikeSavedAddress, LookalikeOf: savedAddress, Source: SourceSavedAddress, Name: "Alice"}}, warnings)
+
+	for _, address := range []common.Address{
+		counterparty,
+		savedAddress,
+		common.HexToAddress("0x9999ffffffffffffffffffffffffffffffff9999"),
+		common.HexToAddress("0x8888ffffffffffffffffffffffffffffffff8888"),
+	} {
+		warnings, err = detector.CheckRecipient(address)
+		require.NoError(t, err)
+		require.Empty(t, warnings, address.Hex())
+	}
+}
@@ -0,0 +1,24 @@
+package poisoning
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// lookalikePrefixLength and lookalikeSuffixLength are the number of hex characters compared at both ends of the
+	// addresses, the wallets truncate the addresses to a few characters on each side
+	lookalikePrefixLength = 4
+	lookalikeSuffixLength = 4
+)
+
+// isLookalike returns true when the addresses are different but share the characters the user checks
+func isLookalike(address common.Address, known common.Address) bool {
+	if address == known {
+		return false
+	}
+
+	a := common.Bytes2Hex(address.Bytes())
+	b := common.Bytes2Hex(known.Bytes())
+	return a[:lookalikePrefixLength] == b[:lookalikePrefixLength] &&
+		a[len(a)-lookalikeSuffixLength:] == b[len(b)-lookalikeSuffixLength:]
+}
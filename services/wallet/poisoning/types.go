@@ -0,0 +1,25 @@
+package poisoning
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Source string
+
+const (
+	// SourceCounterparty is set when the impersonated address is a recent counterparty of the accounts
+	SourceCounterparty Source = "counterparty"
+	// SourceSavedAddress is set when the impersonated address is a saved address
+	SourceSavedAddress Source = "savedAddress"
+)
+
+// Warning is raised for an address looking like an address known by the user without being it, attackers generate
+// vanity addresses sharing the first and last characters of the real ones and send zero value transfers from them
+// so that the user copies the wrong address from the activity
+type Warning struct {
+	Address     common.Address `json:"address"`
+	LookalikeOf common.Address `json:"lookalikeOf"`
+	Source      Source         `json:"source"`
+	// Name is the name of the saved address, set for SourceSavedAddress
+	Name string `json:"name,omitempty"`
+}
@@ -0,0 +1,101 @@
+package pricealerts
+
+import (
+	"database/sql"
+)
+
+type Database struct {
+	db *sql.DB
+}
+
+func NewDB(db *sql.DB) *Database {
+	return &Database{db: db}
+}
+
+const selectAlerts = `SELECT id, symbol, currency, type, threshold, cooldown, enabled, condition_met, last_triggered_at,
+	created_at FROM price_alerts`
+
+func (db *Database) AddAlert(alert *Alert) (int64, error) {
+	res, err := db.db.Exec(`INSERT INTO price_alerts (symbol, currency, type, threshold, cooldown, enabled, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, alert.Symbol, alert.Currency, alert.Type, alert.Threshold, alert.Cooldown,
+		alert.Enabled, alert.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// UpdateAlert updates the rule of the alert, the evaluation state is reset as the condition changed
+func (db *Database) UpdateAlert(alert *Alert) error {
+	res, err := db.db.Exec(`UPDATE price_alerts SET symbol = ?, currency = ?, type = ?, threshold = ?, cooldown = ?,
+		enabled = ?, condition_met = FALSE WHERE id = ?`, alert.Symbol, alert.Currency, alert.Type, alert.Threshold,
+		alert.Cooldown, alert.Enabled, alert.ID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res)
+}
+
+func (db *Database) UpdateState(alert *Alert) error {
+	_, err := db.db.Exec(`UPDATE price_alerts SET condition_met = ?, last_triggered_at = ? WHERE id = ?`,
+		alert.ConditionMet, alert.LastTriggeredAt, alert.ID)
+	return err
+}
+
+func (db *Database) DeleteAlert(id int64) error {
+	res, err := db.db.Exec(`DELETE FROM price_alerts WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res)
+}
+
+func (db *Database) GetAlert(id int64) (*Alert, error) {
+	alerts, err := db.queryAlerts(selectAlerts+` WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) == 0 {
+		return nil, ErrAlertNotFound
+	}
+	return alerts[0], nil
+}
+
+func (db *Database) GetAlerts() ([]*Alert, error) {
+	return db.queryAlerts(selectAlerts + ` ORDER BY id`)
+}
+
+func (db *Database) GetEnabledAlerts() ([]*Alert, error) {
+	return db.queryAlerts(selectAlerts + ` WHERE enabled ORDER BY id`)
+}
+
+func (db *Database) queryAlerts(query string, args ...interface{}) ([]*Alert, error) {
+	rows, err := db.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerts := make([]*Alert, 0)
+	for rows.Next() {
+		alert := &Alert{}
+		err = rows.Scan(&alert.ID, &alert.Symbol, &alert.Currency, &alert.Type, &alert.Threshold, &alert.Cooldown,
+			&alert.Enabled, &alert.ConditionMet, &alert.LastTriggeredAt, &alert.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+func checkAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAlertNotFound
+	}
+	return nil
+}
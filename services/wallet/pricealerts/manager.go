@@ -0,0 +1,277 @@
+package pricealerts
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/logutils"
+	localnotifications "github.com/status-im/status-go/services/local-notifications"
+	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	// Contains a []*TriggeredAlert payload
+	EventPriceAlertsTriggered walletevent.EventType = "wallet-price-alerts-triggered"
+
+	// evaluationInterval is how often the alerts are evaluated when no price refresh of the market manager happened,
+	// the market data older than the interval is fetched again
+	evaluationInterval = 5 * time.Minute
+	marketDataMaxAge   = int64(evaluationInterval / time.Second)
+)
+
+type MarketProvider interface {
+	GetOrFetchPrices(symbols []string, currencies []string, maxAgeInSeconds int64) (market.DataPerTokenAndCurrency, error)
+	GetOrFetchTokenMarketValues(symbols []string, currency string, maxAgeInSeconds int64) (map[string]thirdparty.TokenMarketValues, error)
+}
+
+// Manager keeps the price alerts of the user and notifies them when their condition starts holding
+type Manager struct {
+	db                *Database
+	marketProvider    MarketProvider
+	walletFeed        *event.Feed
+	pushNotifications func(notifications []*localnotifications.Notification)
+	evaluateMutex     sync.Mutex
+}
+
+func NewManager(walletDB *sql.DB, marketProvider MarketProvider, walletFeed *event.Feed) *Manager {
+	return &Manager{
+		db:                NewDB(walletDB),
+		marketProvider:    marketProvider,
+		walletFeed:        walletFeed,
+		pushNotifications: localnotifications.PushMessages,
+	}
+}
+
+// Start evaluates the alerts with the cached market data each time the market manager refreshes the prices, and
+// periodically in case no refresh happens, until the context is done
+func (m *Manager) Start(ctx context.Context) {
+	pricesUpdated := make(chan struct{}, 1)
+
+	// The events are only forwarded, the evaluation may refresh the prices and send an event itself
+	events := make(chan walletevent.Event, 10)
+	sub := m.walletFeed.Subscribe(events)
+	go func() {
+		defer gocommon.LogOnPanic()
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-sub.Err():
+				if err != nil {
+					logutils.ZapLogger().Error("price alerts subscription failed", zap.Error(err))
+				}
+				return
+			case e := <-events:
+				if e.Type != market.EventMarketPricesUpdated {
+					continue
+				}
+				select {
+				case pricesUpdated <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer gocommon.LogOnPanic()
+		ticker := time.NewTicker(evaluationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-pricesUpdated:
+			}
+			if _, err := m.Evaluate(); err != nil {
+				logutils.ZapLogger().Error("failed to evaluate price alerts", zap.Error(err))
+			}
+		}
+	}()
+}
+
+func (m *Manager) AddAlert(alert *Alert) (*Alert, error) {
+	if err := alert.Validate(); err != nil {
+		return nil, err
+	}
+
+	alert.ConditionMet = false
+	alert.LastTriggeredAt = 0
+	alert.CreatedAt = time.Now().Unix()
+	id, err := m.db.AddAlert(alert)
+	if err != nil {
+		return nil, err
+	}
+	alert.ID = id
+	return alert, nil
+}
+
+func (m *Manager) UpdateAlert(alert *Alert) error {
+	if err := alert.Validate(); err != nil {
+		return err
+	}
+	return m.db.UpdateAlert(alert)
+}
+
+func (m *Manager) DeleteAlert(id int64) error {
+	return m.db.DeleteAlert(id)
+}
+
+func (m *Manager) GetAlerts() ([]*Alert, error) {
+	return m.db.GetAlerts()
+}
+
+// Evaluate notifies the enabled alerts whose condition started holding, the cached market data is used unless older
+// than the evaluation interval. An alert is notified once per crossing and not more often than its cooldown, a
+// crossing during the cooldown is notified once the cooldown is over if the condition still holds.
+func (m *Manager) Evaluate() ([]*TriggeredAlert, error) {
+	m.evaluateMutex.Lock()
+	defer m.evaluateMutex.Unlock()
+
+	alerts, err := m.db.GetEnabledAlerts()
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	symbols := make([]string, 0)
+	currencies := make([]string, 0)
+	percentSymbolsPerCurrency := make(map[string][]string)
+	for _, alert := range alerts {
+		symbols = appendUnique(symbols, alert.Symbol)
+		currencies = appendUnique(currencies, alert.Currency)
+		if alert.Type == AlertTypePercentChange {
+			percentSymbolsPerCurrency[alert.Currency] = appendUnique(percentSymbolsPerCurrency[alert.Currency], alert.Symbol)
+		}
+	}
+
+	prices, err := m.marketProvider.GetOrFetchPrices(symbols, currencies, marketDataMaxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	marketValuesPerCurrency := make(map[string]map[string]thirdparty.TokenMarketValues)
+	for currency, percentSymbols := range percentSymbolsPerCurrency {
+		marketValues, err := m.marketProvider.GetOrFetchTokenMarketValues(percentSymbols, currency, marketDataMaxAge)
+		if err != nil {
+			// the threshold alerts are still evaluated
+			logutils.ZapLogger().Warn("failed to fetch market values for price alerts", zap.String("currency", currency), zap.Error(err))
+			continue
+		}
+		marketValuesPerCurrency[currency] = marketValues
+	}
+
+	now := time.Now().Unix()
+	triggered := make([]*TriggeredAlert, 0)
+	for _, alert := range alerts {
+		price := prices[alert.Symbol][alert.Currency].Price
+		if price == 0 {
+			// unknown price, the state is kept until the next evaluation
+			continue
+		}
+
+		var conditionMet bool
+		var changePct24h float64
+		switch alert.Type {
+		case AlertTypeAbove:
+			conditionMet = price >= alert.Threshold
+		case AlertTypeBelow:
+			conditionMet = price <= alert.Threshold
+		case AlertTypePercentChange:
+			marketValues, ok := marketValuesPerCurrency[alert.Currency][alert.Symbol]
+			if !ok {
+				continue
+			}
+			changePct24h = marketValues.CHANGEPCT24HOUR
+			conditionMet = math.Abs(changePct24h) >= alert.Threshold
+		}
+
+		if conditionMet && !alert.ConditionMet && now-alert.LastTriggeredAt < alert.Cooldown {
+			// the crossing stays pending until the cooldown is over
+			continue
+		}
+
+		stateChanged := conditionMet != alert.ConditionMet
+		if conditionMet && !alert.ConditionMet {
+			alert.LastTriggeredAt = now
+			triggered = append(triggered, &TriggeredAlert{
+				Alert:        alert,
+				Price:        price,
+				ChangePct24h: changePct24h,
+				TriggeredAt:  now,
+			})
+		}
+		alert.ConditionMet = conditionMet
+
+		if stateChanged {
+			if err := m.db.UpdateState(alert); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(triggered) > 0 {
+		m.notify(triggered)
+	}
+	return triggered, nil
+}
+
+func (m *Manager) notify(triggered []*TriggeredAlert) {
+	payload, err := json.Marshal(triggered)
+	if err != nil {
+		logutils.ZapLogger().Error("failed to marshal triggered price alerts", zap.Error(err))
+	} else {
+		m.walletFeed.Send(walletevent.Event{
+			Type:    EventPriceAlertsTriggered,
+			Message: string(payload),
+		})
+	}
+
+	notifications := make([]*localnotifications.Notification, 0, len(triggered))
+	for _, t := range triggered {
+		notifications = append(notifications, &localnotifications.Notification{
+			ID:        common.BytesToHash(crypto.Keccak256([]byte(fmt.Sprintf("price-alert-%d-%d", t.Alert.ID, t.TriggeredAt)))),
+			Body:      &notificationBody{t},
+			BodyType:  localnotifications.TypePriceAlert,
+			Category:  localnotifications.CategoryPriceAlert,
+			Title:     fmt.Sprintf("%s price alert", t.Alert.Symbol),
+			Message:   t.message(),
+			Timestamp: uint64(t.TriggeredAt),
+		})
+	}
+	m.pushNotifications(notifications)
+}
+
+type notificationBody struct {
+	*TriggeredAlert
+}
+
+func (b *notificationBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.TriggeredAlert)
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
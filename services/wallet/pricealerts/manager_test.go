@@ -0,0 +1,210 @@
+package pricealerts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/event"
+
+	localnotifications "github.com/status-im/status-go/services/local-notifications"
+	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+type testMarketProvider struct {
+	prices       map[string]map[string]float64
+	marketValues map[string]map[string]thirdparty.TokenMarketValues
+}
+
+func (p *testMarketProvider) GetOrFetchPrices(symbols []string, currencies []string, maxAgeInSeconds int64) (market.DataPerTokenAndCurrency, error) {
+	prices := make(market.DataPerTokenAndCurrency)
+	for symbol, pricesPerCurrency := range p.prices {
+		prices[symbol] = make(map[string]market.DataPoint)
+		for currency, price := range pricesPerCurrency {
+			prices[symbol][currency] = market.DataPoint{Price: price}
+		}
+	}
+	return prices, nil
+}
+
+func (p *testMarketProvider) GetOrFetchTokenMarketValues(symbols []string, currency string, maxAgeInSeconds int64) (map[string]thirdparty.TokenMarketValues, error) {
+	return p.marketValues[currency], nil
+}
+
+func setupTestManager(t *testing.T) (*Manager, *testMarketProvider, *[]*localnotifications.Notification, chan walletevent.Event) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	provider := &testMarketProvider{}
+	feed := &event.Feed{}
+	events := make(chan walletevent.Event, 10)
+	sub := feed.Subscribe(events)
+	t.Cleanup(sub.Unsubscribe)
+
+	manager := NewManager(db, provider, feed)
+	pushed := make([]*localnotifications.Notification, 0)
+	manager.pushNotifications = func(notifications []*localnotifications.Notification) {
+		pushed = append(pushed, notifications...)
+	}
+	return manager, provider, &pushed, events
+}
+
+func TestAlertsCRUD(t *testing.T) {
+	manager, _, _, _ := setupTestManager(t)
+
+	_, err := manager.AddAlert(&Alert{Symbol: "ETH", Currency: "EUR", Type: "sideways", Threshold: 1})
+	require.ErrorIs(t, err, ErrInvalidAlertType)
+	_, err = manager.AddAlert(&Alert{Symbol: "ETH", Currency: "EUR", Type: AlertTypeBelow})
+	require.ErrorIs(t, err, ErrInvalidThreshold)
+
+	alert, err := manager.AddAlert(&Alert{Symbol: "ETH", Currency: "EUR", Type: AlertTypeBelow, Threshold: 2000, Enabled: true})
+	require.NoError(t, err)
+	require.NotZero(t, alert.ID)
+
+	alert.Threshold = 1500
+	require.NoError(t, manager.UpdateAlert(alert))
+
+	alerts, err := manager.GetAlerts()
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t, 1500.0, alerts[0].Threshold)
+
+	require.NoError(t, manager.DeleteAlert(alert.ID))
+	require.ErrorIs(t, manager.DeleteAlert(alert.ID), ErrAlertNotFound)
+	require.ErrorIs(t, manager.UpdateAlert(alert), ErrAlertNotFound)
+}
+
+func TestEvaluateThreshold(t *testing.T) {
+	manager, provider, pushed, events := setupTestManager(t)
+
+	alert, err := manager.AddAlert(&Alert{Symbol: "ETH", Currency: "EUR", Type: AlertTypeBelow, Threshold: 2000, Enabled: true})
+	require.NoError(t, err)
+	_, err = manager.AddAlert(&Alert{Symbol: "ETH", Currency: "EUR", Type: AlertTypeAbove, Threshold: 1000, Enabled: false})
+	require.NoError(t, err)
+
+	setPrice := func(price float64) {
+		provider.prices = map[string]map[string]float64{"ETH": {"EUR": price}}
+	}
+
+	setPrice(2100)
+	triggered, err := manager.Evaluate()
+	require.NoError(t, err)
+	require.Empty(t, triggered)
+
+	setPrice(1990)
+	triggered, err = manager.Evaluate()
+	require.NoError(t, err)
+	require.Len(t, triggered, 1)
+	require.Equal(t, alert.ID, triggered[0].Alert.ID)
+	require.Equal(t, 1990.0, triggered[0].Price)
+	require.Len(t, *pushed, 1)
+	require.Equal(t, localnotifications.CategoryPriceAlert, (*pushed)[0].Category)
+	require.Equal(t, "ETH is below 2000 EUR: 1990 EUR", (*pushed)[0].Message)
+
+	select {
+	case e := <-events:
+		require.Equal(t, EventPriceAlertsTriggered, e.Type)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the triggered event")
+	}
+
+	// the alert is not notified again while the price stays below the threshold
+	setPrice(1980)
+	triggered, err = manager.Evaluate()
+	require.NoError(t, err)
+	require.Empty(t, triggered)
+
+	// unknown prices don't change the state
+	setPrice(0)
+	triggered, err = manager.Evaluate()
+	require.NoError(t, err)
+	require.Empty(t, triggered)
+
+	// the alert is rearmed once the price went back above the threshold
+	setPrice(2050)
+	_, err = manager.Evaluate()
+	require.NoError(t, err)
+	setPrice(1950)
+	triggered, err = manager.Evaluate()
+	require.NoError(t, err)
+	require.Len(t, triggered, 1)
+	require.Len(t, *pushed, 2)
+}
+
+func TestEvaluateCooldown(t *testing.T) {
+	manager, provider, pushed, _ := setupTestManager(t)
+
+	_, err := manager.AddAlert(&Alert{Symbol: "SNT", Currency: "USD", Type: AlertTypeAbove, Threshold: 0.05, Cooldown: 3600, Enabled: true})
+	require.NoError(t, err)
+
+	for _, price := range []float64{0.06, 0.04, 0.06} {
+		provider.prices = map[string]map[string]float64{"SNT": {"USD": price}}
+		_, err = manager.Evaluate()
+		require.NoError(t, err)
+	}
+	require.Len(t, *pushed, 1)
+
+	// the crossing during the cooldown is notified once the cooldown is over
+	alerts, err := manager.GetAlerts()
+	require.NoError(t, err)
+	require.False(t, alerts[0].ConditionMet)
+	alerts[0].LastTriggeredAt -= 3600
+	require.NoError(t, manager.db.UpdateState(alerts[0]))
+
+	triggered, err := manager.Evaluate()
+	require.NoError(t, err)
+	require.Len(t, triggered, 1)
+	require.Len(t, *pushed, 2)
+}
+
+func TestEvaluateOnPricesUpdated(t *testing.T) {
+	manager, provider, _, events := setupTestManager(t)
+
+	_, err := manager.AddAlert(&Alert{Symbol: "ETH", Currency: "USD", Type: AlertTypeAbove, Threshold: 3000, Enabled: true})
+	require.NoError(t, err)
+	provider.prices = map[string]map[string]float64{"ETH": {"USD": 3100}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager.Start(ctx)
+
+	// the alerts are evaluated when the market manager refreshed the prices
+	manager.walletFeed.Send(walletevent.Event{Type: market.EventMarketPricesUpdated, Message: `["ETH"]`})
+	for {
+		select {
+		case e := <-events:
+			if e.Type == EventPriceAlertsTriggered {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the triggered event")
+		}
+	}
+}
+
+func TestEvaluatePercentChange(t *testing.T) {
+	manager, provider, pushed, _ := setupTestManager(t)
+
+	_, err := manager.AddAlert(&Alert{Symbol: "SNT", Currency: "USD", Type: AlertTypePercentChange, Threshold: 10, Enabled: true})
+	require.NoError(t, err)
+
+	provider.prices = map[string]map[string]float64{"SNT": {"USD": 0.03}}
+	provider.marketValues = map[string]map[string]thirdparty.TokenMarketValues{"USD": {"SNT": {CHANGEPCT24HOUR: -5}}}
+	triggered, err := manager.Evaluate()
+	require.NoError(t, err)
+	require.Empty(t, triggered)
+
+	provider.marketValues = map[string]map[string]thirdparty.TokenMarketValues{"USD": {"SNT": {CHANGEPCT24HOUR: -12.5}}}
+	triggered, err = manager.Evaluate()
+	require.NoError(t, err)
+	require.Len(t, triggered, 1)
+	require.Equal(t, -12.5, triggered[0].ChangePct24h)
+	require.Equal(t, "SNT moved -12.50% in 24h: 0.03 USD", (*pushed)[0].Message)
+}
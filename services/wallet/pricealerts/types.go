@@ -0,0 +1,81 @@
+package pricealerts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type AlertType string
+
+const (
+	// AlertTypeAbove triggers when the price goes above the threshold
+	AlertTypeAbove AlertType = "above"
+	// AlertTypeBelow triggers when the price goes below the threshold
+	AlertTypeBelow AlertType = "below"
+	// AlertTypePercentChange triggers when the price moved by at least the threshold, in percent, over 24h
+	AlertTypePercentChange AlertType = "percentChange"
+)
+
+var (
+	ErrAlertNotFound    = errors.New("price alert not found")
+	ErrInvalidAlertType = errors.New("invalid price alert type")
+	ErrInvalidThreshold = errors.New("price alert threshold must be positive")
+	ErrMissingSymbol    = errors.New("price alert symbol is required")
+	ErrMissingCurrency  = errors.New("price alert currency is required")
+)
+
+// Alert is a rule set by the user, ConditionMet and LastTriggeredAt are set by the evaluation
+type Alert struct {
+	ID        int64     `json:"id"`
+	Symbol    string    `json:"symbol"`
+	Currency  string    `json:"currency"`
+	Type      AlertType `json:"type"`
+	Threshold float64   `json:"threshold"`
+	// Cooldown is the minimum number of seconds between two notifications of the alert
+	Cooldown int64 `json:"cooldown"`
+	Enabled  bool  `json:"enabled"`
+	// ConditionMet is true while the condition of the notified crossing holds, the alert is notified again only
+	// after the condition stopped holding. A crossing during the cooldown keeps it false until notified.
+	ConditionMet    bool  `json:"conditionMet"`
+	LastTriggeredAt int64 `json:"lastTriggeredAt"`
+	CreatedAt       int64 `json:"createdAt"`
+}
+
+func (a *Alert) Validate() error {
+	if strings.TrimSpace(a.Symbol) == "" {
+		return ErrMissingSymbol
+	}
+	if strings.TrimSpace(a.Currency) == "" {
+		return ErrMissingCurrency
+	}
+	switch a.Type {
+	case AlertTypeAbove, AlertTypeBelow, AlertTypePercentChange:
+	default:
+		return ErrInvalidAlertType
+	}
+	if a.Threshold <= 0 {
+		return ErrInvalidThreshold
+	}
+	return nil
+}
+
+// TriggeredAlert is sent with EventPriceAlertsTriggered
+type TriggeredAlert struct {
+	Alert        *Alert  `json:"alert"`
+	Price        float64 `json:"price"`
+	ChangePct24h float64 `json:"changePct24h"`
+	TriggeredAt  int64   `json:"triggeredAt"`
+}
+
+func (t *TriggeredAlert) message() string {
+	alert := t.Alert
+	switch alert.Type {
+	case AlertTypeAbove:
+		return fmt.Sprintf("%s is above %g %s: %g %s", alert.Symbol, alert.Threshold, alert.Currency, t.Price, alert.Currency)
+	case AlertTypeBelow:
+		return fmt.Sprintf("%s is below %g %s: %g %s", alert.Symbol, alert.Threshold, alert.Currency, t.Price, alert.Currency)
+	default:
+		return fmt.Sprintf("%s moved %+.2f%% in 24h: %g %s", alert.Symbol, t.ChangePct24h, t.Price, alert.Currency)
+	}
+}
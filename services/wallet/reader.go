@@ -6,6 +6,7 @@ import (
 	"context"
 	"math"
 	"math/big"
+	"slices"
 	"sync"
 	"time"
 
@@ -18,7 +19,9 @@ import (
 	gocommon "github.com/status-im/status-go/common"
 	"github.com/status-im/status-go/logutils"
 	"github.com/status-im/status-go/rpc/chain"
+	wcommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/token"
 	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
 	"github.com/status-im/status-go/services/wallet/transfer"
@@ -570,3 +573,53 @@ func (r *Reader) GetCachedBalances(clients map[uint64]chain.ClientInterface, add
 	balances := tokensToBalancesPerChain(cachedTokens)
 	return r.balancesToTokensByAddress(connectedPerChain, addresses, allTokens, balances, cachedTokens), nil
 }
+
+// fillPrices sets the prices of the balances, the prices by contract are preferred and the prices by symbol are used
+// for the native tokens and the contracts unknown to the market providers
+func fillPrices(marketManager *market.Manager, balances map[common.Address][]tokenTypes.StorageToken, currencies []string) error {
+	contracts := make([]thirdparty.ContractID, 0)
+	symbols := make([]string, 0)
+	for _, storageTokens := range balances {
+		for _, storageToken := range storageTokens {
+			if !slices.Contains(symbols, storageToken.Symbol) {
+				symbols = append(symbols, storageToken.Symbol)
+			}
+			for chainID, balance := range storageToken.BalancesPerChain {
+				if balance.Address != (common.Address{}) {
+					contracts = append(contracts, thirdparty.ContractID{ChainID: wcommon.ChainID(chainID), Address: balance.Address})
+				}
+			}
+		}
+	}
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	pricesByContract, err := marketManager.GetOrFetchPricesByContract(contracts, currencies, market.MaxAgeInSecondsForBalances)
+	if err != nil {
+		logutils.ZapLogger().Warn("failed to fetch prices by contract", zap.Error(err))
+		pricesByContract = make(market.DataPerContractAndCurrency)
+	}
+	pricesBySymbol, err := marketManager.GetOrFetchPrices(symbols, currencies, market.MaxAgeInSecondsForBalances)
+	if err != nil {
+		return err
+	}
+
+	for _, storageTokens := range balances {
+		for i := range storageTokens {
+			for chainID, balance := range storageTokens[i].BalancesPerChain {
+				id := thirdparty.ContractID{ChainID: wcommon.ChainID(chainID), Address: balance.Address}
+				balance.PricePerCurrency = make(map[string]float64)
+				for _, currency := range currencies {
+					if dataPoint, ok := pricesByContract[id][currency]; ok && dataPoint.Price > 0 {
+						balance.PricePerCurrency[currency] = dataPoint.Price
+					} else if dataPoint, ok := pricesBySymbol[storageTokens[i].Symbol][currency]; ok && dataPoint.Price > 0 {
+						balance.PricePerCurrency[currency] = dataPoint.Price
+					}
+				}
+				storageTokens[i].BalancesPerChain[chainID] = balance
+			}
+		}
+	}
+	return nil
+}
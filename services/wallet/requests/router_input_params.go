@@ -39,6 +39,7 @@ var (
 	ErrNoToChainProvided                    = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-022"), Details: "to chain not provided"}
 	ErrFromAndToChainMustBeTheSame          = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-023"), Details: "from and to chain IDs must be the same"}
 	ErrSwapSlippagePercentageMustBePositive = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-024"), Details: "slippage percentage must be positive"}
+	ErrNoAllowancesToRevokeProvided         = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-025"), Details: "no allowances to revoke provided"}
 )
 
 type RouteInputParams struct {
@@ -62,6 +63,15 @@ type RouteInputParams struct {
 	PublicKey string       `json:"publicKey"`
 	PackID    *hexutil.Big `json:"packID"`
 
+	// Batch the transactions of the route in a single EIP-7702 transaction executed by the account
+	BatchTransactions bool `json:"batchTransactions"`
+	// Signature of the authorization delegating the account to the batch executor, required for a batch if the
+	// account is not delegated yet
+	DelegationSignature hexutil.Bytes `json:"delegationSignature,omitempty"`
+
+	// For RevokeAllowances send type, a single route revokes all of them
+	RevokeAllowances []*RevokeAllowanceParams `json:"revokeAllowances"`
+
 	// Used internally
 	PathTxCustomParams map[string]*PathTxCustomParams `json:"-"`
 
@@ -181,6 +191,12 @@ func (i *RouteInputParams) Validate() error {
 		}
 	}
 
+	if i.SendType == sendtype.RevokeAllowances {
+		if len(i.RevokeAllowances) == 0 {
+			return ErrNoAllowancesToRevokeProvided
+		}
+	}
+
 	if i.SendType.IsCommunityRelatedTransfer() {
 		if i.DisabledFromChainIDs == nil || len(i.DisabledFromChainIDs) == 0 {
 			return ErrNoFromChainProvided
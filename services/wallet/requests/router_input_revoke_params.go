@@ -0,0 +1,25 @@
+package requests
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RevokeAllowanceParams identifies an allowance given by the sending account to revoke
+type RevokeAllowanceParams struct {
+	ChainID uint64         `json:"chainId"`
+	Token   common.Address `json:"token"`
+	Spender common.Address `json:"spender"`
+	// ApprovalForAll is set for ERC721 and ERC1155 operator approvals, an ERC20 allowance is revoked otherwise
+	ApprovalForAll bool `json:"approvalForAll"`
+}
+
+func (r *RevokeAllowanceParams) ID() string {
+	return fmt.Sprintf("%s-%s-%t", r.Token.String(), r.Spender.String(), r.ApprovalForAll)
+}
+
+func (r *RevokeAllowanceParams) Copy() *RevokeAllowanceParams {
+	newParams := *r
+	return &newParams
+}
@@ -2,13 +2,16 @@ package responses
 
 import (
 	"github.com/status-im/status-go/errors"
+	"github.com/status-im/status-go/services/wallet/poisoning"
 	"github.com/status-im/status-go/services/wallet/router/routes"
 )
 
 type RouterSuggestedRoutes struct {
-	Uuid          string                `json:"Uuid"`
-	Best          routes.Route          `json:"Best,omitempty"`
-	Candidates    routes.Route          `json:"Candidates,omitempty"`
-	UpdatedPrices map[string]float64    `json:"UpdatedPrices,omitempty"`
-	ErrorResponse *errors.ErrorResponse `json:"ErrorResponse,omitempty"`
+	Uuid          string             `json:"Uuid"`
+	Best          routes.Route       `json:"Best,omitempty"`
+	Candidates    routes.Route       `json:"Candidates,omitempty"`
+	UpdatedPrices map[string]float64 `json:"UpdatedPrices,omitempty"`
+	// AddressWarnings are set when the destination address looks like an address known by the user
+	AddressWarnings []*poisoning.Warning  `json:"AddressWarnings,omitempty"`
+	ErrorResponse   *errors.ErrorResponse `json:"ErrorResponse,omitempty"`
 }
@@ -201,7 +201,7 @@ func (m *Manager) SendRouterTransactionsWithSignatures(ctx context.Context, send
 		}
 		//////////////////////////////////////////////////////////////////////////////
 
-		response.SentTransactions, fromChainID, toChainID, err = m.transactionManager.SendRouterTransactions(ctx, multiTx)
+		response.SentTransactions, fromChainID, toChainID, err = m.transactionManager.SendRouterTransactions(ctx, multiTx, m.router.GetPathProcessors())
 		if err != nil {
 			response.SendDetails.UpdateFields(routeInputParams, fromChainID, toChainID)
 			logutils.ZapLogger().Error("Error sending router transactions", zap.Error(err))
@@ -112,9 +112,12 @@ func putPathData(creator sqlite.StatementCreator, uuid string, pathIdx int, d *w
 			return
 		}
 
-		err = putSentTransaction(creator, d.RouterPath.FromChain.ChainID, d.TxData.SentHash, d.TxData.Tx)
-		if err != nil {
-			return
+		// user operations of smart accounts have no transaction of their own
+		if d.TxData.Tx != nil {
+			err = putSentTransaction(creator, d.RouterPath.FromChain.ChainID, d.TxData.SentHash, d.TxData.Tx)
+			if err != nil {
+				return
+			}
 		}
 	}
 
@@ -24,4 +24,5 @@ const (
 	ProcessorCommunityMintTokensName         = "CommunityMintTokens"
 	ProcessorCommunityRemoteBurnName         = "CommunityRemoteBurn"
 	ProcessorCommunitySetSignerPubKeyName    = "CommunitySetSignerPubKey"
+	ProcessorRevokeAllowanceName             = "RevokeAllowance"
 )
@@ -53,6 +53,9 @@ var (
 	ErrCommunityTokenType             = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-041"), Details: "invalid community token type"}
 	ErrIncorrectSignatureFormat       = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-042"), Details: "incorrect signature length: got %d, want %d"}
 	ErrTransactionNotFound            = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-043"), Details: "transaction not found"}
+	ErrSafeSendNotSupported           = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-044"), Details: "Safe transactions must be built and signed by the owners"}
+	ErrSmartAccountSendNotSupported   = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-045"), Details: "smart account transactions must be sent as user operations"}
+	ErrNoAllowanceToRevoke            = &errors.ErrorResponse{Code: errors.ErrorCode("WPP-046"), Details: "no allowance to revoke provided"}
 )
 
 func createErrorResponse(processorName string, err error) error {
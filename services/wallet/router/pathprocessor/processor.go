@@ -11,6 +11,7 @@ import (
 	"github.com/status-im/status-go/account"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/params"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/requests"
 	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
@@ -44,6 +45,16 @@ type PathProcessorClearable interface {
 	Clear()
 }
 
+// UserOperationProcessor is implemented by the processors able to send from ERC-4337 smart accounts
+type UserOperationProcessor interface {
+	// IsSmartAccount checks if the address is a smart account which sends user operations instead of transactions
+	IsSmartAccount(chainID uint64, address common.Address) (bool, error)
+	// BuildUserOperation builds the user operation based on SendTxArgs, returns the operation and the hash the owner signs
+	BuildUserOperation(sendArgs *wallettypes.SendTxArgs) (*wallettypes.UserOperation, types.Hash, error)
+	// SendUserOperation sends the signed user operation to the bundler, returns the user operation hash
+	SendUserOperation(txData *wallettypes.TransactionData, multiTransactionID walletCommon.MultiTransactionIDType) (types.Hash, error)
+}
+
 type ProcessorCommunityTokenParams struct {
 	Name               string
 	Symbol             string
@@ -75,6 +86,13 @@ type ProcessorInputParams struct {
 	// community related params
 	CommunityParams *requests.CommunityRouteInputParams
 
+	// allowance to revoke, for RevokeAllowances send type
+	RevokeAllowance *requests.RevokeAllowanceParams
+
+	// batch related params
+	BatchTransactions   bool
+	DelegationSignature []byte
+
 	// for testing purposes
 	TestsMode                 bool
 	TestEstimationMap         map[string]requests.Estimation // [bridge-name, estimation]
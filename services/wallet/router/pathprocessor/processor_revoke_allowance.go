@@ -0,0 +1,134 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/contracts/erc721"
+	"github.com/status-im/status-go/contracts/ierc20"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/transactions"
+)
+
+// RevokeAllowanceProcessor revokes an ERC20 allowance by approving a zero amount, or an ERC721/ERC1155 operator
+// approval with `setApprovalForAll(operator, false)`
+type RevokeAllowanceProcessor struct {
+	rpcClient  *rpc.Client
+	transactor transactions.TransactorIface
+}
+
+func NewRevokeAllowanceProcessor(rpcClient *rpc.Client, transactor transactions.TransactorIface) *RevokeAllowanceProcessor {
+	return &RevokeAllowanceProcessor{
+		rpcClient:  rpcClient,
+		transactor: transactor,
+	}
+}
+
+func createRevokeAllowanceErrorResponse(err error) error {
+	return createErrorResponse(pathProcessorCommon.ProcessorRevokeAllowanceName, err)
+}
+
+func (s *RevokeAllowanceProcessor) Name() string {
+	return pathProcessorCommon.ProcessorRevokeAllowanceName
+}
+
+func (s *RevokeAllowanceProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	if params.RevokeAllowance == nil {
+		return false, ErrNoAllowanceToRevoke
+	}
+	return params.FromChain.ChainID == params.RevokeAllowance.ChainID, nil
+}
+
+func (s *RevokeAllowanceProcessor) CalculateFees(params ProcessorInputParams) (*big.Int, *big.Int, error) {
+	return walletCommon.ZeroBigIntValue(), walletCommon.ZeroBigIntValue(), nil
+}
+
+func (s *RevokeAllowanceProcessor) PackTxInputData(params ProcessorInputParams) ([]byte, error) {
+	if params.RevokeAllowance == nil {
+		return []byte{}, createRevokeAllowanceErrorResponse(ErrNoAllowanceToRevoke)
+	}
+
+	if params.RevokeAllowance.ApprovalForAll {
+		erc721ABI, err := abi.JSON(strings.NewReader(erc721.Erc721MetaData.ABI))
+		if err != nil {
+			return []byte{}, createRevokeAllowanceErrorResponse(err)
+		}
+		return erc721ABI.Pack("setApprovalForAll", params.RevokeAllowance.Spender, false)
+	}
+
+	erc20ABI, err := abi.JSON(strings.NewReader(ierc20.IERC20ABI))
+	if err != nil {
+		return []byte{}, createRevokeAllowanceErrorResponse(err)
+	}
+	return erc20ABI.Pack("approve", params.RevokeAllowance.Spender, big.NewInt(0))
+}
+
+func (s *RevokeAllowanceProcessor) EstimateGas(params ProcessorInputParams, input []byte) (uint64, error) {
+	if params.TestsMode {
+		if params.TestEstimationMap != nil {
+			if val, ok := params.TestEstimationMap[s.Name()]; ok {
+				return val.Value, val.Err
+			}
+		}
+		return 0, ErrNoEstimationFound
+	}
+
+	ethClient, err := s.rpcClient.EthClient(params.FromChain.ChainID)
+	if err != nil {
+		return 0, createRevokeAllowanceErrorResponse(err)
+	}
+
+	toAddress := params.RevokeAllowance.Token
+	msg := ethereum.CallMsg{
+		From:  params.FromAddr,
+		To:    &toAddress,
+		Value: walletCommon.ZeroBigIntValue(),
+		Data:  input,
+	}
+
+	estimation, err := ethClient.EstimateGas(context.Background(), msg)
+	if err != nil {
+		return 0, createRevokeAllowanceErrorResponse(err)
+	}
+
+	increasedEstimation := float64(estimation) * pathProcessorCommon.IncreaseEstimatedGasFactor
+	logutils.ZapLogger().Debug("RevokeAllowanceProcessor estimation", zap.Uint64("gas", uint64(increasedEstimation)))
+
+	return uint64(increasedEstimation), nil
+}
+
+func (s *RevokeAllowanceProcessor) Send(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64, verifiedAccount *account.SelectedExtKey) (hash types.Hash, usedNonce uint64, err error) {
+	return s.transactor.SendTransactionWithChainID(sendArgs.ChainID, *sendArgs.TransferTx, lastUsedNonce, verifiedAccount)
+}
+
+func (s *RevokeAllowanceProcessor) BuildTransaction(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.ChainID, *sendArgs.TransferTx, lastUsedNonce)
+}
+
+func (s *RevokeAllowanceProcessor) BuildTransactionV2(sendArgs *wallettypes.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.FromChainID, *sendArgs, lastUsedNonce)
+}
+
+func (s *RevokeAllowanceProcessor) CalculateAmountOut(params ProcessorInputParams) (*big.Int, error) {
+	return params.AmountIn, nil
+}
+
+func (s *RevokeAllowanceProcessor) GetContractAddress(params ProcessorInputParams) (common.Address, error) {
+	if params.RevokeAllowance == nil {
+		return common.Address{}, ErrNoAllowanceToRevoke
+	}
+	return params.RevokeAllowance.Token, nil
+}
@@ -0,0 +1,134 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/services/wallet/safe"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/transactions"
+)
+
+// safeExecGasOverhead covers the signatures check and the refund logic of `execTransaction`
+const safeExecGasOverhead = uint64(100000)
+
+// SafeProcessor sends the transactions of the wrapped processor from a Safe account, the call is executed by the
+// Safe through `execTransaction` sent by a local owner once enough owners signed it
+type SafeProcessor struct {
+	PathProcessor
+	transactor  transactions.TransactorIface
+	safeManager *safe.Manager
+}
+
+func NewSafeProcessor(inner PathProcessor, transactor transactions.TransactorIface, safeManager *safe.Manager) *SafeProcessor {
+	return &SafeProcessor{
+		PathProcessor: inner,
+		transactor:    transactor,
+		safeManager:   safeManager,
+	}
+}
+
+func (s *SafeProcessor) isSafe(chainID uint64, address common.Address) (bool, error) {
+	info, err := s.safeManager.GetSafe(chainID, address)
+	if err != nil {
+		return false, createErrorResponse(s.Name(), err)
+	}
+	return info != nil, nil
+}
+
+func (s *SafeProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	isSafe, err := s.isSafe(params.FromChain.ChainID, params.FromAddr)
+	if err != nil {
+		return false, err
+	}
+	if isSafe && params.FromChain.ChainID != params.ToChain.ChainID {
+		return false, ErrFromAndToChainsMustBeSame
+	}
+	return s.PathProcessor.AvailableFor(params)
+}
+
+func (s *SafeProcessor) EstimateGas(params ProcessorInputParams, input []byte) (uint64, error) {
+	estimation, err := s.PathProcessor.EstimateGas(params, input)
+	if err != nil || params.TestsMode {
+		return estimation, err
+	}
+
+	isSafe, err := s.isSafe(params.FromChain.ChainID, params.FromAddr)
+	if err != nil {
+		return 0, err
+	}
+	if isSafe {
+		estimation += safeExecGasOverhead
+	}
+	return estimation, nil
+}
+
+func (s *SafeProcessor) Send(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64, verifiedAccount *account.SelectedExtKey) (types.Hash, uint64, error) {
+	isSafe, err := s.isSafe(sendArgs.ChainID, common.Address(sendArgs.From()))
+	if err != nil {
+		return types.Hash{}, 0, err
+	}
+	if isSafe {
+		return types.Hash{}, 0, ErrSafeSendNotSupported
+	}
+	return s.PathProcessor.Send(sendArgs, lastUsedNonce, verifiedAccount)
+}
+
+func (s *SafeProcessor) BuildTransaction(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	isSafe, err := s.isSafe(sendArgs.ChainID, common.Address(sendArgs.From()))
+	if err != nil {
+		return nil, 0, err
+	}
+	if isSafe {
+		return nil, 0, ErrSafeSendNotSupported
+	}
+	return s.PathProcessor.BuildTransaction(sendArgs, lastUsedNonce)
+}
+
+// BuildTransactionV2 proposes the SafeTx making the call built by the wrapped processor and, if the owners
+// signatures reach the threshold, returns the `execTransaction` transaction sent by a local owner
+func (s *SafeProcessor) BuildTransactionV2(sendArgs *wallettypes.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	safeAddress := common.Address(sendArgs.From)
+	isSafe, err := s.isSafe(sendArgs.FromChainID, safeAddress)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !isSafe {
+		return s.PathProcessor.BuildTransactionV2(sendArgs, lastUsedNonce)
+	}
+
+	var to common.Address
+	if sendArgs.To != nil {
+		to = common.Address(*sendArgs.To)
+	}
+	value := new(big.Int)
+	if sendArgs.Value != nil {
+		value = sendArgs.Value.ToInt()
+	}
+
+	safeTx, err := s.safeManager.ProposeTransaction(context.Background(), sendArgs.FromChainID, safeAddress, to, value, sendArgs.GetInput())
+	if err != nil {
+		return nil, 0, createErrorResponse(s.Name(), err)
+	}
+
+	executor, execData, err := s.safeManager.PackExecTransaction(safeTx)
+	if err != nil {
+		return nil, 0, createErrorResponse(s.Name(), err)
+	}
+
+	safeTo := types.Address(safeAddress)
+	sendArgs.From = types.Address(executor)
+	sendArgs.To = &safeTo
+	sendArgs.Value = (*hexutil.Big)(big.NewInt(0))
+	sendArgs.Input = nil
+	sendArgs.Data = execData
+	// the nonce resolved by the router is the one of the Safe, the executor's one is used instead
+	sendArgs.Nonce = nil
+
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.FromChainID, *sendArgs, lastUsedNonce)
+}
@@ -0,0 +1,214 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/appdatabase"
+	safeContract "github.com/status-im/status-go/contracts/safe"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/multiaccounts/accounts"
+	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	mock_rpcclient "github.com/status-im/status-go/rpc/mock/client"
+	"github.com/status-im/status-go/services/wallet/safe"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/t/helpers"
+	mock_transactor "github.com/status-im/status-go/transactions/mock"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+var (
+	testSafe      = common.HexToAddress("0x1c8b9b78e3085866521fe206fa4c1a67f49f153a")
+	testSafeOwner = common.HexToAddress("0x41")
+	testRecipient = common.HexToAddress("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+)
+
+// testInnerProcessor is the processor wrapped by the SafeProcessor, it records the transactions it builds
+type testInnerProcessor struct {
+	PathProcessor
+	estimation uint64
+	built      []*wallettypes.SendTxArgs
+}
+
+func (p *testInnerProcessor) Name() string {
+	return "test"
+}
+
+func (p *testInnerProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	return true, nil
+}
+
+func (p *testInnerProcessor) EstimateGas(params ProcessorInputParams, input []byte) (uint64, error) {
+	return p.estimation, nil
+}
+
+func (p *testInnerProcessor) BuildTransactionV2(sendArgs *wallettypes.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	p.built = append(p.built, sendArgs)
+	return ethTypes.NewTx(&ethTypes.LegacyTx{}), 0, nil
+}
+
+type safeProcessorTestState struct {
+	processor   *SafeProcessor
+	inner       *testInnerProcessor
+	transactor  *mock_transactor.MockTransactorIface
+	chainClient *mock_client.MockClientInterface
+}
+
+func setupSafeProcessorTest(t *testing.T) *safeProcessorTestState {
+	appDB, err := helpers.SetupTestMemorySQLDB(appdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, appDB.Close()) })
+	accountsDB, err := accounts.NewDB(appDB)
+	require.NoError(t, err)
+
+	walletDB, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, walletDB.Close()) })
+
+	kp := accounts.GetPrivKeyImportedKeypairForTest()
+	kp.Accounts[0].Address = types.Address(testSafeOwner)
+	require.NoError(t, accountsDB.SaveOrUpdateKeypair(kp))
+
+	require.NoError(t, safe.NewDB(walletDB).SaveSafe(&safe.Info{
+		ChainID:   mainnet.ChainID,
+		Address:   testSafe,
+		Owners:    []common.Address{testSafeOwner},
+		Threshold: 1,
+		Nonce:     3,
+		Version:   "1.3.0",
+		UpdatedAt: time.Now().Unix(),
+	}))
+
+	ctrl := gomock.NewController(t)
+	rpcClient := mock_rpcclient.NewMockClientInterface(ctrl)
+	chainClient := mock_client.NewMockClientInterface(ctrl)
+	rpcClient.EXPECT().EthClient(mainnet.ChainID).Return(chainClient, nil).AnyTimes()
+	transactor := mock_transactor.NewMockTransactorIface(ctrl)
+
+	inner := &testInnerProcessor{estimation: 21000}
+	return &safeProcessorTestState{
+		processor:   NewSafeProcessor(inner, transactor, safe.NewManager(walletDB, rpcClient, accountsDB)),
+		inner:       inner,
+		transactor:  transactor,
+		chainClient: chainClient,
+	}
+}
+
+// expectSafeNonce answers the `nonce()` call of the Safe
+func (s *safeProcessorTestState) expectSafeNonce(t *testing.T, nonce int64) {
+	safeABI, err := safeContract.SafeMetaData.GetAbi()
+	require.NoError(t, err)
+	s.chainClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+			require.Equal(t, testSafe, *msg.To)
+			require.Equal(t, safeABI.Methods["nonce"].ID, msg.Data[:4])
+			return safeABI.Methods["nonce"].Outputs.Pack(big.NewInt(nonce))
+		}).Times(1)
+}
+
+func TestSafeProcessorAvailableFor(t *testing.T) {
+	s := setupSafeProcessorTest(t)
+
+	available, err := s.processor.AvailableFor(ProcessorInputParams{FromChain: &mainnet, ToChain: &mainnet, FromAddr: testSafe})
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// the Safe executes the call on its own chain only
+	available, err = s.processor.AvailableFor(ProcessorInputParams{FromChain: &mainnet, ToChain: &optimism, FromAddr: testSafe})
+	require.ErrorIs(t, err, ErrFromAndToChainsMustBeSame)
+	require.False(t, available)
+
+	available, err = s.processor.AvailableFor(ProcessorInputParams{FromChain: &mainnet, ToChain: &optimism, FromAddr: testRecipient})
+	require.NoError(t, err)
+	require.True(t, available)
+}
+
+func TestSafeProcessorEstimateGas(t *testing.T) {
+	s := setupSafeProcessorTest(t)
+
+	estimation, err := s.processor.EstimateGas(ProcessorInputParams{FromChain: &mainnet, FromAddr: testSafe}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(21000)+safeExecGasOverhead, estimation)
+
+	estimation, err = s.processor.EstimateGas(ProcessorInputParams{FromChain: &mainnet, FromAddr: testRecipient}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(21000), estimation)
+}
+
+func TestSafeProcessorSendNotSupported(t *testing.T) {
+	s := setupSafeProcessorTest(t)
+
+	to := types.Address(testRecipient)
+	sendArgs := &MultipathProcessorTxArgs{
+		ChainID:    mainnet.ChainID,
+		TransferTx: &wallettypes.SendTxArgs{From: types.Address(testSafe), To: &to},
+	}
+	_, _, err := s.processor.Send(sendArgs, -1, nil)
+	require.ErrorIs(t, err, ErrSafeSendNotSupported)
+
+	_, _, err = s.processor.BuildTransaction(sendArgs, -1)
+	require.ErrorIs(t, err, ErrSafeSendNotSupported)
+}
+
+func TestSafeProcessorBuildTransactionV2(t *testing.T) {
+	s := setupSafeProcessorTest(t)
+
+	// the transactions of the other accounts are built by the wrapped processor
+	to := types.Address(testRecipient)
+	regular := &wallettypes.SendTxArgs{FromChainID: mainnet.ChainID, From: types.Address(testSafeOwner), To: &to}
+	_, _, err := s.processor.BuildTransactionV2(regular, -1)
+	require.NoError(t, err)
+	require.Equal(t, []*wallettypes.SendTxArgs{regular}, s.inner.built)
+
+	// only the nonce of the Safe is read, its owners and threshold are stored
+	s.expectSafeNonce(t, 5)
+	nonce := hexutil.Uint64(3)
+	sendArgs := &wallettypes.SendTxArgs{
+		FromChainID: mainnet.ChainID,
+		From:        types.Address(testSafe),
+		To:          &to,
+		Value:       (*hexutil.Big)(big.NewInt(100)),
+		Nonce:       &nonce,
+	}
+	s.transactor.EXPECT().ValidateAndBuildTransaction(mainnet.ChainID, gomock.Any(), int64(-1)).DoAndReturn(
+		func(chainID uint64, args wallettypes.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+			// `execTransaction` is sent to the Safe by its owner
+			require.Equal(t, types.Address(testSafeOwner), args.From)
+			require.Equal(t, types.Address(testSafe), *args.To)
+			require.Zero(t, args.Value.ToInt().Sign())
+			require.Nil(t, args.Nonce)
+
+			safeABI, err := safeContract.SafeMetaData.GetAbi()
+			require.NoError(t, err)
+			method, err := safeABI.MethodById(args.Data)
+			require.NoError(t, err)
+			require.Equal(t, "execTransaction", method.Name)
+			values, err := method.Inputs.Unpack(args.Data[4:])
+			require.NoError(t, err)
+			require.Equal(t, testRecipient, values[0])
+			require.Equal(t, big.NewInt(100), values[1])
+			return ethTypes.NewTx(&ethTypes.LegacyTx{}), 0, nil
+		}).Times(1)
+
+	_, _, err = s.processor.BuildTransactionV2(sendArgs, -1)
+	require.NoError(t, err)
+	require.Len(t, s.inner.built, 1)
+
+	// the SafeTx uses the current nonce of the Safe
+	s.expectSafeNonce(t, 5)
+	pending, err := s.processor.safeManager.PendingTransactions(context.Background(), mainnet.ChainID, testSafe)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, uint64(5), pending[0].Nonce)
+	require.Equal(t, testRecipient, pending[0].To)
+}
@@ -0,0 +1,151 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/smartaccount"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+// smartAccountGasOverhead covers the validation of the user operation by the account and the EntryPoint
+const smartAccountGasOverhead = uint64(100000)
+
+// SmartAccountProcessor sends the transactions of the wrapped processor from an ERC-4337 smart account, the call is
+// executed by the account through a user operation submitted to the bundler of the chain
+type SmartAccountProcessor struct {
+	PathProcessor
+	smartAccountManager *smartaccount.Manager
+}
+
+func NewSmartAccountProcessor(inner PathProcessor, smartAccountManager *smartaccount.Manager) *SmartAccountProcessor {
+	return &SmartAccountProcessor{
+		PathProcessor:       inner,
+		smartAccountManager: smartAccountManager,
+	}
+}
+
+func (s *SmartAccountProcessor) IsSmartAccount(chainID uint64, address common.Address) (bool, error) {
+	acc, err := s.smartAccountManager.GetAccount(address)
+	if err != nil {
+		return false, createErrorResponse(s.Name(), err)
+	}
+	return acc != nil, nil
+}
+
+func (s *SmartAccountProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	isSmartAccount, err := s.IsSmartAccount(params.FromChain.ChainID, params.FromAddr)
+	if err != nil {
+		return false, err
+	}
+	if isSmartAccount {
+		if params.FromChain.ChainID != params.ToChain.ChainID {
+			return false, ErrFromAndToChainsMustBeSame
+		}
+		if !s.smartAccountManager.IsAvailable(params.FromChain.ChainID) {
+			return false, nil
+		}
+	}
+	return s.PathProcessor.AvailableFor(params)
+}
+
+func (s *SmartAccountProcessor) EstimateGas(params ProcessorInputParams, input []byte) (uint64, error) {
+	estimation, err := s.PathProcessor.EstimateGas(params, input)
+	if err != nil || params.TestsMode {
+		return estimation, err
+	}
+
+	isSmartAccount, err := s.IsSmartAccount(params.FromChain.ChainID, params.FromAddr)
+	if err != nil {
+		return 0, err
+	}
+	if isSmartAccount {
+		estimation += smartAccountGasOverhead
+	}
+	return estimation, nil
+}
+
+func (s *SmartAccountProcessor) Send(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64, verifiedAccount *account.SelectedExtKey) (types.Hash, uint64, error) {
+	isSmartAccount, err := s.IsSmartAccount(sendArgs.ChainID, common.Address(sendArgs.From()))
+	if err != nil {
+		return types.Hash{}, 0, err
+	}
+	if isSmartAccount {
+		return types.Hash{}, 0, ErrSmartAccountSendNotSupported
+	}
+	return s.PathProcessor.Send(sendArgs, lastUsedNonce, verifiedAccount)
+}
+
+func (s *SmartAccountProcessor) BuildTransaction(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	isSmartAccount, err := s.IsSmartAccount(sendArgs.ChainID, common.Address(sendArgs.From()))
+	if err != nil {
+		return nil, 0, err
+	}
+	if isSmartAccount {
+		return nil, 0, ErrSmartAccountSendNotSupported
+	}
+	return s.PathProcessor.BuildTransaction(sendArgs, lastUsedNonce)
+}
+
+func (s *SmartAccountProcessor) BuildTransactionV2(sendArgs *wallettypes.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	isSmartAccount, err := s.IsSmartAccount(sendArgs.FromChainID, common.Address(sendArgs.From))
+	if err != nil {
+		return nil, 0, err
+	}
+	if isSmartAccount {
+		return nil, 0, ErrSmartAccountSendNotSupported
+	}
+	return s.PathProcessor.BuildTransactionV2(sendArgs, lastUsedNonce)
+}
+
+// BuildUserOperation builds the user operation making the smart account execute the call built by the wrapped processor
+func (s *SmartAccountProcessor) BuildUserOperation(sendArgs *wallettypes.SendTxArgs) (*wallettypes.UserOperation, types.Hash, error) {
+	var to common.Address
+	if sendArgs.To != nil {
+		to = common.Address(*sendArgs.To)
+	}
+	value := new(big.Int)
+	if sendArgs.Value != nil {
+		value = sendArgs.Value.ToInt()
+	}
+
+	var maxFeePerGas, maxPriorityFeePerGas *big.Int
+	if sendArgs.MaxFeePerGas != nil {
+		maxFeePerGas = sendArgs.MaxFeePerGas.ToInt()
+	} else if sendArgs.GasPrice != nil {
+		maxFeePerGas = sendArgs.GasPrice.ToInt()
+	}
+	if sendArgs.MaxPriorityFeePerGas != nil {
+		maxPriorityFeePerGas = sendArgs.MaxPriorityFeePerGas.ToInt()
+	}
+
+	op, hashToSign, err := s.smartAccountManager.BuildUserOperation(context.Background(), sendArgs.FromChainID, common.Address(sendArgs.From),
+		to, value, sendArgs.GetInput(), maxFeePerGas, maxPriorityFeePerGas)
+	if err != nil {
+		return nil, types.Hash{}, createErrorResponse(s.Name(), err)
+	}
+	return op, types.Hash(hashToSign), nil
+}
+
+func (s *SmartAccountProcessor) SendUserOperation(txData *wallettypes.TransactionData, multiTransactionID walletCommon.MultiTransactionIDType) (types.Hash, error) {
+	var to common.Address
+	if txData.TxArgs.To != nil {
+		to = common.Address(*txData.TxArgs.To)
+	}
+	var value *big.Int
+	if txData.TxArgs.Value != nil {
+		value = txData.TxArgs.Value.ToInt()
+	}
+
+	userOpHash, err := s.smartAccountManager.SendUserOperation(context.Background(), txData.TxArgs.FromChainID, txData.UserOp, txData.Signature,
+		to, value, txData.TxArgs.FromTokenID, multiTransactionID)
+	if err != nil {
+		return types.Hash{}, createErrorResponse(s.Name(), err)
+	}
+	return types.Hash(userOpHash), nil
+}
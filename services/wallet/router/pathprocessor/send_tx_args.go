@@ -0,0 +1,119 @@
+package pathprocessor
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+// BuildApprovalSendTxArgs creates the send tx args for the approval tx of the path
+func BuildApprovalSendTxArgs(path *routes.Path, addressFrom common.Address) *wallettypes.SendTxArgs {
+	addrTo := types.Address(path.FromToken.Address)
+	approavalSendArgs := &wallettypes.SendTxArgs{
+		Version: wallettypes.SendTxArgsVersion1,
+
+		// tx fields
+		From:     types.Address(addressFrom),
+		To:       &addrTo,
+		Value:    (*hexutil.Big)(big.NewInt(0)),
+		Data:     path.ApprovalPackedData,
+		Nonce:    path.ApprovalTxNonce,
+		Gas:      (*hexutil.Uint64)(&path.ApprovalGasAmount),
+		ValueOut: (*hexutil.Big)(big.NewInt(0)),
+
+		// additional fields version 1
+		FromChainID: path.FromChain.ChainID,
+	}
+
+	// set appropriate fields based on EIP-1559 compatibility of the chain
+	if !path.FromChain.EIP1559Enabled {
+		approavalSendArgs.GasPrice = path.ApprovalGasPrice
+	} else {
+		approavalSendArgs.MaxFeePerGas = path.ApprovalMaxFeesPerGas
+		approavalSendArgs.MaxPriorityFeePerGas = path.ApprovalPriorityFee
+	}
+
+	if path.FromToken != nil {
+		approavalSendArgs.FromTokenID = path.FromToken.Symbol
+	}
+
+	return approavalSendArgs
+}
+
+// BuildSendTxArgs creates the send tx args for the path, which are passed to `BuildTransactionV2` of the path processor
+func BuildSendTxArgs(path *routes.Path, processorInputParams *ProcessorInputParams) *wallettypes.SendTxArgs {
+	sendArgs := &wallettypes.SendTxArgs{
+		Version: wallettypes.SendTxArgsVersion1,
+
+		// tx fields
+		From:  types.Address(processorInputParams.FromAddr),
+		Value: path.AmountIn,
+		Data:  path.TxPackedData,
+		Nonce: path.TxNonce,
+		Gas:   (*hexutil.Uint64)(&path.TxGasAmount),
+
+		// additional fields version 1
+		ValueIn:            path.AmountIn,
+		ValueOut:           path.AmountOut,
+		FromChainID:        path.FromChain.ChainID,
+		ToChainID:          path.ToChain.ChainID,
+		SlippagePercentage: processorInputParams.SlippagePercentage,
+	}
+
+	if !path.FromChain.EIP1559Enabled {
+		sendArgs.GasPrice = path.TxGasPrice
+	} else {
+		sendArgs.MaxFeePerGas = path.TxMaxFeesPerGas
+		sendArgs.MaxPriorityFeePerGas = path.TxPriorityFee
+	}
+
+	isContractDeployment := path.ProcessorName == pathProcessorCommon.ProcessorCommunityDeployCollectiblesName ||
+		path.ProcessorName == pathProcessorCommon.ProcessorCommunityDeployAssetsName
+	if !isContractDeployment {
+		addrTo := types.Address(processorInputParams.ToAddr)
+		sendArgs.To = &addrTo
+	}
+
+	if path.FromToken != nil {
+		sendArgs.FromTokenID = path.FromToken.Symbol
+		sendArgs.ToContractAddress = types.Address(path.FromToken.Address)
+
+		// special handling for transfer tx if selected token is not ETH
+		// TODO: we should fix that in the trasactor, but till then, the best place to handle it is here
+		if !path.FromToken.IsNative() {
+			sendArgs.Value = (*hexutil.Big)(big.NewInt(0))
+
+			if path.ProcessorName == pathProcessorCommon.ProcessorTransferName ||
+				path.ProcessorName == pathProcessorCommon.ProcessorStickersBuyName ||
+				path.ProcessorName == pathProcessorCommon.ProcessorENSRegisterName ||
+				path.ProcessorName == pathProcessorCommon.ProcessorENSReleaseName ||
+				path.ProcessorName == pathProcessorCommon.ProcessorENSPublicKeyName ||
+				path.ProcessorName == pathProcessorCommon.ProcessorERC721Name ||
+				path.ProcessorName == pathProcessorCommon.ProcessorERC1155Name {
+				// TODO: update functions from `TransactorIface` to use `ToContractAddress` (as an address of the contract a transaction should be sent to)
+				// and `To` (as the destination address, recipient) of `SendTxArgs` struct appropriately
+				toContractAddr := types.Address(path.FromToken.Address)
+				sendArgs.To = &toContractAddr
+			}
+		} else if path.ProcessorName == pathProcessorCommon.ProcessorCommunityDeployOwnerTokenName || // special handling for community related txs, tokenID for those txs is ETH
+			path.ProcessorName == pathProcessorCommon.ProcessorCommunityMintTokensName ||
+			path.ProcessorName == pathProcessorCommon.ProcessorCommunityRemoteBurnName ||
+			path.ProcessorName == pathProcessorCommon.ProcessorCommunityBurnName ||
+			path.ProcessorName == pathProcessorCommon.ProcessorCommunitySetSignerPubKeyName ||
+			path.ProcessorName == pathProcessorCommon.ProcessorRevokeAllowanceName {
+			toContractAddr := types.Address(*path.UsedContractAddress)
+			sendArgs.To = &toContractAddr
+			sendArgs.ToContractAddress = toContractAddr
+		}
+	}
+	if path.ToToken != nil {
+		sendArgs.ToTokenID = path.ToToken.Symbol
+	}
+
+	return sendArgs
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/status-im/status-go/services/wallet/collectibles"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/poisoning"
 	"github.com/status-im/status-go/services/wallet/requests"
 	"github.com/status-im/status-go/services/wallet/responses"
 	"github.com/status-im/status-go/services/wallet/router/fees"
@@ -27,6 +28,8 @@ import (
 	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
 	"github.com/status-im/status-go/services/wallet/router/routes"
 	"github.com/status-im/status-go/services/wallet/router/sendtype"
+	"github.com/status-im/status-go/services/wallet/router/simulation"
+	"github.com/status-im/status-go/services/wallet/safe"
 	"github.com/status-im/status-go/services/wallet/token"
 	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
 	"github.com/status-im/status-go/signal"
@@ -44,16 +47,25 @@ func makeBalanceKey(chainID uint64, symbol string) string {
 	return fmt.Sprintf("%d-%s", chainID, symbol)
 }
 
+// makeFeeBalanceKey is the key of the native balance paying the fees when it's not the one of the sender, e.g. the
+// owner executing the transactions of a Safe
+func makeFeeBalanceKey(chainID uint64, symbol string) string {
+	return fmt.Sprintf("%d-%s%s", chainID, symbol, feeBalanceKeySuffix)
+}
+
+const feeBalanceKeySuffix = "-fee"
+
 type ProcessorError struct {
 	ProcessorName string
 	Error         error
 }
 
 type SuggestedRoutes struct {
-	Uuid          string
-	Best          routes.Route
-	Candidates    routes.Route
-	UpdatedPrices map[string]float64
+	Uuid            string
+	Best            routes.Route
+	Candidates      routes.Route
+	UpdatedPrices   map[string]float64
+	AddressWarnings []*poisoning.Warning
 }
 
 type Router struct {
@@ -64,8 +76,11 @@ type Router struct {
 	collectiblesService *collectibles.Service
 	collectiblesManager *collectibles.Manager
 	feesManager         *fees.FeeManager
+	simulator           *simulation.Simulator
 	pathProcessors      map[string]pathprocessor.PathProcessor
 	scheduler           *async.Scheduler
+	poisoningDetector   *poisoning.Detector
+	safeManager         *safe.Manager
 
 	activeBalanceMap sync.Map // map[string]*big.Int
 
@@ -95,6 +110,7 @@ func NewRouter(rpcClient *rpc.Client, transactor *transactions.Transactor, token
 		feesManager: &fees.FeeManager{
 			RPCClient: rpcClient,
 		},
+		simulator:      simulation.NewSimulator(rpcClient),
 		pathProcessors: processors,
 		scheduler:      async.NewScheduler(),
 	}
@@ -104,6 +120,31 @@ func (r *Router) AddPathProcessor(processor pathprocessor.PathProcessor) {
 	r.pathProcessors[processor.Name()] = processor
 }
 
+// SetPoisoningDetector enables the check of the destination address against the addresses known by the user
+func (r *Router) SetPoisoningDetector(detector *poisoning.Detector) {
+	r.poisoningDetector = detector
+}
+
+// SetSafeManager enables the check of the fees against the balance of the owner executing the Safe transactions
+func (r *Router) SetSafeManager(safeManager *safe.Manager) {
+	r.safeManager = safeManager
+}
+
+// feePayer returns the account paying the fees of the transactions sent from `from`, the executing owner for a Safe
+func (r *Router) feePayer(chainID uint64, from common.Address) (common.Address, error) {
+	if r.safeManager == nil {
+		return from, nil
+	}
+	info, err := r.safeManager.GetSafe(chainID, from)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if info == nil {
+		return from, nil
+	}
+	return r.safeManager.Executor(chainID, from)
+}
+
 func (r *Router) Stop() {
 	r.scheduler.Stop()
 }
@@ -242,6 +283,9 @@ func (r *Router) ReevaluateRouterPath(ctx context.Context, pathTxIdentity *reque
 			if err != nil {
 				return err
 			}
+			if path.GetRevokeAllowance() != nil {
+				processorInputParams.RevokeAllowance = path.GetRevokeAllowance()
+			}
 
 			txPackedData, err := pProcessor.PackTxInputData(processorInputParams)
 			if err != nil {
@@ -295,7 +339,8 @@ func newSuggestedRoutes(
 		return suggestedRoutes, nil
 	}
 
-	if input.SendType.IsCommunityRelatedTransfer() {
+	// all community txs or revokes are sent together, the candidates are the only route
+	if input.SendType.IsCommunityRelatedTransfer() || input.SendType == sendtype.RevokeAllowances {
 		res := make([]routes.Route, 0)
 		res = append(res, candidates)
 		return suggestedRoutes, res
@@ -335,6 +380,7 @@ func sendRouterResult(uuid string, result interface{}, err error) {
 		routesResponse.Best = suggestedRoutes.Best
 		routesResponse.Candidates = suggestedRoutes.Candidates
 		routesResponse.UpdatedPrices = suggestedRoutes.UpdatedPrices
+		routesResponse.AddressWarnings = suggestedRoutes.AddressWarnings
 		emptySignal = false
 	}
 
@@ -405,6 +451,8 @@ func (r *Router) SuggestedRoutes(ctx context.Context, input *requests.RouteInput
 			for _, path := range suggestedRoutes.Best {
 				err = r.subscribeForUdates(path.FromChain.ChainID)
 			}
+			// the simulation doesn't delay the suggested routes, its outcome is sent as a route update
+			r.simulateRouteAsync(input, suggestedRoutes)
 		}
 		r.routeCanceledMutex.Unlock()
 	}()
@@ -430,6 +478,10 @@ func (r *Router) SuggestedRoutes(ctx context.Context, input *requests.RouteInput
 	// return only if there are no balances, otherwise try to resolve the candidates for chains we know the balances for
 	noBalanceOnAnyChain := true
 	r.activeBalanceMap.Range(func(key, value interface{}) bool {
+		// the balance paying the fees of another account can't be sent
+		if strings.HasSuffix(key.(string), feeBalanceKeySuffix) {
+			return true
+		}
 		if value.(*big.Int).Cmp(walletCommon.ZeroBigIntValue()) > 0 {
 			noBalanceOnAnyChain = false
 			return false
@@ -453,6 +505,9 @@ func (r *Router) SuggestedRoutes(ctx context.Context, input *requests.RouteInput
 		nativeTokenSymbol = selectedFromChains[0].NativeCurrencySymbol
 	}
 	suggestedRoutes, err = r.resolveRoutes(ctx, input, candidates, nativeTokenSymbol)
+	if err == nil && suggestedRoutes != nil {
+		suggestedRoutes.AddressWarnings = r.checkDestinationAddress(input)
+	}
 
 	if err == nil && (suggestedRoutes == nil || len(suggestedRoutes.Best) == 0) {
 		// No best route found, but no error given.
@@ -492,6 +547,21 @@ func (r *Router) SuggestedRoutes(ctx context.Context, input *requests.RouteInput
 	return suggestedRoutes, mapError(err)
 }
 
+// checkDestinationAddress returns the warnings for a destination address looking like a recent counterparty or a saved
+// address, a sign of address poisoning. Failing to check doesn't prevent the send.
+func (r *Router) checkDestinationAddress(input *requests.RouteInputParams) []*poisoning.Warning {
+	if r.poisoningDetector == nil || input.AddrTo == (common.Address{}) || input.AddrTo == input.AddrFrom {
+		return nil
+	}
+
+	warnings, err := r.poisoningDetector.CheckRecipient(input.AddrTo)
+	if err != nil {
+		logutils.ZapLogger().Error("failed to check the destination address", zap.Error(err))
+		return nil
+	}
+	return warnings
+}
+
 // prepareBalanceMapForTokenOnChains prepares the balance map for passed address, where the key is in format "chainID-tokenSymbol" and
 // value is the balance of the token. Native token (EHT) is always added to the balance map.
 func (r *Router) prepareBalanceMapForTokenOnChains(ctx context.Context, input *requests.RouteInputParams, selectedFromChains []*params.Network) (err error) {
@@ -547,18 +617,29 @@ func (r *Router) prepareBalanceMapForTokenOnChains(ctx context.Context, input *r
 			r.activeBalanceMap.Store(makeBalanceKey(chain.ChainID, token.Symbol), tokenBalance)
 		}
 
-		if token.IsNative() {
+		// the fees are paid by the sender unless it's a Safe
+		feePayer, err := r.feePayer(chain.ChainID, input.AddrFrom)
+		if err != nil {
+			chainError(chain.ChainID, nativeToken.Symbol, errors.CreateErrorResponseFromError(err))
+			continue
+		}
+
+		if token.IsNative() && feePayer == input.AddrFrom {
 			continue
 		}
 
 		// add native token balance for the chain
-		nativeBalance, err := r.getBalance(ctx, chain.ChainID, nativeToken, input.AddrFrom)
+		nativeBalance, err := r.getBalance(ctx, chain.ChainID, nativeToken, feePayer)
 		if err != nil {
 			chainError(chain.ChainID, token.Symbol, errors.CreateErrorResponseFromError(err))
 		}
 		// add only if balance is not nil
 		if nativeBalance != nil {
-			r.activeBalanceMap.Store(makeBalanceKey(chain.ChainID, nativeToken.Symbol), nativeBalance)
+			if feePayer == input.AddrFrom {
+				r.activeBalanceMap.Store(makeBalanceKey(chain.ChainID, nativeToken.Symbol), nativeBalance)
+			} else {
+				r.activeBalanceMap.Store(makeFeeBalanceKey(chain.ChainID, nativeToken.Symbol), nativeBalance)
+			}
 		}
 	}
 
@@ -590,7 +671,7 @@ func (r *Router) getSelectedChains(input *requests.RouteInputParams) (selectedFr
 }
 
 func (r *Router) CreateProcessorInputParams(input *requests.RouteInputParams, fromNetwork *params.Network, toNetwork *params.Network,
-	fromToken *tokenTypes.Token, toToken *tokenTypes.Token, useCommunityTokenTransferDetailsAtIndex int) (pathprocessor.ProcessorInputParams, error) {
+	fromToken *tokenTypes.Token, toToken *tokenTypes.Token, paramsIndex int) (pathprocessor.ProcessorInputParams, error) {
 	var err error
 	processorInputParams := pathprocessor.ProcessorInputParams{
 		FromChain:          fromNetwork,
@@ -605,6 +686,9 @@ func (r *Router) CreateProcessorInputParams(input *requests.RouteInputParams, fr
 		Username:  input.Username,
 		PublicKey: input.PublicKey,
 		PackID:    input.PackID.ToInt(),
+
+		BatchTransactions:   input.BatchTransactions,
+		DelegationSignature: input.DelegationSignature,
 	}
 
 	if input.AmountOut != nil {
@@ -619,7 +703,7 @@ func (r *Router) CreateProcessorInputParams(input *requests.RouteInputParams, fr
 		processorInputParams.CommunityParams = input.CommunityRouteInputParams
 
 		if input.CommunityRouteInputParams.UseTransferDetails() && fromNetwork != nil {
-			tokenContractAddress := input.CommunityRouteInputParams.TransferDetails[useCommunityTokenTransferDetailsAtIndex].TokenContractAddress
+			tokenContractAddress := input.CommunityRouteInputParams.TransferDetails[paramsIndex].TokenContractAddress
 			tokenType, err := r.tokenManager.GetCommunityTokenType(fromNetwork.ChainID, tokenContractAddress.String())
 			if err != nil {
 				return processorInputParams, err
@@ -630,16 +714,20 @@ func (r *Router) CreateProcessorInputParams(input *requests.RouteInputParams, fr
 				return processorInputParams, err
 			}
 
-			input.CommunityRouteInputParams.TransferDetails[useCommunityTokenTransferDetailsAtIndex].TokenType = tokenType
-			input.CommunityRouteInputParams.TransferDetails[useCommunityTokenTransferDetailsAtIndex].PrivilegeLevel = privilegeLevel
+			input.CommunityRouteInputParams.TransferDetails[paramsIndex].TokenType = tokenType
+			input.CommunityRouteInputParams.TransferDetails[paramsIndex].PrivilegeLevel = privilegeLevel
 
-			err = input.CommunityRouteInputParams.SetInternalParams(useCommunityTokenTransferDetailsAtIndex)
+			err = input.CommunityRouteInputParams.SetInternalParams(paramsIndex)
 			if err != nil {
 				return processorInputParams, err
 			}
 		}
 	}
 
+	if input.SendType == sendtype.RevokeAllowances && fromNetwork != nil && paramsIndex < len(input.RevokeAllowances) {
+		processorInputParams.RevokeAllowance = input.RevokeAllowances[paramsIndex]
+	}
+
 	if input.TestsMode {
 		processorInputParams.TestsMode = input.TestsMode
 		processorInputParams.TestEstimationMap = input.TestParams.EstimationMap
@@ -765,6 +853,21 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 								continue
 							}
 
+							appendPathFn(path)
+						}
+					} else if input.SendType == sendtype.RevokeAllowances {
+						for i, revoke := range input.RevokeAllowances {
+							if revoke.ChainID != network.ChainID {
+								continue
+							}
+							usedNoncesMu.Lock()
+							path, err := r.buildPath(ctx, input, network, dest, token, toToken, pProcessor, fetchedFees, usedNonces, i)
+							usedNoncesMu.Unlock()
+							if err != nil {
+								appendProcessorErrorFn(pProcessor.Name(), input.SendType, network.ChainID, dest.ChainID, input.AmountIn.ToInt(), err)
+								continue
+							}
+
 							appendPathFn(path)
 						}
 					} else {
@@ -797,7 +900,7 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 func (r *Router) buildPath(ctx context.Context, input *requests.RouteInputParams, fromNetwork *params.Network,
 	toNetwork *params.Network, fromToken *tokenTypes.Token, toToken *tokenTypes.Token,
 	pathProcessor pathprocessor.PathProcessor, fetchedFees *fees.SuggestedFees, usedNonces map[uint64]uint64,
-	useCommunityTokenTransferDetailsAtIndex int) (*routes.Path, error) {
+	paramsIndex int) (*routes.Path, error) {
 	if !input.SendType.IsAvailableFor(fromNetwork) {
 		return nil, ErrPathNotSupportedForProvidedChain
 	}
@@ -806,7 +909,7 @@ func (r *Router) buildPath(ctx context.Context, input *requests.RouteInputParams
 		return nil, ErrPathNotSupportedBetweenProvidedChains
 	}
 
-	processorInputParams, err := r.CreateProcessorInputParams(input, fromNetwork, toNetwork, fromToken, toToken, useCommunityTokenTransferDetailsAtIndex)
+	processorInputParams, err := r.CreateProcessorInputParams(input, fromNetwork, toNetwork, fromToken, toToken, paramsIndex)
 	if err != nil {
 		return nil, err
 	}
@@ -900,8 +1003,10 @@ func (r *Router) buildPath(ctx context.Context, input *requests.RouteInputParams
 		ApprovalGasAmount:       approvalGasLimit,
 	}
 
+	// the fees are not taken from the amount when they are paid by another account
+	_, separateFeePayer := r.activeBalanceMap.Load(makeFeeBalanceKey(path.FromChain.ChainID, path.FromChain.NativeCurrencySymbol))
 	tokenBalance, ok := r.activeBalanceMap.Load(makeBalanceKey(path.FromChain.ChainID, path.FromToken.Symbol))
-	if ok {
+	if ok && !separateFeePayer {
 		tokenBalanceBigInt, ok := tokenBalance.(*big.Int)
 		if ok &&
 			processorInputParams.AmountIn.Cmp(walletCommon.ZeroBigIntValue()) > 0 &&
@@ -915,7 +1020,7 @@ func (r *Router) buildPath(ctx context.Context, input *requests.RouteInputParams
 		communityParams := processorInputParams.CommunityParams.Copy()
 		if input.UseCommunityTransferDetails() {
 			// in case of multi token community transfer we need to set the internal params to refer to the correct token
-			err = communityParams.SetInternalParams(useCommunityTokenTransferDetailsAtIndex)
+			err = communityParams.SetInternalParams(paramsIndex)
 			if err != nil {
 				return nil, err
 			}
@@ -923,6 +1028,10 @@ func (r *Router) buildPath(ctx context.Context, input *requests.RouteInputParams
 		path.SetCommunityParams(communityParams)
 	}
 
+	if processorInputParams.RevokeAllowance != nil {
+		path.SetRevokeAllowance(processorInputParams.RevokeAllowance.Copy())
+	}
+
 	err = r.evaluateAndUpdatePathDetails(ctx, path, fetchedFees, usedNonces, processorInputParams.TestsMode, processorInputParams.TestApprovalL1Fee)
 	if err != nil {
 		return nil, err
@@ -957,31 +1066,45 @@ func (r *Router) checkBalancesForTheBestRoute(ctx context.Context, bestRoute rou
 			}
 		}
 
-		if path.RequiredTokenBalance != nil && path.RequiredTokenBalance.Cmp(walletCommon.ZeroBigIntValue()) > 0 {
+		requiredTokenBalance := path.RequiredTokenBalance
+		requiredNativeBalance := path.RequiredNativeBalance
+		nativeTokenKey := makeBalanceKey(path.FromChain.ChainID, path.FromChain.NativeCurrencySymbol)
+		if feeTokenKey := makeFeeBalanceKey(path.FromChain.ChainID, path.FromChain.NativeCurrencySymbol); balanceMapCopy[feeTokenKey] != nil {
+			// the fees of a Safe are paid by its executing owner, the Safe pays only the amount
+			nativeTokenKey = feeTokenKey
+			requiredNativeBalance = new(big.Int)
+			if path.TxTotalFee != nil {
+				requiredNativeBalance = path.TxTotalFee.ToInt()
+			}
+			if path.FromToken.IsNative() {
+				requiredTokenBalance = path.AmountIn.ToInt()
+			}
+		}
+
+		if requiredTokenBalance != nil && requiredTokenBalance.Cmp(walletCommon.ZeroBigIntValue()) > 0 {
 			if tokenBalance, ok := balanceMapCopy[tokenKey]; ok {
-				if tokenBalance.Cmp(path.RequiredTokenBalance) == -1 {
+				if tokenBalance.Cmp(requiredTokenBalance) == -1 {
 					err := &errors.ErrorResponse{
 						Code:    ErrNotEnoughTokenBalance.Code,
 						Details: fmt.Sprintf(ErrNotEnoughTokenBalance.Details, path.FromToken.Symbol, path.FromChain.ChainID),
 					}
 					return hasPositiveBalance, err
 				}
-				balanceMapCopy[tokenKey].Sub(tokenBalance, path.RequiredTokenBalance)
+				balanceMapCopy[tokenKey].Sub(tokenBalance, requiredTokenBalance)
 			} else {
 				return hasPositiveBalance, ErrTokenNotFound
 			}
 		}
 
-		nativeTokenKey := makeBalanceKey(path.FromChain.ChainID, path.FromChain.NativeCurrencySymbol)
 		if nativeBalance, ok := balanceMapCopy[nativeTokenKey]; ok {
-			if nativeBalance.Cmp(path.RequiredNativeBalance) == -1 {
+			if nativeBalance.Cmp(requiredNativeBalance) == -1 {
 				err := &errors.ErrorResponse{
 					Code:    ErrNotEnoughNativeBalance.Code,
 					Details: fmt.Sprintf(ErrNotEnoughNativeBalance.Details, path.FromChain.NativeCurrencySymbol, path.FromChain.ChainID),
 				}
 				return hasPositiveBalance, err
 			}
-			balanceMapCopy[nativeTokenKey].Sub(nativeBalance, path.RequiredNativeBalance)
+			balanceMapCopy[nativeTokenKey].Sub(nativeBalance, requiredNativeBalance)
 		} else {
 			return hasPositiveBalance, ErrNativeTokenNotFound
 		}
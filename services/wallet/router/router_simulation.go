@@ -0,0 +1,131 @@
+package router
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/router/simulation"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+// simulationTimeout bounds the simulation of all the paths of a route
+const simulationTimeout = 30 * time.Second
+
+// simulateRouteAsync simulates the best route in the background, once done the outcome is attached to the paths of the
+// route if it's still the active one and the clients are informed with the updated route
+func (r *Router) simulateRouteAsync(input *requests.RouteInputParams, suggestedRoutes *SuggestedRoutes) {
+	if input.TestsMode || r.simulator == nil || suggestedRoutes == nil || len(suggestedRoutes.Best) == 0 {
+		return
+	}
+
+	go func() {
+		defer gocommon.LogOnPanic()
+
+		// the paths of the active route are updated on new blocks, the simulation works on their copies
+		r.activeRoutesMutex.Lock()
+		if r.activeRoutes != suggestedRoutes {
+			r.activeRoutesMutex.Unlock()
+			return
+		}
+		route := suggestedRoutes.Best.Copy()
+		r.activeRoutesMutex.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), simulationTimeout)
+		defer cancel()
+		results := r.simulateRoute(ctx, input, route)
+
+		r.activeRoutesMutex.Lock()
+		if r.activeRoutes != suggestedRoutes || len(suggestedRoutes.Best) != len(results) {
+			r.activeRoutesMutex.Unlock()
+			return
+		}
+		for i, path := range suggestedRoutes.Best {
+			path.TxSimulation = results[i]
+		}
+		r.activeRoutesMutex.Unlock()
+
+		r.sendUpdatesError(nil)
+	}()
+}
+
+// simulateRoute simulates the transactions of each path against the latest block and returns the outcome per path.
+// Simulation is best effort, if it fails the path is left without simulation details.
+func (r *Router) simulateRoute(ctx context.Context, input *requests.RouteInputParams, route routes.Route) []*simulation.Result {
+	results := make([]*simulation.Result, len(route))
+
+	// re-use path processor input params structure the same way it's done when building the transactions for signing
+	processorInputParams, err := r.CreateProcessorInputParams(input, nil, nil, nil, nil, 0)
+	if err != nil {
+		logutils.ZapLogger().Warn("failed to create processor input params for simulation", zap.Error(err))
+		return results
+	}
+
+	usedNonces := make(map[uint64]int64)
+	for i, path := range route {
+		results[i], err = r.simulatePath(ctx, path, &processorInputParams, usedNonces)
+		if err != nil {
+			logutils.ZapLogger().Warn("failed to simulate path",
+				zap.String("processor", path.ProcessorName),
+				zap.Uint64("chainID", path.FromChain.ChainID),
+				zap.Error(err))
+		}
+	}
+	return results
+}
+
+func (r *Router) simulatePath(ctx context.Context, path *routes.Path, processorInputParams *pathprocessor.ProcessorInputParams,
+	usedNonces map[uint64]int64) (*simulation.Result, error) {
+	processor, ok := r.pathProcessors[path.ProcessorName]
+	if !ok {
+		return nil, ErrCannotFindPathProcessorForProvidedIdentity
+	}
+
+	lastUsedNonce := int64(-1)
+	if nonce, ok := usedNonces[path.FromChain.ChainID]; ok {
+		lastUsedNonce = nonce
+	}
+
+	var calls []simulation.Call
+	// the approval must be applied first, otherwise the path tx would revert because of the missing allowance
+	if path.ApprovalRequired {
+		calls = append(calls, callFromSendTxArgs(pathprocessor.BuildApprovalSendTxArgs(path, processorInputParams.FromAddr)))
+	}
+
+	sendArgs := pathprocessor.BuildSendTxArgs(path, processorInputParams)
+	tx, usedNonce, err := processor.BuildTransactionV2(sendArgs, lastUsedNonce)
+	if err != nil {
+		return nil, err
+	}
+	usedNonces[path.FromChain.ChainID] = int64(usedNonce)
+
+	calls = append(calls, simulation.CallFromTransaction(processorInputParams.FromAddr, tx))
+
+	return r.simulator.SimulateCalls(ctx, path.FromChain.ChainID, calls)
+}
+
+func callFromSendTxArgs(args *wallettypes.SendTxArgs) simulation.Call {
+	call := simulation.Call{
+		From: common.Address(args.From),
+		Data: args.Data,
+	}
+	if args.To != nil {
+		to := common.Address(*args.To)
+		call.To = &to
+	}
+	if args.Value != nil {
+		call.Value = args.Value.ToInt()
+	}
+	if args.Gas != nil {
+		call.Gas = uint64(*args.Gas)
+	}
+	return call
+}
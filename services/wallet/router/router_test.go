@@ -89,6 +89,9 @@ func setupRouter(t *testing.T) (*Router, func()) {
 	buyStickers := pathprocessor.NewStickersBuyProcessor(nil, nil)
 	router.AddPathProcessor(buyStickers)
 
+	revokeAllowance := pathprocessor.NewRevokeAllowanceProcessor(nil, nil)
+	router.AddPathProcessor(revokeAllowance)
+
 	return router, cleanTmpDb
 }
 
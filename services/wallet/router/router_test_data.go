@@ -66,8 +66,9 @@ const (
 
 var (
 	testEstimationMap = map[string]requests.Estimation{
-		pathProcessorCommon.ProcessorTransferName:  {Value: uint64(1000), Err: nil},
-		pathProcessorCommon.ProcessorBridgeHopName: {Value: uint64(5000), Err: nil},
+		pathProcessorCommon.ProcessorTransferName:        {Value: uint64(1000), Err: nil},
+		pathProcessorCommon.ProcessorBridgeHopName:       {Value: uint64(5000), Err: nil},
+		pathProcessorCommon.ProcessorRevokeAllowanceName: {Value: uint64(500), Err: nil},
 	}
 
 	testBBonderFeeMap = map[string]*big.Int{
@@ -2763,6 +2764,52 @@ func getNormalTestParamsList() []normalTestParams {
 				},
 			},
 		},
+		{
+			name: "ETH transfer from a Safe - Not Enough Native Balance For The Fees",
+			input: &requests.RouteInputParams{
+				TestnetMode:          false,
+				Uuid:                 uuid.NewString(),
+				SendType:             sendtype.Transfer,
+				AddrFrom:             common.HexToAddress("0x1"),
+				AddrTo:               common.HexToAddress("0x2"),
+				AmountIn:             (*hexutil.Big)(big.NewInt(testAmount1ETHInWei)),
+				TokenID:              walletCommon.EthSymbol,
+				DisabledFromChainIDs: []uint64{walletCommon.OptimismMainnet, walletCommon.ArbitrumMainnet, walletCommon.BaseMainnet, walletCommon.BSCMainnet},
+				DisabledToChainIDs:   []uint64{walletCommon.EthereumMainnet, walletCommon.ArbitrumMainnet, walletCommon.BaseMainnet, walletCommon.BSCMainnet},
+
+				TestsMode: true,
+				TestParams: &requests.RouterTestParams{
+					TokenFrom: &tokenTypes.Token{
+						ChainID:  1,
+						Symbol:   walletCommon.EthSymbol,
+						Decimals: 18,
+					},
+					TokenPrices:   testTokenPrices,
+					SuggestedFees: testSuggestedFees,
+					// the Safe holds the amount, the owner executing its transactions can't pay the fees
+					BalanceMap: map[string]*big.Int{
+						makeBalanceKey(walletCommon.EthereumMainnet, walletCommon.EthSymbol):    big.NewInt(testAmount2ETHInWei),
+						makeFeeBalanceKey(walletCommon.EthereumMainnet, walletCommon.EthSymbol): big.NewInt(0),
+					},
+					EstimationMap:         testEstimationMap,
+					BonderFeeMap:          testBBonderFeeMap,
+					ApprovalGasEstimation: testApprovalGasEstimation,
+					ApprovalL1Fee:         testApprovalL1Fee,
+				},
+			},
+			expectedError: &errors.ErrorResponse{
+				Code:    ErrNotEnoughNativeBalance.Code,
+				Details: fmt.Sprintf(ErrNotEnoughNativeBalance.Details, walletCommon.EthSymbol, walletCommon.EthereumMainnet),
+			},
+			expectedCandidates: routes.Route{
+				{
+					ProcessorName:    pathProcessorCommon.ProcessorBridgeHopName,
+					FromChain:        &mainnet,
+					ToChain:          &optimism,
+					ApprovalRequired: false,
+				},
+			},
+		},
 		{
 			name: "ETH transfer - Not Enough Native Balance",
 			input: &requests.RouteInputParams{
@@ -2929,6 +2976,74 @@ func getNormalTestParamsList() []normalTestParams {
 				},
 			},
 		},
+		{
+			name: "Revoke allowances - One route for all revokes on their chains",
+			input: &requests.RouteInputParams{
+				TestnetMode:          false,
+				Uuid:                 uuid.NewString(),
+				SendType:             sendtype.RevokeAllowances,
+				AddrFrom:             common.HexToAddress("0x1"),
+				AddrTo:               common.HexToAddress("0x1"),
+				AmountIn:             (*hexutil.Big)(big.NewInt(0)),
+				TokenID:              walletCommon.EthSymbol,
+				DisabledFromChainIDs: []uint64{walletCommon.ArbitrumMainnet, walletCommon.BaseMainnet, walletCommon.BSCMainnet},
+				DisabledToChainIDs:   []uint64{walletCommon.ArbitrumMainnet, walletCommon.BaseMainnet, walletCommon.BSCMainnet},
+				RevokeAllowances: []*requests.RevokeAllowanceParams{
+					{
+						ChainID: walletCommon.EthereumMainnet,
+						Token:   common.HexToAddress("0x10"),
+						Spender: common.HexToAddress("0x20"),
+					},
+					{
+						ChainID:        walletCommon.EthereumMainnet,
+						Token:          common.HexToAddress("0x11"),
+						Spender:        common.HexToAddress("0x20"),
+						ApprovalForAll: true,
+					},
+					{
+						ChainID: walletCommon.OptimismMainnet,
+						Token:   common.HexToAddress("0x12"),
+						Spender: common.HexToAddress("0x21"),
+					},
+				},
+
+				TestsMode: true,
+				TestParams: &requests.RouterTestParams{
+					TokenFrom: &tokenTypes.Token{
+						ChainID:  1,
+						Symbol:   walletCommon.EthSymbol,
+						Decimals: 18,
+					},
+					TokenPrices:           testTokenPrices,
+					SuggestedFees:         testSuggestedFees,
+					BalanceMap:            testBalanceMapPerChain,
+					EstimationMap:         testEstimationMap,
+					BonderFeeMap:          testBBonderFeeMap,
+					ApprovalGasEstimation: testApprovalGasEstimation,
+					ApprovalL1Fee:         testApprovalL1Fee,
+				},
+			},
+			expectedCandidates: routes.Route{
+				{
+					ProcessorName:    pathProcessorCommon.ProcessorRevokeAllowanceName,
+					FromChain:        &mainnet,
+					ToChain:          &mainnet,
+					ApprovalRequired: false,
+				},
+				{
+					ProcessorName:    pathProcessorCommon.ProcessorRevokeAllowanceName,
+					FromChain:        &mainnet,
+					ToChain:          &mainnet,
+					ApprovalRequired: false,
+				},
+				{
+					ProcessorName:    pathProcessorCommon.ProcessorRevokeAllowanceName,
+					FromChain:        &optimism,
+					ToChain:          &optimism,
+					ApprovalRequired: false,
+				},
+			},
+		},
 	}
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/services/wallet/requests"
 	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/router/simulation"
 	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
 )
 
@@ -70,16 +71,22 @@ type Path struct {
 	RequiredNativeBalance *big.Int // (in base unit of the chain eg. WEI for ETH or BNB)
 	SubtractFees          bool
 
+	TxSimulation *simulation.Result // Outcome of the transaction simulated against the latest block, nil if the simulation wasn't possible
+
 	// used internally
 	communityParams *requests.CommunityRouteInputParams
+	revokeAllowance *requests.RevokeAllowanceParams
 }
 
 func (p *Path) PathIdentity() string {
-	var communityID string
+	// paths of the same processor and chain are told apart by the community token or the revoked allowance
+	var paramsID string
 	if p.communityParams != nil {
-		communityID = p.communityParams.ID()
+		paramsID = p.communityParams.ID()
+	} else if p.revokeAllowance != nil {
+		paramsID = p.revokeAllowance.ID()
 	}
-	return fmt.Sprintf("%s-%s-%d-%s", p.RouterInputParamsUuid, p.ProcessorName, p.FromChain.ChainID, communityID)
+	return fmt.Sprintf("%s-%s-%d-%s", p.RouterInputParamsUuid, p.ProcessorName, p.FromChain.ChainID, paramsID)
 }
 
 func (p *Path) TxIdentityKey(approval bool) string {
@@ -98,6 +105,14 @@ func (p *Path) GetCommunityParams() *requests.CommunityRouteInputParams {
 	return p.communityParams
 }
 
+func (p *Path) SetRevokeAllowance(params *requests.RevokeAllowanceParams) {
+	p.revokeAllowance = params
+}
+
+func (p *Path) GetRevokeAllowance() *requests.RevokeAllowanceParams {
+	return p.revokeAllowance
+}
+
 func (p *Path) Copy() *Path {
 	newPath := &Path{
 		RouterInputParamsUuid:      p.RouterInputParamsUuid,
@@ -299,9 +314,17 @@ func (p *Path) Copy() *Path {
 		newPath.RequiredNativeBalance = big.NewInt(0).Set(p.RequiredNativeBalance)
 	}
 
+	if p.TxSimulation != nil {
+		newPath.TxSimulation = p.TxSimulation.Copy()
+	}
+
 	if p.communityParams != nil {
 		newPath.communityParams = p.communityParams.Copy()
 	}
 
+	if p.revokeAllowance != nil {
+		newPath.revokeAllowance = p.revokeAllowance.Copy()
+	}
+
 	return newPath
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/router/simulation"
 	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
 )
 
@@ -52,6 +53,16 @@ func TestCopyPath(t *testing.T) {
 		RequiredTokenBalance:    big.NewInt(100),
 		RequiredNativeBalance:   big.NewInt(100),
 		SubtractFees:            true,
+		TxSimulation: &simulation.Result{
+			Method: simulation.MethodSimulateV1,
+			AssetDeltas: []*simulation.AssetDelta{
+				{
+					Account: addr,
+					TokenID: (*hexutil.Big)(big.NewInt(1)),
+					Amount:  (*hexutil.Big)(big.NewInt(-100)),
+				},
+			},
+		},
 	}
 
 	newPath := path.Copy()
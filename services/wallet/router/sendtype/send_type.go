@@ -27,6 +27,7 @@ const (
 	CommunityMintTokens
 	CommunityRemoteBurn
 	CommunitySetSignerPubKey
+	RevokeAllowances
 )
 
 func (s SendType) IsCollectiblesTransfer() bool {
@@ -82,6 +83,8 @@ func (s SendType) CanUseProcessor(pathProcessorName string) bool {
 		return pathProcessorName == pathProcessorCommon.ProcessorCommunityRemoteBurnName
 	case CommunitySetSignerPubKey:
 		return pathProcessorName == pathProcessorCommon.ProcessorCommunitySetSignerPubKeyName
+	case RevokeAllowances:
+		return pathProcessorName == pathProcessorCommon.ProcessorRevokeAllowanceName
 	default:
 		return true
 	}
@@ -97,7 +100,7 @@ func (s SendType) ProcessZeroAmountInProcessor(amountIn *big.Int, amountOut *big
 			if amountOut.Cmp(walletCommon.ZeroBigIntValue()) == 0 {
 				return false
 			}
-		} else if s.IsCommunityRelatedTransfer() {
+		} else if s.IsCommunityRelatedTransfer() || s == RevokeAllowances {
 			return true
 		} else if s != ENSRelease {
 			return false
@@ -112,6 +115,7 @@ func (s SendType) IsAvailableBetween(from, to *params.Network) bool {
 		s.IsEnsTransfer() ||
 		s.IsStickersTransfer() ||
 		s.IsCommunityRelatedTransfer() ||
+		s == RevokeAllowances ||
 		s == Swap {
 		return from.ChainID == to.ChainID
 	}
@@ -0,0 +1,138 @@
+package simulation
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+// NativeTransferLogAddress is the address `eth_simulateV1` uses as emitter of the native transfer logs when
+// `traceTransfers` is enabled
+var NativeTransferLogAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// AssetDelta represents the balance change of an account for a single asset
+type AssetDelta struct {
+	Account         common.Address    `json:"account"`
+	TokenType       walletCommon.Type `json:"tokenType"`
+	ContractAddress common.Address    `json:"contractAddress"` // zero address for the native asset
+	TokenID         *hexutil.Big      `json:"tokenId,omitempty"`
+	Amount          *hexutil.Big      `json:"amount"` // negative if the account loses the asset
+}
+
+func (d *AssetDelta) Copy() *AssetDelta {
+	newDelta := *d
+	if d.TokenID != nil {
+		newDelta.TokenID = (*hexutil.Big)(new(big.Int).Set(d.TokenID.ToInt()))
+	}
+	if d.Amount != nil {
+		newDelta.Amount = (*hexutil.Big)(new(big.Int).Set(d.Amount.ToInt()))
+	}
+	return &newDelta
+}
+
+type assetDeltaKey struct {
+	account         common.Address
+	tokenType       walletCommon.Type
+	contractAddress common.Address
+	tokenID         string
+}
+
+// assetDeltas sums up the transfers per account and asset, keeping the order in which the assets were seen
+type assetDeltas struct {
+	keys   []assetDeltaKey
+	deltas map[assetDeltaKey]*AssetDelta
+}
+
+func newAssetDeltas() *assetDeltas {
+	return &assetDeltas{
+		deltas: make(map[assetDeltaKey]*AssetDelta),
+	}
+}
+
+func (d *assetDeltas) addLog(log *ethTypes.Log) {
+	eventType := walletCommon.GetEventType(log)
+	tokenType := walletCommon.EventTypeToSubtransactionType(eventType)
+	switch tokenType {
+	case walletCommon.Erc20Transfer, walletCommon.Erc721Transfer, walletCommon.Erc1155Transfer:
+	default:
+		return
+	}
+
+	from, to, _, tokenIDs, values, err := walletCommon.ParseTransferLog(*log)
+	if err != nil {
+		return
+	}
+
+	if tokenType == walletCommon.Erc20Transfer {
+		if log.Address == NativeTransferLogAddress {
+			d.addNative(from, to, values[0])
+			return
+		}
+		d.add(from, to, tokenType, log.Address, nil, values[0])
+		return
+	}
+
+	for i := range tokenIDs {
+		d.add(from, to, tokenType, log.Address, tokenIDs[i], values[i])
+	}
+}
+
+func (d *assetDeltas) addNative(from, to common.Address, amount *big.Int) {
+	d.add(from, to, walletCommon.EthTransfer, common.Address{}, nil, amount)
+}
+
+func (d *assetDeltas) add(from, to common.Address, tokenType walletCommon.Type, contractAddress common.Address, tokenID *big.Int, amount *big.Int) {
+	if amount == nil || amount.Sign() == 0 {
+		return
+	}
+
+	// zero address is the counterparty of mints and burns, it's not an account which holds assets
+	if from != (common.Address{}) {
+		d.update(from, tokenType, contractAddress, tokenID, new(big.Int).Neg(amount))
+	}
+	if to != (common.Address{}) {
+		d.update(to, tokenType, contractAddress, tokenID, amount)
+	}
+}
+
+func (d *assetDeltas) update(account common.Address, tokenType walletCommon.Type, contractAddress common.Address, tokenID *big.Int, amount *big.Int) {
+	key := assetDeltaKey{
+		account:         account,
+		tokenType:       tokenType,
+		contractAddress: contractAddress,
+	}
+	if tokenID != nil {
+		key.tokenID = tokenID.String()
+	}
+
+	delta, ok := d.deltas[key]
+	if !ok {
+		delta = &AssetDelta{
+			Account:         account,
+			TokenType:       tokenType,
+			ContractAddress: contractAddress,
+			Amount:          (*hexutil.Big)(big.NewInt(0)),
+		}
+		if tokenID != nil {
+			delta.TokenID = (*hexutil.Big)(new(big.Int).Set(tokenID))
+		}
+		d.deltas[key] = delta
+		d.keys = append(d.keys, key)
+	}
+	delta.Amount = (*hexutil.Big)(new(big.Int).Add(delta.Amount.ToInt(), amount))
+}
+
+// list returns the non zero deltas
+func (d *assetDeltas) list() []*AssetDelta {
+	res := make([]*AssetDelta, 0, len(d.keys))
+	for _, key := range d.keys {
+		delta := d.deltas[key]
+		if delta.Amount.ToInt().Sign() != 0 {
+			res = append(res, delta)
+		}
+	}
+	return res
+}
@@ -0,0 +1,305 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/rpc/chain/ethclient"
+)
+
+type Method string
+
+const (
+	MethodSimulateV1 Method = "eth_simulateV1"
+	MethodCall       Method = "eth_call"
+
+	methodNotFoundErrorCode = -32601
+	executionRevertedPrefix = "execution reverted"
+)
+
+var (
+	ErrNoCalls                   = errors.New("no calls to simulate")
+	ErrDependentCallsUnsupported = errors.New("simulating dependent calls requires eth_simulateV1 support")
+)
+
+// Backend is the part of the chain client used for simulating calls
+type Backend interface {
+	ethclient.CallClient
+	ethereum.ContractCaller
+}
+
+// Call represents a single transaction to be simulated
+type Call struct {
+	From  common.Address
+	To    *common.Address
+	Value *big.Int
+	Data  []byte
+	Gas   uint64
+}
+
+// Result represents the outcome of a simulation
+type Result struct {
+	Method       Method        `json:"method"`
+	Reverted     bool          `json:"reverted"`
+	RevertReason string        `json:"revertReason,omitempty"`
+	GasUsed      uint64        `json:"gasUsed,omitempty"`
+	AssetDeltas  []*AssetDelta `json:"assetDeltas"`
+}
+
+func (r *Result) Copy() *Result {
+	newResult := *r
+	if r.AssetDeltas != nil {
+		newResult.AssetDeltas = make([]*AssetDelta, 0, len(r.AssetDeltas))
+		for _, delta := range r.AssetDeltas {
+			newResult.AssetDeltas = append(newResult.AssetDeltas, delta.Copy())
+		}
+	}
+	return &newResult
+}
+
+func CallFromTransaction(from common.Address, tx *ethTypes.Transaction) Call {
+	return Call{
+		From:  from,
+		To:    tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+		Gas:   tx.Gas(),
+	}
+}
+
+func (c Call) toCallMsg() ethereum.CallMsg {
+	return ethereum.CallMsg{
+		From:  c.From,
+		To:    c.To,
+		Value: c.Value,
+		Data:  c.Data,
+		Gas:   c.Gas,
+	}
+}
+
+type Simulator struct {
+	rpcClient rpc.ClientInterface
+
+	simulateV1Unsupported sync.Map // map[uint64]bool
+}
+
+func NewSimulator(rpcClient rpc.ClientInterface) *Simulator {
+	return &Simulator{
+		rpcClient: rpcClient,
+	}
+}
+
+// SimulateCalls executes the calls one after another on top of the latest block, without broadcasting anything.
+// `eth_simulateV1` is used if the chain supports it, otherwise it falls back to `eth_call`, which can only simulate
+// a single call and doesn't return the logs, so only native asset deltas are reported in that case.
+func (s *Simulator) SimulateCalls(ctx context.Context, chainID uint64, calls []Call) (*Result, error) {
+	if len(calls) == 0 {
+		return nil, ErrNoCalls
+	}
+
+	backend, err := s.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.simulate(ctx, chainID, backend, calls)
+}
+
+func (s *Simulator) simulate(ctx context.Context, chainID uint64, backend Backend, calls []Call) (*Result, error) {
+	if _, unsupported := s.simulateV1Unsupported.Load(chainID); !unsupported {
+		res, err := simulateV1(ctx, backend, calls)
+		if err == nil {
+			return res, nil
+		}
+		if !isMethodNotSupported(err) {
+			return nil, err
+		}
+		s.simulateV1Unsupported.Store(chainID, true)
+	}
+
+	if len(calls) > 1 {
+		return nil, ErrDependentCallsUnsupported
+	}
+
+	return simulateWithCall(ctx, backend, calls[0])
+}
+
+type simulateV1Call struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to,omitempty"`
+	Value *hexutil.Big    `json:"value,omitempty"`
+	Input hexutil.Bytes   `json:"input,omitempty"`
+	Gas   *hexutil.Uint64 `json:"gas,omitempty"`
+}
+
+type simulateV1BlockStateCalls struct {
+	Calls []simulateV1Call `json:"calls"`
+}
+
+type simulateV1Opts struct {
+	BlockStateCalls []simulateV1BlockStateCalls `json:"blockStateCalls"`
+	TraceTransfers  bool                        `json:"traceTransfers"`
+	Validation      bool                        `json:"validation"`
+}
+
+type simulateV1Log struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+type simulateV1CallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type simulateV1CallResult struct {
+	ReturnData hexutil.Bytes        `json:"returnData"`
+	Logs       []simulateV1Log      `json:"logs"`
+	GasUsed    hexutil.Uint64       `json:"gasUsed"`
+	Status     hexutil.Uint64       `json:"status"`
+	Error      *simulateV1CallError `json:"error,omitempty"`
+}
+
+type simulateV1BlockResult struct {
+	Calls []simulateV1CallResult `json:"calls"`
+}
+
+func simulateV1(ctx context.Context, backend Backend, calls []Call) (*Result, error) {
+	block := simulateV1BlockStateCalls{}
+	for _, call := range calls {
+		c := simulateV1Call{
+			From:  call.From,
+			To:    call.To,
+			Input: call.Data,
+		}
+		if call.Value != nil {
+			c.Value = (*hexutil.Big)(call.Value)
+		}
+		if call.Gas > 0 {
+			gas := hexutil.Uint64(call.Gas)
+			c.Gas = &gas
+		}
+		block.Calls = append(block.Calls, c)
+	}
+
+	opts := simulateV1Opts{
+		BlockStateCalls: []simulateV1BlockStateCalls{block},
+		// native transfers are reported as ERC20 like logs emitted by `NativeTransferLogAddress`
+		TraceTransfers: true,
+		Validation:     false,
+	}
+
+	var blocks []simulateV1BlockResult
+	err := backend.CallContext(ctx, &blocks, string(MethodSimulateV1), opts, "latest")
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) != 1 || len(blocks[0].Calls) != len(calls) {
+		return nil, errors.New("unexpected eth_simulateV1 response")
+	}
+
+	res := &Result{
+		Method: MethodSimulateV1,
+	}
+	deltas := newAssetDeltas()
+	for _, callRes := range blocks[0].Calls {
+		res.GasUsed += uint64(callRes.GasUsed)
+		if uint64(callRes.Status) == ethTypes.ReceiptStatusFailed {
+			res.Reverted = true
+			message := ""
+			if callRes.Error != nil {
+				message = callRes.Error.Message
+			}
+			res.RevertReason = revertReason(callRes.ReturnData, message)
+			// state changes of a reverted call are discarded, there is no point in simulating further
+			break
+		}
+		for _, l := range callRes.Logs {
+			deltas.addLog(&ethTypes.Log{
+				Address: l.Address,
+				Topics:  l.Topics,
+				Data:    l.Data,
+			})
+		}
+	}
+
+	if !res.Reverted {
+		res.AssetDeltas = deltas.list()
+	}
+
+	return res, nil
+}
+
+func simulateWithCall(ctx context.Context, backend Backend, call Call) (*Result, error) {
+	res := &Result{
+		Method: MethodCall,
+	}
+
+	_, err := backend.CallContract(ctx, call.toCallMsg(), nil)
+	if err != nil {
+		reverted, reason := parseCallError(err)
+		if !reverted {
+			return nil, err
+		}
+		res.Reverted = true
+		res.RevertReason = reason
+		return res, nil
+	}
+
+	// logs are not available through `eth_call`, only the native value transfer is known
+	deltas := newAssetDeltas()
+	if call.To != nil && call.Value != nil {
+		deltas.addNative(call.From, *call.To, call.Value)
+	}
+	res.AssetDeltas = deltas.list()
+
+	return res, nil
+}
+
+func parseCallError(err error) (reverted bool, reason string) {
+	var dataErr gethrpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if revertData, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				return true, revertReason(revertData, dataErr.Error())
+			}
+		}
+	}
+
+	if strings.Contains(err.Error(), executionRevertedPrefix) {
+		return true, revertReason(nil, err.Error())
+	}
+
+	return false, ""
+}
+
+// revertReason decodes the `Error(string)` revert data, if that's not possible the node's error message is used
+func revertReason(data []byte, message string) string {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	return message
+}
+
+func isMethodNotSupported(err error) bool {
+	var rpcErr gethrpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundErrorCode {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "does not exist") ||
+		strings.Contains(msg, "not supported") ||
+		strings.Contains(msg, "method not found")
+}
@@ -0,0 +1,386 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+const erc20ABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+var (
+	tokenAddress   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	accountAddress = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	spenderAddress = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	recipientAddr  = common.HexToAddress("0x4000000000000000000000000000000000000004")
+)
+
+type methodNotFoundError struct{}
+
+func (e methodNotFoundError) Error() string {
+	return "the method eth_simulateV1 does not exist/is not available"
+}
+func (e methodNotFoundError) ErrorCode() int { return methodNotFoundErrorCode }
+
+type revertError struct {
+	data string
+}
+
+func (e revertError) Error() string          { return executionRevertedPrefix }
+func (e revertError) ErrorData() interface{} { return e.data }
+
+// localBackend is a minimal simulated chain, holding a single ERC20 token and native balances
+type localBackend struct {
+	t                  *testing.T
+	tokenABI           abi.ABI
+	simulateV1Disabled bool
+
+	nativeBalances map[common.Address]*big.Int
+	tokenBalances  map[common.Address]*big.Int
+	allowances     map[common.Address]map[common.Address]*big.Int
+}
+
+func newLocalBackend(t *testing.T) *localBackend {
+	tokenABI, err := abi.JSON(strings.NewReader(erc20ABI))
+	require.NoError(t, err)
+
+	return &localBackend{
+		t:        t,
+		tokenABI: tokenABI,
+		nativeBalances: map[common.Address]*big.Int{
+			accountAddress: big.NewInt(1000),
+		},
+		tokenBalances: map[common.Address]*big.Int{
+			accountAddress: big.NewInt(100),
+		},
+		allowances: make(map[common.Address]map[common.Address]*big.Int),
+	}
+}
+
+func balanceOf(balances map[common.Address]*big.Int, account common.Address) *big.Int {
+	if balance, ok := balances[account]; ok {
+		return balance
+	}
+	return big.NewInt(0)
+}
+
+func revertData(t *testing.T, reason string) []byte {
+	errorABI, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"Error","inputs":[{"name":"reason","type":"string"}]}]`))
+	require.NoError(t, err)
+	data, err := errorABI.Pack("Error", reason)
+	require.NoError(t, err)
+	return data
+}
+
+func addressTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
+
+func transferLog(emitter, from, to common.Address, amount *big.Int) simulateV1Log {
+	return simulateV1Log{
+		Address: emitter,
+		Topics: []common.Hash{
+			walletCommon.GetEventSignatureHash(walletCommon.Erc20_721TransferEventSignature),
+			addressTopic(from),
+			addressTopic(to),
+		},
+		Data: common.LeftPadBytes(amount.Bytes(), 32),
+	}
+}
+
+func (b *localBackend) transferToken(from, to common.Address, amount *big.Int) error {
+	if balanceOf(b.tokenBalances, from).Cmp(amount) < 0 {
+		return errors.New("ERC20: transfer amount exceeds balance")
+	}
+	b.tokenBalances[from] = new(big.Int).Sub(balanceOf(b.tokenBalances, from), amount)
+	b.tokenBalances[to] = new(big.Int).Add(balanceOf(b.tokenBalances, to), amount)
+	return nil
+}
+
+// execute applies the call to the backend state and returns the emitted logs or the revert reason
+func (b *localBackend) execute(call simulateV1Call) ([]simulateV1Log, error) {
+	var logs []simulateV1Log
+
+	if call.Value != nil && call.Value.ToInt().Sign() > 0 {
+		value := call.Value.ToInt()
+		if balanceOf(b.nativeBalances, call.From).Cmp(value) < 0 {
+			return nil, errors.New("insufficient funds")
+		}
+		b.nativeBalances[call.From] = new(big.Int).Sub(balanceOf(b.nativeBalances, call.From), value)
+		b.nativeBalances[*call.To] = new(big.Int).Add(balanceOf(b.nativeBalances, *call.To), value)
+		logs = append(logs, transferLog(NativeTransferLogAddress, call.From, *call.To, value))
+	}
+
+	if call.To == nil || *call.To != tokenAddress || len(call.Input) < 4 {
+		return logs, nil
+	}
+
+	method, err := b.tokenABI.MethodById(call.Input[:4])
+	require.NoError(b.t, err)
+	args, err := method.Inputs.Unpack(call.Input[4:])
+	require.NoError(b.t, err)
+
+	switch method.Name {
+	case "approve":
+		if b.allowances[call.From] == nil {
+			b.allowances[call.From] = make(map[common.Address]*big.Int)
+		}
+		b.allowances[call.From][args[0].(common.Address)] = args[1].(*big.Int)
+	case "transfer":
+		to, amount := args[0].(common.Address), args[1].(*big.Int)
+		if err := b.transferToken(call.From, to, amount); err != nil {
+			return nil, err
+		}
+		logs = append(logs, transferLog(tokenAddress, call.From, to, amount))
+	case "transferFrom":
+		from, to, amount := args[0].(common.Address), args[1].(common.Address), args[2].(*big.Int)
+		allowance := balanceOf(b.allowances[from], call.From)
+		if allowance.Cmp(amount) < 0 {
+			return nil, errors.New("ERC20: insufficient allowance")
+		}
+		b.allowances[from][call.From] = new(big.Int).Sub(allowance, amount)
+		if err := b.transferToken(from, to, amount); err != nil {
+			return nil, err
+		}
+		logs = append(logs, transferLog(tokenAddress, from, to, amount))
+	}
+
+	return logs, nil
+}
+
+// snapshot returns a copy of the backend, so the simulation doesn't affect the state
+func (b *localBackend) snapshot() *localBackend {
+	copyBalances := func(balances map[common.Address]*big.Int) map[common.Address]*big.Int {
+		res := make(map[common.Address]*big.Int)
+		for k, v := range balances {
+			res[k] = new(big.Int).Set(v)
+		}
+		return res
+	}
+
+	snapshot := *b
+	snapshot.nativeBalances = copyBalances(b.nativeBalances)
+	snapshot.tokenBalances = copyBalances(b.tokenBalances)
+	snapshot.allowances = make(map[common.Address]map[common.Address]*big.Int)
+	for k, v := range b.allowances {
+		snapshot.allowances[k] = copyBalances(v)
+	}
+	return &snapshot
+}
+
+func (b *localBackend) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if method != string(MethodSimulateV1) || b.simulateV1Disabled {
+		return methodNotFoundError{}
+	}
+
+	opts := args[0].(simulateV1Opts)
+	require.True(b.t, opts.TraceTransfers)
+
+	state := b.snapshot()
+	blocks := make([]simulateV1BlockResult, 0, len(opts.BlockStateCalls))
+	for _, block := range opts.BlockStateCalls {
+		blockRes := simulateV1BlockResult{}
+		for _, call := range block.Calls {
+			callRes := simulateV1CallResult{
+				GasUsed: hexutil.Uint64(21000),
+				Status:  1,
+			}
+			logs, err := state.execute(call)
+			if err != nil {
+				callRes.Status = 0
+				callRes.ReturnData = revertData(b.t, err.Error())
+				callRes.Error = &simulateV1CallError{Code: 3, Message: executionRevertedPrefix}
+			} else {
+				callRes.Logs = logs
+			}
+			blockRes.Calls = append(blockRes.Calls, callRes)
+		}
+		blocks = append(blocks, blockRes)
+	}
+
+	// go through JSON the same way the RPC client does
+	data, err := json.Marshal(blocks)
+	require.NoError(b.t, err)
+	return json.Unmarshal(data, result)
+}
+
+func (b *localBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	call := simulateV1Call{
+		From:  msg.From,
+		To:    msg.To,
+		Input: msg.Data,
+	}
+	if msg.Value != nil {
+		call.Value = (*hexutil.Big)(msg.Value)
+	}
+
+	_, err := b.snapshot().execute(call)
+	if err != nil {
+		return nil, revertError{data: hexutil.Encode(revertData(b.t, err.Error()))}
+	}
+	return nil, nil
+}
+
+func (b *localBackend) packTokenCall(t *testing.T, method string, args ...interface{}) []byte {
+	data, err := b.tokenABI.Pack(method, args...)
+	require.NoError(t, err)
+	return data
+}
+
+func TestSimulateERC20Transfer(t *testing.T) {
+	backend := newLocalBackend(t)
+	simulator := NewSimulator(nil)
+
+	res, err := simulator.simulate(context.Background(), 1, backend, []Call{{
+		From: accountAddress,
+		To:   &tokenAddress,
+		Data: backend.packTokenCall(t, "transfer", recipientAddr, big.NewInt(40)),
+	}})
+	require.NoError(t, err)
+	require.Equal(t, MethodSimulateV1, res.Method)
+	require.False(t, res.Reverted)
+	require.Len(t, res.AssetDeltas, 2)
+
+	require.Equal(t, accountAddress, res.AssetDeltas[0].Account)
+	require.Equal(t, walletCommon.Erc20Transfer, res.AssetDeltas[0].TokenType)
+	require.Equal(t, tokenAddress, res.AssetDeltas[0].ContractAddress)
+	require.Equal(t, big.NewInt(-40), res.AssetDeltas[0].Amount.ToInt())
+
+	require.Equal(t, recipientAddr, res.AssetDeltas[1].Account)
+	require.Equal(t, big.NewInt(40), res.AssetDeltas[1].Amount.ToInt())
+
+	// simulation doesn't change the chain state
+	require.Equal(t, big.NewInt(100), balanceOf(backend.tokenBalances, accountAddress))
+}
+
+func TestSimulateRevert(t *testing.T) {
+	backend := newLocalBackend(t)
+	simulator := NewSimulator(nil)
+
+	res, err := simulator.simulate(context.Background(), 1, backend, []Call{{
+		From: accountAddress,
+		To:   &tokenAddress,
+		Data: backend.packTokenCall(t, "transfer", recipientAddr, big.NewInt(101)),
+	}})
+	require.NoError(t, err)
+	require.True(t, res.Reverted)
+	require.Equal(t, "ERC20: transfer amount exceeds balance", res.RevertReason)
+	require.Empty(t, res.AssetDeltas)
+}
+
+func TestSimulateDependentCalls(t *testing.T) {
+	backend := newLocalBackend(t)
+	simulator := NewSimulator(nil)
+
+	transferFrom := Call{
+		From:  spenderAddress,
+		To:    &tokenAddress,
+		Data:  backend.packTokenCall(t, "transferFrom", accountAddress, recipientAddr, big.NewInt(30)),
+		Value: big.NewInt(0),
+	}
+
+	// without the approval the transfer reverts
+	res, err := simulator.simulate(context.Background(), 1, backend, []Call{transferFrom})
+	require.NoError(t, err)
+	require.True(t, res.Reverted)
+	require.Equal(t, "ERC20: insufficient allowance", res.RevertReason)
+	require.Equal(t, uint64(21000), res.GasUsed)
+
+	approve := Call{
+		From: accountAddress,
+		To:   &tokenAddress,
+		Data: backend.packTokenCall(t, "approve", spenderAddress, big.NewInt(30)),
+	}
+	res, err = simulator.simulate(context.Background(), 1, backend, []Call{approve, transferFrom})
+	require.NoError(t, err)
+	require.False(t, res.Reverted)
+	// the gas of all the calls is counted
+	require.Equal(t, uint64(42000), res.GasUsed)
+	require.Len(t, res.AssetDeltas, 2)
+	require.Equal(t, big.NewInt(-30), res.AssetDeltas[0].Amount.ToInt())
+	require.Equal(t, big.NewInt(30), res.AssetDeltas[1].Amount.ToInt())
+}
+
+func TestSimulateNativeTransfer(t *testing.T) {
+	backend := newLocalBackend(t)
+	simulator := NewSimulator(nil)
+
+	call := Call{
+		From:  accountAddress,
+		To:    &recipientAddr,
+		Value: big.NewInt(500),
+	}
+
+	for _, simulateV1Disabled := range []bool{false, true} {
+		backend.simulateV1Disabled = simulateV1Disabled
+		expectedMethod := MethodSimulateV1
+		if simulateV1Disabled {
+			expectedMethod = MethodCall
+		}
+
+		res, err := simulator.simulate(context.Background(), 1, backend, []Call{call})
+		require.NoError(t, err)
+		require.Equal(t, expectedMethod, res.Method)
+		require.False(t, res.Reverted)
+		require.Len(t, res.AssetDeltas, 2)
+		require.Equal(t, walletCommon.EthTransfer, res.AssetDeltas[0].TokenType)
+		require.Equal(t, common.Address{}, res.AssetDeltas[0].ContractAddress)
+		require.Equal(t, big.NewInt(-500), res.AssetDeltas[0].Amount.ToInt())
+		require.Equal(t, recipientAddr, res.AssetDeltas[1].Account)
+		require.Equal(t, big.NewInt(500), res.AssetDeltas[1].Amount.ToInt())
+	}
+}
+
+func TestSimulateFallbackToCall(t *testing.T) {
+	backend := newLocalBackend(t)
+	backend.simulateV1Disabled = true
+	simulator := NewSimulator(nil)
+
+	transfer := Call{
+		From: accountAddress,
+		To:   &tokenAddress,
+		Data: backend.packTokenCall(t, "transfer", recipientAddr, big.NewInt(101)),
+	}
+
+	res, err := simulator.simulate(context.Background(), 1, backend, []Call{transfer})
+	require.NoError(t, err)
+	require.Equal(t, MethodCall, res.Method)
+	require.True(t, res.Reverted)
+	require.Equal(t, "ERC20: transfer amount exceeds balance", res.RevertReason)
+
+	// the chain is remembered as not supporting eth_simulateV1
+	_, unsupported := simulator.simulateV1Unsupported.Load(uint64(1))
+	require.True(t, unsupported)
+
+	_, err = simulator.simulate(context.Background(), 1, backend, []Call{transfer, transfer})
+	require.ErrorIs(t, err, ErrDependentCallsUnsupported)
+}
+
+func TestParseCallError(t *testing.T) {
+	reverted, reason := parseCallError(errors.New("execution reverted: not owner"))
+	require.True(t, reverted)
+	require.Equal(t, "execution reverted: not owner", reason)
+
+	reverted, _ = parseCallError(errors.New("connection refused"))
+	require.False(t, reverted)
+
+	var rpcErr gethrpc.Error = methodNotFoundError{}
+	require.True(t, isMethodNotSupported(rpcErr))
+	require.False(t, isMethodNotSupported(errors.New("connection refused")))
+}
@@ -0,0 +1,151 @@
+package safe
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type Database struct {
+	db *sql.DB
+}
+
+func NewDB(db *sql.DB) *Database {
+	return &Database{db: db}
+}
+
+func (db *Database) SaveSafe(info *Info) error {
+	owners, err := json.Marshal(info.Owners)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.db.Exec(`INSERT OR REPLACE INTO safe_accounts (chain_id, address, owners, threshold, nonce, version, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, info.ChainID, info.Address, string(owners), info.Threshold, info.Nonce, info.Version, info.UpdatedAt)
+	return err
+}
+
+// GetSafe returns nil if the address is not a known Safe on the chain
+func (db *Database) GetSafe(chainID uint64, address common.Address) (*Info, error) {
+	info := &Info{ChainID: chainID, Address: address}
+	var owners string
+	err := db.db.QueryRow(`SELECT owners, threshold, nonce, version, updated_at FROM safe_accounts WHERE chain_id = ? AND address = ?`,
+		chainID, address).Scan(&owners, &info.Threshold, &info.Nonce, &info.Version, &info.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal([]byte(owners), &info.Owners); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (db *Database) SaveTransaction(tx *Transaction) error {
+	_, err := db.db.Exec(`INSERT OR IGNORE INTO safe_transactions (safe_tx_hash, chain_id, safe_address, to_address, value, data, operation,
+		safe_tx_gas, base_gas, gas_price, gas_token, refund_receiver, nonce, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		tx.SafeTxHash, tx.ChainID, tx.Safe, tx.To, tx.Value.String(), []byte(tx.Data), tx.Operation,
+		tx.SafeTxGas.String(), tx.BaseGas.String(), tx.GasPrice.String(), tx.GasToken, tx.RefundReceiver, tx.Nonce, tx.CreatedAt)
+	return err
+}
+
+func (db *Database) SaveSignature(safeTxHash common.Hash, signature *Signature) error {
+	_, err := db.db.Exec(`INSERT OR REPLACE INTO safe_transaction_signatures (safe_tx_hash, signer, signature) VALUES (?, ?, ?)`,
+		safeTxHash, signature.Signer, []byte(signature.Data))
+	return err
+}
+
+// GetTransaction returns nil if the transaction is unknown
+func (db *Database) GetTransaction(safeTxHash common.Hash) (*Transaction, error) {
+	txs, err := db.queryTransactions(`WHERE safe_tx_hash = ?`, safeTxHash)
+	if err != nil || len(txs) == 0 {
+		return nil, err
+	}
+	return txs[0], nil
+}
+
+// GetTransactionsFromNonce returns the transactions of the Safe with a nonce not lower than the given one,
+// ordered by nonce
+func (db *Database) GetTransactionsFromNonce(chainID uint64, safe common.Address, nonce uint64) ([]*Transaction, error) {
+	return db.queryTransactions(`WHERE chain_id = ? AND safe_address = ? AND nonce >= ? ORDER BY nonce, created_at`, chainID, safe, nonce)
+}
+
+func (db *Database) queryTransactions(where string, args ...interface{}) ([]*Transaction, error) {
+	rows, err := db.db.Query(`SELECT safe_tx_hash, chain_id, safe_address, to_address, value, data, operation, safe_tx_gas, base_gas,
+		gas_price, gas_token, refund_receiver, nonce, created_at FROM safe_transactions `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var txs []*Transaction
+	for rows.Next() {
+		tx := &Transaction{}
+		var value, safeTxGas, baseGas, gasPrice string
+		var data []byte
+		err = rows.Scan(&tx.SafeTxHash, &tx.ChainID, &tx.Safe, &tx.To, &value, &data, &tx.Operation, &safeTxGas, &baseGas,
+			&gasPrice, &tx.GasToken, &tx.RefundReceiver, &tx.Nonce, &tx.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		tx.Data = data
+
+		if tx.Value, err = parseBig(value); err != nil {
+			return nil, err
+		}
+		if tx.SafeTxGas, err = parseBig(safeTxGas); err != nil {
+			return nil, err
+		}
+		if tx.BaseGas, err = parseBig(baseGas); err != nil {
+			return nil, err
+		}
+		if tx.GasPrice, err = parseBig(gasPrice); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		tx.Signatures, err = db.getSignatures(tx.SafeTxHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
+
+func (db *Database) getSignatures(safeTxHash common.Hash) ([]*Signature, error) {
+	rows, err := db.db.Query(`SELECT signer, signature FROM safe_transaction_signatures WHERE safe_tx_hash = ?`, safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	signatures := make([]*Signature, 0)
+	for rows.Next() {
+		signature := &Signature{}
+		var data []byte
+		if err = rows.Scan(&signature.Signer, &data); err != nil {
+			return nil, err
+		}
+		signature.Data = data
+		signatures = append(signatures, signature)
+	}
+	return signatures, rows.Err()
+}
+
+func parseBig(value string) (*hexutil.Big, error) {
+	parsed, err := hexutil.DecodeBig(value)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(parsed), nil
+}
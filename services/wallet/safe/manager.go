@@ -0,0 +1,350 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	safeContract "github.com/status-im/status-go/contracts/safe"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/multiaccounts/accounts"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/typeddata"
+)
+
+var (
+	ErrNotASafe            = errors.New("address is not a Safe")
+	ErrUnsupportedVersion  = errors.New("unsupported Safe version, 1.3.0 or later is required")
+	ErrNotAnOwner          = errors.New("signer is not an owner of the Safe")
+	ErrTransactionNotFound = errors.New("Safe transaction not found")
+	ErrNoLocalOwner        = errors.New("none of the Safe owners is a local account")
+	ErrThresholdNotReached = errors.New("not enough owner signatures to execute the Safe transaction")
+)
+
+// ownersCacheTTL is how long the stored owners and threshold of a Safe are used before being read again on chain
+const ownersCacheTTL = 10 * time.Minute
+
+type Manager struct {
+	db         *Database
+	rpcClient  rpc.ClientInterface
+	accountsDB *accounts.Database
+}
+
+func NewManager(walletDB *sql.DB, rpcClient rpc.ClientInterface, accountsDB *accounts.Database) *Manager {
+	return &Manager{
+		db:         NewDB(walletDB),
+		rpcClient:  rpcClient,
+		accountsDB: accountsDB,
+	}
+}
+
+// Discover reads the owners, the threshold and the nonce of the Safe on chain and stores them
+func (m *Manager) Discover(ctx context.Context, chainID uint64, address common.Address) (*Info, error) {
+	client, err := m.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := safeContract.NewSafeCaller(address, client)
+	if err != nil {
+		return nil, err
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	version, err := caller.VERSION(callOpts)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %v", ErrNotASafe, err)
+	}
+	if !isSupportedVersion(version) {
+		return nil, ErrUnsupportedVersion
+	}
+
+	owners, err := caller.GetOwners(callOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold, err := caller.GetThreshold(callOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := caller.Nonce(callOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{
+		ChainID:   chainID,
+		Address:   address,
+		Owners:    owners,
+		Threshold: threshold.Uint64(),
+		Nonce:     nonce.Uint64(),
+		Version:   version,
+		UpdatedAt: time.Now().Unix(),
+	}
+
+	err = m.db.SaveSafe(info)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// safeInfo returns the stored Safe with its current nonce, the owners and the threshold are read again on chain only
+// when they were stored more than ownersCacheTTL ago
+func (m *Manager) safeInfo(ctx context.Context, chainID uint64, address common.Address) (*Info, error) {
+	info, err := m.db.GetSafe(chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil || time.Since(time.Unix(info.UpdatedAt, 0)) > ownersCacheTTL {
+		return m.Discover(ctx, chainID, address)
+	}
+
+	client, err := m.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := safeContract.NewSafeCaller(address, client)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := caller.Nonce(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+
+	if nonce.Uint64() != info.Nonce {
+		info.Nonce = nonce.Uint64()
+		err = m.db.SaveSafe(info)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// GetSafe returns the stored configuration of the Safe, nil if the address is not a known Safe
+func (m *Manager) GetSafe(chainID uint64, address common.Address) (*Info, error) {
+	return m.db.GetSafe(chainID, address)
+}
+
+// ProposeTransaction returns the pending Safe transaction making the given call, a new one is created with the
+// next available nonce if there's none
+func (m *Manager) ProposeTransaction(ctx context.Context, chainID uint64, safe common.Address, to common.Address, value *big.Int, data []byte) (*Transaction, error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	info, err := m.safeInfo(ctx, chainID, safe)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := m.db.GetTransactionsFromNonce(chainID, safe, info.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := info.Nonce
+	for _, tx := range pending {
+		if tx.To == to && tx.Value.ToInt().Cmp(value) == 0 && bytes.Equal(tx.Data, data) && tx.Operation == OperationCall {
+			return tx, nil
+		}
+		if tx.Nonce >= nonce {
+			nonce = tx.Nonce + 1
+		}
+	}
+
+	tx, err := NewTransaction(chainID, safe, to, value, data, nonce)
+	if err != nil {
+		return nil, err
+	}
+	tx.CreatedAt = time.Now().Unix()
+	tx.Signatures = make([]*Signature, 0)
+
+	err = m.db.SaveTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// PendingTransactions returns the transactions of the Safe which are not executed yet
+func (m *Manager) PendingTransactions(ctx context.Context, chainID uint64, safe common.Address) ([]*Transaction, error) {
+	info, err := m.safeInfo(ctx, chainID, safe)
+	if err != nil {
+		return nil, err
+	}
+	return m.db.GetTransactionsFromNonce(chainID, safe, info.Nonce)
+}
+
+func (m *Manager) GetTransaction(safeTxHash common.Hash) (*Transaction, error) {
+	tx, err := m.db.GetTransaction(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, ErrTransactionNotFound
+	}
+	return tx, nil
+}
+
+// SignTransaction signs the SafeTx hash with the key of a local owner
+func (m *Manager) SignTransaction(safeTxHash common.Hash, key *ecdsa.PrivateKey) (*Transaction, error) {
+	tx, err := m.GetTransaction(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	typed, err := tx.TypedData()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := typeddata.Sign(typed, key, new(big.Int).SetUint64(tx.ChainID))
+	if err != nil {
+		return nil, err
+	}
+
+	return m.AddSignature(safeTxHash, signature)
+}
+
+// AddSignature adds the signature of an owner, e.g. pasted from another wallet
+func (m *Manager) AddSignature(safeTxHash common.Hash, signature []byte) (*Transaction, error) {
+	tx, err := m.GetTransaction(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := RecoverSigner(safeTxHash, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := m.db.GetSafe(tx.ChainID, tx.Safe)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, ErrNotASafe
+	}
+	if !info.IsOwner(signer) {
+		return nil, ErrNotAnOwner
+	}
+
+	err = m.db.SaveSignature(safeTxHash, &Signature{Signer: signer, Data: normalizeSignature(signature)})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.GetTransaction(safeTxHash)
+}
+
+// PackExecTransaction returns the local owner executing the transaction and the `execTransaction` call data.
+// The executor is the one returned by Executor, it doesn't need to sign the SafeTx since it's the sender of the
+// call, the other owners signatures must reach the threshold.
+func (m *Manager) PackExecTransaction(tx *Transaction) (common.Address, []byte, error) {
+	info, err := m.db.GetSafe(tx.ChainID, tx.Safe)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if info == nil {
+		return common.Address{}, nil, ErrNotASafe
+	}
+
+	signatures := make(map[common.Address]*Signature)
+	for _, signature := range tx.Signatures {
+		if info.IsOwner(signature.Signer) {
+			signatures[signature.Signer] = signature
+		}
+	}
+
+	executor, err := m.executor(info)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	signatures[executor] = ExecutorSignature(executor)
+
+	if uint64(len(signatures)) < info.Threshold {
+		return common.Address{}, nil, fmt.Errorf("%w: %d of %d signatures for %s", ErrThresholdNotReached, len(signatures), info.Threshold, tx.SafeTxHash.Hex())
+	}
+
+	list := make([]*Signature, 0, len(signatures))
+	for _, signature := range signatures {
+		list = append(list, signature)
+	}
+
+	safeABI, err := safeContract.SafeMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	data, err := safeABI.Pack("execTransaction", tx.To, tx.Value.ToInt(), []byte(tx.Data), uint8(tx.Operation), tx.SafeTxGas.ToInt(),
+		tx.BaseGas.ToInt(), tx.GasPrice.ToInt(), tx.GasToken, tx.RefundReceiver, EncodeSignatures(list))
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return executor, data, nil
+}
+
+// Executor returns the local owner sending the `execTransaction` transactions of the Safe, it pays their fees
+func (m *Manager) Executor(chainID uint64, safe common.Address) (common.Address, error) {
+	info, err := m.db.GetSafe(chainID, safe)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if info == nil {
+		return common.Address{}, ErrNotASafe
+	}
+	return m.executor(info)
+}
+
+// executor picks the first local owner able to send transactions. It doesn't depend on the signatures of the
+// SafeTx, so the owner whose balance is checked for the fees is the one sending the transaction.
+func (m *Manager) executor(info *Info) (common.Address, error) {
+	for _, owner := range info.Owners {
+		acc, err := m.accountsDB.GetAccountByAddress(types.Address(owner))
+		if err != nil {
+			if err == accounts.ErrDbAccountNotFound {
+				continue
+			}
+			return common.Address{}, err
+		}
+		if acc.IsWalletAccountReadyForTransaction() {
+			return owner, nil
+		}
+	}
+	return common.Address{}, ErrNoLocalOwner
+}
+
+// isSupportedVersion checks that the Safe uses the EIP-712 domain with the chain ID, introduced in 1.3.0
+func isSupportedVersion(version string) bool {
+	parts := strings.Split(strings.SplitN(version, "+", 2)[0], ".")
+	if len(parts) < 2 {
+		return false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return major > 1 || (major == 1 && minor >= 3)
+}
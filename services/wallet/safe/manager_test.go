@@ -0,0 +1,224 @@
+package safe
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/appdatabase"
+	safeContract "github.com/status-im/status-go/contracts/safe"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/multiaccounts/accounts"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+type testOwner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func newTestOwner(t *testing.T) testOwner {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return testOwner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func setupTestManager(t *testing.T, localOwner common.Address) (*Manager, *accounts.Database) {
+	appDB, err := helpers.SetupTestMemorySQLDB(appdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, appDB.Close()) })
+
+	accountsDB, err := accounts.NewDB(appDB)
+	require.NoError(t, err)
+
+	walletDB, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, walletDB.Close()) })
+
+	kp := accounts.GetPrivKeyImportedKeypairForTest()
+	kp.Accounts[0].Address = types.Address(localOwner)
+	require.NoError(t, accountsDB.SaveOrUpdateKeypair(kp))
+
+	return NewManager(walletDB, nil, accountsDB), accountsDB
+}
+
+func TestSafeAccountType(t *testing.T) {
+	owner := newTestOwner(t)
+	_, accountsDB := setupTestManager(t, owner.address)
+
+	safeAccount := &accounts.Account{
+		Address: types.Address{0x5a},
+		Type:    accounts.AccountTypeSafe,
+		Name:    "Treasury",
+		ColorID: "primary",
+	}
+	require.NoError(t, accountsDB.SaveOrUpdateAccounts([]*accounts.Account{safeAccount}, false))
+
+	acc, err := accountsDB.GetAccountByAddress(safeAccount.Address)
+	require.NoError(t, err)
+	require.Equal(t, accounts.AccountTypeSafe, acc.Type)
+	require.True(t, acc.IsKeypairless())
+	require.False(t, acc.IsWalletAccountReadyForTransaction())
+}
+
+func TestSignaturesCollection(t *testing.T) {
+	localOwner := newTestOwner(t)
+	remoteOwner := newTestOwner(t)
+	otherOwner := newTestOwner(t)
+	notOwner := newTestOwner(t)
+
+	manager, _ := setupTestManager(t, localOwner.address)
+
+	info := &Info{
+		ChainID:   1,
+		Address:   common.HexToAddress("0x1c8b9b78e3085866521fe206fa4c1a67f49f153a"),
+		Owners:    []common.Address{localOwner.address, remoteOwner.address, otherOwner.address},
+		Threshold: 3,
+		Nonce:     4,
+		Version:   "1.3.0",
+	}
+	require.NoError(t, manager.db.SaveSafe(info))
+
+	stored, err := manager.GetSafe(info.ChainID, info.Address)
+	require.NoError(t, err)
+	require.Equal(t, info, stored)
+
+	tx, err := NewTransaction(info.ChainID, info.Address, common.HexToAddress("0xd8da6bf26964af9d7eed9e03e53415d37aa96045"), big.NewInt(100), nil, info.Nonce)
+	require.NoError(t, err)
+	require.NoError(t, manager.db.SaveTransaction(tx))
+
+	// the local owner executes the transaction, one more signature is required
+	_, _, err = manager.PackExecTransaction(tx)
+	require.ErrorIs(t, err, ErrThresholdNotReached)
+
+	// signature of a non owner is rejected
+	signature, err := crypto.Sign(tx.SafeTxHash.Bytes(), notOwner.key)
+	require.NoError(t, err)
+	_, err = manager.AddSignature(tx.SafeTxHash, signature)
+	require.ErrorIs(t, err, ErrNotAnOwner)
+
+	// EIP-712 signature pasted from another wallet
+	signature, err = crypto.Sign(tx.SafeTxHash.Bytes(), remoteOwner.key)
+	require.NoError(t, err)
+	tx, err = manager.AddSignature(tx.SafeTxHash, signature)
+	require.NoError(t, err)
+	require.Len(t, tx.Signatures, 1)
+	require.Equal(t, remoteOwner.address, tx.Signatures[0].Signer)
+	require.Equal(t, byte(27)+signature[64], tx.Signatures[0].Data[64])
+
+	// eth_sign signature, with `v` increased by 4
+	prefixed := crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", common.HashLength)), tx.SafeTxHash.Bytes())
+	signature, err = crypto.Sign(prefixed, otherOwner.key)
+	require.NoError(t, err)
+	signature[64] += 31
+	tx, err = manager.AddSignature(tx.SafeTxHash, signature)
+	require.NoError(t, err)
+	require.Len(t, tx.Signatures, 2)
+
+	executor, data, err := manager.PackExecTransaction(tx)
+	require.NoError(t, err)
+	require.Equal(t, localOwner.address, executor)
+
+	safeABI, err := safeContract.SafeMetaData.GetAbi()
+	require.NoError(t, err)
+	args, err := safeABI.Methods["execTransaction"].Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, tx.To, args[0])
+	require.Equal(t, big.NewInt(100), args[1])
+
+	encoded := args[9].([]byte)
+	require.Len(t, encoded, 3*crypto.SignatureLength)
+	signers := make([]common.Address, 0, 3)
+	for i := 0; i < 3; i++ {
+		sig := encoded[i*crypto.SignatureLength : (i+1)*crypto.SignatureLength]
+		if sig[64] == 1 {
+			signers = append(signers, common.BytesToAddress(sig[12:32]))
+			continue
+		}
+		signer, err := RecoverSigner(tx.SafeTxHash, sig)
+		require.NoError(t, err)
+		signers = append(signers, signer)
+	}
+	require.ElementsMatch(t, info.Owners, signers)
+	for i := 1; i < len(signers); i++ {
+		require.Negative(t, bytes.Compare(signers[i-1].Bytes(), signers[i].Bytes()))
+	}
+}
+
+func TestExecutorIgnoresSignatures(t *testing.T) {
+	firstOwner := newTestOwner(t)
+	secondOwner := newTestOwner(t)
+	remoteOwner := newTestOwner(t)
+	manager, accountsDB := setupTestManager(t, firstOwner.address)
+
+	kp := accounts.GetPrivKeyImportedKeypairForTest()
+	kp.KeyUID = "0000000000000000000000000000000000000000000000000000000000000005"
+	kp.Accounts[0].KeyUID = kp.KeyUID
+	kp.Accounts[0].Address = types.Address(secondOwner.address)
+	require.NoError(t, accountsDB.SaveOrUpdateKeypair(kp))
+
+	info := &Info{
+		ChainID:   1,
+		Address:   common.HexToAddress("0x1c8b9b78e3085866521fe206fa4c1a67f49f153a"),
+		Owners:    []common.Address{firstOwner.address, secondOwner.address, remoteOwner.address},
+		Threshold: 2,
+		Nonce:     4,
+		Version:   "1.3.0",
+	}
+	require.NoError(t, manager.db.SaveSafe(info))
+
+	tx, err := NewTransaction(info.ChainID, info.Address, common.HexToAddress("0xd8da6bf26964af9d7eed9e03e53415d37aa96045"), big.NewInt(100), nil, info.Nonce)
+	require.NoError(t, err)
+	require.NoError(t, manager.db.SaveTransaction(tx))
+	tx, err = manager.SignTransaction(tx.SafeTxHash, firstOwner.key)
+	require.NoError(t, err)
+
+	// the owner paying the fees checked by the router is the one executing the transaction, even if it signed it
+	feePayer, err := manager.Executor(info.ChainID, info.Address)
+	require.NoError(t, err)
+	require.Equal(t, firstOwner.address, feePayer)
+
+	_, _, err = manager.PackExecTransaction(tx)
+	require.ErrorIs(t, err, ErrThresholdNotReached)
+
+	signature, err := crypto.Sign(tx.SafeTxHash.Bytes(), remoteOwner.key)
+	require.NoError(t, err)
+	tx, err = manager.AddSignature(tx.SafeTxHash, signature)
+	require.NoError(t, err)
+
+	executor, _, err := manager.PackExecTransaction(tx)
+	require.NoError(t, err)
+	require.Equal(t, feePayer, executor)
+}
+
+func TestPendingTransactions(t *testing.T) {
+	owner := newTestOwner(t)
+	manager, _ := setupTestManager(t, owner.address)
+
+	safe := common.HexToAddress("0x1c8b9b78e3085866521fe206fa4c1a67f49f153a")
+	to := common.HexToAddress("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+	for nonce := uint64(2); nonce < 5; nonce++ {
+		tx, err := NewTransaction(1, safe, to, big.NewInt(int64(nonce)), nil, nonce)
+		require.NoError(t, err)
+		require.NoError(t, manager.db.SaveTransaction(tx))
+	}
+
+	txs, err := manager.db.GetTransactionsFromNonce(1, safe, 3)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, uint64(3), txs[0].Nonce)
+	require.Equal(t, uint64(4), txs[1].Nonce)
+	require.Equal(t, big.NewInt(3), txs[0].Value.ToInt())
+	require.Empty(t, txs[0].Signatures)
+
+	_, err = manager.GetTransaction(common.Hash{0x01})
+	require.ErrorIs(t, err, ErrTransactionNotFound)
+}
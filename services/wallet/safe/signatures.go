@@ -0,0 +1,81 @@
+package safe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrInvalidSignature = errors.New("invalid Safe signature")
+
+// ExecutorSignature returns the "pre-validated" signature of the owner executing the transaction, the Safe
+// accepts it without an actual signature since the owner is the sender of `execTransaction`
+func ExecutorSignature(owner common.Address) *Signature {
+	data := make([]byte, crypto.SignatureLength)
+	copy(data[12:32], owner.Bytes())
+	data[64] = 1
+	return &Signature{Signer: owner, Data: data}
+}
+
+// RecoverSigner returns the owner who signed the SafeTx hash. Both EIP-712 signatures and `eth_sign`
+// signatures (with the `v` value increased by 4, as expected by the Safe) are supported.
+func RecoverSigner(safeTxHash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, ErrInvalidSignature
+	}
+
+	hash := safeTxHash.Bytes()
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+
+	v := sig[64]
+	switch {
+	case v < 27:
+		sig[64] = v
+	case v == 27 || v == 28:
+		sig[64] = v - 27
+	case v == 31 || v == 32:
+		hash = crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(hash))), hash)
+		sig[64] = v - 31
+	default:
+		return common.Address{}, ErrInvalidSignature
+	}
+	if sig[64] > 1 {
+		return common.Address{}, ErrInvalidSignature
+	}
+
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// normalizeSignature makes sure `v` is the one expected by the Safe, 27 or 28 for EIP-712 signatures
+func normalizeSignature(signature []byte) []byte {
+	sig := make([]byte, len(signature))
+	copy(sig, signature)
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return sig
+}
+
+// EncodeSignatures concatenates the signatures sorted by signer, as required by `checkSignatures`
+func EncodeSignatures(signatures []*Signature) []byte {
+	sorted := make([]*Signature, len(signatures))
+	copy(sorted, signatures)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Signer.Bytes(), sorted[j].Signer.Bytes()) < 0
+	})
+
+	encoded := make([]byte, 0, len(sorted)*crypto.SignatureLength)
+	for _, signature := range sorted {
+		encoded = append(encoded, signature.Data...)
+	}
+	return encoded
+}
@@ -0,0 +1,166 @@
+package safe
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/typeddata"
+)
+
+type Operation uint8
+
+const (
+	OperationCall         Operation = 0
+	OperationDelegateCall Operation = 1
+)
+
+var safeTxTypes = typeddata.Types{
+	"EIP712Domain": {
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	"SafeTx": {
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "data", Type: "bytes"},
+		{Name: "operation", Type: "uint8"},
+		{Name: "safeTxGas", Type: "uint256"},
+		{Name: "baseGas", Type: "uint256"},
+		{Name: "gasPrice", Type: "uint256"},
+		{Name: "gasToken", Type: "address"},
+		{Name: "refundReceiver", Type: "address"},
+		{Name: "nonce", Type: "uint256"},
+	},
+}
+
+// Info is the on-chain configuration of a Safe
+type Info struct {
+	ChainID   uint64           `json:"chainId"`
+	Address   common.Address   `json:"address"`
+	Owners    []common.Address `json:"owners"`
+	Threshold uint64           `json:"threshold"`
+	Nonce     uint64           `json:"nonce"`
+	Version   string           `json:"version"`
+	UpdatedAt int64            `json:"updatedAt"`
+}
+
+func (i *Info) IsOwner(address common.Address) bool {
+	for _, owner := range i.Owners {
+		if owner == address {
+			return true
+		}
+	}
+	return false
+}
+
+// Signature is the signature of a Safe transaction by one of the owners
+type Signature struct {
+	Signer common.Address `json:"signer"`
+	Data   hexutil.Bytes  `json:"data"`
+}
+
+// Transaction is a SafeTx, the transaction executed by the Safe once it's signed by enough owners
+type Transaction struct {
+	ChainID        uint64         `json:"chainId"`
+	Safe           common.Address `json:"safe"`
+	To             common.Address `json:"to"`
+	Value          *hexutil.Big   `json:"value"`
+	Data           hexutil.Bytes  `json:"data"`
+	Operation      Operation      `json:"operation"`
+	SafeTxGas      *hexutil.Big   `json:"safeTxGas"`
+	BaseGas        *hexutil.Big   `json:"baseGas"`
+	GasPrice       *hexutil.Big   `json:"gasPrice"`
+	GasToken       common.Address `json:"gasToken"`
+	RefundReceiver common.Address `json:"refundReceiver"`
+	Nonce          uint64         `json:"nonce"`
+	CreatedAt      int64          `json:"createdAt"`
+
+	// SafeTxHash is the EIP-712 hash signed by the owners
+	SafeTxHash common.Hash  `json:"safeTxHash"`
+	Signatures []*Signature `json:"signatures"`
+}
+
+// NewTransaction returns a call from the Safe without gas refund, the executor pays the fees
+func NewTransaction(chainID uint64, safe common.Address, to common.Address, value *big.Int, data []byte, nonce uint64) (*Transaction, error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	tx := &Transaction{
+		ChainID:   chainID,
+		Safe:      safe,
+		To:        to,
+		Value:     (*hexutil.Big)(value),
+		Data:      data,
+		Operation: OperationCall,
+		SafeTxGas: (*hexutil.Big)(new(big.Int)),
+		BaseGas:   (*hexutil.Big)(new(big.Int)),
+		GasPrice:  (*hexutil.Big)(new(big.Int)),
+		Nonce:     nonce,
+	}
+
+	var err error
+	tx.SafeTxHash, err = tx.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// TypedData returns the EIP-712 SafeTx message, using the domain of Safe 1.3.0 and later versions
+func (tx *Transaction) TypedData() (typeddata.TypedData, error) {
+	domain, err := encodeFields(map[string]interface{}{
+		"chainId":           tx.ChainID,
+		"verifyingContract": tx.Safe,
+	})
+	if err != nil {
+		return typeddata.TypedData{}, err
+	}
+
+	message, err := encodeFields(map[string]interface{}{
+		"to":             tx.To,
+		"value":          tx.Value.ToInt(),
+		"data":           tx.Data,
+		"operation":      tx.Operation,
+		"safeTxGas":      tx.SafeTxGas.ToInt(),
+		"baseGas":        tx.BaseGas.ToInt(),
+		"gasPrice":       tx.GasPrice.ToInt(),
+		"gasToken":       tx.GasToken,
+		"refundReceiver": tx.RefundReceiver,
+		"nonce":          tx.Nonce,
+	})
+	if err != nil {
+		return typeddata.TypedData{}, err
+	}
+
+	return typeddata.TypedData{
+		Types:       safeTxTypes,
+		PrimaryType: "SafeTx",
+		Domain:      domain,
+		Message:     message,
+	}, nil
+}
+
+func encodeFields(values map[string]interface{}) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage, len(values))
+	for key, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = encoded
+	}
+	return fields, nil
+}
+
+// Hash computes the EIP-712 SafeTx hash
+func (tx *Transaction) Hash() (common.Hash, error) {
+	typed, err := tx.TypedData()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return typeddata.ValidateAndHash(typed, new(big.Int).SetUint64(tx.ChainID))
+}
@@ -0,0 +1,55 @@
+package safe
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func word(value *big.Int) []byte {
+	return common.LeftPadBytes(value.Bytes(), 32)
+}
+
+// safeTxHash reproduces `Safe.getTransactionHash`
+func safeTxHash(chainID uint64, safe common.Address, to common.Address, value *big.Int, data []byte, nonce uint64) common.Hash {
+	domainSeparatorTypeHash := common.HexToHash("0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218")
+	safeTxTypeHash := common.HexToHash("0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8")
+
+	domainSeparator := crypto.Keccak256(domainSeparatorTypeHash.Bytes(), word(new(big.Int).SetUint64(chainID)), common.LeftPadBytes(safe.Bytes(), 32))
+
+	zero := word(new(big.Int))
+	structHash := crypto.Keccak256(safeTxTypeHash.Bytes(), common.LeftPadBytes(to.Bytes(), 32), word(value), crypto.Keccak256(data),
+		zero, zero, zero, zero, zero, zero, word(new(big.Int).SetUint64(nonce)))
+
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, structHash)
+}
+
+func TestTransactionHash(t *testing.T) {
+	safe := common.HexToAddress("0x1c8b9b78e3085866521fe206fa4c1a67f49f153a")
+	to := common.HexToAddress("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+	value := big.NewInt(1000000000000000000)
+	data := common.FromHex("0xa9059cbb000000000000000000000000d8da6bf26964af9d7eed9e03e53415d37aa960450000000000000000000000000000000000000000000000000000000000000001")
+
+	tx, err := NewTransaction(10, safe, to, value, data, 7)
+	require.NoError(t, err)
+	require.Equal(t, safeTxHash(10, safe, to, value, data, 7), tx.SafeTxHash)
+
+	tx, err = NewTransaction(1, safe, to, nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, safeTxHash(1, safe, to, new(big.Int), nil, 0), tx.SafeTxHash)
+}
+
+func TestIsSupportedVersion(t *testing.T) {
+	require.True(t, isSupportedVersion("1.3.0"))
+	require.True(t, isSupportedVersion("1.4.1"))
+	require.True(t, isSupportedVersion("1.3.0+L2"))
+	require.True(t, isSupportedVersion("2.0.0"))
+	require.False(t, isSupportedVersion("1.2.0"))
+	require.False(t, isSupportedVersion("1.1.1"))
+	require.False(t, isSupportedVersion(""))
+	require.False(t, isSupportedVersion("v1"))
+}
@@ -8,7 +8,10 @@ import (
 	"sync"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
@@ -18,27 +21,39 @@ import (
 	"github.com/status-im/status-go/params"
 	protocolCommon "github.com/status-im/status-go/protocol/common"
 	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/rpc/network/networksevent"
 	"github.com/status-im/status-go/server"
 	"github.com/status-im/status-go/services/ens/ensresolver"
 	"github.com/status-im/status-go/services/wallet/activity"
+	"github.com/status-im/status-go/services/wallet/allowances"
 	"github.com/status-im/status-go/services/wallet/balance"
 	"github.com/status-im/status-go/services/wallet/blockchainstate"
 	"github.com/status-im/status-go/services/wallet/collectibles"
+	wcommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/community"
 	"github.com/status-im/status-go/services/wallet/currency"
+	"github.com/status-im/status-go/services/wallet/delegation"
 	"github.com/status-im/status-go/services/wallet/history"
 	"github.com/status-im/status-go/services/wallet/leaderboard"
 	"github.com/status-im/status-go/services/wallet/market"
 	"github.com/status-im/status-go/services/wallet/onramp"
+	"github.com/status-im/status-go/services/wallet/poisoning"
+	"github.com/status-im/status-go/services/wallet/pricealerts"
 	"github.com/status-im/status-go/services/wallet/routeexecution"
 	"github.com/status-im/status-go/services/wallet/router"
 	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/safe"
+	"github.com/status-im/status-go/services/wallet/signingpreview"
+	"github.com/status-im/status-go/services/wallet/smartaccount"
+	"github.com/status-im/status-go/services/wallet/spam"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/thirdparty/collectibles/alchemy"
+	"github.com/status-im/status-go/services/wallet/thirdparty/collectibles/onchain"
 	"github.com/status-im/status-go/services/wallet/thirdparty/collectibles/opensea"
 	"github.com/status-im/status-go/services/wallet/thirdparty/collectibles/rarible"
 	"github.com/status-im/status-go/services/wallet/thirdparty/market/coingecko"
 	"github.com/status-im/status-go/services/wallet/thirdparty/market/cryptocompare"
+	"github.com/status-im/status-go/services/wallet/thirdparty/market/oracle"
 	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/services/wallet/transfer"
 	"github.com/status-im/status-go/services/wallet/walletevent"
@@ -131,6 +146,12 @@ func NewService(
 	savedAddressesManager := &SavedAddressesManager{db: db}
 	transactionManager := transfer.NewTransactionManager(transfer.NewMultiTransactionDB(db), gethManager, transactor, config, accountsDB, pendingTxManager, feed)
 	blockChainState := blockchainstate.NewBlockChainState()
+	headsTracker := blockchainstate.NewHeadsTracker(blockChainState)
+	if pendingTxManager != nil {
+		headsTracker.AddListener(func(chainID uint64, header *types.Header) {
+			pendingTxManager.OnNewBlock()
+		})
+	}
 	transferController := transfer.NewTransferController(db, accountsDB, rpcClient, accountFeed, feed, transactionManager, pendingTxManager,
 		tokenManager, balanceCacher, blockChainState)
 
@@ -142,7 +163,9 @@ func NewService(
 		User:     config.WalletConfig.StatusProxyMarketUser,
 		Password: config.WalletConfig.StatusProxyMarketPassword,
 	})
-	marketManager := market.NewManager([]thirdparty.MarketDataProvider{cryptoCompare, coingecko, cryptoCompareProxy}, tokenManager, feed)
+	// the on-chain prices are the last fallback, they cover fewer tokens and cost RPC calls
+	priceOracle := oracle.NewClient(rpcClient)
+	marketManager := market.NewManager([]thirdparty.MarketDataProvider{cryptoCompare, coingecko, cryptoCompareProxy, priceOracle}, tokenManager, feed)
 	reader := NewReader(tokenManager, marketManager, token.NewPersistence(db), feed)
 	history := history.NewService(db, accountsDB, accountFeed, feed, rpcClient, tokenManager, marketManager, balanceCacher.Cache())
 	currency := currency.NewService(db, feed, tokenManager, marketManager)
@@ -151,6 +174,9 @@ func NewService(
 	openseaV2Client := opensea.NewClientV2(config.WalletConfig.OpenseaAPIKey, openseaHTTPClient)
 	raribleClient := rarible.NewClient(config.WalletConfig.RaribleMainnetAPIKey, config.WalletConfig.RaribleTestnetAPIKey)
 	alchemyClient := alchemy.NewClient(config.WalletConfig.AlchemyAPIKeys)
+	onchainClient := onchain.NewClient(rpcClient, db, func() bool {
+		return !config.WalletConfig.DisableCollectiblesMetadataHosts
+	})
 
 	// Collectible providers in priority order (i.e. provider N+1 will be tried only if provider N fails)
 	contractOwnershipProviders := []thirdparty.CollectibleContractOwnershipProvider{
@@ -162,18 +188,21 @@ func NewService(
 		raribleClient,
 		alchemyClient,
 		openseaV2Client,
+		onchainClient,
 	}
 
 	collectibleDataProviders := []thirdparty.CollectibleDataProvider{
 		raribleClient,
 		alchemyClient,
 		openseaV2Client,
+		onchainClient,
 	}
 
 	collectionDataProviders := []thirdparty.CollectionDataProvider{
 		raribleClient,
 		alchemyClient,
 		openseaV2Client,
+		onchainClient,
 	}
 
 	collectibleSearchProviders := []thirdparty.CollectibleSearchProvider{
@@ -198,17 +227,40 @@ func NewService(
 	)
 	collectibles := collectibles.NewService(db, feed, accountsDB, accountFeed, networksFeed, communityManager, rpcClient.NetworkManager, collectiblesManager)
 
-	activity := activity.NewService(db, accountsDB, tokenManager, collectiblesManager, feed)
+	activity := activity.NewService(db, accountsDB, tokenManager, collectiblesManager, feed, history.Exchange())
 
 	router := router.NewRouter(rpcClient, transactor, tokenManager, marketManager, collectibles,
 		collectiblesManager)
-	pathProcessors := buildPathProcessors(rpcClient, transactor, tokenManager, ensResolver, featureFlags)
+	router.SetPoisoningDetector(poisoning.NewDetector(db))
+	safeManager := safe.NewManager(db, rpcClient, accountsDB)
+	router.SetSafeManager(safeManager)
+
+	bundlerURLs := make(map[uint64]string)
+	for chainID, url := range config.WalletConfig.BundlerURLs {
+		bundlerURLs[chainID] = url.Reveal()
+	}
+	smartAccountManager := smartaccount.NewManager(db, rpcClient, pendingTxManager, bundlerURLs)
+	if pendingTxManager != nil {
+		pendingTxManager.SetUserOperationStatusFetcher(smartAccountManager)
+	}
+
+	allowancesManager := allowances.NewManager(db, rpcClient, feed)
+	spamManager := spam.NewManager(db, tokenManager, marketManager, feed)
+	priceAlertsManager := pricealerts.NewManager(db, marketManager, feed)
+
+	delegationManager := delegation.NewManager(transactor, accountsDB, delegation.BatchExecutors(config.WalletConfig))
+
+	pathProcessors := buildPathProcessors(rpcClient, transactor, tokenManager, ensResolver, safeManager, smartAccountManager, featureFlags)
 	for _, processor := range pathProcessors {
 		router.AddPathProcessor(processor)
 	}
 
 	routeExecutionManager := routeexecution.NewManager(db, feed, router, transactionManager, transferController)
 
+	decoder := NewDecoder()
+	signingPreviewer := signingpreview.NewPreviewer(decoder)
+	signingPreviewer.SetRPCClient(rpcClient)
+
 	leaderboardConfig := leaderboard.NewLeaderboardConfig(config.WalletConfig.MarketDataProxyConfig)
 	leaderboardService := leaderboard.NewMarketDataService(leaderboardConfig, db, feed)
 
@@ -234,14 +286,23 @@ func NewService(
 		history:               history,
 		currency:              currency,
 		activity:              activity,
-		decoder:               NewDecoder(),
+		decoder:               decoder,
 		blockChainState:       blockChainState,
+		headsTracker:          headsTracker,
+		networksFeed:          networksFeed,
 		keycardPairings:       NewKeycardPairings(),
 		config:                config,
 		featureFlags:          featureFlags,
 		router:                router,
 		routeExecutionManager: routeExecutionManager,
 		leaderboardService:    leaderboardService,
+		safeManager:           safeManager,
+		smartAccountManager:   smartAccountManager,
+		delegationManager:     delegationManager,
+		allowancesManager:     allowancesManager,
+		spamManager:           spamManager,
+		priceAlertsManager:    priceAlertsManager,
+		signingPreviewer:      signingPreviewer,
 		started:               false,
 	}
 }
@@ -251,18 +312,22 @@ func buildPathProcessors(
 	transactor *transactions.Transactor,
 	tokenManager *token.Manager,
 	ensResolver *ensresolver.EnsResolver,
+	safeManager *safe.Manager,
+	smartAccountManager *smartaccount.Manager,
 	featureFlags *protocolCommon.FeatureFlags,
 ) []pathprocessor.PathProcessor {
 	ret := make([]pathprocessor.PathProcessor, 0)
 
+	// transfers can also be sent from a Safe account, through one of its local owners, or from a smart account,
+	// as a user operation
 	transfer := pathprocessor.NewTransferProcessor(rpcClient, transactor)
-	ret = append(ret, transfer)
+	ret = append(ret, pathprocessor.NewSmartAccountProcessor(pathprocessor.NewSafeProcessor(transfer, transactor, safeManager), smartAccountManager))
 
 	erc721Transfer := pathprocessor.NewERC721Processor(rpcClient, transactor)
-	ret = append(ret, erc721Transfer)
+	ret = append(ret, pathprocessor.NewSmartAccountProcessor(pathprocessor.NewSafeProcessor(erc721Transfer, transactor, safeManager), smartAccountManager))
 
 	erc1155Transfer := pathprocessor.NewERC1155Processor(rpcClient, transactor)
-	ret = append(ret, erc1155Transfer)
+	ret = append(ret, pathprocessor.NewSmartAccountProcessor(pathprocessor.NewSafeProcessor(erc1155Transfer, transactor, safeManager), smartAccountManager))
 
 	hop := pathprocessor.NewHopBridgeProcessor(rpcClient, transactor, tokenManager, rpcClient.NetworkManager)
 	ret = append(ret, hop)
@@ -309,6 +374,9 @@ func buildPathProcessors(
 	communitySetSignerPubKey := pathprocessor.NewCommunitySetSignerPubKeyProcessor(rpcClient, transactor)
 	ret = append(ret, communitySetSignerPubKey)
 
+	revokeAllowance := pathprocessor.NewRevokeAllowanceProcessor(rpcClient, transactor)
+	ret = append(ret, revokeAllowance)
+
 	return ret
 }
 
@@ -337,12 +405,24 @@ type Service struct {
 	activity              *activity.Service
 	decoder               *Decoder
 	blockChainState       *blockchainstate.BlockChainState
+	headsTracker          *blockchainstate.HeadsTracker
+	headsTrackerMu        sync.Mutex
+	headsTrackerRunning   bool
+	networksFeed          *event.Feed
+	networksWatcher       *networksevent.Watcher
 	keycardPairings       *KeycardPairings
 	config                *params.NodeConfig
 	featureFlags          *protocolCommon.FeatureFlags
 	router                *router.Router
 	routeExecutionManager *routeexecution.Manager
 	leaderboardService    *leaderboard.MarketDataService
+	safeManager           *safe.Manager
+	smartAccountManager   *smartaccount.Manager
+	delegationManager     *delegation.Manager
+	allowancesManager     *allowances.Manager
+	spamManager           *spam.Manager
+	priceAlertsManager    *pricealerts.Manager
+	signingPreviewer      *signingpreview.Previewer
 	started               bool
 
 	cancelWalletServiceCtx context.CancelFunc
@@ -369,10 +449,81 @@ func (s *Service) Start() error {
 	s.history.Start(ctx)
 	s.collectibles.Start(ctx)
 	s.leaderboardService.Start(ctx)
+	s.priceAlertsManager.Start(ctx)
+	s.startHeadsTracker()
+	s.startNetworksWatcher()
 	s.started = true
 	return err
 }
 
+// startHeadsTracker follows the new blocks of the active networks
+func (s *Service) startHeadsTracker() {
+	s.headsTrackerMu.Lock()
+	defer s.headsTrackerMu.Unlock()
+
+	s.headsTrackerRunning = true
+	s.followActiveNetworks()
+}
+
+// restartHeadsTracker follows the networks active after a change, the changes after Stop are ignored
+func (s *Service) restartHeadsTracker() {
+	s.headsTrackerMu.Lock()
+	defer s.headsTrackerMu.Unlock()
+
+	if !s.headsTrackerRunning {
+		return
+	}
+	s.followActiveNetworks()
+}
+
+func (s *Service) stopHeadsTracker() {
+	s.headsTrackerMu.Lock()
+	defer s.headsTrackerMu.Unlock()
+
+	s.headsTrackerRunning = false
+	s.headsTracker.Stop()
+}
+
+// followActiveNetworks (re)starts the heads tracker with the active networks, it's called with headsTrackerMu held
+func (s *Service) followActiveNetworks() {
+	activeNetworks, err := s.rpcClient.NetworkManager.GetActiveNetworks()
+	if err != nil {
+		logutils.ZapLogger().Error("failed to get active networks", zap.Error(err))
+		return
+	}
+
+	chainClients, err := s.rpcClient.EthClients(wcommon.NetworksToChainIDs(activeNetworks))
+	if err != nil {
+		logutils.ZapLogger().Error("failed to get chain clients", zap.Error(err))
+		return
+	}
+
+	headsReaders := make(map[uint64]blockchainstate.HeadsReader, len(chainClients))
+	for chainID, chainClient := range chainClients {
+		headsReaders[chainID] = chainClient
+	}
+	s.headsTracker.Start(headsReaders)
+}
+
+// startNetworksWatcher restarts the heads tracker with the chains active after a network change
+func (s *Service) startNetworksWatcher() {
+	if s.networksFeed == nil || s.networksWatcher != nil {
+		return
+	}
+
+	s.networksWatcher = networksevent.NewWatcher(s.networksFeed, networksevent.EventCallbacks{
+		ActiveNetworksChangeCb: s.restartHeadsTracker,
+	})
+	s.networksWatcher.Start()
+}
+
+func (s *Service) stopNetworksWatcher() {
+	if s.networksWatcher != nil {
+		s.networksWatcher.Stop()
+		s.networksWatcher = nil
+	}
+}
+
 // Set external Collectibles community info provider
 func (s *Service) SetWalletCommunityInfoProvider(provider thirdparty.CommunityInfoProvider) {
 	s.communityManager.SetCommunityInfoProvider(provider)
@@ -390,6 +541,10 @@ func (s *Service) Stop() error {
 	s.collectibles.Stop()
 	s.tokenManager.Stop()
 	s.leaderboardService.Stop()
+	s.smartAccountManager.Stop()
+	s.allowancesManager.Stop()
+	s.stopNetworksWatcher()
+	s.stopHeadsTracker()
 	s.started = false
 	logutils.ZapLogger().Info("wallet stopped")
 
@@ -442,6 +597,10 @@ func (s *Service) GetTokenManager() *token.Manager {
 	return s.tokenManager
 }
 
+func (s *Service) GetSigningPreviewer() *signingpreview.Previewer {
+	return s.signingPreviewer
+}
+
 func (s *Service) GetMarketManager() *market.Manager {
 	return s.marketManager
 }
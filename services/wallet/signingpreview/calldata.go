@@ -0,0 +1,239 @@
+package signingpreview
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+// tokenABI holds the ERC-20 and ERC-721 functions decoded locally, the preview must not depend on the
+// availability of the signature databases for the calls moving or approving tokens
+const tokenABI = `[
+	{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"increaseAllowance","inputs":[{"name":"spender","type":"address"},{"name":"addedValue","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"setApprovalForAll","inputs":[{"name":"operator","type":"address"},{"name":"approved","type":"bool"}],"outputs":[]},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"transferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"safeTransferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[]}
+]`
+
+const permit2ABI = `[
+	{"type":"function","name":"approve","inputs":[{"name":"token","type":"address"},{"name":"spender","type":"address"},{"name":"amount","type":"uint160"},{"name":"expiration","type":"uint48"}],"outputs":[]}
+]`
+
+const erc165ABI = `[
+	{"type":"function","name":"supportsInterface","stateMutability":"view","inputs":[{"name":"interfaceId","type":"bytes4"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+// erc721InterfaceID is the ERC-165 identifier of the ERC-721 interface
+var erc721InterfaceID = [4]byte{0x80, 0xac, 0x58, 0xcd}
+
+const interfaceCheckTimeout = 5 * time.Second
+
+var permit2Address = common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+
+var (
+	parsedTokenABI   abi.ABI
+	parsedPermit2ABI abi.ABI
+	parsedERC165ABI  abi.ABI
+)
+
+func init() {
+	var err error
+	parsedTokenABI, err = abi.JSON(strings.NewReader(tokenABI))
+	if err != nil {
+		panic(err)
+	}
+	parsedPermit2ABI, err = abi.JSON(strings.NewReader(permit2ABI))
+	if err != nil {
+		panic(err)
+	}
+	parsedERC165ABI, err = abi.JSON(strings.NewReader(erc165ABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// PreviewTransaction returns the preview of a transaction, token transfers and approvals are decoded locally while
+// the signature of other calls is resolved by the decoder
+func (p *Previewer) PreviewTransaction(chainID uint64, args *wallettypes.SendTxArgs) (*Preview, error) {
+	if !args.Valid() {
+		return nil, wallettypes.ErrInvalidSendTxArgs
+	}
+
+	preview := newPreview(KindTransfer, chainID)
+	preview.Value = args.Value
+	data := args.GetInput()
+	if args.To == nil {
+		preview.Kind = KindContractCall
+		return preview, nil
+	}
+	to := common.Address(*args.To)
+	preview.To = &to
+	if len(data) < 4 {
+		return preview, nil
+	}
+
+	preview.Kind = KindContractCall
+	preview.Contract = &to
+
+	if method, err := parsedTokenABI.MethodById(data[:4]); err == nil {
+		if err := p.previewTokenCall(preview, method, data[4:]); err == nil {
+			return preview, nil
+		}
+	}
+	if to == permit2Address {
+		if method, err := parsedPermit2ABI.MethodById(data[:4]); err == nil {
+			if err := p.previewPermit2Approve(preview, method, data[4:]); err == nil {
+				return preview, nil
+			}
+		}
+	}
+
+	if p.decoder != nil {
+		// an unknown function is not an error, the preview is shown without the decoded call
+		decoded, err := p.decoder.Decode(hexutil.Encode(data))
+		if err == nil {
+			preview.Method = decoded.Signature
+			preview.Decoded = decoded
+		}
+	}
+	return preview, nil
+}
+
+func (p *Previewer) previewTokenCall(preview *Preview, method *abi.Method, data []byte) error {
+	values, err := method.Inputs.Unpack(data)
+	if err != nil {
+		return err
+	}
+	setDecodedCall(preview, method, values)
+
+	token := *preview.Contract
+	switch method.RawName {
+	case "approve", "increaseAllowance":
+		allowance := p.newAllowance(token, values[0].(common.Address))
+		amount := values[1].(*big.Int)
+		// ERC-721 `approve` shares the selector, its argument is the ID of the approved token
+		if method.RawName == "approve" && p.isCollection(preview.ChainID, token) {
+			allowance.TokenID = (*hexutil.Big)(amount)
+		} else {
+			allowance.Amount = (*hexutil.Big)(amount)
+			allowance.Unlimited = isUnlimited(amount, erc20AmountBits)
+		}
+		addAllowance(preview, KindApprove, allowance)
+	case "setApprovalForAll":
+		allowance := p.newAllowance(token, values[0].(common.Address))
+		allowance.Unlimited = values[1].(bool)
+		preview.Kind = KindSetApprovalForAll
+		preview.Allowances = append(preview.Allowances, allowance)
+		if allowance.Unlimited {
+			operator := allowance.Spender
+			preview.addRisk(RiskApprovalForAll, &operator)
+		}
+	default:
+		preview.Kind = KindTransfer
+	}
+	return nil
+}
+
+// isCollection checks if the contract supports ERC-721, a contract whose interfaces can't be checked is considered
+// an ERC-20 token to keep warning about unlimited allowances
+func (p *Previewer) isCollection(chainID uint64, contract common.Address) bool {
+	if p.rpcClient == nil {
+		return false
+	}
+
+	key := contractKey{chainID: chainID, address: contract}
+	p.collectionsMutex.Lock()
+	supported, ok := p.collections[key]
+	p.collectionsMutex.Unlock()
+	if ok {
+		return supported
+	}
+
+	// a failed call isn't cached, it may be a revert of a contract without ERC-165 or a transient RPC error
+	supported, err := p.supportsInterface(chainID, contract, erc721InterfaceID)
+	if err != nil {
+		return false
+	}
+
+	p.collectionsMutex.Lock()
+	p.collections[key] = supported
+	p.collectionsMutex.Unlock()
+	return supported
+}
+
+func (p *Previewer) supportsInterface(chainID uint64, contract common.Address, interfaceID [4]byte) (bool, error) {
+	client, err := p.rpcClient.EthClient(chainID)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := parsedERC165ABI.Pack("supportsInterface", interfaceID)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), interfaceCheckTimeout)
+	defer cancel()
+	out, err := client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := parsedERC165ABI.Unpack("supportsInterface", out)
+	if err != nil {
+		// the contract doesn't implement ERC-165, like most ERC-20 tokens
+		return false, nil
+	}
+	return res[0].(bool), nil
+}
+
+func (p *Previewer) previewPermit2Approve(preview *Preview, method *abi.Method, data []byte) error {
+	values, err := method.Inputs.Unpack(data)
+	if err != nil {
+		return err
+	}
+	setDecodedCall(preview, method, values)
+
+	allowance := p.newAllowance(values[0].(common.Address), values[1].(common.Address))
+	amount := values[2].(*big.Int)
+	allowance.Amount = (*hexutil.Big)(amount)
+	allowance.Unlimited = isUnlimited(amount, permit2AmountBits)
+	allowance.Expiration = values[3].(*big.Int).Uint64()
+	addAllowance(preview, KindApprove, allowance)
+	return nil
+}
+
+func addAllowance(preview *Preview, kind Kind, allowance Allowance) {
+	preview.Kind = kind
+	preview.Allowances = append(preview.Allowances, allowance)
+	if allowance.Unlimited {
+		spender := allowance.Spender
+		preview.addRisk(RiskUnlimitedAllowance, &spender)
+	}
+}
+
+func setDecodedCall(preview *Preview, method *abi.Method, values []interface{}) {
+	inputs := make(map[string]string, len(values))
+	for i, input := range method.Inputs {
+		inputs[input.Name] = fmt.Sprintf("%v", values[i])
+	}
+	preview.Method = method.Sig
+	preview.Decoded = &thirdparty.DataParsed{
+		Name:      method.RawName,
+		ID:        hexutil.Encode(method.ID),
+		Signature: method.Sig,
+		Inputs:    inputs,
+	}
+}
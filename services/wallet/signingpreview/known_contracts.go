@@ -0,0 +1,26 @@
+package signingpreview
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// knownSpenders are well known protocol contracts deployed at the same address on all supported chains, permits
+// and approvals for them are expected
+var knownSpenders = map[common.Address]string{
+	common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3"): "Uniswap Permit2",
+	common.HexToAddress("0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD"): "Uniswap Universal Router",
+	common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"): "Uniswap V3 Router",
+	common.HexToAddress("0x00000000000000ADc04C56Bf30aC9d3c0aAF14dC"): "Seaport 1.5",
+	common.HexToAddress("0x0000000000000068F116a894984e2DB1123eB395"): "Seaport 1.6",
+	common.HexToAddress("0x1E0049783F008A0085193E00003D00cd54003c71"): "OpenSea Conduit",
+	common.HexToAddress("0x216B4B4Ba9F3e719726886d34a177484278Bfcae"): "ParaSwap Token Transfer Proxy",
+	common.HexToAddress("0x6A000F20005980200259B80c5102003040001068"): "ParaSwap Augustus V6",
+	common.HexToAddress("0x1111111254EEB25477B68fb85Ed929f73A960582"): "1inch Router V5",
+	common.HexToAddress("0x111111125421cA6dc452d289314280a0f8842A65"): "1inch Router V6",
+	common.HexToAddress("0xDef1C0ded9bec7F1a1670819833240f027b25EfF"): "0x Exchange Proxy",
+}
+
+const (
+	permit2DomainName = "Permit2"
+	seaportDomainName = "Seaport"
+)
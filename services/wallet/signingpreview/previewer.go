@@ -0,0 +1,92 @@
+package signingpreview
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+// Decoder resolves the signature of the function called by the calldata
+type Decoder interface {
+	Decode(data string) (*thirdparty.DataParsed, error)
+}
+
+// Previewer builds the previews shown to the user before signing
+type Previewer struct {
+	decoder       Decoder
+	knownSpenders map[common.Address]string
+	rpcClient     rpc.ClientInterface
+
+	// collections caches the ERC-721 support of the approved contracts
+	collectionsMutex sync.Mutex
+	collections      map[contractKey]bool
+}
+
+type contractKey struct {
+	chainID uint64
+	address common.Address
+}
+
+func NewPreviewer(decoder Decoder) *Previewer {
+	return &Previewer{
+		decoder:       decoder,
+		knownSpenders: knownSpenders,
+		collections:   make(map[contractKey]bool),
+	}
+}
+
+// SetRPCClient lets the previewer check the interfaces of the called contracts, without it every `approve` call is
+// previewed as an ERC-20 approval
+func (p *Previewer) SetRPCClient(rpcClient rpc.ClientInterface) {
+	p.rpcClient = rpcClient
+}
+
+// KnownSpender returns the name of the protocol contract, an empty string is returned for unknown addresses
+func (p *Previewer) KnownSpender(address common.Address) string {
+	return p.knownSpenders[address]
+}
+
+// PreviewMessage returns the preview of a `personal_sign` challenge, the text is shown if the challenge is readable
+func (p *Previewer) PreviewMessage(challenge string, chainID uint64) *Preview {
+	preview := newPreview(KindMessage, chainID)
+	preview.Message = challenge
+
+	if !strings.HasPrefix(challenge, "0x") {
+		return preview
+	}
+	data, err := hexutil.Decode(challenge)
+	if err != nil {
+		return preview
+	}
+	if isReadableText(data) {
+		preview.Message = string(data)
+	}
+	return preview
+}
+
+func (p *Previewer) newAllowance(token common.Address, spender common.Address) Allowance {
+	return Allowance{
+		Token:        token,
+		Spender:      spender,
+		KnownSpender: p.KnownSpender(spender),
+	}
+}
+
+func isReadableText(data []byte) bool {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,323 @@
+package signingpreview
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+
+	"github.com/status-im/status-go/eth-node/types"
+	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	mock_rpcclient "github.com/status-im/status-go/rpc/mock/client"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+type testDecoder struct {
+	parsed *thirdparty.DataParsed
+	calls  int
+}
+
+func (d *testDecoder) Decode(data string) (*thirdparty.DataParsed, error) {
+	d.calls++
+	if d.parsed == nil {
+		return nil, errors.New("unknown signature")
+	}
+	return d.parsed, nil
+}
+
+var (
+	testToken   = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	testSpender = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testOwner   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+const permitTypedData = `{
+	"types": {
+		"EIP712Domain": [{"name":"name","type":"string"},{"name":"version","type":"string"},{"name":"chainId","type":"uint256"},{"name":"verifyingContract","type":"address"}],
+		"Permit": [{"name":"owner","type":"address"},{"name":"spender","type":"address"},{"name":"value","type":"uint256"},{"name":"nonce","type":"uint256"},{"name":"deadline","type":"uint256"}]
+	},
+	"primaryType": "Permit",
+	"domain": {"name":"USD Coin","version":"2","chainId":1,"verifyingContract":"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"},
+	"message": {
+		"owner": "0x2222222222222222222222222222222222222222",
+		"spender": "0x1111111111111111111111111111111111111111",
+		"value": "115792089237316195423570985008687907853269984665640564039457584007913129639935",
+		"nonce": 0,
+		"deadline": "1700000000"
+	}
+}`
+
+const permit2TypedData = `{
+	"types": {
+		"EIP712Domain": [{"name":"name","type":"string"},{"name":"chainId","type":"uint256"},{"name":"verifyingContract","type":"address"}],
+		"PermitSingle": [{"name":"details","type":"PermitDetails"},{"name":"spender","type":"address"},{"name":"sigDeadline","type":"uint256"}],
+		"PermitDetails": [{"name":"token","type":"address"},{"name":"amount","type":"uint160"},{"name":"expiration","type":"uint48"},{"name":"nonce","type":"uint48"}]
+	},
+	"primaryType": "PermitSingle",
+	"domain": {"name":"Permit2","chainId":"0xa","verifyingContract":"0x000000000022D473030F116dDEE9F6B43aC78BA3"},
+	"message": {
+		"details": {
+			"token": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+			"amount": "1461501637330902918203684832716283019655932542975",
+			"expiration": "1700000000",
+			"nonce": "0"
+		},
+		"spender": "0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD",
+		"sigDeadline": "1700000000"
+	}
+}`
+
+const seaportTypedData = `{
+	"types": {
+		"EIP712Domain": [{"name":"name","type":"string"},{"name":"version","type":"string"},{"name":"chainId","type":"uint256"},{"name":"verifyingContract","type":"address"}],
+		"OrderComponents": [{"name":"offerer","type":"address"},{"name":"offer","type":"OfferItem[]"},{"name":"consideration","type":"ConsiderationItem[]"},{"name":"startTime","type":"uint256"},{"name":"endTime","type":"uint256"}],
+		"OfferItem": [{"name":"itemType","type":"uint8"},{"name":"token","type":"address"},{"name":"identifierOrCriteria","type":"uint256"},{"name":"startAmount","type":"uint256"},{"name":"endAmount","type":"uint256"}],
+		"ConsiderationItem": [{"name":"itemType","type":"uint8"},{"name":"token","type":"address"},{"name":"identifierOrCriteria","type":"uint256"},{"name":"startAmount","type":"uint256"},{"name":"endAmount","type":"uint256"},{"name":"recipient","type":"address"}]
+	},
+	"primaryType": "OrderComponents",
+	"domain": {"name":"Seaport","version":"1.6","chainId":1,"verifyingContract":"0x0000000000000068F116a894984e2DB1123eB395"},
+	"message": {
+		"offerer": "0x2222222222222222222222222222222222222222",
+		"offer": [{"itemType":2,"token":"0x3333333333333333333333333333333333333333","identifierOrCriteria":"42","startAmount":"1","endAmount":"1"}],
+		"consideration": [{"itemType":0,"token":"0x0000000000000000000000000000000000000000","identifierOrCriteria":"0","startAmount":"1000","endAmount":"1000","recipient":"0x2222222222222222222222222222222222222222"}],
+		"startTime": "1600000000",
+		"endTime": "1700000000"
+	}
+}`
+
+func TestPreviewMessage(t *testing.T) {
+	p := NewPreviewer(nil)
+
+	preview := p.PreviewMessage(hexutil.Encode([]byte("Sign in to example.com")), 1)
+	require.Equal(t, KindMessage, preview.Kind)
+	require.Equal(t, "Sign in to example.com", preview.Message)
+	require.Equal(t, RiskLevelNone, preview.RiskLevel)
+
+	binary := hexutil.Encode([]byte{0x00, 0xff, 0x10})
+	preview = p.PreviewMessage(binary, 1)
+	require.Equal(t, binary, preview.Message)
+}
+
+func TestPreviewTypedDataPermit(t *testing.T) {
+	p := NewPreviewer(nil)
+
+	preview, err := p.PreviewTypedData(permitTypedData, 1)
+	require.NoError(t, err)
+	require.Equal(t, KindPermit, preview.Kind)
+	require.Equal(t, "Permit", preview.Method)
+	require.Equal(t, testToken, *preview.Contract)
+	require.Len(t, preview.Allowances, 1)
+	require.Equal(t, testToken, preview.Allowances[0].Token)
+	require.Equal(t, testSpender, preview.Allowances[0].Spender)
+	require.True(t, preview.Allowances[0].Unlimited)
+	require.Equal(t, uint64(1700000000), preview.Allowances[0].Expiration)
+
+	require.Len(t, preview.Risks, 2)
+	require.Equal(t, RiskUnlimitedAllowance, preview.Risks[0].Type)
+	require.Equal(t, RiskPermitToUnknownSpender, preview.Risks[1].Type)
+	require.Equal(t, testSpender, *preview.Risks[1].Address)
+	require.Equal(t, RiskLevelCritical, preview.RiskLevel)
+
+	// same permit requested by a dApp connected to another chain
+	preview, err = p.PreviewTypedData(permitTypedData, 10)
+	require.NoError(t, err)
+	require.Len(t, preview.Risks, 3)
+	require.Equal(t, RiskChainMismatch, preview.Risks[0].Type)
+}
+
+func TestPreviewTypedDataPermit2(t *testing.T) {
+	p := NewPreviewer(nil)
+
+	preview, err := p.PreviewTypedData(permit2TypedData, 10)
+	require.NoError(t, err)
+	require.Equal(t, KindPermit2, preview.Kind)
+	require.Len(t, preview.Allowances, 1)
+	require.Equal(t, testToken, preview.Allowances[0].Token)
+	require.Equal(t, "Uniswap Universal Router", preview.Allowances[0].KnownSpender)
+	require.True(t, preview.Allowances[0].Unlimited)
+
+	// the spender is known, only the amount is flagged
+	require.Len(t, preview.Risks, 1)
+	require.Equal(t, RiskUnlimitedAllowance, preview.Risks[0].Type)
+	require.Equal(t, RiskLevelWarning, preview.RiskLevel)
+}
+
+func TestPreviewTypedDataSeaportOrder(t *testing.T) {
+	p := NewPreviewer(nil)
+
+	preview, err := p.PreviewTypedData(seaportTypedData, 1)
+	require.NoError(t, err)
+	require.Equal(t, KindSeaportOrder, preview.Kind)
+	require.NotNil(t, preview.Order)
+	require.Equal(t, testOwner, preview.Order.Offerer)
+	require.Len(t, preview.Order.Offer, 1)
+	require.Equal(t, int64(42), preview.Order.Offer[0].Identifier.ToInt().Int64())
+	require.Len(t, preview.Order.Consideration, 1)
+	require.Equal(t, int64(1000), preview.Order.Consideration[0].Amount.ToInt().Int64())
+	require.Equal(t, testOwner, preview.Order.Consideration[0].Recipient)
+	require.Equal(t, uint64(1700000000), preview.Order.EndTime)
+	require.Empty(t, preview.Risks)
+}
+
+func TestPreviewTypedDataInvalid(t *testing.T) {
+	p := NewPreviewer(nil)
+
+	_, err := p.PreviewTypedData("{}", 1)
+	require.Error(t, err)
+}
+
+func txArgs(to common.Address, data []byte) *wallettypes.SendTxArgs {
+	toAddress := types.Address(to)
+	return &wallettypes.SendTxArgs{
+		From:  types.Address(testOwner),
+		To:    &toAddress,
+		Value: (*hexutil.Big)(big.NewInt(0)),
+		Data:  data,
+	}
+}
+
+func TestPreviewTransactionApprove(t *testing.T) {
+	decoder := &testDecoder{}
+	p := NewPreviewer(decoder)
+
+	data, err := parsedTokenABI.Pack("approve", testSpender, math.MaxBig256)
+	require.NoError(t, err)
+
+	preview, err := p.PreviewTransaction(1, txArgs(testToken, data))
+	require.NoError(t, err)
+	require.Equal(t, KindApprove, preview.Kind)
+	require.Equal(t, "approve(address,uint256)", preview.Method)
+	require.Equal(t, testSpender.Hex(), preview.Decoded.Inputs["spender"])
+	require.Len(t, preview.Allowances, 1)
+	require.True(t, preview.Allowances[0].Unlimited)
+	require.Len(t, preview.Risks, 1)
+	require.Equal(t, RiskUnlimitedAllowance, preview.Risks[0].Type)
+	require.Equal(t, 0, decoder.calls)
+
+	data, err = parsedTokenABI.Pack("approve", testSpender, big.NewInt(1000))
+	require.NoError(t, err)
+
+	preview, err = p.PreviewTransaction(1, txArgs(testToken, data))
+	require.NoError(t, err)
+	require.False(t, preview.Allowances[0].Unlimited)
+	require.Empty(t, preview.Risks)
+}
+
+func TestPreviewTransactionApproveCollectible(t *testing.T) {
+	collection := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	ctrl := gomock.NewController(t)
+	rpcClient := mock_rpcclient.NewMockClientInterface(ctrl)
+	chainClient := mock_client.NewMockClientInterface(ctrl)
+	rpcClient.EXPECT().EthClient(uint64(1)).Return(chainClient, nil).AnyTimes()
+	chainClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+			values, err := parsedERC165ABI.Methods["supportsInterface"].Inputs.Unpack(msg.Data[4:])
+			require.NoError(t, err)
+			require.Equal(t, erc721InterfaceID, values[0].([4]byte))
+			if *msg.To != collection {
+				return nil, errors.New("execution reverted")
+			}
+			return parsedERC165ABI.Methods["supportsInterface"].Outputs.Pack(true)
+		}).Times(3)
+
+	p := NewPreviewer(nil)
+	p.SetRPCClient(rpcClient)
+
+	// the token ID of an ERC-721 approval isn't an amount, however large it is
+	tokenID := new(big.Int).Lsh(big.NewInt(1), 255)
+	data, err := parsedTokenABI.Pack("approve", testSpender, tokenID)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		// the interface of the collection is checked once
+		preview, err := p.PreviewTransaction(1, txArgs(collection, data))
+		require.NoError(t, err)
+		require.Equal(t, KindApprove, preview.Kind)
+		require.Len(t, preview.Allowances, 1)
+		require.Nil(t, preview.Allowances[0].Amount)
+		require.Equal(t, tokenID, preview.Allowances[0].TokenID.ToInt())
+		require.False(t, preview.Allowances[0].Unlimited)
+		require.Empty(t, preview.Risks)
+	}
+
+	// a contract without ERC-165 is an ERC-20 token, a failed check is done again
+	data, err = parsedTokenABI.Pack("approve", testSpender, math.MaxBig256)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		preview, err := p.PreviewTransaction(1, txArgs(testToken, data))
+		require.NoError(t, err)
+		require.Nil(t, preview.Allowances[0].TokenID)
+		require.True(t, preview.Allowances[0].Unlimited)
+		require.Len(t, preview.Risks, 1)
+	}
+}
+
+func TestPreviewTransactionSetApprovalForAll(t *testing.T) {
+	p := NewPreviewer(nil)
+
+	data, err := parsedTokenABI.Pack("setApprovalForAll", testSpender, true)
+	require.NoError(t, err)
+
+	preview, err := p.PreviewTransaction(1, txArgs(testToken, data))
+	require.NoError(t, err)
+	require.Equal(t, KindSetApprovalForAll, preview.Kind)
+	require.Len(t, preview.Risks, 1)
+	require.Equal(t, RiskApprovalForAll, preview.Risks[0].Type)
+	require.Equal(t, RiskLevelCritical, preview.RiskLevel)
+}
+
+func TestPreviewTransactionPermit2Approve(t *testing.T) {
+	p := NewPreviewer(nil)
+
+	maxUint160 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+	data, err := parsedPermit2ABI.Pack("approve", testToken, testSpender, maxUint160, big.NewInt(1700000000))
+	require.NoError(t, err)
+
+	preview, err := p.PreviewTransaction(1, txArgs(permit2Address, data))
+	require.NoError(t, err)
+	require.Equal(t, KindApprove, preview.Kind)
+	require.Equal(t, testToken, preview.Allowances[0].Token)
+	require.Equal(t, uint64(1700000000), preview.Allowances[0].Expiration)
+	require.Equal(t, RiskUnlimitedAllowance, preview.Risks[0].Type)
+}
+
+func TestPreviewTransactionUnknownCall(t *testing.T) {
+	decoder := &testDecoder{}
+	p := NewPreviewer(decoder)
+
+	data := hexutil.MustDecode("0xdeadbeef0000000000000000000000000000000000000000000000000000000000000001")
+	preview, err := p.PreviewTransaction(1, txArgs(testSpender, data))
+	require.NoError(t, err)
+	require.Equal(t, KindContractCall, preview.Kind)
+	require.Nil(t, preview.Decoded)
+	require.Equal(t, 1, decoder.calls)
+
+	decoder.parsed = &thirdparty.DataParsed{Name: "mint", Signature: "mint(uint256)"}
+	preview, err = p.PreviewTransaction(1, txArgs(testSpender, data))
+	require.NoError(t, err)
+	require.Equal(t, "mint(uint256)", preview.Method)
+	require.Equal(t, decoder.parsed, preview.Decoded)
+}
+
+func TestPreviewTransactionTransfer(t *testing.T) {
+	p := NewPreviewer(nil)
+
+	args := txArgs(testSpender, nil)
+	args.Value = (*hexutil.Big)(big.NewInt(100))
+	preview, err := p.PreviewTransaction(1, args)
+	require.NoError(t, err)
+	require.Equal(t, KindTransfer, preview.Kind)
+	require.Equal(t, testSpender, *preview.To)
+	require.Equal(t, args.Value, preview.Value)
+	require.Nil(t, preview.Contract)
+}
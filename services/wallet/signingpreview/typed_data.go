@@ -0,0 +1,230 @@
+package signingpreview
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/typeddata"
+)
+
+const (
+	permitPrimaryType       = "Permit"
+	seaportOrderPrimaryType = "OrderComponents"
+
+	// permit2AmountBits is the size of the Permit2 allowance amount (uint160)
+	permit2AmountBits uint = 160
+	erc20AmountBits   uint = 256
+)
+
+// PreviewTypedData returns the preview of EIP-712 typed data, Permit (EIP-2612), Permit2 and Seaport orders are
+// decoded. The typed data is expected to be signed on `chainID`.
+func (p *Previewer) PreviewTypedData(typedJson string, chainID uint64) (*Preview, error) {
+	var typed typeddata.TypedData
+	if err := json.Unmarshal([]byte(typedJson), &typed); err != nil {
+		return nil, err
+	}
+	if err := typed.Validate(); err != nil {
+		return nil, err
+	}
+
+	preview := newPreview(KindTypedData, chainID)
+	preview.Method = typed.PrimaryType
+	if contract, ok := parseAddress(typed.Domain["verifyingContract"]); ok {
+		preview.Contract = &contract
+	}
+
+	if _, exist := typed.Domain[typeddata.ChainIDKey]; exist {
+		if err := typed.ValidateChainID(new(big.Int).SetUint64(chainID)); err != nil {
+			preview.addRisk(RiskChainMismatch, nil)
+		}
+	}
+
+	domainName, _ := parseString(typed.Domain["name"])
+	switch {
+	case domainName == permit2DomainName:
+		p.previewPermit2(preview, typed.Message)
+	case domainName == seaportDomainName && typed.PrimaryType == seaportOrderPrimaryType:
+		previewSeaportOrder(preview, typed.Message)
+	case typed.PrimaryType == permitPrimaryType:
+		p.previewPermit(preview, typed.Message)
+	}
+
+	return preview, nil
+}
+
+// previewPermit decodes EIP-2612 permits and the DAI permits using an `allowed` flag instead of an amount
+func (p *Previewer) previewPermit(preview *Preview, message map[string]json.RawMessage) {
+	spender, ok := parseAddress(message["spender"])
+	if !ok || preview.Contract == nil {
+		return
+	}
+	preview.Kind = KindPermit
+
+	allowance := p.newAllowance(*preview.Contract, spender)
+	if value, ok := parseBigInt(message["value"]); ok {
+		allowance.Amount = (*hexutil.Big)(value)
+		allowance.Unlimited = isUnlimited(value, erc20AmountBits)
+	} else if allowed, ok := parseBool(message["allowed"]); ok {
+		allowance.Unlimited = allowed
+		if !allowed {
+			allowance.Amount = (*hexutil.Big)(new(big.Int))
+		}
+	}
+	if deadline, ok := parseBigInt(message["deadline"]); ok {
+		allowance.Expiration = deadline.Uint64()
+	} else if expiry, ok := parseBigInt(message["expiry"]); ok {
+		allowance.Expiration = expiry.Uint64()
+	}
+
+	addPermitAllowance(preview, allowance)
+}
+
+// previewPermit2 decodes the allowance (`details`) and the signature transfer (`permitted`) permits of Permit2
+func (p *Previewer) previewPermit2(preview *Preview, message map[string]json.RawMessage) {
+	spender, ok := parseAddress(message["spender"])
+	if !ok {
+		return
+	}
+	preview.Kind = KindPermit2
+
+	items, amountBits := parseObjects(message["details"]), permit2AmountBits
+	if len(items) == 0 {
+		items, amountBits = parseObjects(message["permitted"]), erc20AmountBits
+	}
+	deadline, _ := parseBigInt(message["deadline"])
+
+	for _, item := range items {
+		token, ok := parseAddress(item["token"])
+		if !ok {
+			continue
+		}
+		allowance := p.newAllowance(token, spender)
+		if amount, ok := parseBigInt(item["amount"]); ok {
+			allowance.Amount = (*hexutil.Big)(amount)
+			allowance.Unlimited = isUnlimited(amount, amountBits)
+		}
+		if expiration, ok := parseBigInt(item["expiration"]); ok {
+			allowance.Expiration = expiration.Uint64()
+		} else if deadline != nil {
+			allowance.Expiration = deadline.Uint64()
+		}
+		addPermitAllowance(preview, allowance)
+	}
+}
+
+func previewSeaportOrder(preview *Preview, message map[string]json.RawMessage) {
+	offerer, ok := parseAddress(message["offerer"])
+	if !ok {
+		return
+	}
+	preview.Kind = KindSeaportOrder
+
+	order := &Order{
+		Offerer:       offerer,
+		Offer:         parseOrderItems(message["offer"]),
+		Consideration: parseOrderItems(message["consideration"]),
+	}
+	if startTime, ok := parseBigInt(message["startTime"]); ok {
+		order.StartTime = startTime.Uint64()
+	}
+	if endTime, ok := parseBigInt(message["endTime"]); ok {
+		order.EndTime = endTime.Uint64()
+	}
+	preview.Order = order
+}
+
+func parseOrderItems(raw json.RawMessage) []OrderItem {
+	items := make([]OrderItem, 0)
+	for _, object := range parseObjects(raw) {
+		item := OrderItem{}
+		if itemType, ok := parseBigInt(object["itemType"]); ok {
+			item.ItemType = int(itemType.Int64())
+		}
+		item.Token, _ = parseAddress(object["token"])
+		item.Recipient, _ = parseAddress(object["recipient"])
+		if identifier, ok := parseBigInt(object["identifierOrCriteria"]); ok {
+			item.Identifier = (*hexutil.Big)(identifier)
+		}
+		if amount, ok := parseBigInt(object["endAmount"]); ok {
+			item.Amount = (*hexutil.Big)(amount)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func addPermitAllowance(preview *Preview, allowance Allowance) {
+	preview.Allowances = append(preview.Allowances, allowance)
+
+	spender := allowance.Spender
+	if allowance.Unlimited {
+		preview.addRisk(RiskUnlimitedAllowance, &spender)
+	}
+	// revoking an allowance is harmless whoever the spender is
+	revoked := allowance.Amount != nil && allowance.Amount.ToInt().Sign() == 0
+	if allowance.KnownSpender == "" && !revoked {
+		preview.addRisk(RiskPermitToUnknownSpender, &spender)
+	}
+}
+
+// isUnlimited returns true for amounts no account could hold, dApps use the max value of the type or a value close
+// to it for unlimited allowances
+func isUnlimited(amount *big.Int, bits uint) bool {
+	return amount.Cmp(new(big.Int).Lsh(big.NewInt(1), bits-1)) >= 0
+}
+
+func parseString(raw json.RawMessage) (string, bool) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+func parseBool(raw json.RawMessage) (bool, bool) {
+	var value bool
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return false, false
+	}
+	return value, true
+}
+
+func parseAddress(raw json.RawMessage) (common.Address, bool) {
+	value, ok := parseString(raw)
+	if !ok || !common.IsHexAddress(value) {
+		return common.Address{}, false
+	}
+	return common.HexToAddress(value), true
+}
+
+// parseBigInt parses numbers encoded as JSON numbers, decimal strings or hex strings
+func parseBigInt(raw json.RawMessage) (*big.Int, bool) {
+	if len(raw) == 0 {
+		return nil, false
+	}
+	value := strings.Trim(string(raw), `"`)
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		return new(big.Int).SetString(value[2:], 16)
+	}
+	return new(big.Int).SetString(value, 10)
+}
+
+// parseObjects parses a single object or an array of objects
+func parseObjects(raw json.RawMessage) []map[string]json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &objects); err == nil {
+		return objects
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err == nil {
+		return []map[string]json.RawMessage{object}
+	}
+	return nil
+}
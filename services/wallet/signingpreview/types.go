@@ -0,0 +1,129 @@
+package signingpreview
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+type Kind string
+
+const (
+	KindMessage           Kind = "message"
+	KindTypedData         Kind = "typedData"
+	KindPermit            Kind = "permit"
+	KindPermit2           Kind = "permit2"
+	KindSeaportOrder      Kind = "seaportOrder"
+	KindTransfer          Kind = "transfer"
+	KindApprove           Kind = "approve"
+	KindSetApprovalForAll Kind = "setApprovalForAll"
+	KindContractCall      Kind = "contractCall"
+)
+
+type RiskType string
+
+const (
+	// RiskUnlimitedAllowance is an allowance the spender can use to move all the tokens of the account
+	RiskUnlimitedAllowance RiskType = "unlimitedAllowance"
+	// RiskApprovalForAll lets the operator move all the collectibles of a collection
+	RiskApprovalForAll RiskType = "approvalForAll"
+	// RiskPermitToUnknownSpender is an off-chain permit for a spender that is not a known protocol contract
+	RiskPermitToUnknownSpender RiskType = "permitToUnknownSpender"
+	// RiskChainMismatch is typed data bound to another chain than the one the dApp is connected to
+	RiskChainMismatch RiskType = "chainMismatch"
+)
+
+type RiskLevel string
+
+const (
+	RiskLevelNone     RiskLevel = "none"
+	RiskLevelWarning  RiskLevel = "warning"
+	RiskLevelCritical RiskLevel = "critical"
+)
+
+var riskLevels = map[RiskType]RiskLevel{
+	RiskUnlimitedAllowance:     RiskLevelWarning,
+	RiskApprovalForAll:         RiskLevelCritical,
+	RiskPermitToUnknownSpender: RiskLevelCritical,
+	RiskChainMismatch:          RiskLevelCritical,
+}
+
+type Risk struct {
+	Type  RiskType  `json:"type"`
+	Level RiskLevel `json:"level"`
+	// Address the risk relates to, e.g. the spender of an allowance
+	Address *common.Address `json:"address,omitempty"`
+}
+
+// Allowance is the right to move tokens of the account given by a signed request
+type Allowance struct {
+	Token   common.Address `json:"token"`
+	Spender common.Address `json:"spender"`
+	// Amount is not set for approvals of a whole collection or of a single collectible
+	Amount *hexutil.Big `json:"amount,omitempty"`
+	// TokenID is the collectible approved by an ERC-721 `approve`
+	TokenID   *hexutil.Big `json:"tokenId,omitempty"`
+	Unlimited bool         `json:"unlimited"`
+	// Expiration is the unix timestamp the allowance or the signature expires at, 0 if it doesn't expire
+	Expiration   uint64 `json:"expiration,omitempty"`
+	KnownSpender string `json:"knownSpender,omitempty"`
+}
+
+type OrderItem struct {
+	ItemType   int            `json:"itemType"`
+	Token      common.Address `json:"token"`
+	Identifier *hexutil.Big   `json:"identifier"`
+	Amount     *hexutil.Big   `json:"amount"`
+	Recipient  common.Address `json:"recipient,omitempty"`
+}
+
+// Order is the summary of a Seaport order, the offerer gives the offer items in exchange for the consideration
+type Order struct {
+	Offerer       common.Address `json:"offerer"`
+	Offer         []OrderItem    `json:"offer"`
+	Consideration []OrderItem    `json:"consideration"`
+	StartTime     uint64         `json:"startTime"`
+	EndTime       uint64         `json:"endTime"`
+}
+
+// Preview is the human readable summary of a message, typed data or transaction to sign
+type Preview struct {
+	Kind    Kind   `json:"kind"`
+	ChainID uint64 `json:"chainId"`
+	// Method is the called function or the primary type of the typed data
+	Method string `json:"method,omitempty"`
+	// To is the recipient of the transaction
+	To *common.Address `json:"to,omitempty"`
+	// Contract is the called contract or the verifying contract of the typed data
+	Contract *common.Address `json:"contract,omitempty"`
+	// Message is the text of a personal message
+	Message    string                 `json:"message,omitempty"`
+	Value      *hexutil.Big           `json:"value,omitempty"`
+	Allowances []Allowance            `json:"allowances,omitempty"`
+	Order      *Order                 `json:"order,omitempty"`
+	Decoded    *thirdparty.DataParsed `json:"decoded,omitempty"`
+	Risks      []Risk                 `json:"risks"`
+	RiskLevel  RiskLevel              `json:"riskLevel"`
+}
+
+func newPreview(kind Kind, chainID uint64) *Preview {
+	return &Preview{
+		Kind:      kind,
+		ChainID:   chainID,
+		Risks:     make([]Risk, 0),
+		RiskLevel: RiskLevelNone,
+	}
+}
+
+func (p *Preview) addRisk(riskType RiskType, address *common.Address) {
+	level := riskLevels[riskType]
+	p.Risks = append(p.Risks, Risk{
+		Type:    riskType,
+		Level:   level,
+		Address: address,
+	})
+	if level == RiskLevelCritical || p.RiskLevel == RiskLevelNone {
+		p.RiskLevel = level
+	}
+}
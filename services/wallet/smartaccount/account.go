@@ -0,0 +1,68 @@
+package smartaccount
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// EntryPointV06 is the ERC-4337 EntryPoint v0.6 singleton, deployed at the same address on all chains
+	EntryPointV06 = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	// SimpleAccountFactoryV06 deploys the reference SimpleAccount implementation for EntryPoint v0.6
+	SimpleAccountFactoryV06 = common.HexToAddress("0x9406Cc6185a346906296840746125a0E44976454")
+)
+
+const erc4337ABI = `[
+	{"type":"function","name":"getNonce","stateMutability":"view","inputs":[{"name":"sender","type":"address"},{"name":"key","type":"uint192"}],"outputs":[{"name":"nonce","type":"uint256"}]},
+	{"type":"function","name":"getAddress","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"salt","type":"uint256"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"function","name":"createAccount","stateMutability":"nonpayable","inputs":[{"name":"owner","type":"address"},{"name":"salt","type":"uint256"}],"outputs":[{"name":"ret","type":"address"}]},
+	{"type":"function","name":"execute","stateMutability":"nonpayable","inputs":[{"name":"dest","type":"address"},{"name":"value","type":"uint256"},{"name":"func","type":"bytes"}],"outputs":[]}
+]`
+
+// dummySignature passes the signature recovery of the account during gas estimation, the recovered owner is wrong
+// but SimpleAccount returns a validation failure instead of reverting
+var dummySignature = common.FromHex("0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c")
+
+var parsedERC4337ABI abi.ABI
+
+func init() {
+	var err error
+	parsedERC4337ABI, err = abi.JSON(strings.NewReader(erc4337ABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Account is an ERC-4337 smart account, its address is known before the deployment which happens with the first
+// user operation
+type Account struct {
+	Address    common.Address `json:"address"`
+	Owner      common.Address `json:"owner"`
+	Factory    common.Address `json:"factory"`
+	EntryPoint common.Address `json:"entryPoint"`
+	Salt       *big.Int       `json:"salt"`
+	CreatedAt  int64          `json:"createdAt"`
+}
+
+// initCode returns the factory call deploying the account
+func (a *Account) initCode() ([]byte, error) {
+	data, err := parsedERC4337ABI.Pack("createAccount", a.Owner, a.Salt)
+	if err != nil {
+		return nil, err
+	}
+	return append(a.Factory.Bytes(), data...), nil
+}
+
+// PackExecute returns the call data of a user operation making the account call `to`
+func PackExecute(to common.Address, value *big.Int, data []byte) ([]byte, error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+	if data == nil {
+		data = []byte{}
+	}
+	return parsedERC4337ABI.Pack("execute", to, value, data)
+}
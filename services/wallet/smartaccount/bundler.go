@@ -0,0 +1,77 @@
+package smartaccount
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+// UserOperationGas is the result of `eth_estimateUserOperationGas`
+type UserOperationGas struct {
+	PreVerificationGas   *hexutil.Big `json:"preVerificationGas"`
+	VerificationGasLimit *hexutil.Big `json:"verificationGasLimit"`
+	CallGasLimit         *hexutil.Big `json:"callGasLimit"`
+}
+
+// UserOperationReceipt is the result of `eth_getUserOperationReceipt`, only the fields used by the wallet are decoded
+type UserOperationReceipt struct {
+	UserOpHash    common.Hash    `json:"userOpHash"`
+	Sender        common.Address `json:"sender"`
+	Success       bool           `json:"success"`
+	Reason        string         `json:"reason"`
+	ActualGasCost *hexutil.Big   `json:"actualGasCost"`
+	Receipt       struct {
+		TransactionHash common.Hash  `json:"transactionHash"`
+		BlockNumber     *hexutil.Big `json:"blockNumber"`
+	} `json:"receipt"`
+}
+
+// BundlerClient talks to an ERC-4337 bundler JSON-RPC endpoint
+type BundlerClient struct {
+	client *gethrpc.Client
+}
+
+func DialBundler(ctx context.Context, url string) (*BundlerClient, error) {
+	client, err := gethrpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return &BundlerClient{client: client}, nil
+}
+
+func (b *BundlerClient) Close() {
+	b.client.Close()
+}
+
+func (b *BundlerClient) SupportedEntryPoints(ctx context.Context) ([]common.Address, error) {
+	var entryPoints []common.Address
+	err := b.client.CallContext(ctx, &entryPoints, "eth_supportedEntryPoints")
+	return entryPoints, err
+}
+
+func (b *BundlerClient) EstimateUserOperationGas(ctx context.Context, op *wallettypes.UserOperation, entryPoint common.Address) (*UserOperationGas, error) {
+	var gas UserOperationGas
+	err := b.client.CallContext(ctx, &gas, "eth_estimateUserOperationGas", op, entryPoint)
+	if err != nil {
+		return nil, err
+	}
+	return &gas, nil
+}
+
+// SendUserOperation submits the signed operation and returns its hash
+func (b *BundlerClient) SendUserOperation(ctx context.Context, op *wallettypes.UserOperation, entryPoint common.Address) (common.Hash, error) {
+	var hash common.Hash
+	err := b.client.CallContext(ctx, &hash, "eth_sendUserOperation", op, entryPoint)
+	return hash, err
+}
+
+// GetUserOperationReceipt returns nil while the operation is not included in a transaction
+func (b *BundlerClient) GetUserOperationReceipt(ctx context.Context, userOpHash common.Hash) (*UserOperationReceipt, error) {
+	var receipt *UserOperationReceipt
+	err := b.client.CallContext(ctx, &receipt, "eth_getUserOperationReceipt", userOpHash)
+	return receipt, err
+}
@@ -0,0 +1,39 @@
+package smartaccount
+
+import (
+	"database/sql"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Database struct {
+	db *sql.DB
+}
+
+func NewDB(db *sql.DB) *Database {
+	return &Database{db: db}
+}
+
+func (db *Database) SaveAccount(account *Account) error {
+	_, err := db.db.Exec(`INSERT OR REPLACE INTO smart_accounts (address, owner, factory, entry_point, salt, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		account.Address, account.Owner, account.Factory, account.EntryPoint, account.Salt.String(), account.CreatedAt)
+	return err
+}
+
+// GetAccount returns nil if the address is not a known smart account
+func (db *Database) GetAccount(address common.Address) (*Account, error) {
+	account := &Account{Address: address, Salt: new(big.Int)}
+	var salt string
+	err := db.db.QueryRow(`SELECT owner, factory, entry_point, salt, created_at FROM smart_accounts WHERE address = ?`, address).
+		Scan(&account.Owner, &account.Factory, &account.EntryPoint, &salt, &account.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	account.Salt.SetString(salt, 10)
+	return account, nil
+}
@@ -0,0 +1,315 @@
+package smartaccount
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/transactions"
+)
+
+const bundlerRequestTimeout = 20 * time.Second
+
+var (
+	ErrNotASmartAccount = errors.New("address is not a smart account")
+	ErrNoBundler        = errors.New("no bundler configured for the chain")
+	ErrInvalidSignature = errors.New("invalid user operation signature")
+)
+
+// Manager creates the ERC-4337 smart accounts and sends their user operations through the bundler of the chain
+type Manager struct {
+	db             *Database
+	rpcClient      rpc.ClientInterface
+	pendingTracker *transactions.PendingTxTracker
+	bundlerURLs    map[uint64]string
+
+	bundlersMutex sync.Mutex
+	bundlers      map[uint64]*BundlerClient
+}
+
+func NewManager(walletDB *sql.DB, rpcClient rpc.ClientInterface, pendingTracker *transactions.PendingTxTracker, bundlerURLs map[uint64]string) *Manager {
+	return &Manager{
+		db:             NewDB(walletDB),
+		rpcClient:      rpcClient,
+		pendingTracker: pendingTracker,
+		bundlerURLs:    bundlerURLs,
+		bundlers:       make(map[uint64]*BundlerClient),
+	}
+}
+
+func (m *Manager) bundler(ctx context.Context, chainID uint64) (*BundlerClient, error) {
+	m.bundlersMutex.Lock()
+	defer m.bundlersMutex.Unlock()
+
+	if bundler, ok := m.bundlers[chainID]; ok {
+		return bundler, nil
+	}
+
+	url, ok := m.bundlerURLs[chainID]
+	if !ok || url == "" {
+		return nil, ErrNoBundler
+	}
+
+	bundler, err := DialBundler(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	m.bundlers[chainID] = bundler
+	return bundler, nil
+}
+
+// IsAvailable checks if user operations can be sent on the chain
+func (m *Manager) IsAvailable(chainID uint64) bool {
+	return m.bundlerURLs[chainID] != ""
+}
+
+// CreateAccount computes the counterfactual address of the SimpleAccount owned by `owner` and stores it, the account
+// is deployed by its first user operation
+func (m *Manager) CreateAccount(ctx context.Context, chainID uint64, owner common.Address) (*Account, error) {
+	account := &Account{
+		Owner:      owner,
+		Factory:    SimpleAccountFactoryV06,
+		EntryPoint: EntryPointV06,
+		Salt:       new(big.Int),
+		CreatedAt:  time.Now().Unix(),
+	}
+
+	data, err := parsedERC4337ABI.Pack("getAddress", account.Owner, account.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := m.call(ctx, chainID, account.Factory, data)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := parsedERC4337ABI.Unpack("getAddress", out)
+	if err != nil {
+		return nil, err
+	}
+	account.Address = res[0].(common.Address)
+
+	err = m.db.SaveAccount(account)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// GetAccount returns nil if the address is not a smart account
+func (m *Manager) GetAccount(address common.Address) (*Account, error) {
+	return m.db.GetAccount(address)
+}
+
+func (m *Manager) call(ctx context.Context, chainID uint64, to common.Address, data []byte) ([]byte, error) {
+	client, err := m.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+}
+
+// BuildUserOperation returns the user operation making the smart account call `to` and the hash the owner has to sign.
+// The account is deployed by the operation if needed, gas limits are estimated by the bundler.
+func (m *Manager) BuildUserOperation(ctx context.Context, chainID uint64, sender common.Address, to common.Address, value *big.Int,
+	data []byte, maxFeePerGas *big.Int, maxPriorityFeePerGas *big.Int) (*wallettypes.UserOperation, common.Hash, error) {
+	account, err := m.db.GetAccount(sender)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	if account == nil {
+		return nil, common.Hash{}, ErrNotASmartAccount
+	}
+
+	bundler, err := m.bundler(ctx, chainID)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	client, err := m.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	nonceData, err := parsedERC4337ABI.Pack("getNonce", sender, new(big.Int))
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	out, err := client.CallContract(ctx, ethereum.CallMsg{To: &account.EntryPoint, Data: nonceData}, nil)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	res, err := parsedERC4337ABI.Unpack("getNonce", out)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	nonce := res[0].(*big.Int)
+
+	var initCode []byte
+	code, err := client.CodeAt(ctx, sender, nil)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	if len(code) == 0 {
+		initCode, err = account.initCode()
+		if err != nil {
+			return nil, common.Hash{}, err
+		}
+	}
+
+	callData, err := PackExecute(to, value, data)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	if maxFeePerGas == nil {
+		maxFeePerGas, err = client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, common.Hash{}, err
+		}
+	}
+	if maxPriorityFeePerGas == nil {
+		maxPriorityFeePerGas = maxFeePerGas
+	}
+
+	op := &wallettypes.UserOperation{
+		Sender:               sender,
+		Nonce:                (*hexutil.Big)(nonce),
+		InitCode:             initCode,
+		CallData:             callData,
+		CallGasLimit:         (*hexutil.Big)(new(big.Int)),
+		VerificationGasLimit: (*hexutil.Big)(new(big.Int)),
+		PreVerificationGas:   (*hexutil.Big)(new(big.Int)),
+		MaxFeePerGas:         (*hexutil.Big)(maxFeePerGas),
+		MaxPriorityFeePerGas: (*hexutil.Big)(maxPriorityFeePerGas),
+		PaymasterAndData:     []byte{},
+		Signature:            dummySignature,
+	}
+
+	estimateCtx, cancel := context.WithTimeout(ctx, bundlerRequestTimeout)
+	defer cancel()
+	gas, err := bundler.EstimateUserOperationGas(estimateCtx, op, account.EntryPoint)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	op.CallGasLimit = gas.CallGasLimit
+	op.VerificationGasLimit = gas.VerificationGasLimit
+	op.PreVerificationGas = gas.PreVerificationGas
+	op.Signature = []byte{}
+
+	userOpHash, err := op.Hash(account.EntryPoint, chainID)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	// SimpleAccount checks an `eth_sign` signature of the user operation hash
+	return op, common.BytesToHash(accounts.TextHash(userOpHash.Bytes())), nil
+}
+
+// SendUserOperation adds the owner's signature to the operation, submits it to the bundler and tracks it until it's
+// included in a transaction. The user operation hash is returned.
+func (m *Manager) SendUserOperation(ctx context.Context, chainID uint64, op *wallettypes.UserOperation, signature []byte,
+	to common.Address, value *big.Int, symbol string, multiTransactionID walletCommon.MultiTransactionIDType) (common.Hash, error) {
+	if len(signature) != 65 {
+		return common.Hash{}, ErrInvalidSignature
+	}
+
+	account, err := m.db.GetAccount(op.Sender)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if account == nil {
+		return common.Hash{}, ErrNotASmartAccount
+	}
+
+	bundler, err := m.bundler(ctx, chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	sig := make([]byte, len(signature))
+	copy(sig, signature)
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	op.Signature = sig
+
+	sendCtx, cancel := context.WithTimeout(ctx, bundlerRequestTimeout)
+	defer cancel()
+	userOpHash, err := bundler.SendUserOperation(sendCtx, op, account.EntryPoint)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if m.pendingTracker != nil {
+		if value == nil {
+			value = new(big.Int)
+		}
+		autoDelete := transactions.AutoDelete
+		err = m.pendingTracker.StoreAndTrackPendingTx(&transactions.PendingTransaction{
+			Hash:               userOpHash,
+			Timestamp:          uint64(time.Now().Unix()),
+			Value:              bigint.BigInt{Int: value},
+			From:               op.Sender,
+			To:                 to,
+			Data:               string(op.CallData),
+			Symbol:             symbol,
+			Type:               transactions.UserOperation,
+			ChainID:            walletCommon.ChainID(chainID),
+			MultiTransactionID: multiTransactionID,
+			Nonce:              op.Nonce.ToInt().Uint64(),
+			AutoDelete:         &autoDelete,
+		})
+		if err != nil {
+			return userOpHash, err
+		}
+	}
+
+	return userOpHash, nil
+}
+
+// FetchUserOperationStatus implements transactions.UserOperationStatusFetcher
+func (m *Manager) FetchUserOperationStatus(ctx context.Context, chainID uint64, userOpHash common.Hash) (transactions.TxStatus, error) {
+	bundler, err := m.bundler(ctx, chainID)
+	if err != nil {
+		return transactions.Pending, err
+	}
+
+	receiptCtx, cancel := context.WithTimeout(ctx, bundlerRequestTimeout)
+	defer cancel()
+	receipt, err := bundler.GetUserOperationReceipt(receiptCtx, userOpHash)
+	if err != nil {
+		return transactions.Pending, err
+	}
+
+	if receipt == nil || receipt.Receipt.BlockNumber == nil {
+		return transactions.Pending, nil
+	}
+	if receipt.Success {
+		return transactions.Success, nil
+	}
+	return transactions.Failed, nil
+}
+
+func (m *Manager) Stop() {
+	m.bundlersMutex.Lock()
+	defer m.bundlersMutex.Unlock()
+
+	for chainID, bundler := range m.bundlers {
+		bundler.Close()
+		delete(m.bundlers, chainID)
+	}
+}
@@ -0,0 +1,196 @@
+package smartaccount
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	mock_rpcclient "github.com/status-im/status-go/rpc/mock/client"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/transactions"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+const testChainID = uint64(10)
+
+// testBundler serves the `eth_` bundler methods used by the manager
+type testBundler struct {
+	mu       sync.Mutex
+	sent     []wallettypes.UserOperation
+	included map[common.Hash]bool
+}
+
+func (b *testBundler) EstimateUserOperationGas(op wallettypes.UserOperation, entryPoint common.Address) (*UserOperationGas, error) {
+	return &UserOperationGas{
+		PreVerificationGas:   (*hexutil.Big)(big.NewInt(50000)),
+		VerificationGasLimit: (*hexutil.Big)(big.NewInt(400000)),
+		CallGasLimit:         (*hexutil.Big)(big.NewInt(35000)),
+	}, nil
+}
+
+func (b *testBundler) SendUserOperation(op wallettypes.UserOperation, entryPoint common.Address) (common.Hash, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent = append(b.sent, op)
+	return op.Hash(entryPoint, testChainID)
+}
+
+func (b *testBundler) GetUserOperationReceipt(userOpHash common.Hash) (*UserOperationReceipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	success, ok := b.included[userOpHash]
+	if !ok {
+		return nil, nil
+	}
+	receipt := &UserOperationReceipt{UserOpHash: userOpHash, Success: success}
+	receipt.Receipt.BlockNumber = (*hexutil.Big)(big.NewInt(100))
+	return receipt, nil
+}
+
+func setupTestManager(t *testing.T) (*Manager, *testBundler, *mock_client.MockClientInterface) {
+	walletDB, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, walletDB.Close()) })
+
+	bundler := &testBundler{included: make(map[common.Hash]bool)}
+	server := gethrpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", bundler))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	ctrl := gomock.NewController(t)
+	rpcClient := mock_rpcclient.NewMockClientInterface(ctrl)
+	chainClient := mock_client.NewMockClientInterface(ctrl)
+	rpcClient.EXPECT().EthClient(testChainID).Return(chainClient, nil).AnyTimes()
+
+	manager := NewManager(walletDB, rpcClient, nil, map[uint64]string{testChainID: httpServer.URL})
+	t.Cleanup(manager.Stop)
+
+	return manager, bundler, chainClient
+}
+
+func TestCreateAndSendUserOperation(t *testing.T) {
+	manager, bundler, chainClient := setupTestManager(t)
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(ownerKey.PublicKey)
+	smartAccountAddress := common.HexToAddress("0x5a")
+
+	chainClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Nil()).DoAndReturn(
+		func(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+			method, err := parsedERC4337ABI.MethodById(msg.Data[:4])
+			require.NoError(t, err)
+			switch method.Name {
+			case "getAddress":
+				require.Equal(t, SimpleAccountFactoryV06, *msg.To)
+				return method.Outputs.Pack(smartAccountAddress)
+			case "getNonce":
+				require.Equal(t, EntryPointV06, *msg.To)
+				return method.Outputs.Pack(big.NewInt(3))
+			}
+			t.Fatalf("unexpected call to %s", method.Name)
+			return nil, nil
+		}).AnyTimes()
+	// the account is not deployed yet
+	chainClient.EXPECT().CodeAt(gomock.Any(), smartAccountAddress, gomock.Nil()).Return(nil, nil).AnyTimes()
+
+	account, err := manager.CreateAccount(context.Background(), testChainID, owner)
+	require.NoError(t, err)
+	require.Equal(t, smartAccountAddress, account.Address)
+
+	stored, err := manager.GetAccount(smartAccountAddress)
+	require.NoError(t, err)
+	require.Equal(t, owner, stored.Owner)
+	require.Equal(t, EntryPointV06, stored.EntryPoint)
+	require.Equal(t, 0, stored.Salt.Sign())
+
+	recipient := common.HexToAddress("0xbeef")
+	op, hashToSign, err := manager.BuildUserOperation(context.Background(), testChainID, smartAccountAddress, recipient,
+		big.NewInt(1000), nil, big.NewInt(2000000000), big.NewInt(1000000))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), op.Nonce.ToInt().Int64())
+	require.Equal(t, SimpleAccountFactoryV06.Bytes(), []byte(op.InitCode[:common.AddressLength]))
+	require.Equal(t, int64(35000), op.CallGasLimit.ToInt().Int64())
+	require.Empty(t, op.Signature)
+
+	expectedCallData, err := PackExecute(recipient, big.NewInt(1000), nil)
+	require.NoError(t, err)
+	require.Equal(t, expectedCallData, []byte(op.CallData))
+
+	userOpHash, err := op.Hash(EntryPointV06, testChainID)
+	require.NoError(t, err)
+	require.Equal(t, common.BytesToHash(accounts.TextHash(userOpHash.Bytes())), hashToSign)
+
+	signature, err := crypto.Sign(hashToSign.Bytes(), ownerKey)
+	require.NoError(t, err)
+
+	sentHash, err := manager.SendUserOperation(context.Background(), testChainID, op, signature, recipient, big.NewInt(1000), "ETH", 0)
+	require.NoError(t, err)
+	require.Equal(t, userOpHash, sentHash)
+
+	require.Len(t, bundler.sent, 1)
+	sentSignature := bundler.sent[0].Signature
+	require.Equal(t, signature[64]+27, sentSignature[64])
+
+	// the signature is the one SimpleAccount recovers the owner from
+	recoverable := make([]byte, 65)
+	copy(recoverable, sentSignature)
+	recoverable[64] -= 27
+	pubKey, err := crypto.SigToPub(accounts.TextHash(userOpHash.Bytes()), recoverable)
+	require.NoError(t, err)
+	require.Equal(t, owner, crypto.PubkeyToAddress(*pubKey))
+
+	status, err := manager.FetchUserOperationStatus(context.Background(), testChainID, sentHash)
+	require.NoError(t, err)
+	require.Equal(t, transactions.Pending, status)
+
+	bundler.mu.Lock()
+	bundler.included[sentHash] = true
+	bundler.mu.Unlock()
+
+	status, err = manager.FetchUserOperationStatus(context.Background(), testChainID, sentHash)
+	require.NoError(t, err)
+	require.Equal(t, transactions.Success, status)
+}
+
+func TestBuildUserOperationErrors(t *testing.T) {
+	manager, _, _ := setupTestManager(t)
+
+	_, _, err := manager.BuildUserOperation(context.Background(), testChainID, common.HexToAddress("0x1"), common.Address{}, nil, nil, nil, nil)
+	require.ErrorIs(t, err, ErrNotASmartAccount)
+
+	account := &Account{
+		Address:    common.HexToAddress("0x5a"),
+		Owner:      common.HexToAddress("0x1"),
+		Factory:    SimpleAccountFactoryV06,
+		EntryPoint: EntryPointV06,
+		Salt:       big.NewInt(0),
+	}
+	require.NoError(t, manager.db.SaveAccount(account))
+
+	_, _, err = manager.BuildUserOperation(context.Background(), 1, account.Address, common.Address{}, nil, nil, nil, nil)
+	require.ErrorIs(t, err, ErrNoBundler)
+	require.False(t, manager.IsAvailable(1))
+	require.True(t, manager.IsAvailable(testChainID))
+
+	_, err = manager.SendUserOperation(context.Background(), testChainID, &wallettypes.UserOperation{Sender: account.Address}, []byte{0x1}, common.Address{}, nil, "ETH", 0)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
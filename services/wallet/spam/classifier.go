@@ -0,0 +1,104 @@
+package spam
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// spamScoreThreshold is the score from which a token is considered spam, a single strong signal (lookalike symbol,
+// provider flag) is enough while the weak ones have to be combined
+const spamScoreThreshold = 3
+
+var reasonWeights = map[Reason]int{
+	ReasonNotInTokenList:     1,
+	ReasonNoPrice:            1,
+	ReasonZeroValueTransfers: 2,
+	ReasonLookalikeSymbol:    3,
+	ReasonProviderFlag:       3,
+}
+
+// Signals is what is known about a token when classifying it
+type Signals struct {
+	InTokenList  bool
+	ProviderFlag bool
+	// HasPrice is nil when the price could not be fetched
+	HasPrice           *bool
+	ZeroValueTransfers int
+	LookalikeSymbol    bool
+}
+
+// classify returns the score and the reasons of the signals, tokens part of a token list are never spam, the lists
+// are curated
+func classify(signals Signals) (int, []Reason) {
+	reasons := make([]Reason, 0)
+	if signals.InTokenList {
+		return 0, reasons
+	}
+
+	reasons = append(reasons, ReasonNotInTokenList)
+	if signals.ProviderFlag {
+		reasons = append(reasons, ReasonProviderFlag)
+	}
+	if signals.HasPrice != nil && !*signals.HasPrice {
+		reasons = append(reasons, ReasonNoPrice)
+	}
+	if signals.ZeroValueTransfers > 0 {
+		reasons = append(reasons, ReasonZeroValueTransfers)
+	}
+	if signals.LookalikeSymbol {
+		reasons = append(reasons, ReasonLookalikeSymbol)
+	}
+
+	score := 0
+	for _, reason := range reasons {
+		score += reasonWeights[reason]
+	}
+	return score, reasons
+}
+
+// confusables maps the characters commonly used to imitate latin letters and digits
+var confusables = map[rune]rune{
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p', 'с': 'c', 'т': 't', 'у': 'y',
+	'х': 'x', 'ѕ': 's', 'і': 'i', 'ј': 'j', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'μ': 'u', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't',
+	'υ': 'u', 'χ': 'x', 'ζ': 'z',
+	'0': 'o', '1': 'l', '$': 's',
+}
+
+// skeleton returns the form of the symbol used to detect lookalikes: compatibility characters (full width, ligatures)
+// decomposed, accents, invisible characters and punctuation removed, homoglyphs replaced by the latin letter
+func skeleton(symbol string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(strings.ToLower(symbol)) {
+		if replacement, ok := confusables[r]; ok {
+			r = replacement
+		}
+		if r == 'i' {
+			// "l" and "I" are the same in most fonts
+			r = 'l'
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// advertisingMarkers are found in the symbols of tokens airdropped to lure the users to phishing sites
+var advertisingMarkers = []string{"http", "www.", ".com", ".io", ".net", ".org", ".xyz", "claim", "visit", "reward"}
+
+// isLookalikeSymbol returns true when the symbol of a token not part of any token list looks like the symbol of a
+// listed token, `listedSkeletons` are the skeletons of the listed symbols
+func isLookalikeSymbol(symbol string, listedSkeletons map[string]bool) bool {
+	lowerSymbol := strings.ToLower(symbol)
+	for _, marker := range advertisingMarkers {
+		if strings.Contains(lowerSymbol, marker) {
+			return true
+		}
+	}
+
+	symbolSkeleton := skeleton(symbol)
+	return symbolSkeleton != "" && listedSkeletons[symbolSkeleton]
+}
@@ -0,0 +1,58 @@
+package spam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	noPrice := false
+
+	score, reasons := classify(Signals{InTokenList: true, ProviderFlag: true, HasPrice: &noPrice, ZeroValueTransfers: 3})
+	require.Equal(t, 0, score)
+	require.Empty(t, reasons)
+
+	// a new token without a price yet is not enough
+	score, reasons = classify(Signals{HasPrice: &noPrice})
+	require.Less(t, score, spamScoreThreshold)
+	require.Equal(t, []Reason{ReasonNotInTokenList, ReasonNoPrice}, reasons)
+
+	score, reasons = classify(Signals{HasPrice: &noPrice, ZeroValueTransfers: 1})
+	require.GreaterOrEqual(t, score, spamScoreThreshold)
+	require.Equal(t, []Reason{ReasonNotInTokenList, ReasonNoPrice, ReasonZeroValueTransfers}, reasons)
+
+	score, reasons = classify(Signals{LookalikeSymbol: true})
+	require.GreaterOrEqual(t, score, spamScoreThreshold)
+	require.Equal(t, []Reason{ReasonNotInTokenList, ReasonLookalikeSymbol}, reasons)
+
+	score, reasons = classify(Signals{ProviderFlag: true})
+	require.GreaterOrEqual(t, score, spamScoreThreshold)
+	require.Equal(t, []Reason{ReasonNotInTokenList, ReasonProviderFlag}, reasons)
+}
+
+func TestIsLookalikeSymbol(t *testing.T) {
+	listedSkeletons := map[string]bool{
+		skeleton("USDC"):  true,
+		skeleton("USDT"):  true,
+		skeleton("1INCH"): true,
+	}
+
+	for _, symbol := range []string{
+		"USDC",
+		"usdc",
+		"USD\u0421",                // cyrillic C
+		"\uff35\uff33\uff24\uff34", // full width
+		"U.S.D.T",
+		"USD\u200bC", // zero width space
+		"lINCH",
+		"Visit claim-usdt.com",
+		"WWW.FREE-DROP.XYZ",
+	} {
+		require.True(t, isLookalikeSymbol(symbol, listedSkeletons), symbol)
+	}
+
+	for _, symbol := range []string{"USDX", "DAI", "PEPE", ""} {
+		require.False(t, isLookalikeSymbol(symbol, listedSkeletons), symbol)
+	}
+}
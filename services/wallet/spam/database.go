@@ -0,0 +1,237 @@
+package spam
+
+import (
+	"database/sql"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
+)
+
+// zeroAmountPadded128Hex is the amount of zero value transfers in the transfers table
+const zeroAmountPadded128Hex = "00000000000000000000000000000000"
+
+type Database struct {
+	db *sql.DB
+}
+
+func NewDB(db *sql.DB) *Database {
+	return &Database{db: db}
+}
+
+func (db *Database) SaveVerdict(verdict *Verdict) error {
+	reasons := make([]string, 0, len(verdict.Reasons))
+	for _, reason := range verdict.Reasons {
+		reasons = append(reasons, string(reason))
+	}
+
+	_, err := db.db.Exec(`INSERT OR REPLACE INTO token_spam_verdicts (chain_id, address, is_spam, score, reasons, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`, verdict.ChainID, verdict.Address, verdict.IsSpam, verdict.Score,
+		strings.Join(reasons, ","), verdict.UpdatedAt)
+	return err
+}
+
+// GetVerdicts returns the verdicts of the tokens classified on the chains with the user overrides applied, the
+// tokens only overridden by the user are included
+func (db *Database) GetVerdicts(chainIDs []uint64) ([]*Verdict, error) {
+	verdicts := make([]*Verdict, 0)
+	for _, chainID := range chainIDs {
+		rows, err := db.db.Query(`SELECT v.address, v.is_spam, v.score, v.reasons, v.updated_at, o.is_spam
+			FROM token_spam_verdicts v
+			LEFT JOIN token_spam_overrides o ON v.chain_id = o.chain_id AND v.address = o.address
+			WHERE v.chain_id = ?
+			UNION ALL
+			SELECT o.address, o.is_spam, 0, '', 0, o.is_spam
+			FROM token_spam_overrides o
+			WHERE o.chain_id = ? AND NOT EXISTS (
+				SELECT 1 FROM token_spam_verdicts v WHERE v.chain_id = o.chain_id AND v.address = o.address
+			)`, chainID, chainID)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			verdict := &Verdict{ChainID: chainID, Reasons: make([]Reason, 0)}
+			var reasons string
+			var override sql.NullBool
+			err = rows.Scan(&verdict.Address, &verdict.IsSpam, &verdict.Score, &reasons, &verdict.UpdatedAt, &override)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+
+			if reasons != "" {
+				for _, reason := range strings.Split(reasons, ",") {
+					verdict.Reasons = append(verdict.Reasons, Reason(reason))
+				}
+			}
+			if override.Valid {
+				verdict.IsSpam = override.Bool
+				verdict.Overridden = true
+			}
+			verdicts = append(verdicts, verdict)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return verdicts, nil
+}
+
+// GetVerdictUpdateTimestamps returns when the tokens of the chain were last classified
+func (db *Database) GetVerdictUpdateTimestamps(chainID uint64) (map[common.Address]int64, error) {
+	rows, err := db.db.Query(`SELECT address, updated_at FROM token_spam_verdicts WHERE chain_id = ?`, chainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	timestamps := make(map[common.Address]int64)
+	for rows.Next() {
+		var address common.Address
+		var updatedAt int64
+		if err = rows.Scan(&address, &updatedAt); err != nil {
+			return nil, err
+		}
+		timestamps[address] = updatedAt
+	}
+	return timestamps, rows.Err()
+}
+
+// GetSpamTokens returns the tokens and collections considered spam, see the spam_tokens view
+func (db *Database) GetSpamTokens() ([]thirdparty.ContractID, error) {
+	rows, err := db.db.Query(`SELECT chain_id, address FROM spam_tokens`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]thirdparty.ContractID, 0)
+	for rows.Next() {
+		var id thirdparty.ContractID
+		if err = rows.Scan(&id.ChainID, &id.Address); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// IsProviderFlagged returns true when a collectibles provider flagged the contract as spam
+func (db *Database) IsProviderFlagged(chainID uint64, address common.Address) (bool, error) {
+	var flagged bool
+	err := db.db.QueryRow(`SELECT is_spam FROM collection_data_cache WHERE chain_id = ? AND contract_address = ?`,
+		chainID, address).Scan(&flagged)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return flagged, err
+}
+
+// CountZeroValueTransfers returns the number of zero value transfers of the token received or sent by the accounts
+func (db *Database) CountZeroValueTransfers(chainID uint64, address common.Address) (int, error) {
+	var count int
+	err := db.db.QueryRow(`SELECT COUNT(*) FROM transfers WHERE network_id = ? AND token_address = ? AND amount_padded128hex = ?`,
+		chainID, address, zeroAmountPadded128Hex).Scan(&count)
+	return count, err
+}
+
+// GetTransferredTokens returns the ERC20 tokens transferred from or to the accounts on the chains, the symbol is set
+// for the tokens known by the token manager
+func (db *Database) GetTransferredTokens(chainIDs []uint64) ([]*tokenTypes.Token, error) {
+	query, args, err := sq.Select("DISTINCT t.network_id", "t.token_address", "COALESCE(tk.symbol, '')").
+		From("transfers t").
+		LeftJoin("tokens tk ON tk.network_id = t.network_id AND tk.address = t.token_address").
+		Where(sq.Eq{"t.type": walletCommon.Erc20Transfer, "t.network_id": chainIDs}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := make([]*tokenTypes.Token, 0)
+	for rows.Next() {
+		token := &tokenTypes.Token{}
+		if err = rows.Scan(&token.ChainID, &token.Address, &token.Symbol); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// UpdateOverrides replaces the overrides set by the user, clock is the clock of the change used to sync them
+func (db *Database) UpdateOverrides(overrides []Override, clock uint64) (err error) {
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`DELETE FROM token_spam_overrides`)
+	if err != nil {
+		return err
+	}
+
+	insert, err := tx.Prepare(`INSERT OR REPLACE INTO token_spam_overrides (chain_id, address, is_spam) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for _, override := range overrides {
+		_, err = insert.Exec(override.ChainID, override.Address, override.IsSpam)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO token_spam_overrides_clock (synthetic_id, clock) VALUES ('id', ?)`, clock)
+	return err
+}
+
+func (db *Database) GetOverrides() ([]Override, error) {
+	rows, err := db.db.Query(`SELECT chain_id, address, is_spam FROM token_spam_overrides ORDER BY chain_id, address`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := make([]Override, 0)
+	for rows.Next() {
+		var override Override
+		if err = rows.Scan(&override.ChainID, &override.Address, &override.IsSpam); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, rows.Err()
+}
+
+// GetClockOfLastOverridesChange returns the clock of the last change of the overrides, 0 if never changed
+func (db *Database) GetClockOfLastOverridesChange() (uint64, error) {
+	var clock uint64
+	err := db.db.QueryRow(`SELECT clock FROM token_spam_overrides_clock WHERE synthetic_id = 'id'`).Scan(&clock)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return clock, err
+}
@@ -0,0 +1,307 @@
+package spam
+
+import (
+	"database/sql"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/logutils"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	// verdictMaxAge is the age from which a verdict is classified again, prices and transfers change over time
+	verdictMaxAge = 24 * time.Hour
+	// priceMaxAgeInSeconds is the max age of the cached prices used for the classification
+	priceMaxAgeInSeconds = 3600
+	priceCurrency        = "usd"
+
+	// EventSpamVerdictsUpdated is sent when tokens marked by MarkSpam were classified in the background, the balances
+	// are marked with the new verdicts by the next call
+	EventSpamVerdictsUpdated walletevent.EventType = "wallet-spam-verdicts-updated"
+)
+
+type TokenProvider interface {
+	GetTokensByChainIDs(chainIDs []uint64) ([]*tokenTypes.Token, error)
+}
+
+type PriceProvider interface {
+	GetOrFetchPricesByContract(contracts []thirdparty.ContractID, currencies []string, maxAgeInSeconds int64) (market.DataPerContractAndCurrency, error)
+}
+
+// Manager classifies the tokens held by the accounts as spam or not. The verdicts are stored, the overrides set by
+// the user win over them.
+type Manager struct {
+	db            *Database
+	tokenProvider TokenProvider
+	priceProvider PriceProvider
+	feed          *event.Feed
+
+	classifyingMutex sync.Mutex
+	classifying      bool
+}
+
+func NewManager(walletDB *sql.DB, tokenProvider TokenProvider, priceProvider PriceProvider, feed *event.Feed) *Manager {
+	return &Manager{
+		db:            NewDB(walletDB),
+		tokenProvider: tokenProvider,
+		priceProvider: priceProvider,
+		feed:          feed,
+	}
+}
+
+// GetVerdicts returns the verdicts of the tokens classified on the chains with the user overrides applied
+func (m *Manager) GetVerdicts(chainIDs []uint64) ([]*Verdict, error) {
+	return m.db.GetVerdicts(chainIDs)
+}
+
+// GetSpamTokens returns the tokens and collections considered spam
+func (m *Manager) GetSpamTokens() ([]thirdparty.ContractID, error) {
+	return m.db.GetSpamTokens()
+}
+
+// ClassifyTokens classifies the tokens never classified or classified more than a day ago
+func (m *Manager) ClassifyTokens(tokens []*tokenTypes.Token) error {
+	toClassify, err := m.tokensToClassify(tokens)
+	if err != nil || len(toClassify) == 0 {
+		return err
+	}
+
+	chainIDs := make([]uint64, 0)
+	for _, token := range toClassify {
+		if !slices.Contains(chainIDs, token.ChainID) {
+			chainIDs = append(chainIDs, token.ChainID)
+		}
+	}
+	listedTokens, err := m.tokenProvider.GetTokensByChainIDs(chainIDs)
+	if err != nil {
+		return err
+	}
+
+	listed := make(map[string]bool, len(listedTokens))
+	listedSkeletons := make(map[string]bool, len(listedTokens))
+	for _, token := range listedTokens {
+		// community tokens can be minted by anyone, they are not curated
+		if token.CommunityData != nil {
+			continue
+		}
+		listed[tokenKey(token)] = true
+		listedSkeletons[skeleton(token.Symbol)] = true
+	}
+
+	hasPrice := m.fetchHasPrice(toClassify, listed)
+
+	now := time.Now().Unix()
+	for _, token := range toClassify {
+		inTokenList := listed[tokenKey(token)]
+		signals := Signals{
+			InTokenList:     inTokenList,
+			LookalikeSymbol: !inTokenList && isLookalikeSymbol(token.Symbol, listedSkeletons),
+		}
+		if price, ok := hasPrice[tokenKey(token)]; ok {
+			signals.HasPrice = &price
+		}
+
+		signals.ProviderFlag, err = m.db.IsProviderFlagged(token.ChainID, token.Address)
+		if err != nil {
+			return err
+		}
+		signals.ZeroValueTransfers, err = m.db.CountZeroValueTransfers(token.ChainID, token.Address)
+		if err != nil {
+			return err
+		}
+
+		score, reasons := classify(signals)
+		err = m.db.SaveVerdict(&Verdict{
+			ChainID:   token.ChainID,
+			Address:   token.Address,
+			IsSpam:    score >= spamScoreThreshold,
+			Score:     score,
+			Reasons:   reasons,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClassifyTransferredTokens classifies the ERC20 tokens transferred from or to the accounts, the airdropped tokens
+// show up in the activity
+func (m *Manager) ClassifyTransferredTokens(chainIDs []uint64) error {
+	tokens, err := m.db.GetTransferredTokens(chainIDs)
+	if err != nil {
+		return err
+	}
+	return m.ClassifyTokens(tokens)
+}
+
+func (m *Manager) tokensToClassify(tokens []*tokenTypes.Token) ([]*tokenTypes.Token, error) {
+	updateTimestamps := make(map[uint64]map[common.Address]int64)
+	outdated := time.Now().Add(-verdictMaxAge).Unix()
+
+	toClassify := make([]*tokenTypes.Token, 0)
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		// native tokens are never spam
+		key := tokenKey(token)
+		if token.Address == (common.Address{}) || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if _, ok := updateTimestamps[token.ChainID]; !ok {
+			timestamps, err := m.db.GetVerdictUpdateTimestamps(token.ChainID)
+			if err != nil {
+				return nil, err
+			}
+			updateTimestamps[token.ChainID] = timestamps
+		}
+		if updateTimestamps[token.ChainID][token.Address] < outdated {
+			toClassify = append(toClassify, token)
+		}
+	}
+	return toClassify, nil
+}
+
+// fetchHasPrice returns whether the tokens not part of a token list have a price, by contract since spam tokens reuse
+// the symbols of the priced ones. The tokens are missing when the prices could not be fetched.
+func (m *Manager) fetchHasPrice(tokens []*tokenTypes.Token, listed map[string]bool) map[string]bool {
+	contracts := make([]thirdparty.ContractID, 0)
+	for _, token := range tokens {
+		if !listed[tokenKey(token)] {
+			contracts = append(contracts, thirdparty.ContractID{ChainID: walletCommon.ChainID(token.ChainID), Address: token.Address})
+		}
+	}
+
+	hasPrice := make(map[string]bool)
+	if len(contracts) == 0 || m.priceProvider == nil {
+		return hasPrice
+	}
+
+	prices, err := m.priceProvider.GetOrFetchPricesByContract(contracts, []string{priceCurrency}, priceMaxAgeInSeconds)
+	if err != nil {
+		logutils.ZapLogger().Warn("cannot fetch prices for the spam classification", zap.Error(err))
+		return hasPrice
+	}
+	for _, contract := range contracts {
+		// the contracts unknown to the price providers are left out
+		hasPrice[contract.HashKey()] = prices[contract][priceCurrency].Price > 0
+	}
+	return hasPrice
+}
+
+// classifyTokensAsync classifies the tokens in the background, the tokens are skipped if a classification is running
+// already, they are classified again with the next call
+func (m *Manager) classifyTokensAsync(tokens []*tokenTypes.Token) {
+	m.classifyingMutex.Lock()
+	defer m.classifyingMutex.Unlock()
+	if m.classifying {
+		return
+	}
+	m.classifying = true
+
+	go func() {
+		defer gocommon.LogOnPanic()
+		defer func() {
+			m.classifyingMutex.Lock()
+			m.classifying = false
+			m.classifyingMutex.Unlock()
+		}()
+
+		if err := m.ClassifyTokens(tokens); err != nil {
+			logutils.ZapLogger().Error("failed to classify tokens", zap.Error(err))
+			return
+		}
+		if m.feed != nil {
+			m.feed.Send(walletevent.Event{Type: EventSpamVerdictsUpdated})
+		}
+	}()
+}
+
+// MarkSpam sets `IsSpam` of the tokens considered spam on all the chains by the stored verdicts. The tokens without
+// an up to date verdict are classified in the background, EventSpamVerdictsUpdated is sent once done.
+func (m *Manager) MarkSpam(balances map[common.Address][]tokenTypes.StorageToken) error {
+	tokens := make([]*tokenTypes.Token, 0)
+	for _, storageTokens := range balances {
+		for _, storageToken := range storageTokens {
+			for chainID, balance := range storageToken.BalancesPerChain {
+				tokens = append(tokens, &tokenTypes.Token{
+					ChainID: chainID,
+					Address: balance.Address,
+					Symbol:  storageToken.Symbol,
+				})
+			}
+		}
+	}
+
+	toClassify, err := m.tokensToClassify(tokens)
+	if err != nil {
+		return err
+	}
+	if len(toClassify) > 0 {
+		m.classifyTokensAsync(toClassify)
+	}
+
+	spamTokens, err := m.spamTokensSet()
+	if err != nil {
+		return err
+	}
+
+	for _, storageTokens := range balances {
+		for i := range storageTokens {
+			storageTokens[i].IsSpam = len(storageTokens[i].BalancesPerChain) > 0
+			for chainID, balance := range storageTokens[i].BalancesPerChain {
+				id := thirdparty.ContractID{ChainID: walletCommon.ChainID(chainID), Address: balance.Address}
+				if !spamTokens[id.HashKey()] {
+					storageTokens[i].IsSpam = false
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FilterSpam returns the balances without the tokens marked as spam by MarkSpam
+func FilterSpam(balances map[common.Address][]tokenTypes.StorageToken) map[common.Address][]tokenTypes.StorageToken {
+	result := make(map[common.Address][]tokenTypes.StorageToken, len(balances))
+	for address, storageTokens := range balances {
+		result[address] = make([]tokenTypes.StorageToken, 0, len(storageTokens))
+		for _, storageToken := range storageTokens {
+			if !storageToken.IsSpam {
+				result[address] = append(result[address], storageToken)
+			}
+		}
+	}
+	return result
+}
+
+func (m *Manager) spamTokensSet() (map[string]bool, error) {
+	ids, err := m.db.GetSpamTokens()
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id.HashKey()] = true
+	}
+	return set, nil
+}
+
+func tokenKey(token *tokenTypes.Token) string {
+	id := thirdparty.ContractID{ChainID: walletCommon.ChainID(token.ChainID), Address: token.Address}
+	return id.HashKey()
+}
@@ -0,0 +1,207 @@
+package spam
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/status-im/status-go/services/wallet/community"
+	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+const testChainID = uint64(1)
+
+var (
+	account        = common.Address{0x01}
+	usdc           = common.Address{0x10}
+	fakeUsdc       = common.Address{0x11}
+	airdrop        = common.Address{0x12}
+	newToken       = common.Address{0x13}
+	communityToken = common.Address{0x14}
+	collection     = common.Address{0x20}
+)
+
+type testTokenProvider struct {
+	tokens []*tokenTypes.Token
+}
+
+func (p *testTokenProvider) GetTokensByChainIDs(chainIDs []uint64) ([]*tokenTypes.Token, error) {
+	return p.tokens, nil
+}
+
+type testPriceProvider struct {
+	prices map[common.Address]float64
+}
+
+func (p *testPriceProvider) GetOrFetchPricesByContract(contracts []thirdparty.ContractID, currencies []string, maxAgeInSeconds int64) (market.DataPerContractAndCurrency, error) {
+	res := make(market.DataPerContractAndCurrency)
+	for _, contract := range contracts {
+		if price, ok := p.prices[contract.Address]; ok {
+			res[contract] = map[string]market.DataPoint{priceCurrency: {Price: price}}
+		}
+	}
+	return res, nil
+}
+
+func setupTestManager(t *testing.T) (*Manager, *sql.DB) {
+	walletDB, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, walletDB.Close()) })
+
+	tokenProvider := &testTokenProvider{tokens: []*tokenTypes.Token{
+		{ChainID: testChainID, Address: usdc, Symbol: "USDC"},
+		{ChainID: testChainID, Address: communityToken, Symbol: "COM", CommunityData: &community.Data{ID: "0x02"}},
+	}}
+	// the price is known for the listed USDC only, not for the tokens reusing its symbol
+	priceProvider := &testPriceProvider{prices: map[common.Address]float64{usdc: 1, newToken: 0.5}}
+
+	return NewManager(walletDB, tokenProvider, priceProvider, nil), walletDB
+}
+
+func insertZeroValueTransfer(t *testing.T, db *sql.DB, token common.Address, hash common.Hash) {
+	_, err := db.Exec(`INSERT OR IGNORE INTO blocks (network_id, address, blk_number, blk_hash) VALUES (?, ?, 1, ?)`,
+		testChainID, account, common.Hash{})
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO transfers (network_id, hash, address, blk_hash, type, blk_number, timestamp, token_address, amount_padded128hex)
+		VALUES (?, ?, ?, ?, 'erc20', 1, 1, ?, ?)`, testChainID, hash, account, common.Hash{}, token, zeroAmountPadded128Hex)
+	require.NoError(t, err)
+}
+
+func TestClassifyTokens(t *testing.T) {
+	manager, db := setupTestManager(t)
+
+	insertZeroValueTransfer(t, db, airdrop, common.Hash{0x01})
+	insertZeroValueTransfer(t, db, usdc, common.Hash{0x02})
+
+	_, err := db.Exec(`INSERT INTO collection_data_cache (chain_id, contract_address, provider, name, slug, image_url, is_spam)
+		VALUES (?, ?, 'alchemy', 'Free mint', '', '', TRUE)`, testChainID, collection)
+	require.NoError(t, err)
+
+	err = manager.ClassifyTokens([]*tokenTypes.Token{
+		{ChainID: testChainID, Address: usdc, Symbol: "USDC"},
+		{ChainID: testChainID, Address: fakeUsdc, Symbol: "USDC"},
+		{ChainID: testChainID, Address: airdrop, Symbol: "AIR"},
+		{ChainID: testChainID, Address: newToken, Symbol: "NEW"},
+		{ChainID: testChainID, Address: communityToken, Symbol: "COM"},
+		{ChainID: testChainID, Address: common.Address{}, Symbol: "ETH"},
+	})
+	require.NoError(t, err)
+
+	verdicts, err := manager.GetVerdicts([]uint64{testChainID})
+	require.NoError(t, err)
+	require.Len(t, verdicts, 5)
+
+	byAddress := make(map[common.Address]*Verdict)
+	for _, verdict := range verdicts {
+		byAddress[verdict.Address] = verdict
+	}
+	require.False(t, byAddress[usdc].IsSpam)
+	require.Empty(t, byAddress[usdc].Reasons)
+	require.True(t, byAddress[fakeUsdc].IsSpam)
+	require.Contains(t, byAddress[fakeUsdc].Reasons, ReasonLookalikeSymbol)
+	require.Contains(t, byAddress[fakeUsdc].Reasons, ReasonNoPrice)
+	require.True(t, byAddress[airdrop].IsSpam)
+	require.Equal(t, []Reason{ReasonNotInTokenList, ReasonNoPrice, ReasonZeroValueTransfers}, byAddress[airdrop].Reasons)
+	require.False(t, byAddress[newToken].IsSpam)
+	require.Equal(t, []Reason{ReasonNotInTokenList}, byAddress[newToken].Reasons)
+	require.False(t, byAddress[communityToken].IsSpam)
+	require.Contains(t, byAddress[communityToken].Reasons, ReasonNotInTokenList)
+
+	spamTokens, err := manager.spamTokensSet()
+	require.NoError(t, err)
+	require.Len(t, spamTokens, 3)
+
+	// the user overrides win over the classification and the provider flags
+	err = manager.db.UpdateOverrides([]Override{
+		{ChainID: testChainID, Address: airdrop, IsSpam: false},
+		{ChainID: testChainID, Address: collection, IsSpam: false},
+		{ChainID: testChainID, Address: newToken, IsSpam: true},
+	}, 10)
+	require.NoError(t, err)
+
+	clock, err := manager.db.GetClockOfLastOverridesChange()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), clock)
+
+	ids, err := manager.GetSpamTokens()
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	require.ElementsMatch(t, []common.Address{fakeUsdc, newToken}, []common.Address{ids[0].Address, ids[1].Address})
+
+	verdicts, err = manager.GetVerdicts([]uint64{testChainID})
+	require.NoError(t, err)
+	require.Len(t, verdicts, 6)
+	for _, verdict := range verdicts {
+		switch verdict.Address {
+		case airdrop, collection:
+			require.True(t, verdict.Overridden)
+			require.False(t, verdict.IsSpam)
+		case newToken:
+			require.True(t, verdict.Overridden)
+			require.True(t, verdict.IsSpam)
+		}
+	}
+}
+
+func TestMarkSpam(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	balances := map[common.Address][]tokenTypes.StorageToken{
+		account: {
+			{
+				Token: tokenTypes.Token{Symbol: "USDC"},
+				BalancesPerChain: map[uint64]tokenTypes.ChainBalance{
+					testChainID: {Address: usdc, ChainID: testChainID},
+				},
+			},
+			{
+				Token: tokenTypes.Token{Symbol: "USD\u0421"},
+				BalancesPerChain: map[uint64]tokenTypes.ChainBalance{
+					testChainID: {Address: fakeUsdc, ChainID: testChainID},
+				},
+			},
+		},
+	}
+
+	manager.feed = &event.Feed{}
+	ch := make(chan walletevent.Event, 1)
+	sub := manager.feed.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	// the balances are marked with the stored verdicts only, the tokens are classified in the background
+	require.NoError(t, manager.MarkSpam(balances))
+	require.False(t, balances[account][0].IsSpam)
+	require.False(t, balances[account][1].IsSpam)
+
+	select {
+	case e := <-ch:
+		require.Equal(t, EventSpamVerdictsUpdated, e.Type)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "tokens not classified")
+	}
+
+	require.NoError(t, manager.MarkSpam(balances))
+	require.False(t, balances[account][0].IsSpam)
+	require.True(t, balances[account][1].IsSpam)
+
+	// the verdicts are up to date, nothing is classified again
+	select {
+	case <-ch:
+		require.FailNow(t, "tokens classified again")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	filtered := FilterSpam(balances)
+	require.Len(t, filtered[account], 1)
+	require.Equal(t, "USDC", filtered[account][0].Symbol)
+}
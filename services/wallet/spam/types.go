@@ -0,0 +1,39 @@
+package spam
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Reason string
+
+const (
+	// ReasonNotInTokenList is set for tokens not part of any token list
+	ReasonNotInTokenList Reason = "notInTokenList"
+	// ReasonProviderFlag is set for tokens and collections flagged as spam by a collectibles provider
+	ReasonProviderFlag Reason = "providerFlag"
+	// ReasonNoPrice is set for tokens without a market price
+	ReasonNoPrice Reason = "noPrice"
+	// ReasonZeroValueTransfers is set for tokens received in zero value transfers, a pattern of airdrop spam
+	ReasonZeroValueTransfers Reason = "zeroValueTransfers"
+	// ReasonLookalikeSymbol is set for tokens impersonating a listed token or advertising a site in their symbol
+	ReasonLookalikeSymbol Reason = "lookalikeSymbol"
+)
+
+// Verdict is the spam classification of a token or a collection
+type Verdict struct {
+	ChainID uint64         `json:"chainId"`
+	Address common.Address `json:"address"`
+	IsSpam  bool           `json:"isSpam"`
+	Score   int            `json:"score"`
+	Reasons []Reason       `json:"reasons"`
+	// Overridden is true when IsSpam was set by the user
+	Overridden bool  `json:"overridden"`
+	UpdatedAt  int64 `json:"updatedAt"`
+}
+
+// Override is a verdict set by the user, it wins over the classification and is synced with the paired devices
+type Override struct {
+	ChainID uint64         `json:"chainId"`
+	Address common.Address `json:"address"`
+	IsSpam  bool           `json:"isSpam"`
+}
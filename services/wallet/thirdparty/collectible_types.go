@@ -143,6 +143,8 @@ type CollectionData struct {
 	ImagePayload []byte
 	Traits       map[string]CollectionTrait `json:"traits"`
 	Socials      *CollectionSocials         `json:"socials"`
+	// IsSpam is set when the provider flagged the collection as spam
+	IsSpam bool `json:"is_spam"`
 }
 
 type CollectionSocials struct {
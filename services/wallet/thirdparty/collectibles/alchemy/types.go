@@ -115,6 +115,9 @@ type Contract struct {
 	Symbol          string          `json:"symbol"`
 	TokenType       string          `json:"tokenType"`
 	OpenSeaMetadata OpenSeaMetadata `json:"openseaMetadata"`
+	IsSpam          bool            `json:"isSpam"`
+	// SpamClassifications are the reasons of the spam flag, e.g. "Erc721DishonestTotalSupply"
+	SpamClassifications []string `json:"spamClassifications"`
 }
 
 type ContractList struct {
@@ -203,6 +206,7 @@ func (c *Contract) toCollectionData(id thirdparty.ContractID) thirdparty.Collect
 		ImageURL:     c.OpenSeaMetadata.ImageURL,
 		Traits:       make(map[string]thirdparty.CollectionTrait, 0),
 		Socials:      c.toCollectionSocials(),
+		IsSpam:       c.IsSpam,
 	}
 	return ret
 }
@@ -0,0 +1,525 @@
+package onchain
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/connection"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+var (
+	transferEventHash       = walletCommon.GetEventSignatureHash(walletCommon.Erc20_721TransferEventSignature)
+	transferSingleEventHash = walletCommon.GetEventSignatureHash(walletCommon.Erc1155TransferSingleEventSignature)
+	transferBatchEventHash  = walletCommon.GetEventSignatureHash(walletCommon.Erc1155TransferBatchEventSignature)
+)
+
+var errMethodNotSupported = errors.New("method not supported by the contract")
+
+// unsafeBlocksCount is the number of latest blocks which can still be reorganized, their logs are scanned on every
+// call instead of being persisted
+const unsafeBlocksCount = 64
+
+// Backend is the subset of the chain client used to rebuild the ownership and read the metadata
+type Backend interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+type Client struct {
+	thirdparty.CollectibleAccountOwnershipProvider
+	getBackend       func(chainID walletCommon.ChainID) (Backend, error)
+	contractABI      abi.ABI
+	metadata         *MetadataFetcher
+	db               *Database
+	connectionStatus *connection.Status
+}
+
+// NewClient creates a provider reading everything from the chain through the RPC client, it doesn't need
+// any third-party API and is meant to be used as the last fallback. The metadata hosted outside of IPFS is loaded
+// from its host unless allowDirectFetch returns false.
+func NewClient(rpcClient rpc.ClientInterface, db *sql.DB, allowDirectFetch func() bool) *Client {
+	client := newClient(func(chainID walletCommon.ChainID) (Backend, error) {
+		return rpcClient.EthClient(uint64(chainID))
+	}, &http.Client{Timeout: time.Minute}, DefaultIPFSGateways, allowDirectFetch)
+	client.db = NewDB(db)
+	return client
+}
+
+func newClient(getBackend func(chainID walletCommon.ChainID) (Backend, error), httpClient *http.Client, ipfsGateways []string, allowDirectFetch func() bool) *Client {
+	contractABI, err := abi.JSON(strings.NewReader(collectiblesABI))
+	if err != nil {
+		// the ABI is a constant, this can't happen
+		panic(err)
+	}
+
+	return &Client{
+		getBackend:       getBackend,
+		contractABI:      contractABI,
+		metadata:         NewMetadataFetcher(httpClient, ipfsGateways, allowDirectFetch),
+		connectionStatus: connection.NewStatus(),
+	}
+}
+
+func (o *Client) ID() string {
+	return OnchainID
+}
+
+func (o *Client) IsChainSupported(chainID walletCommon.ChainID) bool {
+	_, err := o.getBackend(chainID)
+	return err == nil
+}
+
+func (o *Client) IsConnected() bool {
+	return o.connectionStatus.IsConnected()
+}
+
+func (o *Client) FetchAllAssetsByOwner(ctx context.Context, chainID walletCommon.ChainID, owner common.Address, cursor string, limit int) (*thirdparty.FullCollectibleDataContainer, error) {
+	return o.FetchAllAssetsByOwnerAndContractAddress(ctx, chainID, owner, nil, cursor, limit)
+}
+
+func (o *Client) FetchAllAssetsByOwnerAndContractAddress(ctx context.Context, chainID walletCommon.ChainID, owner common.Address, contractAddresses []common.Address, cursor string, limit int) (*thirdparty.FullCollectibleDataContainer, error) {
+	offset := 0
+	if cursor != "" {
+		var err error
+		offset, err = strconv.Atoi(cursor)
+		if err != nil || offset < 0 {
+			return nil, errors.New("invalid cursor")
+		}
+	}
+
+	backend, err := o.getBackend(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := o.fetchBalances(ctx, backend, chainID, owner, contractAddresses)
+	o.connectionStatus.SetIsConnected(err == nil)
+	if err != nil {
+		return nil, err
+	}
+
+	container := &thirdparty.FullCollectibleDataContainer{
+		Provider:       o.ID(),
+		PreviousCursor: cursor,
+	}
+	if offset >= len(balances) {
+		return container, nil
+	}
+
+	end := len(balances)
+	if limit != thirdparty.FetchNoLimit && offset+limit < end {
+		end = offset + limit
+		container.NextCursor = strconv.Itoa(end)
+	}
+
+	collections := make(map[common.Address]*thirdparty.CollectionData)
+	for _, balance := range balances[offset:end] {
+		id := thirdparty.CollectibleUniqueID{
+			ContractID: thirdparty.ContractID{
+				ChainID: chainID,
+				Address: balance.contract,
+			},
+			TokenID: &bigint.BigInt{Int: balance.tokenID},
+		}
+
+		collection, ok := collections[balance.contract]
+		if !ok {
+			collection = o.fetchCollectionData(ctx, backend, id.ContractID, balance.contractType)
+			collections[balance.contract] = collection
+		}
+
+		item := thirdparty.FullCollectibleData{
+			CollectibleData: o.fetchCollectibleData(ctx, backend, id, balance.contractType),
+			CollectionData:  collection,
+			AccountBalance:  &bigint.BigInt{Int: balance.balance},
+		}
+		container.Items = append(container.Items, item)
+	}
+
+	return container, nil
+}
+
+func (o *Client) FetchAssetsByCollectibleUniqueID(ctx context.Context, uniqueIDs []thirdparty.CollectibleUniqueID) ([]thirdparty.FullCollectibleData, error) {
+	ret := make([]thirdparty.FullCollectibleData, 0, len(uniqueIDs))
+	for _, id := range uniqueIDs {
+		backend, err := o.getBackend(id.ContractID.ChainID)
+		if err != nil {
+			return nil, err
+		}
+
+		// ERC721 is far more common, ERC1155 is assumed only if `tokenURI` is not available
+		contractType := walletCommon.ContractTypeERC721
+		if _, err := o.tokenURI(ctx, backend, id.ContractID.Address, walletCommon.ContractTypeERC721, id.TokenID.Int); errors.Is(err, errMethodNotSupported) {
+			contractType = walletCommon.ContractTypeERC1155
+		}
+
+		ret = append(ret, thirdparty.FullCollectibleData{
+			CollectibleData: o.fetchCollectibleData(ctx, backend, id, contractType),
+			CollectionData:  o.fetchCollectionData(ctx, backend, id.ContractID, contractType),
+		})
+	}
+	return ret, nil
+}
+
+func (o *Client) FetchCollectionSocials(ctx context.Context, contractID thirdparty.ContractID) (*thirdparty.CollectionSocials, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (o *Client) FetchCollectionsDataByContractID(ctx context.Context, contractIDs []thirdparty.ContractID) ([]thirdparty.CollectionData, error) {
+	ret := make([]thirdparty.CollectionData, 0, len(contractIDs))
+	for _, id := range contractIDs {
+		backend, err := o.getBackend(id.ChainID)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *o.fetchCollectionData(ctx, backend, id, walletCommon.ContractTypeUnknown))
+	}
+	return ret, nil
+}
+
+func (o *Client) fetchCollectibleData(ctx context.Context, backend Backend, id thirdparty.CollectibleUniqueID, contractType walletCommon.ContractType) thirdparty.CollectibleData {
+	data := thirdparty.CollectibleData{
+		ID:           id,
+		ContractType: contractType,
+		Provider:     o.ID(),
+	}
+
+	// missing metadata is not an error, the collectible is still owned
+	uri, err := o.tokenURI(ctx, backend, id.ContractID.Address, contractType, id.TokenID.Int)
+	if err != nil {
+		logutils.ZapLogger().Debug("onchain: failed to fetch token URI",
+			zap.Stringer("contract", id.ContractID.Address),
+			zap.Stringer("tokenID", id.TokenID),
+			zap.Error(err))
+		return data
+	}
+	data.TokenURI = uri
+
+	metadata, err := o.metadata.Fetch(ctx, uri)
+	if err != nil {
+		logutils.ZapLogger().Debug("onchain: failed to fetch metadata",
+			zap.Stringer("contract", id.ContractID.Address),
+			zap.Stringer("tokenID", id.TokenID),
+			zap.Error(err))
+		return data
+	}
+	metadata.toCollectibleData(&data, o.metadata.ipfsGateway())
+
+	return data
+}
+
+func (o *Client) fetchCollectionData(ctx context.Context, backend Backend, id thirdparty.ContractID, contractType walletCommon.ContractType) *thirdparty.CollectionData {
+	collection := &thirdparty.CollectionData{
+		ID:           id,
+		ContractType: contractType,
+		Provider:     o.ID(),
+	}
+
+	// `name` is optional for both standards
+	name, err := o.callString(ctx, backend, id.Address, "name")
+	if err == nil {
+		collection.Name = name
+	}
+
+	return collection
+}
+
+func (o *Client) tokenURI(ctx context.Context, backend Backend, contract common.Address, contractType walletCommon.ContractType, tokenID *big.Int) (string, error) {
+	if contractType == walletCommon.ContractTypeERC1155 {
+		uri, err := o.callString(ctx, backend, contract, "uri", tokenID)
+		if err != nil {
+			return "", err
+		}
+		return ERC1155URI(uri, tokenID), nil
+	}
+	return o.callString(ctx, backend, contract, "tokenURI", tokenID)
+}
+
+func (o *Client) callString(ctx context.Context, backend Backend, contract common.Address, method string, args ...interface{}) (string, error) {
+	input, err := o.contractABI.Pack(method, args...)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := backend.CallContract(ctx, ethereum.CallMsg{
+		To:   &contract,
+		Data: input,
+	}, nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "execution reverted") {
+			return "", errMethodNotSupported
+		}
+		return "", err
+	}
+
+	values, err := o.contractABI.Unpack(method, output)
+	if err != nil || len(values) != 1 {
+		// contracts not implementing the method return no data
+		return "", errMethodNotSupported
+	}
+
+	value, ok := values[0].(string)
+	if !ok {
+		return "", errMethodNotSupported
+	}
+	return value, nil
+}
+
+type tokenBalance struct {
+	contractType walletCommon.ContractType
+	contract     common.Address
+	tokenID      *big.Int
+	balance      *big.Int
+}
+
+// fetchBalances replays the owner's incoming and outgoing transfers, the resulting balances are sorted by
+// contract address and token ID so that cursors are stable across calls. The replay of the blocks older than
+// unsafeBlocksCount is persisted per owner and contract, only the newer blocks are scanned on the next calls.
+func (o *Client) fetchBalances(ctx context.Context, backend Backend, chainID walletCommon.ChainID, owner common.Address, contractAddresses []common.Address) ([]*tokenBalance, error) {
+	latest, err := backend.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	safeBlock := uint64(0)
+	if latest > unsafeBlocksCount {
+		safeBlock = latest - unsafeBlocksCount
+	}
+
+	// the zero address stands for the scan of all the contracts
+	scanContracts := contractAddresses
+	if len(scanContracts) == 0 {
+		scanContracts = []common.Address{{}}
+	}
+	scans := make(map[common.Address]*scan, len(scanContracts))
+	for _, contract := range scanContracts {
+		scans[contract] = newScan()
+		if o.db == nil {
+			continue
+		}
+		stored, err := o.db.GetScan(chainID, owner, contract)
+		if err != nil {
+			return nil, err
+		}
+		scans[contract] = stored
+	}
+
+	// the blocks which can't be reorganized anymore are replayed on the persisted scans
+	err = o.replayLogs(ctx, backend, owner, scans, safeBlock)
+	if err != nil {
+		return nil, err
+	}
+	if o.db != nil {
+		for contract, s := range scans {
+			if err := o.db.SaveScan(chainID, owner, contract, s); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// the latest blocks are replayed on copies
+	for contract, s := range scans {
+		scans[contract] = s.copy()
+	}
+	err = o.replayLogs(ctx, backend, owner, scans, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*tokenBalance, 0)
+	for _, s := range scans {
+		for _, balance := range s.balances {
+			if balance.balance.Sign() > 0 {
+				ret = append(ret, balance)
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if cmp := bytes.Compare(ret[i].contract.Bytes(), ret[j].contract.Bytes()); cmp != 0 {
+			return cmp < 0
+		}
+		return ret[i].tokenID.Cmp(ret[j].tokenID) < 0
+	})
+
+	return ret, nil
+}
+
+// replayLogs applies the transfer logs up to toBlock to the scans, the scans starting at the same block share the
+// queries
+func (o *Client) replayLogs(ctx context.Context, backend Backend, owner common.Address, scans map[common.Address]*scan, toBlock uint64) error {
+	groups := make(map[uint64][]common.Address)
+	for contract, s := range scans {
+		if s.nextBlock <= toBlock {
+			groups[s.nextBlock] = append(groups[s.nextBlock], contract)
+		}
+	}
+
+	for fromBlock, contracts := range groups {
+		var addresses []common.Address
+		if contracts[0] != (common.Address{}) {
+			addresses = contracts
+		}
+
+		logs, err := transferLogs(ctx, backend, owner, addresses, fromBlock, toBlock)
+		if err != nil {
+			return err
+		}
+
+		for _, log := range logs {
+			s, ok := scans[log.Address]
+			if addresses == nil {
+				s, ok = scans[common.Address{}]
+			}
+			if ok {
+				applyTransferLog(s.balances, log, owner)
+			}
+		}
+		for _, contract := range contracts {
+			scans[contract].nextBlock = toBlock + 1
+		}
+	}
+	return nil
+}
+
+// transferLogs returns the ERC721 and ERC1155 transfer logs of the owner between the blocks
+func transferLogs(ctx context.Context, backend Backend, owner common.Address, addresses []common.Address, fromBlock uint64, toBlock uint64) ([]types.Log, error) {
+	ownerTopic := common.BytesToHash(owner.Bytes())
+	queries := [][][]common.Hash{
+		// ERC721 Transfer(from, to, tokenId)
+		{{transferEventHash}, nil, {ownerTopic}},
+		{{transferEventHash}, {ownerTopic}},
+		// ERC1155 TransferSingle/TransferBatch(operator, from, to, ...)
+		{{transferSingleEventHash, transferBatchEventHash}, nil, nil, {ownerTopic}},
+		{{transferSingleEventHash, transferBatchEventHash}, nil, {ownerTopic}},
+	}
+
+	// self-transfers match both the incoming and the outgoing queries
+	seen := make(map[string]bool)
+	ret := make([]types.Log, 0)
+	for _, topics := range queries {
+		logs, err := filterLogs(ctx, backend, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(toBlock),
+			Addresses: addresses,
+			Topics:    topics,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, log := range logs {
+			logKey := log.TxHash.Hex() + "-" + strconv.FormatUint(uint64(log.Index), 10)
+			if seen[logKey] || log.Removed {
+				continue
+			}
+			seen[logKey] = true
+			ret = append(ret, log)
+		}
+	}
+	return ret, nil
+}
+
+func balanceKey(contract common.Address, tokenID *big.Int) string {
+	return contract.Hex() + "-" + tokenID.String()
+}
+
+func applyTransferLog(balances map[string]*tokenBalance, log types.Log, owner common.Address) {
+	var contractType walletCommon.ContractType
+	switch walletCommon.GetEventType(&log) {
+	case walletCommon.Erc721TransferEventType:
+		contractType = walletCommon.ContractTypeERC721
+	case walletCommon.Erc1155TransferSingleEventType, walletCommon.Erc1155TransferBatchEventType:
+		contractType = walletCommon.ContractTypeERC1155
+	default:
+		// ERC20 transfers share the ERC721 signature
+		return
+	}
+
+	from, to, _, tokenIDs, values, err := walletCommon.ParseTransferLog(log)
+	if err != nil {
+		logutils.ZapLogger().Debug("onchain: failed to parse transfer log", zap.Stringer("txHash", log.TxHash), zap.Error(err))
+		return
+	}
+
+	for i, tokenID := range tokenIDs {
+		if i >= len(values) {
+			break
+		}
+		key := balanceKey(log.Address, tokenID)
+		balance, ok := balances[key]
+		if !ok {
+			balance = &tokenBalance{
+				contractType: contractType,
+				contract:     log.Address,
+				tokenID:      tokenID,
+				balance:      new(big.Int),
+			}
+			balances[key] = balance
+		}
+		if to == owner {
+			balance.balance.Add(balance.balance, values[i])
+		}
+		if from == owner {
+			balance.balance.Sub(balance.balance, values[i])
+		}
+	}
+}
+
+func isRangeTooLargeError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range []string{"block range", "range is too large", "more than", "too many", "exceed"} {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterLogs splits the block range in halves until the node accepts the query, providers limit the range
+// or the number of results differently
+func filterLogs(ctx context.Context, backend Backend, query ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := backend.FilterLogs(ctx, query)
+	if err == nil {
+		return logs, nil
+	}
+
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	if to <= from || !isRangeTooLargeError(err) || ctx.Err() != nil {
+		return nil, err
+	}
+
+	mid := from + (to-from)/2
+	lower, upper := query, query
+	lower.ToBlock = new(big.Int).SetUint64(mid)
+	upper.FromBlock = new(big.Int).SetUint64(mid + 1)
+
+	lowerLogs, err := filterLogs(ctx, backend, lower)
+	if err != nil {
+		return nil, err
+	}
+	upperLogs, err := filterLogs(ctx, backend, upper)
+	if err != nil {
+		return nil, err
+	}
+	return append(lowerLogs, upperLogs...), nil
+}
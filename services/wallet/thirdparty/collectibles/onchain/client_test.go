@@ -0,0 +1,332 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+const testMaxBlockRange = 100
+
+var (
+	testOwner    = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testOther    = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testERC721   = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	testERC1155  = common.HexToAddress("0x4444444444444444444444444444444444444444")
+	testERC20    = common.HexToAddress("0x5555555555555555555555555555555555555555")
+	testOperator = common.HexToAddress("0x6666666666666666666666666666666666666666")
+)
+
+type testBackend struct {
+	contractABI abi.ABI
+	logs        []types.Log
+	latest      uint64
+	// contract -> method -> returned string, missing methods revert
+	methods       map[common.Address]map[string]string
+	filterQueries int
+	fromBlocks    []uint64
+}
+
+func (b *testBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	return b.latest, nil
+}
+
+func (b *testBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	b.filterQueries++
+	b.fromBlocks = append(b.fromBlocks, q.FromBlock.Uint64())
+	if q.ToBlock.Uint64()-q.FromBlock.Uint64() > testMaxBlockRange {
+		return nil, errors.New("query exceeds max block range 100")
+	}
+
+	var ret []types.Log
+	for _, log := range b.logs {
+		if log.BlockNumber < q.FromBlock.Uint64() || log.BlockNumber > q.ToBlock.Uint64() {
+			continue
+		}
+		if len(q.Addresses) > 0 && !containsAddress(q.Addresses, log.Address) {
+			continue
+		}
+		if matchesTopics(log.Topics, q.Topics) {
+			ret = append(ret, log)
+		}
+	}
+	return ret, nil
+}
+
+func (b *testBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := b.contractABI.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	value, ok := b.methods[*call.To][method.Name]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	return method.Outputs.Pack(value)
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesTopics(logTopics []common.Hash, filter [][]common.Hash) bool {
+	if len(filter) > len(logTopics) {
+		return false
+	}
+	for i, alternatives := range filter {
+		if len(alternatives) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range alternatives {
+			if topic == logTopics[i] {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func addressTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
+
+func erc721Log(block uint64, index uint, from, to common.Address, tokenID int64) types.Log {
+	return types.Log{
+		Address:     testERC721,
+		Topics:      []common.Hash{transferEventHash, addressTopic(from), addressTopic(to), common.BigToHash(big.NewInt(tokenID))},
+		BlockNumber: block,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(block)),
+		Index:       index,
+	}
+}
+
+func erc1155Log(block uint64, index uint, from, to common.Address, tokenID, value int64) types.Log {
+	data := append(common.BigToHash(big.NewInt(tokenID)).Bytes(), common.BigToHash(big.NewInt(value)).Bytes()...)
+	return types.Log{
+		Address:     testERC1155,
+		Topics:      []common.Hash{transferSingleEventHash, addressTopic(testOperator), addressTopic(from), addressTopic(to)},
+		Data:        data,
+		BlockNumber: block,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(block)),
+		Index:       index,
+	}
+}
+
+func erc20Log(block uint64, from, to common.Address) types.Log {
+	return types.Log{
+		Address:     testERC20,
+		Topics:      []common.Hash{transferEventHash, addressTopic(from), addressTopic(to)},
+		Data:        common.BigToHash(big.NewInt(1000)).Bytes(),
+		BlockNumber: block,
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(block)),
+	}
+}
+
+func setupTestClient(t *testing.T) (*Client, *testBackend) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/QmCollection/1":
+			fmt.Fprint(w, `{"name":"Token #1","image":"ipfs://QmImage/1.png","attributes":[{"trait_type":"Level","value":5}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := newClient(nil, server.Client(), []string{server.URL + "/ipfs/"}, nil)
+	backend := &testBackend{
+		contractABI: client.contractABI,
+		latest:      1000,
+		methods: map[common.Address]map[string]string{
+			testERC721: {
+				"name":     "Test721",
+				"tokenURI": "ipfs://QmCollection/1",
+			},
+			testERC1155: {
+				"uri": `data:application/json,{"name":"Item {id}"}`,
+			},
+		},
+	}
+	client.getBackend = func(chainID walletCommon.ChainID) (Backend, error) {
+		if chainID != walletCommon.ChainID(walletCommon.EthereumMainnet) {
+			return nil, thirdparty.ErrChainIDNotSupported
+		}
+		return backend, nil
+	}
+
+	return client, backend
+}
+
+func TestFetchAllAssetsByOwner(t *testing.T) {
+	client, backend := setupTestClient(t)
+	backend.logs = []types.Log{
+		// token 1 received and kept
+		erc721Log(10, 0, testOther, testOwner, 1),
+		// token 2 received and sent away
+		erc721Log(20, 0, testOther, testOwner, 2),
+		erc721Log(520, 0, testOwner, testOther, 2),
+		// self-transfer shows up in both the incoming and outgoing queries
+		erc721Log(530, 0, testOwner, testOwner, 1),
+		// 5 received, 2 sent
+		erc1155Log(600, 0, testOther, testOwner, 7, 5),
+		erc1155Log(900, 1, testOwner, testOther, 7, 2),
+		// fungible transfers are ignored
+		erc20Log(700, testOther, testOwner),
+	}
+
+	chainID := walletCommon.ChainID(walletCommon.EthereumMainnet)
+	require.True(t, client.IsChainSupported(chainID))
+	require.False(t, client.IsChainSupported(walletCommon.ChainID(walletCommon.OptimismMainnet)))
+
+	container, err := client.FetchAllAssetsByOwner(context.Background(), chainID, testOwner, "", thirdparty.FetchNoLimit)
+	require.NoError(t, err)
+	require.True(t, client.IsConnected())
+	require.Equal(t, OnchainID, container.Provider)
+	require.Empty(t, container.NextCursor)
+	// the block range was split to satisfy the node limits
+	require.Greater(t, backend.filterQueries, 4)
+
+	require.Len(t, container.Items, 2)
+
+	erc721 := container.Items[0]
+	require.Equal(t, testERC721, erc721.CollectibleData.ID.ContractID.Address)
+	require.Equal(t, int64(1), erc721.CollectibleData.ID.TokenID.Int64())
+	require.Equal(t, int64(1), erc721.AccountBalance.Int64())
+	require.Equal(t, walletCommon.ContractTypeERC721, erc721.CollectibleData.ContractType)
+	require.Equal(t, "ipfs://QmCollection/1", erc721.CollectibleData.TokenURI)
+	require.Equal(t, "Token #1", erc721.CollectibleData.Name)
+	require.Equal(t, client.metadata.ipfsGateways[0]+"QmImage/1.png", erc721.CollectibleData.ImageURL)
+	require.Equal(t, []thirdparty.CollectibleTrait{{TraitType: "Level", Value: "5"}}, erc721.CollectibleData.Traits)
+	require.Equal(t, "Test721", erc721.CollectionData.Name)
+
+	erc1155 := container.Items[1]
+	require.Equal(t, testERC1155, erc1155.CollectibleData.ID.ContractID.Address)
+	require.Equal(t, int64(7), erc1155.CollectibleData.ID.TokenID.Int64())
+	require.Equal(t, int64(3), erc1155.AccountBalance.Int64())
+	require.Equal(t, walletCommon.ContractTypeERC1155, erc1155.CollectibleData.ContractType)
+	require.Equal(t, fmt.Sprintf("Item %064x", 7), erc1155.CollectibleData.Name)
+	require.Empty(t, erc1155.CollectionData.Name)
+}
+
+func TestFetchAllAssetsByOwnerScannedBlocks(t *testing.T) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	client, backend := setupTestClient(t)
+	client.db = NewDB(db)
+	backend.logs = []types.Log{
+		erc721Log(10, 0, testOther, testOwner, 1),
+		// not final yet
+		erc721Log(990, 0, testOther, testOwner, 2),
+	}
+
+	chainID := walletCommon.ChainID(walletCommon.EthereumMainnet)
+	tokenIDs := func(contractAddresses []common.Address) []int64 {
+		container, err := client.FetchAllAssetsByOwnerAndContractAddress(context.Background(), chainID, testOwner, contractAddresses, "", thirdparty.FetchNoLimit)
+		require.NoError(t, err)
+		ret := make([]int64, 0, len(container.Items))
+		for _, item := range container.Items {
+			ret = append(ret, item.CollectibleData.ID.TokenID.Int64())
+		}
+		return ret
+	}
+	require.Equal(t, []int64{1, 2}, tokenIDs(nil))
+	require.Equal(t, []int64{1, 2}, tokenIDs([]common.Address{testERC721}))
+
+	// the second log was reorganized out, a new one was added in a new block
+	backend.logs = []types.Log{
+		erc721Log(10, 0, testOther, testOwner, 1),
+		erc721Log(1050, 0, testOther, testOwner, 3),
+	}
+	backend.latest = 1100
+	backend.fromBlocks = nil
+	require.Equal(t, []int64{1, 3}, tokenIDs(nil))
+	require.Equal(t, []int64{1, 3}, tokenIDs([]common.Address{testERC721}))
+
+	// only the blocks after the persisted ones are scanned
+	require.NotEmpty(t, backend.fromBlocks)
+	for _, fromBlock := range backend.fromBlocks {
+		require.Greater(t, fromBlock, uint64(1000-unsafeBlocksCount))
+	}
+
+	// the replay of the final blocks is persisted
+	stored, err := client.db.GetScan(chainID, testOwner, common.Address{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1100-unsafeBlocksCount+1), stored.nextBlock)
+	require.Len(t, stored.balances, 1)
+}
+
+func TestFetchAllAssetsByOwnerPagination(t *testing.T) {
+	client, backend := setupTestClient(t)
+	for i := int64(0); i < 5; i++ {
+		backend.logs = append(backend.logs, erc721Log(uint64(10+i), 0, testOther, testOwner, 10-i))
+	}
+
+	chainID := walletCommon.ChainID(walletCommon.EthereumMainnet)
+	var tokenIDs []int64
+	cursor := ""
+	for {
+		container, err := client.FetchAllAssetsByOwnerAndContractAddress(context.Background(), chainID, testOwner, []common.Address{testERC721}, cursor, 2)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(container.Items), 2)
+		for _, item := range container.Items {
+			tokenIDs = append(tokenIDs, item.CollectibleData.ID.TokenID.Int64())
+		}
+		if container.NextCursor == "" {
+			break
+		}
+		cursor = container.NextCursor
+	}
+	require.Equal(t, []int64{6, 7, 8, 9, 10}, tokenIDs)
+
+	container, err := client.FetchAllAssetsByOwnerAndContractAddress(context.Background(), chainID, testOwner, []common.Address{testERC1155}, "", 2)
+	require.NoError(t, err)
+	require.Empty(t, container.Items)
+}
+
+func TestFetchAssetsByCollectibleUniqueID(t *testing.T) {
+	client, _ := setupTestClient(t)
+
+	chainID := walletCommon.ChainID(walletCommon.EthereumMainnet)
+	ids := []thirdparty.CollectibleUniqueID{
+		{ContractID: thirdparty.ContractID{ChainID: chainID, Address: testERC721}, TokenID: tokenID(1)},
+		{ContractID: thirdparty.ContractID{ChainID: chainID, Address: testERC1155}, TokenID: tokenID(255)},
+	}
+
+	items, err := client.FetchAssetsByCollectibleUniqueID(context.Background(), ids)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, walletCommon.ContractTypeERC721, items[0].CollectibleData.ContractType)
+	require.Equal(t, "Token #1", items[0].CollectibleData.Name)
+	require.Equal(t, walletCommon.ContractTypeERC1155, items[1].CollectibleData.ContractType)
+	require.Equal(t, fmt.Sprintf("Item %064x", 255), items[1].CollectibleData.Name)
+
+	_, err = client.FetchCollectionSocials(context.Background(), ids[0].ContractID)
+	require.ErrorIs(t, err, thirdparty.ErrEndpointNotSupported)
+}
@@ -0,0 +1,128 @@
+package onchain
+
+import (
+	"database/sql"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+// scan is the state of the transfer logs replay of an owner, the logs are scanned from nextBlock on
+type scan struct {
+	nextBlock uint64
+	balances  map[string]*tokenBalance
+}
+
+func newScan() *scan {
+	return &scan{balances: make(map[string]*tokenBalance)}
+}
+
+func (s *scan) copy() *scan {
+	ret := &scan{
+		nextBlock: s.nextBlock,
+		balances:  make(map[string]*tokenBalance, len(s.balances)),
+	}
+	for key, balance := range s.balances {
+		copied := *balance
+		copied.balance = new(big.Int).Set(balance.balance)
+		ret.balances[key] = &copied
+	}
+	return ret
+}
+
+// Database persists the scans so that only the blocks produced since the last call are scanned
+type Database struct {
+	db *sql.DB
+}
+
+func NewDB(db *sql.DB) *Database {
+	return &Database{db: db}
+}
+
+// GetScan returns the scan of the owner restricted to scanContract, the zero address for all the contracts, an empty
+// scan if never scanned
+func (db *Database) GetScan(chainID walletCommon.ChainID, owner common.Address, scanContract common.Address) (*scan, error) {
+	ret := newScan()
+	var blockNumber uint64
+	err := db.db.QueryRow(`SELECT block_number FROM collectibles_onchain_scanned_blocks WHERE chain_id = ? AND owner = ? AND scan_contract = ?`,
+		chainID, owner, scanContract).Scan(&blockNumber)
+	if err == sql.ErrNoRows {
+		return ret, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret.nextBlock = blockNumber + 1
+
+	rows, err := db.db.Query(`SELECT contract, token_id, contract_type, balance FROM collectibles_onchain_balances
+		WHERE chain_id = ? AND owner = ? AND scan_contract = ?`, chainID, owner, scanContract)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		balance := &tokenBalance{}
+		var tokenID, amount string
+		err = rows.Scan(&balance.contract, &tokenID, &balance.contractType, &amount)
+		if err != nil {
+			return nil, err
+		}
+
+		var ok bool
+		if balance.tokenID, ok = new(big.Int).SetString(tokenID, 10); !ok {
+			continue
+		}
+		if balance.balance, ok = new(big.Int).SetString(amount, 10); !ok {
+			continue
+		}
+		ret.balances[balanceKey(balance.contract, balance.tokenID)] = balance
+	}
+	return ret, rows.Err()
+}
+
+// SaveScan replaces the stored scan of the owner, the logs were scanned up to the block before scan.nextBlock
+func (db *Database) SaveScan(chainID walletCommon.ChainID, owner common.Address, scanContract common.Address, s *scan) (err error) {
+	if s.nextBlock == 0 {
+		return nil
+	}
+
+	tx, err := db.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`DELETE FROM collectibles_onchain_balances WHERE chain_id = ? AND owner = ? AND scan_contract = ?`,
+		chainID, owner, scanContract)
+	if err != nil {
+		return err
+	}
+
+	insert, err := tx.Prepare(`INSERT INTO collectibles_onchain_balances (chain_id, owner, scan_contract, contract, token_id, contract_type, balance)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for _, balance := range s.balances {
+		_, err = insert.Exec(chainID, owner, scanContract, balance.contract, balance.tokenID.String(), balance.contractType,
+			balance.balance.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO collectibles_onchain_scanned_blocks (chain_id, owner, scan_contract, block_number) VALUES (?, ?, ?, ?)`,
+		chainID, owner, scanContract, s.nextBlock-1)
+	return err
+}
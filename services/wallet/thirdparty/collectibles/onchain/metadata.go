@@ -0,0 +1,206 @@
+package onchain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/status-im/status-go/params"
+)
+
+const (
+	ipfsScheme    = "ipfs://"
+	arweaveScheme = "ar://"
+	dataScheme    = "data:"
+
+	arweaveGateway = "https://arweave.net/"
+
+	// maxMetadataSize protects from huge documents, metadata is expected to be a few KB
+	maxMetadataSize = 1024 * 1024
+)
+
+// DefaultIPFSGateways are the gateways run by Status, the same used to load the other IPFS content
+var DefaultIPFSGateways = []string{
+	params.IpfsGatewayURL,
+}
+
+var (
+	ErrEmptyURI              = errors.New("empty URI")
+	ErrUnsupportedURIScheme  = errors.New("unsupported URI scheme")
+	ErrMalformedDataURI      = errors.New("malformed data URI")
+	ErrDirectFetchNotAllowed = errors.New("loading metadata from its host is not allowed")
+)
+
+// MetadataFetcher loads the collectibles metadata from the URIs returned by the contracts. IPFS content is loaded
+// through the IPFS gateways, including the content referenced by URLs of other gateways, the metadata hosted
+// elsewhere is loaded from its host unless allowDirectFetch returns false, the request reveals the user IP to the host.
+type MetadataFetcher struct {
+	httpClient       *http.Client
+	ipfsGateways     []string
+	allowDirectFetch func() bool
+}
+
+// NewMetadataFetcher creates the fetcher, DefaultIPFSGateways are used when no gateway is given
+func NewMetadataFetcher(httpClient *http.Client, ipfsGateways []string, allowDirectFetch func() bool) *MetadataFetcher {
+	if len(ipfsGateways) == 0 {
+		ipfsGateways = DefaultIPFSGateways
+	}
+	return &MetadataFetcher{
+		httpClient:       httpClient,
+		ipfsGateways:     ipfsGateways,
+		allowDirectFetch: allowDirectFetch,
+	}
+}
+
+// ResolveURI converts IPFS and Arweave URIs to URLs that can be loaded by clients
+func (f *MetadataFetcher) ResolveURI(uri string) string {
+	return resolveURI(uri, f.ipfsGateway())
+}
+
+// ipfsGateway returns the gateway of the URLs returned to the clients
+func (f *MetadataFetcher) ipfsGateway() string {
+	return f.ipfsGateways[0]
+}
+
+// ipfsGatewayPath returns the IPFS path referenced by the URL of an IPFS gateway, either a path gateway
+// ("https://gateway/ipfs/<cid>/path") or a subdomain gateway ("https://<cid>.ipfs.gateway/path")
+func ipfsGatewayPath(uri string) (string, bool) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Host == "" {
+		return "", false
+	}
+	if _, path, found := strings.Cut(parsed.Path, "/ipfs/"); found && path != "" {
+		return path, true
+	}
+	if cid, _, found := strings.Cut(parsed.Hostname(), ".ipfs."); found && cid != "" {
+		return cid + parsed.Path, true
+	}
+	return "", false
+}
+
+// ERC1155URI replaces the `{id}` placeholder with the lowercase hex token ID padded to 64 characters,
+// as required by the ERC1155 metadata extension
+func ERC1155URI(uri string, tokenID *big.Int) string {
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", tokenID))
+}
+
+// resolveURI converts IPFS and Arweave URIs to URLs of the given IPFS gateway, so they can be loaded by clients
+func resolveURI(uri string, ipfsGateway string) string {
+	uri = strings.TrimSpace(uri)
+	switch {
+	case strings.HasPrefix(uri, ipfsScheme):
+		path := strings.TrimPrefix(uri, ipfsScheme)
+		path = strings.TrimPrefix(path, "ipfs/")
+		return ipfsGateway + path
+	case strings.HasPrefix(uri, arweaveScheme):
+		return arweaveGateway + strings.TrimPrefix(uri, arweaveScheme)
+	}
+	return uri
+}
+
+// Fetch loads and parses the metadata document, IPFS gateways are tried in order
+func (f *MetadataFetcher) Fetch(ctx context.Context, uri string) (*Metadata, error) {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return nil, ErrEmptyURI
+	}
+
+	var data []byte
+	var err error
+	switch {
+	case strings.HasPrefix(uri, dataScheme):
+		data, err = decodeDataURI(uri)
+	case strings.HasPrefix(uri, ipfsScheme):
+		data, err = f.getIPFS(ctx, strings.TrimPrefix(uri, ipfsScheme))
+	case strings.HasPrefix(uri, arweaveScheme):
+		data, err = f.get(ctx, resolveURI(uri, ""))
+	case strings.HasPrefix(uri, "https://"), strings.HasPrefix(uri, "http://"):
+		if path, ok := ipfsGatewayPath(uri); ok {
+			data, err = f.getIPFS(ctx, path)
+		} else if f.allowDirectFetch == nil || f.allowDirectFetch() {
+			data, err = f.get(ctx, uri)
+		} else {
+			err = ErrDirectFetchNotAllowed
+		}
+	default:
+		// some contracts return the JSON document itself
+		if strings.HasPrefix(uri, "{") {
+			data = []byte(uri)
+		} else {
+			err = ErrUnsupportedURIScheme
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := new(Metadata)
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// getIPFS loads the IPFS path, gateways are tried in order, the content is the same on all of them
+func (f *MetadataFetcher) getIPFS(ctx context.Context, path string) (data []byte, err error) {
+	for _, gateway := range f.ipfsGateways {
+		data, err = f.get(ctx, resolveURI(ipfsScheme+path, gateway))
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	return data, err
+}
+
+func (f *MetadataFetcher) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("accept", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unsuccessful request: %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+}
+
+// decodeDataURI decodes RFC 2397 URIs, e.g. "data:application/json;base64,eyJuYW1lIjoiIn0="
+func decodeDataURI(uri string) ([]byte, error) {
+	header, payload, found := strings.Cut(strings.TrimPrefix(uri, dataScheme), ",")
+	if !found {
+		return nil, ErrMalformedDataURI
+	}
+
+	if strings.HasSuffix(header, ";base64") {
+		data, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			data, err = base64.RawStdEncoding.DecodeString(payload)
+		}
+		if err != nil {
+			return nil, ErrMalformedDataURI
+		}
+		return data, nil
+	}
+
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		// not every contract escapes the JSON document
+		return []byte(payload), nil
+	}
+	return []byte(data), nil
+}
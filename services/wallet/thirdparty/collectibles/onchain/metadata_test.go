@@ -0,0 +1,123 @@
+package onchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+)
+
+func tokenID(id int64) *bigint.BigInt {
+	return &bigint.BigInt{Int: big.NewInt(id)}
+}
+
+func TestFetchMetadata(t *testing.T) {
+	failingGateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer failingGateway.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/QmHash/1.json":
+			fmt.Fprint(w, `{"name":"IPFS","image":"ipfs://ipfs/QmImage"}`)
+		case "/token/1":
+			fmt.Fprint(w, `{"name":"HTTP","image_url":"https://example.com/1.png","traits":[{"trait_type":"Color","value":"red"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	allowDirectFetch := false
+	fetcher := NewMetadataFetcher(server.Client(), []string{failingGateway.URL + "/ipfs/", server.URL + "/ipfs/"}, func() bool {
+		return allowDirectFetch
+	})
+	ctx := context.Background()
+
+	metadata, err := fetcher.Fetch(ctx, "ipfs://QmHash/1.json")
+	require.NoError(t, err)
+	require.Equal(t, "IPFS", metadata.Name)
+	require.Equal(t, "https://gateway/QmImage", resolveURI(metadata.Image, "https://gateway/"))
+
+	// the URLs of other IPFS gateways are loaded through the configured ones
+	metadata, err = fetcher.Fetch(ctx, "https://gateway.example.com/ipfs/QmHash/1.json")
+	require.NoError(t, err)
+	require.Equal(t, "IPFS", metadata.Name)
+
+	metadata, err = fetcher.Fetch(ctx, "https://QmHash.ipfs.dweb.example.com/1.json")
+	require.NoError(t, err)
+	require.Equal(t, "IPFS", metadata.Name)
+
+	// other hosts are contacted only when allowed
+	_, err = fetcher.Fetch(ctx, server.URL+"/token/1")
+	require.ErrorIs(t, err, ErrDirectFetchNotAllowed)
+
+	allowDirectFetch = true
+	metadata, err = fetcher.Fetch(ctx, server.URL+"/token/1")
+	require.NoError(t, err)
+	require.Equal(t, "HTTP", metadata.Name)
+	require.Equal(t, []Attribute{{TraitType: "Color", Value: "red"}}, metadata.Attributes)
+
+	// {"name":"Base64"}
+	metadata, err = fetcher.Fetch(ctx, "data:application/json;base64,eyJuYW1lIjoiQmFzZTY0In0=")
+	require.NoError(t, err)
+	require.Equal(t, "Base64", metadata.Name)
+
+	metadata, err = fetcher.Fetch(ctx, "data:application/json;utf8,%7B%22name%22%3A%22Escaped%22%7D")
+	require.NoError(t, err)
+	require.Equal(t, "Escaped", metadata.Name)
+
+	metadata, err = fetcher.Fetch(ctx, `data:application/json,{"name":"Plain 100%"}`)
+	require.NoError(t, err)
+	require.Equal(t, "Plain 100%", metadata.Name)
+
+	_, err = fetcher.Fetch(ctx, server.URL+"/missing")
+	require.Error(t, err)
+
+	_, err = fetcher.Fetch(ctx, "")
+	require.ErrorIs(t, err, ErrEmptyURI)
+
+	_, err = fetcher.Fetch(ctx, "ftp://example.com/1.json")
+	require.ErrorIs(t, err, ErrUnsupportedURIScheme)
+
+	_, err = fetcher.Fetch(ctx, "data:application/json;base64")
+	require.ErrorIs(t, err, ErrMalformedDataURI)
+}
+
+func TestMetadataFetcherDefaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"HTTP"}`)
+	}))
+	defer server.Close()
+
+	fetcher := NewMetadataFetcher(server.Client(), nil, nil)
+	require.Equal(t, DefaultIPFSGateways, fetcher.ipfsGateways)
+	require.Equal(t, DefaultIPFSGateways[0]+"QmHash", fetcher.ResolveURI("ipfs://QmHash"))
+
+	// the metadata is loaded from its host by default
+	metadata, err := fetcher.Fetch(context.Background(), server.URL+"/1.json")
+	require.NoError(t, err)
+	require.Equal(t, "HTTP", metadata.Name)
+}
+
+func TestResolveURI(t *testing.T) {
+	gateway := "https://ipfs.io/ipfs/"
+	require.Equal(t, "https://ipfs.io/ipfs/QmHash/1.png", resolveURI("ipfs://QmHash/1.png", gateway))
+	require.Equal(t, "https://ipfs.io/ipfs/QmHash", resolveURI("ipfs://ipfs/QmHash", gateway))
+	require.Equal(t, "https://arweave.net/TxID", resolveURI("ar://TxID", gateway))
+	require.Equal(t, "https://example.com/1.png", resolveURI("https://example.com/1.png", gateway))
+	require.Equal(t, "", resolveURI("", gateway))
+}
+
+func TestERC1155URI(t *testing.T) {
+	require.Equal(t, "https://example.com/00000000000000000000000000000000000000000000000000000000000004d2.json",
+		ERC1155URI("https://example.com/{id}.json", big.NewInt(1234)))
+	require.Equal(t, "https://example.com/1234", ERC1155URI("https://example.com/1234", big.NewInt(1234)))
+}
@@ -0,0 +1,101 @@
+package onchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const OnchainID = "onchain"
+
+// Minimal ABI of the ERC721 and ERC1155 methods used to fetch the metadata
+const collectiblesABI = `[
+	{"inputs":[{"name":"tokenId","type":"uint256"}],"name":"tokenURI","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"id","type":"uint256"}],"name":"uri","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"name","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"}
+]`
+
+// Attribute follows the OpenSea metadata standard, values can be strings or numbers
+type Attribute struct {
+	TraitType   string      `json:"trait_type"`
+	Value       interface{} `json:"value"`
+	DisplayType string      `json:"display_type"`
+	MaxValue    interface{} `json:"max_value"`
+}
+
+// Metadata is the JSON document referenced by the ERC721 `tokenURI` and the ERC1155 `uri`
+type Metadata struct {
+	Name            string      `json:"name"`
+	Description     string      `json:"description"`
+	Image           string      `json:"image"`
+	ImageURL        string      `json:"image_url"`
+	AnimationURL    string      `json:"animation_url"`
+	ExternalURL     string      `json:"external_url"`
+	BackgroundColor string      `json:"background_color"`
+	Attributes      []Attribute `json:"attributes"`
+}
+
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	type metadataAlias Metadata
+	aux := struct {
+		*metadataAlias
+		// some collections use a different name or format for the attributes
+		Attributes json.RawMessage `json:"attributes"`
+		Traits     json.RawMessage `json:"traits"`
+	}{
+		metadataAlias: (*metadataAlias)(m),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	for _, raw := range []json.RawMessage{aux.Attributes, aux.Traits} {
+		if len(raw) == 0 {
+			continue
+		}
+		var attributes []Attribute
+		if err := json.Unmarshal(raw, &attributes); err == nil {
+			m.Attributes = attributes
+			break
+		}
+	}
+
+	return nil
+}
+
+func attributeValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%f", v), "0"), ".")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (m *Metadata) toCollectibleData(data *thirdparty.CollectibleData, gateway string) {
+	data.Name = m.Name
+	data.Description = m.Description
+	data.Permalink = m.ExternalURL
+	data.ImageURL = resolveURI(m.Image, gateway)
+	if data.ImageURL == "" {
+		data.ImageURL = resolveURI(m.ImageURL, gateway)
+	}
+	data.AnimationURL = resolveURI(m.AnimationURL, gateway)
+	data.BackgroundColor = m.BackgroundColor
+
+	data.Traits = make([]thirdparty.CollectibleTrait, 0, len(m.Attributes))
+	for _, attribute := range m.Attributes {
+		data.Traits = append(data.Traits, thirdparty.CollectibleTrait{
+			TraitType:   attribute.TraitType,
+			Value:       attributeValueToString(attribute.Value),
+			DisplayType: attribute.DisplayType,
+			MaxValue:    attributeValueToString(attribute.MaxValue),
+		})
+	}
+}
@@ -58,10 +58,12 @@ var coinGeckoMapping = map[string]string{
 }
 
 type Client struct {
-	httpClient       *thirdparty.HTTPClient
-	tokens           map[string][]GeckoToken
-	baseURL          string
-	fetchTokensMutex sync.Mutex
+	httpClient          *thirdparty.HTTPClient
+	tokens              map[string][]GeckoToken
+	platforms           map[uint64]string // chain ID to asset platform ID
+	baseURL             string
+	fetchTokensMutex    sync.Mutex
+	fetchPlatformsMutex sync.Mutex
 }
 
 func NewClient() *Client {
@@ -83,6 +85,7 @@ func NewClient() *Client {
 	return &Client{
 		httpClient: httpClient,
 		tokens:     make(map[string][]GeckoToken),
+		platforms:  make(map[uint64]string),
 		baseURL:    baseURL,
 	}
 }
@@ -141,6 +144,28 @@ func (c *Client) getTokens() (map[string][]GeckoToken, error) {
 	return c.tokens, nil
 }
 
+func (c *Client) getPlatforms() (map[uint64]string, error) {
+	c.fetchPlatformsMutex.Lock()
+	defer c.fetchPlatformsMutex.Unlock()
+
+	if len(c.platforms) > 0 {
+		return c.platforms, nil
+	}
+
+	chains, err := c.FetchPlatforms(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chain := range chains {
+		// platforms not backed by an EVM chain have no chain identifier
+		if chain.ChainID != 0 {
+			c.platforms[chain.ChainID] = chain.ID
+		}
+	}
+	return c.platforms, nil
+}
+
 func (c *Client) mapSymbolsToIds(symbols []string) (mappedSymbols map[string]string, unmappedSymbols []string, err error) {
 	tokens, err := c.getTokens()
 	if err != nil {
@@ -189,6 +214,50 @@ func (c *Client) FetchPrices(symbols []string, currencies []string) (map[string]
 	return result, nil
 }
 
+func (c *Client) FetchPricesByContract(contracts []thirdparty.ContractID, currencies []string) (map[thirdparty.ContractID]map[string]float64, error) {
+	platforms, err := c.getPlatforms()
+	if err != nil {
+		return nil, err
+	}
+
+	contractsPerChain := make(map[uint64][]thirdparty.ContractID)
+	for _, contract := range contracts {
+		chainID := uint64(contract.ChainID)
+		contractsPerChain[chainID] = append(contractsPerChain[chainID], contract)
+	}
+
+	result := make(map[thirdparty.ContractID]map[string]float64)
+	for chainID, chainContracts := range contractsPerChain {
+		platformID, ok := platforms[chainID]
+		if !ok {
+			continue
+		}
+
+		addresses := make([]string, 0, len(chainContracts))
+		for _, contract := range chainContracts {
+			addresses = append(addresses, strings.ToLower(contract.Address.Hex()))
+		}
+
+		tokenPrices, err := c.FetchSimpleTokenPrice(context.Background(), platformID, addresses, currencies)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, contract := range chainContracts {
+			prices, ok := tokenPrices[strings.ToLower(contract.Address.Hex())]
+			if !ok {
+				continue
+			}
+			result[contract] = map[string]float64{}
+			for _, currency := range currencies {
+				result[contract][currency] = prices[strings.ToLower(currency)]
+			}
+		}
+	}
+
+	return result, nil
+}
+
 func (c *Client) FetchTokenDetails(symbols []string) (map[string]thirdparty.TokenDetails, error) {
 	tokens, err := c.getTokens()
 	if err != nil {
@@ -265,13 +334,40 @@ func (c *Client) FetchHistoricalDailyPrices(symbol string, currency string, limi
 		return nil, err
 	}
 
-	result := make([]thirdparty.HistoricalPrice, 0)
+	return toHistoricalPrices(container), nil
+}
+
+func (c *Client) FetchHistoricalDailyPricesByContract(contract thirdparty.ContractID, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	platforms, err := c.getPlatforms()
+	if err != nil {
+		return nil, err
+	}
+
+	platformID, ok := platforms[uint64(contract.ChainID)]
+	if !ok {
+		return nil, fmt.Errorf("platform not found for chain %d", contract.ChainID)
+	}
+
+	days := limit
+	if allData {
+		days = 0
+	}
+	container, err := c.FetchContractHistoryMarketData(context.Background(), platformID, strings.ToLower(contract.Address.Hex()), currency, days)
+	if err != nil {
+		return nil, err
+	}
+
+	return toHistoricalPrices(container), nil
+}
+
+// toHistoricalPrices converts the prices of the market chart, timestamped in milliseconds
+func toHistoricalPrices(container HistoricalPriceContainer) []thirdparty.HistoricalPrice {
+	result := make([]thirdparty.HistoricalPrice, 0, len(container.Prices))
 	for _, price := range container.Prices {
 		result = append(result, thirdparty.HistoricalPrice{
-			Timestamp: int64(price[0]),
+			Timestamp: int64(price[0]) / 1000,
 			Value:     price[1],
 		})
 	}
-
-	return result, nil
+	return result
 }
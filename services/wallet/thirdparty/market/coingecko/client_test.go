@@ -5,10 +5,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
 
@@ -209,6 +212,40 @@ func TestFetchPrices(t *testing.T) {
 	require.Len(t, prices, len(symbols))
 }
 
+func TestFetchPricesByContract(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/asset_platforms", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(responseAssetPlatformsData)
+	})
+
+	requestedPlatforms := make([]string, 0)
+	mux.HandleFunc("/simple/token_price/", func(w http.ResponseWriter, r *http.Request) {
+		requestedPlatforms = append(requestedPlatforms, strings.TrimPrefix(r.URL.Path, "/simple/token_price/"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(responseSimpleTokenPriceData)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	geckoClient := &Client{
+		httpClient: thirdparty.NewHTTPClient(),
+		platforms:  make(map[uint64]string),
+		baseURL:    srv.URL,
+	}
+
+	snt := thirdparty.ContractID{ChainID: 1, Address: common.HexToAddress("0x744d70FDBE2Ba4CF95131626614a1763DF805B9E")}
+	unknown := thirdparty.ContractID{ChainID: 1, Address: common.HexToAddress("0x01")}
+	unsupportedChain := thirdparty.ContractID{ChainID: 123456789, Address: common.HexToAddress("0x02")}
+
+	prices, err := geckoClient.FetchPricesByContract([]thirdparty.ContractID{snt, unknown, unsupportedChain}, []string{"USD"})
+	require.NoError(t, err)
+	require.Equal(t, map[thirdparty.ContractID]map[string]float64{snt: {"USD": 0.02597611}}, prices)
+	require.Equal(t, []string{"ethereum"}, requestedPlatforms)
+}
+
 func TestFetchMarketValues(t *testing.T) {
 	mux := http.NewServeMux()
 
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	netUrl "net/url"
+	"strconv"
 )
 
 const coinMarketChartURL = "%s/coins/%s/market_chart"
+const contractMarketChartURL = "%s/coins/%s/contract/%s/market_chart"
 
 type HistoricalPriceContainer struct {
 	Prices [][]float64 `json:"prices"`
@@ -19,6 +21,25 @@ func (c *Client) FetchHistoryMarketData(ctx context.Context, id string, currency
 	params.Add("vs_currency", currency)
 	params.Add("days", "30")
 	url := fmt.Sprintf(coinMarketChartURL, c.baseURL, id)
+	return c.fetchMarketChart(ctx, url, params)
+}
+
+// FetchContractHistoryMarketData fetches the daily prices of the token deployed at contractAddress on the platform,
+// all the available days are fetched if days is zero
+func (c *Client) FetchContractHistoryMarketData(ctx context.Context, platformID string, contractAddress string, currency string, days int) (HistoricalPriceContainer, error) {
+	params := netUrl.Values{}
+	params.Add("vs_currency", currency)
+	if days > 0 {
+		params.Add("days", strconv.Itoa(days))
+	} else {
+		params.Add("days", "max")
+	}
+	params.Add("interval", "daily")
+	url := fmt.Sprintf(contractMarketChartURL, c.baseURL, platformID, contractAddress)
+	return c.fetchMarketChart(ctx, url, params)
+}
+
+func (c *Client) fetchMarketChart(ctx context.Context, url string, params netUrl.Values) (HistoricalPriceContainer, error) {
 	response, err := c.httpClient.DoGetRequest(ctx, url, params)
 	if err != nil {
 		return HistoricalPriceContainer{}, err
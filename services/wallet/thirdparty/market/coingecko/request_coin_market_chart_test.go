@@ -2,11 +2,16 @@ package coingecko
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
 
@@ -52,3 +57,34 @@ func TestErrorWhenFetchingHistoryMarketData(t *testing.T) {
 	require.Error(t, err)
 	require.Len(t, received.Prices, 0)
 }
+
+func TestFetchContractHistoryMarketData(t *testing.T) {
+	var requestURL *url.URL
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURL = r.URL
+		_, _ = w.Write(responseCoinMarketChartData)
+	}))
+	defer srv.Close()
+
+	geckoClient := &Client{
+		httpClient: thirdparty.NewHTTPClient(),
+		baseURL:    srv.URL,
+		platforms:  map[uint64]string{1: "ethereum"},
+	}
+
+	contract := thirdparty.ContractID{ChainID: 1, Address: common.HexToAddress("0x744d70fdbe2ba4cf95131626614a1763df805b9e")}
+	prices, err := geckoClient.FetchHistoricalDailyPricesByContract(contract, "usd", 30, false, 1)
+	require.NoError(t, err)
+	require.Equal(t, "/coins/ethereum/contract/0x744d70fdbe2ba4cf95131626614a1763df805b9e/market_chart", requestURL.Path)
+	require.Equal(t, "30", requestURL.Query().Get("days"))
+	require.Len(t, prices, 10)
+	// the timestamps of the chart are in milliseconds
+	require.Equal(t, int64(1737889461), prices[0].Timestamp)
+
+	_, err = geckoClient.FetchHistoricalDailyPricesByContract(contract, "usd", 1, true, 30)
+	require.NoError(t, err)
+	require.Equal(t, "max", requestURL.Query().Get("days"))
+
+	_, err = geckoClient.FetchHistoricalDailyPricesByContract(thirdparty.ContractID{ChainID: 10, Address: contract.Address}, "usd", 30, false, 1)
+	require.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	netUrl "net/url"
+	"strings"
+)
+
+const simpleTokenPriceURL = "%s/simple/token_price/%s"
+
+// maxContractAddressesPerRequest keeps the request URL within the limits accepted by the API
+const maxContractAddressesPerRequest = 50
+
+// ContractPriceMap represents a map of lowercase contract addresses with price per currency for each.
+type ContractPriceMap map[string]map[string]float64
+
+// FetchSimpleTokenPrice fetches the prices of the contracts deployed on the platform, the contracts are split in
+// several requests when needed
+func (c *Client) FetchSimpleTokenPrice(ctx context.Context, platformID string, contractAddresses []string, currencies []string) (ContractPriceMap, error) {
+	prices := make(ContractPriceMap)
+	for start := 0; start < len(contractAddresses); start += maxContractAddressesPerRequest {
+		end := start + maxContractAddressesPerRequest
+		if end > len(contractAddresses) {
+			end = len(contractAddresses)
+		}
+
+		params := netUrl.Values{}
+		params.Add("contract_addresses", strings.Join(contractAddresses[start:end], ","))
+		params.Add("vs_currencies", strings.Join(currencies, ","))
+		url := fmt.Sprintf(simpleTokenPriceURL, c.baseURL, platformID)
+		response, err := c.httpClient.DoGetRequest(ctx, url, params)
+		if err != nil {
+			return nil, err
+		}
+
+		chunkPrices, err := handleFetchSimpleTokenPriceResponse(response)
+		if err != nil {
+			return nil, err
+		}
+		for address, addressPrices := range chunkPrices {
+			prices[address] = addressPrices
+		}
+	}
+
+	return prices, nil
+}
+
+func handleFetchSimpleTokenPriceResponse(response []byte) (ContractPriceMap, error) {
+	prices := make(ContractPriceMap)
+	err := json.Unmarshal(response, &prices)
+	if err != nil {
+		return nil, fmt.Errorf("%s - %s", err, string(response))
+	}
+	return prices, nil
+}
@@ -0,0 +1,85 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+func TestFetchingSimpleTokenPriceData(t *testing.T) {
+	expectedData := ContractPriceMap{
+		"0x744d70fdbe2ba4cf95131626614a1763df805b9e": {
+			"usd": 0.02597611,
+			"eur": 0.02474128,
+		},
+	}
+
+	srv, stop := setupTest(t, responseSimpleTokenPriceData)
+	defer stop()
+
+	geckoClient := &Client{
+		httpClient: thirdparty.NewHTTPClient(),
+		baseURL:    srv.URL,
+	}
+
+	received, err := geckoClient.FetchSimpleTokenPrice(context.Background(), "ethereum", []string{"0x744d70fdbe2ba4cf95131626614a1763df805b9e"}, []string{"usd", "eur"})
+	require.NoError(t, err)
+	require.True(t, reflect.DeepEqual(expectedData, received))
+}
+
+func TestErrorWhenFetchingSimpleTokenPriceData(t *testing.T) {
+	srv, stop := setupTest(t, responseError)
+	defer stop()
+
+	geckoClient := &Client{
+		httpClient: thirdparty.NewHTTPClient(),
+		baseURL:    srv.URL,
+	}
+
+	received, err := geckoClient.FetchSimpleTokenPrice(context.Background(), "ethereum", []string{"0x744d70fdbe2ba4cf95131626614a1763df805b9e"}, []string{"usd", "eur"})
+	require.Error(t, err)
+	require.Len(t, received, 0)
+}
+
+func TestFetchingSimpleTokenPriceDataInChunks(t *testing.T) {
+	requestedAddresses := [][]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addresses := strings.Split(r.URL.Query().Get("contract_addresses"), ",")
+		requestedAddresses = append(requestedAddresses, addresses)
+
+		prices := ContractPriceMap{}
+		for _, address := range addresses {
+			prices[address] = map[string]float64{"usd": 1}
+		}
+		response, err := json.Marshal(prices)
+		require.NoError(t, err)
+		_, _ = w.Write(response)
+	}))
+	defer srv.Close()
+
+	geckoClient := &Client{
+		httpClient: thirdparty.NewHTTPClient(),
+		baseURL:    srv.URL,
+	}
+
+	addresses := make([]string, 0, maxContractAddressesPerRequest+1)
+	for i := 0; i <= maxContractAddressesPerRequest; i++ {
+		addresses = append(addresses, fmt.Sprintf("0x%040x", i))
+	}
+
+	received, err := geckoClient.FetchSimpleTokenPrice(context.Background(), "ethereum", addresses, []string{"usd"})
+	require.NoError(t, err)
+	require.Len(t, received, len(addresses))
+	require.Len(t, requestedAddresses, 2)
+	require.Len(t, requestedAddresses[0], maxContractAddressesPerRequest)
+	require.Equal(t, []string{addresses[maxContractAddressesPerRequest]}, requestedAddresses[1])
+}
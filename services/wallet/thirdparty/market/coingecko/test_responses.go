@@ -381,3 +381,10 @@ var responseSimplePriceData = []byte(`{
     "eur": 0.02474128
   }
 }`)
+
+var responseSimpleTokenPriceData = []byte(`{
+  "0x744d70fdbe2ba4cf95131626614a1763df805b9e": {
+    "usd": 0.02597611,
+    "eur": 0.02474128
+  }
+}`)
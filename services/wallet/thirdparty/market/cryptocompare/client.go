@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/pkg/security"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/thirdparty/utils"
 )
@@ -21,6 +25,10 @@ const baseURL = "https://min-api.cryptocompare.com"
 // to suppress the error from the API for unknown symbols
 const maxFsymsLength = 300 - len("ETH")
 
+// contractsChainID is the chain of the smart contract addresses in the coin list, only the Ethereum contract of the
+// tokens is listed
+const contractsChainID = walletCommon.EthereumMainnet
+
 type HistoricalPricesContainer struct {
 	Aggregated     bool                         `json:"Aggregated"`
 	TimeFrom       int64                        `json:"TimeFrom"`
@@ -52,6 +60,9 @@ type Client struct {
 	httpClient *thirdparty.HTTPClient
 	baseURL    string
 	creds      *thirdparty.BasicCreds
+
+	contractSymbols      map[common.Address]string
+	contractSymbolsMutex sync.Mutex
 }
 
 func NewClient() *Client {
@@ -144,7 +155,7 @@ func (c *Client) FetchPrices(symbols []string, currencies []string) (map[string]
 	return result, nil
 }
 
-func (c *Client) FetchTokenDetails(symbols []string) (map[string]thirdparty.TokenDetails, error) {
+func (c *Client) fetchCoinList() (map[string]thirdparty.TokenDetails, error) {
 	url := c.buildURL("data/all/coinlist")
 	response, err := c.httpClient.DoGetRequestWithCredentials(context.Background(), url, nil, c.creds)
 	if err != nil {
@@ -157,15 +168,94 @@ func (c *Client) FetchTokenDetails(symbols []string) (map[string]thirdparty.Toke
 		return nil, err
 	}
 
+	return container.Data, nil
+}
+
+func (c *Client) FetchTokenDetails(symbols []string) (map[string]thirdparty.TokenDetails, error) {
+	coinList, err := c.fetchCoinList()
+	if err != nil {
+		return nil, err
+	}
+
 	tokenDetails := make(map[string]thirdparty.TokenDetails)
 
 	for _, symbol := range symbols {
-		tokenDetails[symbol] = container.Data[utils.GetRealSymbol(symbol)]
+		tokenDetails[symbol] = coinList[utils.GetRealSymbol(symbol)]
 	}
 
 	return tokenDetails, nil
 }
 
+// getContractSymbols returns the symbols of the tokens by their Ethereum contract address, the coin list is fetched once
+func (c *Client) getContractSymbols() (map[common.Address]string, error) {
+	c.contractSymbolsMutex.Lock()
+	defer c.contractSymbolsMutex.Unlock()
+
+	if c.contractSymbols != nil {
+		return c.contractSymbols, nil
+	}
+
+	coinList, err := c.fetchCoinList()
+	if err != nil {
+		return nil, err
+	}
+
+	c.contractSymbols = make(map[common.Address]string)
+	for symbol, details := range coinList {
+		if common.IsHexAddress(details.SmartContractAddress) {
+			c.contractSymbols[common.HexToAddress(details.SmartContractAddress)] = symbol
+		}
+	}
+	return c.contractSymbols, nil
+}
+
+// FetchPricesByContract maps the contracts to the symbols known by cryptocompare, only the contracts deployed on
+// Ethereum can be mapped. It fails with ErrEndpointNotSupported when none of the contracts can be mapped.
+func (c *Client) FetchPricesByContract(contracts []thirdparty.ContractID, currencies []string) (map[thirdparty.ContractID]map[string]float64, error) {
+	result := make(map[thirdparty.ContractID]map[string]float64)
+
+	mappableContracts := make([]thirdparty.ContractID, 0, len(contracts))
+	for _, contract := range contracts {
+		if uint64(contract.ChainID) == contractsChainID {
+			mappableContracts = append(mappableContracts, contract)
+		}
+	}
+	if len(mappableContracts) == 0 {
+		return nil, thirdparty.ErrEndpointNotSupported
+	}
+
+	contractSymbols, err := c.getContractSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	symbolContracts := make(map[string][]thirdparty.ContractID)
+	for _, contract := range mappableContracts {
+		if symbol, ok := contractSymbols[contract.Address]; ok {
+			symbolContracts[symbol] = append(symbolContracts[symbol], contract)
+		}
+	}
+	if len(symbolContracts) == 0 {
+		return nil, thirdparty.ErrEndpointNotSupported
+	}
+
+	symbols := make([]string, 0, len(symbolContracts))
+	for symbol := range symbolContracts {
+		symbols = append(symbols, symbol)
+	}
+	prices, err := c.FetchPrices(symbols, currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	for symbol, contracts := range symbolContracts {
+		for _, contract := range contracts {
+			result[contract] = prices[symbol]
+		}
+	}
+	return result, nil
+}
+
 func (c *Client) FetchTokenMarketValues(symbols []string, currency string) (map[string]thirdparty.TokenMarketValues, error) {
 	chunkSymbolParams := utils.ChunkSymbolsParams{
 		MaxCharsPerChunk:    maxFsymsLength,
@@ -265,6 +355,25 @@ func (c *Client) FetchHistoricalDailyPrices(symbol string, currency string, limi
 	return item, nil
 }
 
+// FetchHistoricalDailyPricesByContract maps the contract to the symbol known by cryptocompare, only the contracts
+// deployed on Ethereum can be mapped
+func (c *Client) FetchHistoricalDailyPricesByContract(contract thirdparty.ContractID, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	if uint64(contract.ChainID) != contractsChainID {
+		return nil, fmt.Errorf("contracts on chain %d can't be mapped", contract.ChainID)
+	}
+
+	contractSymbols, err := c.getContractSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	symbol, ok := contractSymbols[contract.Address]
+	if !ok {
+		return nil, fmt.Errorf("symbol not found for contract %s", contract.Address.Hex())
+	}
+	return c.FetchHistoricalDailyPrices(symbol, currency, limit, allData, aggregate)
+}
+
 func (c *Client) ID() string {
 	return c.id
 }
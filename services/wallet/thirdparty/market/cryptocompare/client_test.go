@@ -1,10 +1,16 @@
 package cryptocompare
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
 
 func TestIDs(t *testing.T) {
@@ -79,3 +85,33 @@ func TestBuildURL(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchPricesByContract(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/data/all/coinlist", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"Data":{"SNT":{"Symbol":"SNT","SmartContractAddress":"0x744d70fdbe2ba4cf95131626614a1763df805b9e"},"BTC":{"Symbol":"BTC","SmartContractAddress":"N/A"}}}`))
+	})
+	mux.HandleFunc("/data/pricemulti", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"SNT":{"USD":0.025},"ETH":{"USD":2500}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := NewClientWithParams(Params{ID: baseID, URL: srv.URL})
+
+	snt := thirdparty.ContractID{ChainID: 1, Address: common.HexToAddress("0x744d70FDBE2Ba4CF95131626614a1763DF805B9E")}
+	sntOnOptimism := thirdparty.ContractID{ChainID: 10, Address: snt.Address}
+	unknown := thirdparty.ContractID{ChainID: 1, Address: common.HexToAddress("0x01")}
+
+	prices, err := client.FetchPricesByContract([]thirdparty.ContractID{snt, sntOnOptimism, unknown}, []string{"USD"})
+	require.NoError(t, err)
+	require.Equal(t, map[thirdparty.ContractID]map[string]float64{snt: {"USD": 0.025}}, prices)
+
+	// none of the contracts can be mapped, the next providers are asked
+	_, err = client.FetchPricesByContract([]thirdparty.ContractID{sntOnOptimism}, []string{"USD"})
+	require.ErrorIs(t, err, thirdparty.ErrEndpointNotSupported)
+	_, err = client.FetchPricesByContract([]thirdparty.ContractID{unknown}, []string{"USD"})
+	require.ErrorIs(t, err, thirdparty.ErrEndpointNotSupported)
+}
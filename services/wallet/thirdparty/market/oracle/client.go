@@ -0,0 +1,387 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/contracts/chainlink"
+	"github.com/status-im/status-go/contracts/ierc20"
+	uniswapv3 "github.com/status-im/status-go/contracts/uniswapV3"
+	"github.com/status-im/status-go/logutils"
+	"github.com/status-im/status-go/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const OracleID = "oracle"
+
+const (
+	requestTimeout = 30 * time.Second
+	// feedMaxAge is above the 24h heartbeat of the feeds updated the least often
+	feedMaxAge = 25 * time.Hour
+	// twapPeriod is the number of seconds the pool prices are averaged over, a price manipulated for a few blocks
+	// barely moves the average
+	twapPeriod = 30 * 60
+)
+
+var (
+	errStaleAnswer   = errors.New("stale feed answer")
+	errInvalidAnswer = errors.New("invalid feed answer")
+	errNoPool        = errors.New("no pool with liquidity")
+)
+
+// Client reads the prices from Chainlink aggregators and, for the tokens without a feed, from the time weighted
+// average price of their Uniswap V3 pools
+type Client struct {
+	getBackend    func(chainID walletCommon.ChainID) (bind.ContractCaller, error)
+	decimals      map[common.Address]uint8
+	decimalsMutex sync.Mutex
+}
+
+// NewClient creates a provider reading the prices from the chain through the RPC client, it doesn't need any
+// third-party API and is meant to be used as the last fallback
+func NewClient(rpcClient rpc.ClientInterface) *Client {
+	return newClient(func(chainID walletCommon.ChainID) (bind.ContractCaller, error) {
+		return rpcClient.EthClient(uint64(chainID))
+	})
+}
+
+func newClient(getBackend func(chainID walletCommon.ChainID) (bind.ContractCaller, error)) *Client {
+	return &Client{
+		getBackend: getBackend,
+		decimals:   make(map[common.Address]uint8),
+	}
+}
+
+func (c *Client) ID() string {
+	return OracleID
+}
+
+// session reads the prices of one request, each feed is read at most once
+type session struct {
+	client    *Client
+	ctx       context.Context
+	backend   bind.ContractCaller
+	usdPrices map[string]float64
+}
+
+func (c *Client) newSession(ctx context.Context) (*session, error) {
+	backend, err := c.getBackend(pricesChainID)
+	if err != nil {
+		return nil, err
+	}
+	return &session{
+		client:    c,
+		ctx:       ctx,
+		backend:   backend,
+		usdPrices: make(map[string]float64),
+	}, nil
+}
+
+func (c *Client) FetchPrices(symbols []string, currencies []string) (map[string]map[string]float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	s, err := c.newSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	found := false
+	result := make(map[string]map[string]float64)
+	for _, symbol := range symbols {
+		result[symbol] = make(map[string]float64)
+		for _, currency := range currencies {
+			result[symbol][currency] = 0
+		}
+		if _, ok := usdFeeds[strings.ToUpper(symbol)]; !ok {
+			continue
+		}
+
+		usdPrice, err := s.usdPrice(strings.ToUpper(symbol))
+		if err != nil {
+			logutils.ZapLogger().Debug("cannot read price feed", zap.String("symbol", symbol), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		found = true
+		s.setPrices(result[symbol], usdPrice, currencies)
+	}
+
+	if !found && lastErr != nil {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+func (c *Client) FetchPricesByContract(contracts []thirdparty.ContractID, currencies []string) (map[thirdparty.ContractID]map[string]float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	s, err := c.newSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	result := make(map[thirdparty.ContractID]map[string]float64)
+	for _, contract := range contracts {
+		if contract.ChainID != pricesChainID {
+			continue
+		}
+
+		usdPrice, err := s.contractUSDPrice(contract.Address)
+		if errors.Is(err, errNoPool) {
+			continue
+		}
+		if err != nil {
+			logutils.ZapLogger().Debug("cannot read contract price", zap.Stringer("contract", contract.Address), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		result[contract] = make(map[string]float64)
+		s.setPrices(result[contract], usdPrice, currencies)
+	}
+
+	if len(result) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+func (c *Client) FetchHistoricalDailyPrices(symbol string, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (c *Client) FetchHistoricalDailyPricesByContract(contract thirdparty.ContractID, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (c *Client) FetchHistoricalHourlyPrices(symbol string, currency string, limit int, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (c *Client) FetchTokenMarketValues(symbols []string, currency string) (map[string]thirdparty.TokenMarketValues, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (c *Client) FetchTokenDetails(symbols []string) (map[string]thirdparty.TokenDetails, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+// setPrices converts the USD price to the currencies, the currencies without a feed are left at zero
+func (s *session) setPrices(prices map[string]float64, usdPrice float64, currencies []string) {
+	for _, currency := range currencies {
+		prices[currency] = 0
+		if strings.EqualFold(currency, "USD") {
+			prices[currency] = usdPrice
+			continue
+		}
+		if _, ok := usdFeeds[strings.ToUpper(currency)]; !ok {
+			continue
+		}
+		rate, err := s.usdPrice(strings.ToUpper(currency))
+		if err != nil {
+			logutils.ZapLogger().Debug("cannot read currency feed", zap.String("currency", currency), zap.Error(err))
+			continue
+		}
+		prices[currency] = usdPrice / rate
+	}
+}
+
+func (s *session) usdPrice(symbol string) (float64, error) {
+	if price, ok := s.usdPrices[symbol]; ok {
+		return price, nil
+	}
+	price, err := s.readFeed(usdFeeds[symbol])
+	if err != nil {
+		return 0, err
+	}
+	s.usdPrices[symbol] = price
+	return price, nil
+}
+
+func (s *session) readFeed(feed common.Address) (float64, error) {
+	aggregator, err := chainlink.NewAggregatorV3Caller(feed, s.backend)
+	if err != nil {
+		return 0, err
+	}
+	opts := &bind.CallOpts{Context: s.ctx}
+
+	decimals, err := s.client.cachedDecimals(feed, func() (uint8, error) {
+		return aggregator.Decimals(opts)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	round, err := aggregator.LatestRoundData(opts)
+	if err != nil {
+		return 0, err
+	}
+	if round.Answer == nil || round.Answer.Sign() <= 0 {
+		return 0, errInvalidAnswer
+	}
+	if time.Since(time.Unix(round.UpdatedAt.Int64(), 0)) > feedMaxAge {
+		return 0, errStaleAnswer
+	}
+
+	price, _ := new(big.Float).Quo(new(big.Float).SetInt(round.Answer), big.NewFloat(math.Pow10(int(decimals)))).Float64()
+	return price, nil
+}
+
+func (s *session) contractUSDPrice(token common.Address) (float64, error) {
+	if symbol, ok := feedSymbols[token]; ok {
+		return s.usdPrice(symbol)
+	}
+
+	// a quote token failing, e.g. a pool without enough observations for the period, falls back to the next one
+	var lastErr error
+	for _, quote := range twapQuotes {
+		pool, err := s.mostLiquidPool(token, quote.address)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if pool == nil {
+			continue
+		}
+
+		priceInQuote, err := s.twapPrice(pool, token, quote.address)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		quoteUSDPrice, err := s.usdPrice(quote.symbol)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return priceInQuote * quoteUSDPrice, nil
+	}
+	if lastErr != nil {
+		return 0, lastErr
+	}
+	return 0, errNoPool
+}
+
+// mostLiquidPool returns the pool of the tokens with the most in range liquidity among the fee tiers, nil if none
+// is deployed or has liquidity
+func (s *session) mostLiquidPool(token common.Address, quote common.Address) (*uniswapv3.Uniswapv3Caller, error) {
+	factory, err := uniswapv3.NewUniswapV3FactoryCaller(uniswapV3Factory, s.backend)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: s.ctx}
+
+	var best *uniswapv3.Uniswapv3Caller
+	bestLiquidity := big.NewInt(0)
+	for _, fee := range uniswapV3Fees {
+		address, err := factory.GetPool(opts, token, quote, big.NewInt(fee))
+		if err != nil {
+			return nil, err
+		}
+		if address == (common.Address{}) {
+			continue
+		}
+
+		pool, err := uniswapv3.NewUniswapv3Caller(address, s.backend)
+		if err != nil {
+			return nil, err
+		}
+		liquidity, err := pool.Liquidity(opts)
+		if err != nil {
+			return nil, err
+		}
+		if liquidity.Cmp(bestLiquidity) > 0 {
+			best = pool
+			bestLiquidity = liquidity
+		}
+	}
+	return best, nil
+}
+
+// twapPrice returns the price of the token in the quote token averaged over twapPeriod
+func (s *session) twapPrice(pool *uniswapv3.Uniswapv3Caller, token common.Address, quote common.Address) (float64, error) {
+	observations, err := pool.Observe(&bind.CallOpts{Context: s.ctx}, []uint32{twapPeriod, 0})
+	if err != nil {
+		return 0, err
+	}
+	if len(observations.TickCumulatives) != 2 {
+		return 0, errors.New("unexpected observations")
+	}
+
+	token0, token1 := sortTokens(token, quote)
+	decimals0, err := s.tokenDecimals(token0)
+	if err != nil {
+		return 0, err
+	}
+	decimals1, err := s.tokenDecimals(token1)
+	if err != nil {
+		return 0, err
+	}
+
+	price := tickToPrice(averageTick(observations.TickCumulatives[0], observations.TickCumulatives[1]), decimals0, decimals1)
+	if token == token0 {
+		return price, nil
+	}
+	return 1 / price, nil
+}
+
+func (s *session) tokenDecimals(token common.Address) (uint8, error) {
+	return s.client.cachedDecimals(token, func() (uint8, error) {
+		erc20, err := ierc20.NewIERC20Caller(token, s.backend)
+		if err != nil {
+			return 0, err
+		}
+		return erc20.Decimals(&bind.CallOpts{Context: s.ctx})
+	})
+}
+
+// cachedDecimals returns the decimals of the token or feed, they never change so they are read once
+func (c *Client) cachedDecimals(address common.Address, fetch func() (uint8, error)) (uint8, error) {
+	c.decimalsMutex.Lock()
+	decimals, ok := c.decimals[address]
+	c.decimalsMutex.Unlock()
+	if ok {
+		return decimals, nil
+	}
+
+	decimals, err := fetch()
+	if err != nil {
+		return 0, err
+	}
+
+	c.decimalsMutex.Lock()
+	c.decimals[address] = decimals
+	c.decimalsMutex.Unlock()
+	return decimals, nil
+}
+
+// averageTick returns the average tick between the two cumulatives twapPeriod apart, rounded towards negative
+// infinity like the Uniswap OracleLibrary
+func averageTick(tickCumulativeStart *big.Int, tickCumulativeEnd *big.Int) int64 {
+	delta := new(big.Int).Sub(tickCumulativeEnd, tickCumulativeStart)
+	period := big.NewInt(twapPeriod)
+	tick, remainder := new(big.Int).QuoRem(delta, period, new(big.Int))
+	if delta.Sign() < 0 && remainder.Sign() != 0 {
+		tick.Sub(tick, common.Big1)
+	}
+	return tick.Int64()
+}
+
+// tickToPrice returns the price of token0 in token1 at the tick, adjusted to the decimals of the tokens
+func tickToPrice(tick int64, decimals0 uint8, decimals1 uint8) float64 {
+	return math.Pow(1.0001, float64(tick)) * math.Pow10(int(decimals0)-int(decimals1))
+}
@@ -0,0 +1,201 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/contracts/chainlink"
+	"github.com/status-im/status-go/contracts/ierc20"
+	uniswapv3 "github.com/status-im/status-go/contracts/uniswapV3"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+var testToken = common.HexToAddress("0x0000000000000000000000000000000000000001")
+
+type testBackend struct {
+	abis []abi.ABI
+	// contract -> method -> returned values, missing contracts have no code and missing methods revert
+	methods map[common.Address]map[string][]interface{}
+	// quote token -> fee -> pool of testToken and the quote token returned by the factory
+	pools map[common.Address]map[int64]common.Address
+}
+
+func newTestBackend(t *testing.T) *testBackend {
+	abis := make([]abi.ABI, 0, 4)
+	for _, contractABI := range []string{chainlink.AggregatorV3MetaData.ABI, uniswapv3.Uniswapv3MetaData.ABI,
+		uniswapv3.UniswapV3FactoryMetaData.ABI, ierc20.IERC20ABI} {
+		parsed, err := abi.JSON(strings.NewReader(contractABI))
+		require.NoError(t, err)
+		abis = append(abis, parsed)
+	}
+	return &testBackend{abis: abis, methods: make(map[common.Address]map[string][]interface{})}
+}
+
+func (b *testBackend) set(contract common.Address, method string, values ...interface{}) {
+	if _, ok := b.methods[contract]; !ok {
+		b.methods[contract] = make(map[string][]interface{})
+	}
+	b.methods[contract][method] = values
+}
+
+func (b *testBackend) setFeed(feed common.Address, answer float64, updatedAt time.Time) {
+	b.set(feed, "decimals", uint8(8))
+	b.set(feed, "latestRoundData", big.NewInt(1), big.NewInt(int64(answer*1e8)), big.NewInt(updatedAt.Unix()),
+		big.NewInt(updatedAt.Unix()), big.NewInt(1))
+}
+
+func (b *testBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if _, ok := b.methods[contract]; !ok {
+		return nil, nil
+	}
+	return []byte{1}, nil
+}
+
+func (b *testBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	methods, ok := b.methods[*call.To]
+	if !ok {
+		return nil, nil
+	}
+	for _, contractABI := range b.abis {
+		method, err := contractABI.MethodById(call.Data[:4])
+		if err != nil {
+			continue
+		}
+		values, ok := methods[method.Name]
+		if !ok {
+			continue
+		}
+		if method.Name == "getPool" {
+			return b.getPool(method, call.Data[4:])
+		}
+		return method.Outputs.Pack(values...)
+	}
+	return nil, errors.New("execution reverted")
+}
+
+func (b *testBackend) getPool(method *abi.Method, data []byte) ([]byte, error) {
+	args, err := method.Inputs.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	pool := common.Address{}
+	tokenA, tokenB := args[0].(common.Address), args[1].(common.Address)
+	if tokenA == testToken {
+		pool = b.pools[tokenB][args[2].(*big.Int).Int64()]
+	} else if tokenB == testToken {
+		pool = b.pools[tokenA][args[2].(*big.Int).Int64()]
+	}
+	return method.Outputs.Pack(pool)
+}
+
+func newTestClient(backend *testBackend) *Client {
+	return newClient(func(chainID walletCommon.ChainID) (bind.ContractCaller, error) {
+		return backend, nil
+	})
+}
+
+func TestAverageTick(t *testing.T) {
+	require.Equal(t, int64(10), averageTick(big.NewInt(0), big.NewInt(10*twapPeriod)))
+	require.Equal(t, int64(-10), averageTick(big.NewInt(0), big.NewInt(-10*twapPeriod)))
+	require.Equal(t, int64(-11), averageTick(big.NewInt(0), big.NewInt(-10*twapPeriod-1)))
+}
+
+func TestFetchPrices(t *testing.T) {
+	backend := newTestBackend(t)
+	backend.setFeed(usdFeeds["ETH"], 2000, time.Now())
+	backend.setFeed(usdFeeds["EUR"], 1.25, time.Now())
+	client := newTestClient(backend)
+
+	prices, err := client.FetchPrices([]string{"ETH", "ANYTHING"}, []string{"USD", "EUR", "JPY"})
+	require.NoError(t, err)
+	require.InDelta(t, 2000, prices["ETH"]["USD"], 1e-9)
+	require.InDelta(t, 1600, prices["ETH"]["EUR"], 1e-9)
+	require.Equal(t, 0.0, prices["ETH"]["JPY"])
+	require.Equal(t, map[string]float64{"USD": 0, "EUR": 0, "JPY": 0}, prices["ANYTHING"])
+
+	// stale answers are not used
+	backend.setFeed(usdFeeds["ETH"], 2000, time.Now().Add(-feedMaxAge-time.Hour))
+	_, err = client.FetchPrices([]string{"ETH"}, []string{"USD"})
+	require.ErrorIs(t, err, errStaleAnswer)
+}
+
+func TestFetchPricesByContract(t *testing.T) {
+	backend := newTestBackend(t)
+	backend.setFeed(usdFeeds["ETH"], 2000, time.Now())
+	backend.setFeed(usdFeeds["USDC"], 1, time.Now())
+	backend.set(testToken, "decimals", uint8(18))
+	backend.set(wethAddress, "decimals", uint8(18))
+
+	// the token is token0 of the pools and worth 0.001 WETH, the deeper pool is used
+	tick := int64(math.Round(math.Log(0.001) / math.Log(1.0001)))
+	deepPool := common.HexToAddress("0x3000")
+	backend.set(deepPool, "liquidity", big.NewInt(1000))
+	backend.set(deepPool, "observe", []*big.Int{big.NewInt(0), big.NewInt(tick * twapPeriod)}, []*big.Int{big.NewInt(0), big.NewInt(0)})
+	shallowPool := common.HexToAddress("0x10000")
+	backend.set(shallowPool, "liquidity", big.NewInt(10))
+	backend.set(shallowPool, "observe", []*big.Int{big.NewInt(0), big.NewInt(0)}, []*big.Int{big.NewInt(0), big.NewInt(0)})
+	backend.pools = map[common.Address]map[int64]common.Address{wethAddress: {3000: deepPool, 10000: shallowPool}}
+	backend.set(uniswapV3Factory, "getPool")
+
+	client := newTestClient(backend)
+
+	mainnet := walletCommon.ChainID(walletCommon.EthereumMainnet)
+	token := thirdparty.ContractID{ChainID: mainnet, Address: testToken}
+	usdc := thirdparty.ContractID{ChainID: mainnet, Address: usdcAddress}
+	withoutPool := thirdparty.ContractID{ChainID: mainnet, Address: common.HexToAddress("0x2")}
+	otherChain := thirdparty.ContractID{ChainID: walletCommon.ChainID(walletCommon.OptimismMainnet), Address: testToken}
+
+	prices, err := client.FetchPricesByContract([]thirdparty.ContractID{token, usdc, withoutPool, otherChain}, []string{"USD"})
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	require.InDelta(t, 2, prices[token]["USD"], 0.001)
+	require.InDelta(t, 1, prices[usdc]["USD"], 1e-9)
+}
+
+func TestFetchPricesByContractFallsBackToNextQuote(t *testing.T) {
+	backend := newTestBackend(t)
+	backend.setFeed(usdFeeds["ETH"], 2000, time.Now())
+	backend.setFeed(usdFeeds["USDC"], 1, time.Now())
+	backend.set(testToken, "decimals", uint8(18))
+	backend.set(wethAddress, "decimals", uint8(18))
+	backend.set(usdcAddress, "decimals", uint8(6))
+
+	// the WETH pool reverts as it doesn't have enough observations for the period
+	wethPool := common.HexToAddress("0x3000")
+	backend.set(wethPool, "liquidity", big.NewInt(1000))
+	// the token is token0 of the USDC pool and worth 2 USDC
+	tick := int64(math.Round(math.Log(2e-12) / math.Log(1.0001)))
+	usdcPool := common.HexToAddress("0x500")
+	backend.set(usdcPool, "liquidity", big.NewInt(1000))
+	backend.set(usdcPool, "observe", []*big.Int{big.NewInt(0), big.NewInt(tick * twapPeriod)}, []*big.Int{big.NewInt(0), big.NewInt(0)})
+	backend.pools = map[common.Address]map[int64]common.Address{
+		wethAddress: {3000: wethPool},
+		usdcAddress: {500: usdcPool},
+	}
+	backend.set(uniswapV3Factory, "getPool")
+
+	client := newTestClient(backend)
+
+	token := thirdparty.ContractID{ChainID: walletCommon.ChainID(walletCommon.EthereumMainnet), Address: testToken}
+	prices, err := client.FetchPricesByContract([]thirdparty.ContractID{token}, []string{"USD"})
+	require.NoError(t, err)
+	require.InDelta(t, 2, prices[token]["USD"], 0.001)
+
+	// the error of the last quote is returned when none works
+	backend.set(usdcPool, "observe")
+	_, err = client.FetchPricesByContract([]thirdparty.ContractID{token}, []string{"USD"})
+	require.Error(t, err)
+}
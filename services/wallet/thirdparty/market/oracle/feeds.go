@@ -0,0 +1,65 @@
+package oracle
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+// The feeds and the pools are read on Ethereum mainnet, where they have the most liquidity and the most frequent updates
+const pricesChainID = walletCommon.ChainID(walletCommon.EthereumMainnet)
+
+// usdFeeds are the Chainlink aggregators quoting the assets in USD, the fiat feeds are used to convert the prices to
+// the other currencies
+var usdFeeds = map[string]common.Address{
+	"ETH":  common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"),
+	"BTC":  common.HexToAddress("0xF4030086522a5bEEa4988F8cA5B36dbC97BeE88c"),
+	"USDC": common.HexToAddress("0x8fFfFfd4AfB6115b954Bd326cbe7B4BA576818f6"),
+	"USDT": common.HexToAddress("0x3E7d1eAB13ad0104d2750B8863b489D65364e32D"),
+	"DAI":  common.HexToAddress("0xAed0c38402a5d19df6E4c03F4E2DceD6e29c1ee9"),
+	"LINK": common.HexToAddress("0x2c1d072e956AFFC0D435Cb7AC38EF18d24d9127c"),
+	"EUR":  common.HexToAddress("0xb49f677943BC038e9857d61E7d053CaA2C1734C1"),
+	"GBP":  common.HexToAddress("0x5c0Ab2d9b5a7ed9f470386e82BB36A3613cDd4b5"),
+}
+
+var (
+	wethAddress = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	usdcAddress = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+)
+
+// feedSymbols are the tokens priced with a feed instead of a pool
+var feedSymbols = map[common.Address]string{
+	wethAddress: "ETH",
+	usdcAddress: "USDC",
+	common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7"): "USDT",
+	common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"): "DAI",
+	common.HexToAddress("0x514910771AF9Ca656af840dff83E8264EcF986CA"): "LINK",
+}
+
+// twapQuote is a token the pools are looked for against, its USD price comes from a feed
+type twapQuote struct {
+	address common.Address
+	symbol  string
+}
+
+// twapQuotes are tried in order, most tokens have their deepest pool against WETH
+var twapQuotes = []twapQuote{
+	{address: wethAddress, symbol: "ETH"},
+	{address: usdcAddress, symbol: "USDC"},
+}
+
+var (
+	uniswapV3Factory = common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
+	// uniswapV3Fees are the fee tiers of the pools, in hundredths of a bip
+	uniswapV3Fees = []int64{500, 3000, 10000}
+)
+
+// sortTokens returns the tokens in the order of the pool, token0 has the lowest address
+func sortTokens(tokenA common.Address, tokenB common.Address) (common.Address, common.Address) {
+	if bytes.Compare(tokenA.Bytes(), tokenB.Bytes()) < 0 {
+		return tokenA, tokenB
+	}
+	return tokenB, tokenA
+}
@@ -33,7 +33,12 @@ type TokenDetails struct {
 type MarketDataProvider interface {
 	ID() string
 	FetchPrices(symbols []string, currencies []string) (map[string]map[string]float64, error)
+	// FetchPricesByContract returns the prices of the tokens identified by their contract, the tokens unknown to the
+	// provider are left out
+	FetchPricesByContract(contracts []ContractID, currencies []string) (map[ContractID]map[string]float64, error)
 	FetchHistoricalDailyPrices(symbol string, currency string, limit int, allData bool, aggregate int) ([]HistoricalPrice, error)
+	// FetchHistoricalDailyPricesByContract returns the daily prices of the token identified by its contract
+	FetchHistoricalDailyPricesByContract(contract ContractID, currency string, limit int, allData bool, aggregate int) ([]HistoricalPrice, error)
 	FetchHistoricalHourlyPrices(symbol string, currency string, limit int, aggregate int) ([]HistoricalPrice, error)
 	FetchTokenMarketValues(symbols []string, currency string) (map[string]TokenMarketValues, error)
 	FetchTokenDetails(symbols []string) (map[string]TokenDetails, error)
@@ -25,4 +25,6 @@ type ChainBalance struct {
 	Address        common.Address `json:"address"`
 	ChainID        uint64         `json:"chainId"`
 	HasError       bool           `json:"hasError"`
+	// PricePerCurrency is the price of the token on the chain, tokens sharing a symbol can have different prices
+	PricePerCurrency map[string]float64 `json:"pricePerCurrency,omitempty"`
 }
@@ -37,6 +37,8 @@ type StorageToken struct {
 	AssetWebsiteURL         string                       `json:"assetWebsiteUrl"`
 	BuiltOn                 string                       `json:"builtOn"`
 	MarketValuesPerCurrency map[string]TokenMarketValues `json:"marketValuesPerCurrency"`
+	// IsSpam is set when the token is considered spam on all the chains, see spam.Manager
+	IsSpam bool `json:"isSpam"`
 }
 
 func (t *Token) IsNative() bool {
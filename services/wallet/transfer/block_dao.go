@@ -175,3 +175,116 @@ func deleteAllRanges(creator statementCreator, account common.Address) error {
 	_, err = delete.Exec(account)
 	return err
 }
+
+// BlockHash is the hash of a checked chain head
+type BlockHash struct {
+	Number *big.Int
+	Hash   common.Hash
+}
+
+// saveBlockHash stores the hash of a checked head and keeps only the hashes of the last keep heads of the chain
+func (b *BlockDAO) saveBlockHash(chainID uint64, number *big.Int, hash common.Hash, keep int) error {
+	_, err := b.db.Exec(`INSERT OR REPLACE INTO blocks_hashes (network_id, blk_number, blk_hash) VALUES (?, ?, ?)`,
+		chainID, (*bigint.SQLBigInt)(number), hash)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.db.Exec(`DELETE FROM blocks_hashes WHERE network_id = ? AND blk_number NOT IN
+		(SELECT blk_number FROM blocks_hashes WHERE network_id = ? ORDER BY blk_number DESC LIMIT ?)`,
+		chainID, chainID, keep)
+	return err
+}
+
+// getBlockHashes returns the stored hashes of the chain, the latest head first
+func (b *BlockDAO) getBlockHashes(chainID uint64) (rst []*BlockHash, err error) {
+	rows, err := b.db.Query(`SELECT blk_number, blk_hash FROM blocks_hashes WHERE network_id = ? ORDER BY blk_number DESC`, chainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		blockHash := &BlockHash{Number: &big.Int{}}
+		err = rows.Scan((*bigint.SQLBigInt)(blockHash.Number), &blockHash.Hash)
+		if err != nil {
+			return nil, err
+		}
+		rst = append(rst, blockHash)
+	}
+	return rst, rows.Err()
+}
+
+// rollbackAfter removes the blocks and transfers of the accounts found after blockNumber and the hashes of the heads
+// after it, and moves the last scanned blocks of the accounts back to blockNumber. It returns the hashes of the
+// transactions of the removed transfers.
+func (b *BlockDAO) rollbackAfter(chainID uint64, accounts []common.Address, blockNumber *big.Int) (txHashes []common.Hash, err error) {
+	var tx *sql.Tx
+	tx, err = b.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	number := (*bigint.SQLBigInt)(blockNumber)
+	seen := make(map[common.Hash]bool)
+	for _, account := range accounts {
+		var rows *sql.Rows
+		rows, err = tx.Query(`SELECT DISTINCT tx_hash FROM transfers WHERE network_id = ? AND address = ? AND blk_number > ? AND tx_hash IS NOT NULL`,
+			chainID, account, number)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var txHash common.Hash
+			err = rows.Scan(&txHash)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if !seen[txHash] {
+				seen[txHash] = true
+				txHashes = append(txHashes, txHash)
+			}
+		}
+		rows.Close()
+
+		_, err = tx.Exec(`DELETE FROM transfers WHERE network_id = ? AND address = ? AND blk_number > ?`, chainID, account, number)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(`DELETE FROM blocks WHERE network_id = ? AND address = ? AND blk_number > ?`, chainID, account, number)
+		if err != nil {
+			return nil, err
+		}
+
+		// the balance check hash is cleared so the blocks after blockNumber are searched again even if the search
+		// following the rollback fails
+		_, err = tx.Exec(`UPDATE blocks_ranges_sequential SET blk_last = MIN(blk_last, ?), token_blk_last = MIN(token_blk_last, ?),
+			balance_check_hash = ? WHERE network_id = ? AND address = ?`, number, number, "", chainID, account)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(`DELETE FROM blocks_hashes WHERE network_id = ? AND blk_number > ?`, chainID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	logutils.ZapLogger().Info("rolled back reorganized blocks",
+		zap.Uint64("chainID", chainID),
+		zap.Stringers("accounts", accounts),
+		zap.Stringer("block", blockNumber),
+		zap.Int("transactions", len(txHashes)),
+	)
+
+	return txHashes, nil
+}
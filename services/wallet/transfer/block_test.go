@@ -10,6 +10,9 @@ import (
 	"github.com/status-im/status-go/walletdatabase"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	w_common "github.com/status-im/status-go/services/wallet/common"
 )
 
 func setupTestTransferDB(t *testing.T) (*BlockDAO, func()) {
@@ -206,3 +209,87 @@ func TestInsertZeroBalance(t *testing.T) {
 	require.Equal(t, big.NewInt(0).Int64(), block.Balance.Int64())
 	require.Equal(t, nonce, uint64(*block.Nonce))
 }
+
+func TestBlockHashes(t *testing.T) {
+	b, stop := setupTestTransferDB(t)
+	defer stop()
+
+	for i := int64(1); i <= 4; i++ {
+		require.NoError(t, b.saveBlockHash(777, big.NewInt(i), common.Hash{byte(i)}, 3))
+	}
+	require.NoError(t, b.saveBlockHash(778, big.NewInt(1), common.Hash{1}, 3))
+
+	hashes, err := b.getBlockHashes(777)
+	require.NoError(t, err)
+	require.Len(t, hashes, 3)
+	for i, hash := range hashes {
+		require.Equal(t, int64(4-i), hash.Number.Int64())
+		require.Equal(t, common.Hash{byte(4 - i)}, hash.Hash)
+	}
+
+	hashes, err = b.getBlockHashes(778)
+	require.NoError(t, err)
+	require.Len(t, hashes, 1)
+}
+
+func TestRollbackAfter(t *testing.T) {
+	db, b, stop := setupTestDB(t)
+	defer stop()
+
+	address := common.Address{1}
+	headers := []*DBHeader{}
+	transfers := []Transfer{}
+	blockNumbers := []*big.Int{}
+	for i := 1; i <= 4; i++ {
+		header := &DBHeader{
+			Number:  big.NewInt(int64(i)),
+			Hash:    common.Hash{byte(i)},
+			Address: address,
+		}
+		headers = append(headers, header)
+		blockNumbers = append(blockNumbers, header.Number)
+		tx := types.NewTransaction(uint64(i), address, nil, 10, big.NewInt(10), nil)
+		receipt := types.NewReceipt(nil, false, 100)
+		receipt.TxHash = tx.Hash()
+		receipt.Logs = []*types.Log{}
+		transfers = append(transfers, Transfer{
+			ID:          tx.Hash(),
+			Type:        w_common.EthTransfer,
+			BlockNumber: header.Number,
+			BlockHash:   header.Hash,
+			Transaction: tx,
+			Receipt:     receipt,
+			Address:     address,
+		})
+		require.NoError(t, b.saveBlockHash(777, header.Number, header.Hash, reorgCheckDepth))
+	}
+	require.NoError(t, db.SaveBlocks(777, headers))
+	require.NoError(t, saveTransfersMarkBlocksLoaded(db.client, 777, address, transfers, blockNumbers))
+
+	rangeDAO := &BlockRangeSequentialDAO{db.client}
+	blockRange := newEthTokensBlockRanges()
+	blockRange.eth = &BlockRange{Start: big.NewInt(1), FirstKnown: big.NewInt(1), LastKnown: big.NewInt(4)}
+	blockRange.tokens = &BlockRange{Start: big.NewInt(1), FirstKnown: big.NewInt(1), LastKnown: big.NewInt(4)}
+	blockRange.balanceCheckHash = "hash"
+	require.NoError(t, rangeDAO.upsertRange(777, address, blockRange))
+
+	txHashes, err := b.rollbackAfter(777, []common.Address{address}, big.NewInt(2))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []common.Hash{transfers[2].ID, transfers[3].ID}, txHashes)
+
+	rst, err := db.GetTransfers(777, big.NewInt(1), nil)
+	require.NoError(t, err)
+	require.Len(t, rst, 2)
+
+	hashes, err := b.getBlockHashes(777)
+	require.NoError(t, err)
+	require.Len(t, hashes, 2)
+	require.Equal(t, int64(2), hashes[0].Number.Int64())
+
+	blockRange, _, err = rangeDAO.getBlockRange(777, address)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), blockRange.eth.LastKnown.Int64())
+	require.Equal(t, int64(2), blockRange.tokens.LastKnown.Int64())
+	require.Equal(t, int64(1), blockRange.eth.FirstKnown.Int64())
+	require.Empty(t, blockRange.balanceCheckHash)
+}
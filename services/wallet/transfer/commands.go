@@ -34,6 +34,9 @@ const (
 	EventFetchingHistoryError walletevent.EventType = "fetching-history-error"
 	// EventNonArchivalNodeDetected emitted when a connection to a non archival node is detected
 	EventNonArchivalNodeDetected walletevent.EventType = "non-archival-node-detected"
+	// EventChainReorganized emitted when the blocks after BlockNumber were dropped by a reorganization of the chain,
+	// the transfers of Accounts found in them are removed and searched again
+	EventChainReorganized walletevent.EventType = "chain-reorganized"
 
 	// Internal events emitted when different kinds of transfers are detected
 	EventInternalETHTransferDetected     walletevent.EventType = walletevent.InternalEventTypePrefix + "eth-transfer-detected"
@@ -22,6 +22,7 @@ import (
 	"github.com/status-im/status-go/services/wallet/async"
 	"github.com/status-im/status-go/services/wallet/balance"
 	"github.com/status-im/status-go/services/wallet/blockchainstate"
+	w_common "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/services/wallet/walletevent"
 	"github.com/status-im/status-go/transactions"
@@ -45,6 +46,8 @@ type nonceInfo struct {
 
 type findNewBlocksCommand struct {
 	*findBlocksCommand
+	blockDAO                     *BlockDAO
+	pendingTxManager             *transactions.PendingTxTracker
 	contractMaker                *contracts.ContractMaker
 	iteration                    int
 	blockChainState              *blockchainstate.BlockChainState
@@ -205,6 +208,120 @@ func (c *findNewBlocksCommand) detectNonceChange(parent context.Context, to *big
 var nonceCheckIntervalIterations = 30
 var logsCheckIntervalIterations = 5
 
+// reorgCheckDepth is the number of checked heads whose hashes are kept to find where a reorganization started
+const reorgCheckDepth = 64
+
+// checkReorg compares the head with the previously checked heads. It returns the last block still in the chain
+// when some of their blocks were dropped by a reorganization, and nil otherwise.
+func (c *findNewBlocksCommand) checkReorg(parent context.Context, head *types.Header) (*big.Int, error) {
+	chainID := c.chainClient.NetworkID()
+	hashes, err := c.blockDAO.getBlockHashes(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	last := hashes[0]
+	var reorganized bool
+	switch new(big.Int).Sub(head.Number, last.Number).Int64() {
+	case 0:
+		reorganized = head.Hash() != last.Hash
+	case 1:
+		reorganized = head.ParentHash != last.Hash
+	default:
+		if head.Number.Cmp(last.Number) < 0 {
+			// the node is behind the previous one, the heads are compared once it catches up
+			return nil, nil
+		}
+		ctx, cancel := context.WithTimeout(parent, requestTimeout)
+		defer cancel()
+		header, err := c.chainClient.HeaderByNumber(ctx, last.Number)
+		if err != nil {
+			return nil, err
+		}
+		reorganized = header.Hash() != last.Hash
+	}
+	if !reorganized {
+		return nil, nil
+	}
+
+	return c.findCommonAncestor(parent, hashes[1:])
+}
+
+// findCommonAncestor returns the last of the checked heads still in the chain
+func (c *findNewBlocksCommand) findCommonAncestor(parent context.Context, hashes []*BlockHash) (*big.Int, error) {
+	for _, blockHash := range hashes {
+		ctx, cancel := context.WithTimeout(parent, requestTimeout)
+		header, err := c.chainClient.HeaderByNumber(ctx, blockHash.Number)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if header.Hash() == blockHash.Hash {
+			return blockHash.Number, nil
+		}
+	}
+
+	// the reorganization is deeper than the checked heads, the whole window is searched again
+	oldest := c.fromBlockNumber
+	if len(hashes) > 0 {
+		oldest = hashes[len(hashes)-1].Number
+	}
+	ancestor := new(big.Int).Sub(oldest, big.NewInt(1))
+	if ancestor.Sign() < 0 {
+		ancestor.SetInt64(0)
+	}
+	logutils.ZapLogger().Warn("findNewBlocksCommand reorganization deeper than the checked heads",
+		zap.Uint64("chain", c.chainClient.NetworkID()),
+		zap.Stringer("ancestor", ancestor),
+	)
+	return ancestor, nil
+}
+
+// rollback removes what was found in the blocks dropped by a reorganization, the transfers, the cached balances
+// and the statuses of the tracked transactions, so it is searched again in the blocks of the new chain
+func (c *findNewBlocksCommand) rollback(parent context.Context, ancestor *big.Int) error {
+	chainID := c.chainClient.NetworkID()
+	logutils.ZapLogger().Info("findNewBlocksCommand chain reorganized",
+		zap.Uint64("chain", chainID),
+		zap.Stringer("ancestor", ancestor),
+	)
+
+	txHashes, err := c.blockDAO.rollbackAfter(chainID, c.accounts, ancestor)
+	if err != nil {
+		return err
+	}
+
+	c.balanceCacher.Cache().RemoveAfter(chainID, ancestor)
+	c.lastNonces = nil
+	if c.fromBlockNumber == nil || c.fromBlockNumber.Cmp(ancestor) > 0 {
+		c.fromBlockNumber = new(big.Int).Set(ancestor)
+	}
+	if c.logsCheckLastKnownBlock != nil && c.logsCheckLastKnownBlock.Cmp(ancestor) > 0 {
+		c.logsCheckLastKnownBlock = new(big.Int).Set(ancestor)
+	}
+
+	if len(txHashes) > 0 {
+		err = c.pendingTxManager.RevertReorganized(parent, w_common.ChainID(chainID), txHashes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.feed != nil {
+		c.feed.Send(walletevent.Event{
+			Type:        EventChainReorganized,
+			ChainID:     chainID,
+			Accounts:    c.accounts,
+			BlockNumber: ancestor,
+		})
+	}
+
+	return nil
+}
+
 func (c *findNewBlocksCommand) Run(parent context.Context) error {
 	mnemonicWasNotShown, err := c.accountsDB.GetMnemonicWasNotShown()
 	if err != nil {
@@ -247,6 +364,30 @@ func (c *findNewBlocksCommand) Run(parent context.Context) error {
 		return err
 	}
 
+	ctx, cancel := context.WithTimeout(parent, requestTimeout)
+	head, err := c.chainClient.HeaderByNumber(ctx, headNum)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	ancestor, err := c.checkReorg(parent, head)
+	if err != nil {
+		logutils.ZapLogger().Error("findNewBlocksCommand error on reorganization check",
+			zap.Uint64("chain", c.chainClient.NetworkID()),
+			zap.Error(err),
+		)
+		return err
+	}
+	if ancestor != nil {
+		err = c.rollback(parent, ancestor)
+		if err != nil {
+			return err
+		}
+		// the blocks after the ancestor are searched again for all the accounts
+		accountsWithDetectedChanges = accountsToCheck
+	}
+
 	c.blockChainState.SetLastBlockNumber(c.chainClient.NetworkID(), headNum.Uint64())
 
 	if len(accountsWithDetectedChanges) != 0 {
@@ -297,6 +438,13 @@ func (c *findNewBlocksCommand) Run(parent context.Context) error {
 		}
 		c.logsCheckLastKnownBlock = headNum
 	}
+
+	// the head is stored once its blocks are searched, a failed search is repeated from the previous head
+	err = c.blockDAO.saveBlockHash(c.chainClient.NetworkID(), head.Number, head.Hash(), reorgCheckDepth)
+	if err != nil {
+		return err
+	}
+
 	c.fromBlockNumber = headNum
 	c.iteration++
 
@@ -1384,6 +1532,8 @@ func (c *loadBlocksAndTransfersCommand) startFetchingNewBlocks(ctx context.Conte
 				blocksLoadedCh:            blocksLoadedCh,
 				defaultNodeBlockChunkSize: DefaultNodeBlockChunkSize,
 			},
+			blockDAO:                     c.blockDAO,
+			pendingTxManager:             c.pendingTxManager,
 			contractMaker:                c.contractMaker,
 			blockChainState:              c.blockChainState,
 			nonceCheckIntervalIterations: nonceCheckIntervalIterations,
@@ -49,6 +49,7 @@ import (
 	walletcommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/community"
 	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/services/wallet/walletevent"
 	"github.com/status-im/status-go/t/helpers"
 	"github.com/status-im/status-go/transactions"
 	"github.com/status-im/status-go/walletdatabase"
@@ -291,15 +292,31 @@ func (tc *TestClient) tokenBalanceAt(account common.Address, token common.Addres
 }
 
 func getTestHeader(number *big.Int) *types.Header {
-	return &types.Header{
-		Number:     big.NewInt(0).Set(number),
-		Time:       0,
-		Difficulty: big.NewInt(0),
-		ParentHash: common.Hash{},
-		Nonce:      types.BlockNonce{},
-		MixDigest:  common.Hash{},
-		Extra:      make([]byte, 0),
+	return getTestForkHeader(number, nil)
+}
+
+// getTestForkHeader returns the headers of a test chain, the parent hash of a header is the hash of the previous one.
+// The headers from forkBlock on belong to a different branch of the chain when forkBlock is set.
+func getTestForkHeader(number *big.Int, forkBlock *big.Int) *types.Header {
+	var header *types.Header
+	parentHash := common.Hash{}
+	for i := int64(0); i <= number.Int64(); i++ {
+		extra := make([]byte, 0)
+		if forkBlock != nil && i >= forkBlock.Int64() {
+			extra = []byte("fork")
+		}
+		header = &types.Header{
+			Number:     big.NewInt(i),
+			Time:       0,
+			Difficulty: big.NewInt(0),
+			ParentHash: parentHash,
+			Nonce:      types.BlockNonce{},
+			MixDigest:  common.Hash{},
+			Extra:      extra,
+		}
+		parentHash = header.Hash()
 	}
+	return header
 }
 
 func (tc *TestClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
@@ -659,6 +676,11 @@ func (tc *TestClient) SubscribeFilterLogs(ctx context.Context, q ethereum.Filter
 	return nil, err
 }
 
+func (tc *TestClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	err := tc.countAndlog("SubscribeNewHead")
+	return nil, err
+}
+
 func (tc *TestClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
 	err := tc.countAndlog("TransactionReceipt")
 	return nil, err
@@ -1605,6 +1627,7 @@ func TestFetchNewBlocksCommand_nonceDetection(t *testing.T) {
 			defaultNodeBlockChunkSize: scanRange,
 			fromBlockNumber:           big.NewInt(0),
 		},
+		blockDAO:                     &BlockDAO{db},
 		blockChainState:              blockchainstate.NewBlockChainState(),
 		contractMaker:                maker,
 		nonceCheckIntervalIterations: 2,
@@ -1720,6 +1743,7 @@ func TestFetchNewBlocksCommand(t *testing.T) {
 			blocksLoadedCh:            blockChannel,
 			defaultNodeBlockChunkSize: DefaultNodeBlockChunkSize,
 		},
+		blockDAO:                     &BlockDAO{db},
 		contractMaker:                tokenManager.ContractMaker,
 		blockChainState:              blockchainstate.NewBlockChainState(),
 		nonceCheckIntervalIterations: nonceCheckIntervalIterations,
@@ -1784,6 +1808,112 @@ func TestFetchNewBlocksCommand(t *testing.T) {
 	require.Equal(t, 3, tc.callsCounter["FilterLogs"], "calls to FilterLogs")
 }
 
+// TestClientWithReorg returns the headers of a branch of the test chain forked at forkBlock
+type TestClientWithReorg struct {
+	*TestClient
+	forkBlock *big.Int
+}
+
+func (tc *TestClientWithReorg) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		number = big.NewInt(int64(tc.currentBlock))
+	}
+
+	err := tc.countAndlog("HeaderByNumber", fmt.Sprintf("number: %d", number))
+	if err != nil {
+		return nil, err
+	}
+
+	return getTestForkHeader(number, tc.forkBlock), nil
+}
+
+func TestFetchNewBlocksCommand_reorg(t *testing.T) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+
+	wdb := NewDB(db)
+	address := common.HexToAddress("0x1234")
+	tc := &TestClientWithReorg{
+		TestClient: &TestClient{
+			t:            t,
+			callsCounter: map[string]int{},
+			currentBlock: 9,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rpcClient := mock_rpcclient.NewMockClientInterface(ctrl)
+	tracker := transactions.NewPendingTxTracker(db, rpcClient, &event.Feed{}, transactions.PendingCheckInterval)
+	feed := &event.Feed{}
+	balanceCacher := balance.NewSimpleCacher()
+
+	cmd := &findNewBlocksCommand{
+		findBlocksCommand: &findBlocksCommand{
+			accounts:        []common.Address{address},
+			db:              wdb,
+			blockRangeDAO:   &BlockRangeSequentialDAO{db},
+			chainClient:     tc,
+			balanceCacher:   balanceCacher,
+			feed:            feed,
+			fromBlockNumber: big.NewInt(8),
+		},
+		blockDAO:         &BlockDAO{db},
+		pendingTxManager: tracker,
+	}
+
+	// The heads 5 and 8 were checked and blocks with transfers were found at 4 and 7
+	for _, number := range []int64{5, 8} {
+		head := getTestForkHeader(big.NewInt(number), nil)
+		require.NoError(t, cmd.blockDAO.saveBlockHash(tc.NetworkID(), head.Number, head.Hash(), reorgCheckDepth))
+	}
+	headers := []*DBHeader{}
+	for _, number := range []int64{4, 7} {
+		headers = append(headers, toDBHeader(getTestForkHeader(big.NewInt(number), nil), address))
+	}
+	require.NoError(t, wdb.SaveBlocks(tc.NetworkID(), headers))
+	balanceCacher.Cache().AddBalance(address, tc.NetworkID(), big.NewInt(7), big.NewInt(1))
+
+	// No reorganization
+	ancestor, err := cmd.checkReorg(context.Background(), getTestForkHeader(big.NewInt(9), nil))
+	require.NoError(t, err)
+	require.Nil(t, ancestor)
+
+	// The blocks from 6 on are replaced
+	tc.forkBlock = big.NewInt(6)
+	ancestor, err = cmd.checkReorg(context.Background(), getTestForkHeader(big.NewInt(9), tc.forkBlock))
+	require.NoError(t, err)
+	require.Equal(t, int64(5), ancestor.Int64())
+
+	eventChan := make(chan walletevent.Event, 1)
+	sub := feed.Subscribe(eventChan)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, cmd.rollback(context.Background(), ancestor))
+
+	select {
+	case ev := <-eventChan:
+		require.Equal(t, EventChainReorganized, ev.Type)
+		require.Equal(t, tc.NetworkID(), ev.ChainID)
+		require.Equal(t, []common.Address{address}, ev.Accounts)
+		require.Equal(t, int64(5), ev.BlockNumber.Int64())
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+
+	blocks, err := cmd.blockDAO.GetBlocksToLoadByAddress(tc.NetworkID(), address, 10)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, int64(4), blocks[0].Int64())
+	require.Nil(t, balanceCacher.Cache().GetBalance(address, tc.NetworkID(), big.NewInt(7)))
+	require.Equal(t, int64(5), cmd.fromBlockNumber.Int64())
+
+	hashes, err := cmd.blockDAO.getBlockHashes(tc.NetworkID())
+	require.NoError(t, err)
+	require.Len(t, hashes, 1)
+	require.Equal(t, int64(5), hashes[0].Number.Int64())
+}
+
 type TestClientWithError struct {
 	*TestClient
 }
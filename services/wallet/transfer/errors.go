@@ -9,4 +9,9 @@ var (
 	ErrNoRoute                   = &errors.ErrorResponse{Code: errors.ErrorCode("WT-001"), Details: "no generated route"}
 	ErrNoTrsansactionsBeingBuilt = &errors.ErrorResponse{Code: errors.ErrorCode("WT-002"), Details: "no transactions being built"}
 	ErrMissingSignatureForTx     = &errors.ErrorResponse{Code: errors.ErrorCode("WT-003"), Details: "missing signature for transaction %s"}
+	ErrSmartAccountOwnerNotFound = &errors.ErrorResponse{Code: errors.ErrorCode("WT-004"), Details: "smart account owner not found"}
+	ErrBatchNotSupported         = &errors.ErrorResponse{Code: errors.ErrorCode("WT-005"), Details: "transactions of the route can't be batched"}
+	ErrDelegationRequired        = &errors.ErrorResponse{Code: errors.ErrorCode("WT-006"), Details: "account must be delegated to the batch executor"}
+	ErrInvalidDelegation         = &errors.ErrorResponse{Code: errors.ErrorCode("WT-007"), Details: "delegation signature doesn't match the account"}
+	ErrSafeExecutorsMismatch     = &errors.ErrorResponse{Code: errors.ErrorCode("WT-008"), Details: "the transactions of the Safe are sent by different owners on the chains of the route"}
 )
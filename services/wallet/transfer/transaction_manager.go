@@ -223,6 +223,41 @@ func (tm *TransactionManager) BuildTransaction(chainID uint64, sendArgs walletty
 	}, nil
 }
 
+// BuildReplacementTransaction builds a speed-up or a cancel transaction for the pending one identified by (chainID, replacedHash)
+func (tm *TransactionManager) BuildReplacementTransaction(chainID uint64, replacedHash common.Hash, replacementType transactions.ReplacementType) (response *TxResponse, err error) {
+	sendArgs, txBeingSigned, err := tm.transactor.BuildReplacementTransaction(chainID, replacedHash, replacementType)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := tm.accountsDB.GetAccountByAddress(sendArgs.From)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve account: %w", err)
+	}
+
+	kp, err := tm.accountsDB.GetKeypairByKeyUID(account.KeyUID)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := ethTypes.NewLondonSigner(new(big.Int).SetUint64(chainID))
+
+	return &TxResponse{
+		KeyUID:        account.KeyUID,
+		Address:       account.Address,
+		AddressPath:   account.Path,
+		SignOnKeycard: kp.MigratedToKeycard(),
+		ChainID:       chainID,
+		MessageToSign: signer.Hash(txBeingSigned),
+		TxArgs:        sendArgs,
+	}, nil
+}
+
+func (tm *TransactionManager) SendReplacementTransactionWithSignature(chainID uint64, replacedHash common.Hash, replacementType transactions.ReplacementType,
+	sendArgs wallettypes.SendTxArgs, signature []byte) (hash types.Hash, err error) {
+	return tm.transactor.SendReplacementTransactionWithSignature(chainID, replacedHash, replacementType, sendArgs, signature)
+}
+
 func (tm *TransactionManager) BuildRawTransaction(chainID uint64, sendArgs wallettypes.SendTxArgs, signature []byte) (response *TxResponse, err error) {
 	tx, err := tm.transactor.BuildTransactionWithSignature(chainID, sendArgs, signature)
 	if err != nil {
@@ -0,0 +1,198 @@
+package transfer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/multiaccounts/accounts"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/delegation"
+	"github.com/status-im/status-go/services/wallet/responses"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	"github.com/status-im/status-go/transactions"
+)
+
+const (
+	// batchGasOverhead covers the self call and the dispatch of the calls by the batch executor
+	batchGasOverhead = uint64(30000)
+	// authorizationGas is the cost of an authorization delegating an account (`PER_EMPTY_ACCOUNT_COST`)
+	authorizationGas = uint64(25000)
+)
+
+func (tm *TransactionManager) batchExecutor(chainID uint64) (common.Address, bool) {
+	if tm.config == nil {
+		return common.Address{}, false
+	}
+	executor, ok := delegation.BatchExecutors(tm.config.WalletConfig)[chainID]
+	return executor, ok
+}
+
+// buildDelegationAuthorizations returns the authorization delegating the account to the batch executor if it's not
+// delegated yet, the signature is provided by the client
+func (tm *TransactionManager) buildDelegationAuthorizations(chainID uint64, from common.Address, executor common.Address,
+	signature []byte) ([]wallettypes.SetCodeAuthorization, error) {
+	delegate, delegated, err := tm.transactor.GetDelegation(chainID, from)
+	if err != nil {
+		return nil, err
+	}
+	if delegated && delegate == executor {
+		return nil, nil
+	}
+	if len(signature) == 0 {
+		return nil, ErrDelegationRequired
+	}
+
+	auth, err := tm.transactor.BuildSetCodeAuthorization(chainID, from, executor, -1)
+	if err != nil {
+		return nil, err
+	}
+	auth, err = auth.WithSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// the authorization is built for the current nonce of the account, an outdated signature recovers another address
+	authority, err := auth.Authority()
+	if err != nil || authority != from {
+		return nil, ErrInvalidDelegation
+	}
+	return []wallettypes.SetCodeAuthorization{*auth}, nil
+}
+
+// buildBatchTxForRoute collapses the transactions of the route, including the approvals, in a single EIP-7702
+// transaction the account sends to itself and executes through the batch executor it's delegated to. The
+// transaction data is shared by all paths of the route.
+func (tm *TransactionManager) buildBatchTxForRoute(route routes.Route, accFrom *accounts.Account, pathProcessors map[string]pathprocessor.PathProcessor,
+	processorInputParams *pathprocessor.ProcessorInputParams) (*wallettypes.TransactionData, error) {
+	if accFrom.IsKeypairless() {
+		return nil, ErrBatchNotSupported
+	}
+
+	firstPath := route[0]
+	chainID := firstPath.FromChain.ChainID
+	executor, ok := tm.batchExecutor(chainID)
+	if !ok {
+		return nil, delegation.ErrNoBatchExecutor
+	}
+
+	from := processorInputParams.FromAddr
+	calls := make([]delegation.Call, 0, 2*len(route))
+	gas := batchGasOverhead
+	amountIn := new(big.Int)
+	amountOut := new(big.Int)
+	for _, path := range route {
+		if path.FromChain.ChainID != chainID || path.ToChain.ChainID != chainID || !path.FromChain.EIP1559Enabled {
+			return nil, ErrBatchNotSupported
+		}
+
+		if path.ApprovalRequired && !tm.ApprovalPlacedForPath(path.ProcessorName) {
+			approvalArgs := pathprocessor.BuildApprovalSendTxArgs(path, from)
+			calls = append(calls, delegation.Call{
+				To:   common.Address(*approvalArgs.To),
+				Data: approvalArgs.Data,
+			})
+			gas += path.ApprovalGasAmount
+		}
+
+		sendArgs := pathprocessor.BuildSendTxArgs(path, processorInputParams)
+		builtTx, _, err := pathProcessors[path.ProcessorName].BuildTransactionV2(sendArgs, -1)
+		if err != nil {
+			return nil, err
+		}
+		if builtTx.To() == nil {
+			return nil, ErrBatchNotSupported
+		}
+		calls = append(calls, delegation.Call{
+			To:    *builtTx.To(),
+			Value: builtTx.Value(),
+			Data:  builtTx.Data(),
+		})
+		gas += builtTx.Gas()
+
+		if path.AmountIn != nil {
+			amountIn.Add(amountIn, path.AmountIn.ToInt())
+		}
+		if path.AmountOut != nil {
+			amountOut.Add(amountOut, path.AmountOut.ToInt())
+		}
+	}
+
+	authList, err := tm.buildDelegationAuthorizations(chainID, from, executor, processorInputParams.DelegationSignature)
+	if err != nil {
+		return nil, err
+	}
+	gas += authorizationGas * uint64(len(authList))
+
+	data, err := delegation.PackBatchExecute(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	to := types.Address(from)
+	batchArgs := &wallettypes.SendTxArgs{
+		Version: wallettypes.SendTxArgsVersion1,
+
+		// tx fields
+		From:                 types.Address(from),
+		To:                   &to,
+		Value:                (*hexutil.Big)(big.NewInt(0)),
+		Data:                 data,
+		Gas:                  (*hexutil.Uint64)(&gas),
+		MaxFeePerGas:         firstPath.TxMaxFeesPerGas,
+		MaxPriorityFeePerGas: firstPath.TxPriorityFee,
+
+		// additional fields version 1
+		ValueIn:            (*hexutil.Big)(amountIn),
+		ValueOut:           (*hexutil.Big)(amountOut),
+		FromChainID:        chainID,
+		ToChainID:          chainID,
+		SlippagePercentage: processorInputParams.SlippagePercentage,
+	}
+	if firstPath.FromToken != nil {
+		batchArgs.FromTokenID = firstPath.FromToken.Symbol
+		batchArgs.ToContractAddress = types.Address(firstPath.FromToken.Address)
+	}
+	if firstPath.ToToken != nil {
+		batchArgs.ToTokenID = firstPath.ToToken.Symbol
+	}
+	if len(authList) > 0 {
+		// the authorization is only valid for the nonce it was built for
+		nonce := hexutil.Uint64(uint64(authList[0].Nonce) - 1)
+		batchArgs.Nonce = &nonce
+	}
+
+	tx, _, err := tm.transactor.BuildSetCodeTransaction(chainID, *batchArgs, authList, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallettypes.TransactionData{
+		TxArgs:     batchArgs,
+		SetCodeTx:  tx,
+		HashToSign: types.Hash(tx.SigHash()),
+	}, nil
+}
+
+func addSignatureAndSendSetCodeTransaction(
+	transactor transactions.TransactorIface,
+	txData *wallettypes.TransactionData,
+	multiTransactionID walletCommon.MultiTransactionIDType) (*responses.RouterSentTransaction, error) {
+	txWithSignature, err := transactor.AddSignatureToSetCodeTransaction(txData.SetCodeTx, txData.Signature)
+	if err != nil {
+		return nil, err
+	}
+	txData.SetCodeTx = txWithSignature
+
+	txData.SentHash, err = transactor.SendSetCodeTransactionWithSignature(common.Address(txData.TxArgs.From), txData.TxArgs.FromTokenID, multiTransactionID, txWithSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	txData.TxArgs.MultiTransactionID = multiTransactionID
+
+	return responses.NewRouterSentTransaction(txData.TxArgs, txData.SentHash, false), nil
+}
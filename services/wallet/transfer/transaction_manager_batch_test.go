@@ -0,0 +1,202 @@
+package transfer
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/multiaccounts/accounts"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	pathProcessorCommon "github.com/status-im/status-go/services/wallet/router/pathprocessor/common"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+	tokenTypes "github.com/status-im/status-go/services/wallet/token/types"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+	mock_transactor "github.com/status-im/status-go/transactions/mock"
+)
+
+var (
+	batchTestExecutor  = common.HexToAddress("0x63c0c19a282a1b52b07dd5a65b58948a07dae32b")
+	batchTestFrom      = common.HexToAddress("0x01")
+	batchTestRecipient = common.HexToAddress("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+	batchTestUSDC      = common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+)
+
+// testBatchProcessor builds the transactions straight from the send args
+type testBatchProcessor struct {
+	pathprocessor.PathProcessor
+}
+
+func (p *testBatchProcessor) BuildTransactionV2(sendArgs *wallettypes.SendTxArgs, lastUsedNonce int64) (*gethtypes.Transaction, uint64, error) {
+	return gethtypes.NewTx(&gethtypes.DynamicFeeTx{
+		To:    (*common.Address)(sendArgs.To),
+		Value: sendArgs.Value.ToInt(),
+		Data:  sendArgs.Data,
+		Gas:   uint64(*sendArgs.Gas),
+	}), 0, nil
+}
+
+type batchTestCall struct {
+	Target   common.Address
+	Value    *big.Int
+	CallData []byte
+}
+
+// unpackBatchExecute decodes the calls of an ERC-7821 `execute` call data
+func unpackBatchExecute(t *testing.T, data []byte) []batchTestCall {
+	executeABI, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"execute","stateMutability":"payable","inputs":[{"name":"mode","type":"bytes32"},{"name":"executionData","type":"bytes"}],"outputs":[]}]`))
+	require.NoError(t, err)
+	method, err := executeABI.MethodById(data)
+	require.NoError(t, err)
+	require.Equal(t, "execute", method.Name)
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	// the batch reverts if any of its calls fails
+	require.Equal(t, [32]byte{0x01}, args[0].([32]byte))
+
+	executionsType, err := abi.NewType("tuple[]", "", []abi.ArgumentMarshaling{
+		{Name: "target", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "callData", Type: "bytes"},
+	})
+	require.NoError(t, err)
+	executionsArgs := abi.Arguments{{Type: executionsType}}
+	unpacked, err := executionsArgs.Unpack(args[1].([]byte))
+	require.NoError(t, err)
+
+	calls := []batchTestCall{}
+	require.NoError(t, executionsArgs.Copy(&calls, unpacked))
+	return calls
+}
+
+func setupBatchTest(t *testing.T) (*TransactionManager, *mock_transactor.MockTransactorIface, *accounts.Account) {
+	tm, transactor := setupTestSuite(t)
+	tm.config = &params.NodeConfig{
+		WalletConfig: params.WalletConfig{
+			BatchExecutorAddresses: map[uint64]string{1: batchTestExecutor.Hex()},
+		},
+	}
+	accFrom := &accounts.Account{KeyUID: "keyUid", Address: types.Address(batchTestFrom), Type: accounts.AccountTypeGenerated}
+	return tm, transactor, accFrom
+}
+
+func batchTestRoute() routes.Route {
+	mainnet := &params.Network{ChainID: 1, EIP1559Enabled: true}
+	gas := func(fee int64) *hexutil.Big { return (*hexutil.Big)(big.NewInt(fee)) }
+	return routes.Route{
+		{
+			ProcessorName:      pathProcessorCommon.ProcessorTransferName,
+			FromChain:          mainnet,
+			ToChain:            mainnet,
+			FromToken:          &tokenTypes.Token{Symbol: "USDC", Address: batchTestUSDC, ChainID: 1},
+			AmountIn:           (*hexutil.Big)(big.NewInt(100)),
+			AmountOut:          (*hexutil.Big)(big.NewInt(100)),
+			TxPackedData:       []byte{0xa9, 0x05, 0x9c, 0xbb},
+			TxGasAmount:        65000,
+			TxMaxFeesPerGas:    gas(30),
+			TxPriorityFee:      gas(2),
+			ApprovalRequired:   true,
+			ApprovalPackedData: []byte{0x09, 0x5e, 0xa7, 0xb3},
+			ApprovalGasAmount:  50000,
+		},
+		{
+			ProcessorName: pathProcessorCommon.ProcessorTransferName,
+			FromChain:     mainnet,
+			ToChain:       mainnet,
+			FromToken:     &tokenTypes.Token{Symbol: "ETH", ChainID: 1},
+			AmountIn:      (*hexutil.Big)(big.NewInt(1000)),
+			AmountOut:     (*hexutil.Big)(big.NewInt(1000)),
+			TxGasAmount:   21000,
+		},
+	}
+}
+
+func TestBuildBatchTxForRoute(t *testing.T) {
+	tm, transactor, accFrom := setupBatchTest(t)
+	route := batchTestRoute()
+	pathProcessors := map[string]pathprocessor.PathProcessor{pathProcessorCommon.ProcessorTransferName: &testBatchProcessor{}}
+	inputParams := &pathprocessor.ProcessorInputParams{FromAddr: batchTestFrom, ToAddr: batchTestRecipient}
+
+	// the account is already delegated to the executor, no authorization is needed
+	transactor.EXPECT().GetDelegation(uint64(1), batchTestFrom).Return(batchTestExecutor, true, nil)
+	transactor.EXPECT().BuildSetCodeTransaction(uint64(1), gomock.Any(), gomock.Len(0), int64(-1)).DoAndReturn(
+		func(chainID uint64, args wallettypes.SendTxArgs, authList []wallettypes.SetCodeAuthorization, lastUsedNonce int64) (*wallettypes.SetCodeTx, uint64, error) {
+			return &wallettypes.SetCodeTx{
+				ChainID: (*hexutil.Big)(big.NewInt(1)),
+				Gas:     *args.Gas,
+				To:      common.Address(*args.To),
+				Value:   args.Value,
+				Data:    hexutil.Bytes(args.Data),
+			}, 0, nil
+		})
+
+	txData, err := tm.buildBatchTxForRoute(route, accFrom, pathProcessors, inputParams)
+	require.NoError(t, err)
+
+	// the account sends the batch to itself, fees are taken from the first path
+	args := txData.TxArgs
+	require.Equal(t, types.Address(batchTestFrom), args.From)
+	require.Equal(t, types.Address(batchTestFrom), *args.To)
+	require.Zero(t, args.Value.ToInt().Sign())
+	require.Nil(t, args.Nonce)
+	require.Equal(t, route[0].TxMaxFeesPerGas, args.MaxFeePerGas)
+	require.Equal(t, route[0].TxPriorityFee, args.MaxPriorityFeePerGas)
+	require.Equal(t, batchGasOverhead+50000+65000+21000, uint64(*args.Gas))
+	require.Equal(t, big.NewInt(1100), args.ValueIn.ToInt())
+	require.Equal(t, "USDC", args.FromTokenID)
+	require.Equal(t, txData.SetCodeTx.SigHash(), common.Hash(txData.HashToSign))
+
+	// the approval precedes the transfer of its path, the paths keep the order of the route
+	calls := unpackBatchExecute(t, args.Data)
+	require.Len(t, calls, 3)
+
+	require.Equal(t, batchTestUSDC, calls[0].Target)
+	require.Zero(t, calls[0].Value.Sign())
+	require.Equal(t, route[0].ApprovalPackedData, calls[0].CallData)
+
+	require.Equal(t, batchTestUSDC, calls[1].Target)
+	require.Zero(t, calls[1].Value.Sign())
+	require.Equal(t, route[0].TxPackedData, calls[1].CallData)
+
+	require.Equal(t, batchTestRecipient, calls[2].Target)
+	require.Equal(t, big.NewInt(1000), calls[2].Value)
+	require.Empty(t, calls[2].CallData)
+}
+
+func TestBuildBatchTxForRouteDelegation(t *testing.T) {
+	tm, transactor, accFrom := setupBatchTest(t)
+	route := batchTestRoute()
+	pathProcessors := map[string]pathprocessor.PathProcessor{pathProcessorCommon.ProcessorTransferName: &testBatchProcessor{}}
+	inputParams := &pathprocessor.ProcessorInputParams{FromAddr: batchTestFrom, ToAddr: batchTestRecipient}
+
+	// an account which isn't delegated yet needs the signed authorization of the client
+	transactor.EXPECT().GetDelegation(uint64(1), batchTestFrom).Return(common.Address{}, false, nil)
+	_, err := tm.buildBatchTxForRoute(route, accFrom, pathProcessors, inputParams)
+	require.ErrorIs(t, err, ErrDelegationRequired)
+}
+
+func TestBuildBatchTxForRouteNotSupported(t *testing.T) {
+	tm, _, accFrom := setupBatchTest(t)
+	pathProcessors := map[string]pathprocessor.PathProcessor{pathProcessorCommon.ProcessorTransferName: &testBatchProcessor{}}
+	inputParams := &pathprocessor.ProcessorInputParams{FromAddr: batchTestFrom, ToAddr: batchTestRecipient}
+
+	// the calls of a batch are executed on a single chain
+	route := batchTestRoute()
+	route[1].ToChain = &params.Network{ChainID: 10, EIP1559Enabled: true}
+	_, err := tm.buildBatchTxForRoute(route, accFrom, pathProcessors, inputParams)
+	require.ErrorIs(t, err, ErrBatchNotSupported)
+
+	// accounts without a keypair can't delegate
+	watchOnly := &accounts.Account{Address: types.Address(batchTestFrom), Type: accounts.AccountTypeWatch}
+	_, err = tm.buildBatchTxForRoute(batchTestRoute(), watchOnly, pathProcessors, inputParams)
+	require.ErrorIs(t, err, ErrBatchNotSupported)
+}
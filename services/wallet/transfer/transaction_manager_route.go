@@ -7,11 +7,11 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/status-im/status-go/errors"
 	"github.com/status-im/status-go/eth-node/crypto"
 	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/multiaccounts/accounts"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/requests"
 	"github.com/status-im/status-go/services/wallet/responses"
@@ -80,34 +80,7 @@ func buildApprovalTxForPath(transactor transactions.TransactorIface, path *route
 		lastUsedNonce = nonce
 	}
 
-	addrTo := types.Address(path.FromToken.Address)
-	approavalSendArgs := &wallettypes.SendTxArgs{
-		Version: wallettypes.SendTxArgsVersion1,
-
-		// tx fields
-		From:     types.Address(addressFrom),
-		To:       &addrTo,
-		Value:    (*hexutil.Big)(big.NewInt(0)),
-		Data:     path.ApprovalPackedData,
-		Nonce:    path.ApprovalTxNonce,
-		Gas:      (*hexutil.Uint64)(&path.ApprovalGasAmount),
-		ValueOut: (*hexutil.Big)(big.NewInt(0)),
-
-		// additional fields version 1
-		FromChainID: path.FromChain.ChainID,
-	}
-
-	// set appropriate fields based on EIP-1559 compatibility of the chain
-	if !path.FromChain.EIP1559Enabled {
-		approavalSendArgs.GasPrice = path.ApprovalGasPrice
-	} else {
-		approavalSendArgs.MaxFeePerGas = path.ApprovalMaxFeesPerGas
-		approavalSendArgs.MaxPriorityFeePerGas = path.ApprovalPriorityFee
-	}
-
-	if path.FromToken != nil {
-		approavalSendArgs.FromTokenID = path.FromToken.Symbol
-	}
+	approavalSendArgs := pathprocessor.BuildApprovalSendTxArgs(path, addressFrom)
 
 	builtApprovalTx, usedNonce, err := transactor.ValidateAndBuildTransaction(approavalSendArgs.FromChainID, *approavalSendArgs, lastUsedNonce)
 	if err != nil {
@@ -130,72 +103,26 @@ func buildTxForPath(path *routes.Path, pathProcessors map[string]pathprocessor.P
 		lastUsedNonce = nonce
 	}
 
-	sendArgs := &wallettypes.SendTxArgs{
-		Version: wallettypes.SendTxArgsVersion1,
-
-		// tx fields
-		From:  types.Address(processorInputParams.FromAddr),
-		Value: path.AmountIn,
-		Data:  path.TxPackedData,
-		Nonce: path.TxNonce,
-		Gas:   (*hexutil.Uint64)(&path.TxGasAmount),
-
-		// additional fields version 1
-		ValueIn:            path.AmountIn,
-		ValueOut:           path.AmountOut,
-		FromChainID:        path.FromChain.ChainID,
-		ToChainID:          path.ToChain.ChainID,
-		SlippagePercentage: processorInputParams.SlippagePercentage,
-	}
-
-	if !path.FromChain.EIP1559Enabled {
-		sendArgs.GasPrice = path.TxGasPrice
-	} else {
-		sendArgs.MaxFeePerGas = path.TxMaxFeesPerGas
-		sendArgs.MaxPriorityFeePerGas = path.TxPriorityFee
-	}
-
-	isContractDeployment := path.ProcessorName == pathProcessorCommon.ProcessorCommunityDeployCollectiblesName ||
-		path.ProcessorName == pathProcessorCommon.ProcessorCommunityDeployAssetsName
-	if !isContractDeployment {
-		addrTo := types.Address(processorInputParams.ToAddr)
-		sendArgs.To = &addrTo
-	}
+	sendArgs := pathprocessor.BuildSendTxArgs(path, processorInputParams)
 
-	if path.FromToken != nil {
-		sendArgs.FromTokenID = path.FromToken.Symbol
-		sendArgs.ToContractAddress = types.Address(path.FromToken.Address)
-
-		// special handling for transfer tx if selected token is not ETH
-		// TODO: we should fix that in the trasactor, but till then, the best place to handle it is here
-		if !path.FromToken.IsNative() {
-			sendArgs.Value = (*hexutil.Big)(big.NewInt(0))
-
-			if path.ProcessorName == pathProcessorCommon.ProcessorTransferName ||
-				path.ProcessorName == pathProcessorCommon.ProcessorStickersBuyName ||
-				path.ProcessorName == pathProcessorCommon.ProcessorENSRegisterName ||
-				path.ProcessorName == pathProcessorCommon.ProcessorENSReleaseName ||
-				path.ProcessorName == pathProcessorCommon.ProcessorENSPublicKeyName ||
-				path.ProcessorName == pathProcessorCommon.ProcessorERC721Name ||
-				path.ProcessorName == pathProcessorCommon.ProcessorERC1155Name {
-				// TODO: update functions from `TransactorIface` to use `ToContractAddress` (as an address of the contract a transaction should be sent to)
-				// and `To` (as the destination address, recipient) of `SendTxArgs` struct appropriately
-				toContractAddr := types.Address(path.FromToken.Address)
-				sendArgs.To = &toContractAddr
+	if userOpProcessor, ok := pathProcessors[path.ProcessorName].(pathprocessor.UserOperationProcessor); ok {
+		isSmartAccount, err := userOpProcessor.IsSmartAccount(path.FromChain.ChainID, processorInputParams.FromAddr)
+		if err != nil {
+			return nil, err
+		}
+		if isSmartAccount {
+			// the nonce of a smart account is managed by the EntryPoint, the used nonces are not affected
+			userOp, hashToSign, err := userOpProcessor.BuildUserOperation(sendArgs)
+			if err != nil {
+				return nil, err
 			}
-		} else if path.ProcessorName == pathProcessorCommon.ProcessorCommunityDeployOwnerTokenName || // special handling for community related txs, tokenID for those txs is ETH
-			path.ProcessorName == pathProcessorCommon.ProcessorCommunityMintTokensName ||
-			path.ProcessorName == pathProcessorCommon.ProcessorCommunityRemoteBurnName ||
-			path.ProcessorName == pathProcessorCommon.ProcessorCommunityBurnName ||
-			path.ProcessorName == pathProcessorCommon.ProcessorCommunitySetSignerPubKeyName {
-			toContractAddr := types.Address(*path.UsedContractAddress)
-			sendArgs.To = &toContractAddr
-			sendArgs.ToContractAddress = toContractAddr
+			return &wallettypes.TransactionData{
+				TxArgs:     sendArgs,
+				UserOp:     userOp,
+				HashToSign: hashToSign,
+			}, nil
 		}
 	}
-	if path.ToToken != nil {
-		sendArgs.ToTokenID = path.ToToken.Symbol
-	}
 
 	builtTx, usedNonce, err := pathProcessors[path.ProcessorName].BuildTransactionV2(sendArgs, lastUsedNonce)
 	if err != nil {
@@ -222,49 +149,118 @@ func (tm *TransactionManager) BuildTransactionsFromRoute(route routes.Route, pat
 		return nil, 0, 0, err
 	}
 
-	keypair, err := tm.accountsDB.GetKeypairByKeyUID(accFrom.KeyUID)
-	if err != nil {
-		return nil, 0, 0, err
+	var keypair *accounts.Keypair
+	if !accFrom.IsKeypairless() {
+		keypair, err = tm.accountsDB.GetKeypairByKeyUID(accFrom.KeyUID)
+		if err != nil {
+			return nil, 0, 0, err
+		}
 	}
 
-	response := &responses.SigningDetails{
-		Address:       accFrom.Address,
-		AddressPath:   accFrom.Path,
-		KeyUid:        accFrom.KeyUID,
-		SignOnKeycard: keypair.MigratedToKeycard(),
-	}
+	// transactions of a Safe are sent and signed by one of its local owners, resolved while building the txs, the
+	// hashes of the route are signed by a single account
+	signingAddress := accFrom.Address
+	var safeExecutor *types.Address
+	var hashes []types.Hash
+
+	if processorInputParams.BatchTransactions {
+		batchTxData, err := tm.buildBatchTxForRoute(route, accFrom, pathProcessors, processorInputParams)
+		if err != nil {
+			fromChainID, toChainID := route.GetFirstPathChains()
+			return nil, fromChainID, toChainID, err
+		}
+		for _, path := range route {
+			txDetails := tm.getOrInitDetailsForPath(path)
+			txDetails.ApprovalTxData = nil
+			txDetails.TxData = batchTxData
+		}
+		hashes = append(hashes, batchTxData.HashToSign)
+	} else {
+		usedNonces := make(map[uint64]int64)
+		for _, path := range route {
+			signer := ethTypes.NewLondonSigner(big.NewInt(int64(path.FromChain.ChainID)))
 
-	usedNonces := make(map[uint64]int64)
-	for _, path := range route {
-		signer := ethTypes.NewLondonSigner(big.NewInt(int64(path.FromChain.ChainID)))
+			txDetails := tm.getOrInitDetailsForPath(path)
 
-		txDetails := tm.getOrInitDetailsForPath(path)
+			if accFrom.Type == accounts.AccountTypeSmart {
+				if _, ok := pathProcessors[path.ProcessorName].(pathprocessor.UserOperationProcessor); !ok || path.ApprovalRequired {
+					return nil, path.FromChain.ChainID, path.ToChain.ChainID, pathprocessor.ErrSmartAccountSendNotSupported
+				}
+			}
+
+			// always check for approval tx first for the path and build it if needed
+			if path.ApprovalRequired && !tm.ApprovalPlacedForPath(path.ProcessorName) {
+				txDetails.ApprovalTxData, err = buildApprovalTxForPath(tm.transactor, path, processorInputParams.FromAddr, usedNonces, signer)
+				if err != nil {
+					return nil, path.FromChain.ChainID, path.ToChain.ChainID, err
+				}
+				hashes = append(hashes, txDetails.ApprovalTxData.HashToSign)
+
+				// if approval is needed for swap, we cannot build the swap tx before the approval tx is mined
+				if path.ProcessorName == pathProcessorCommon.ProcessorSwapParaswapName {
+					continue
+				}
+			}
 
-		// always check for approval tx first for the path and build it if needed
-		if path.ApprovalRequired && !tm.ApprovalPlacedForPath(path.ProcessorName) {
-			txDetails.ApprovalTxData, err = buildApprovalTxForPath(tm.transactor, path, processorInputParams.FromAddr, usedNonces, signer)
+			// build tx for the path
+			txDetails.TxData, err = buildTxForPath(path, pathProcessors, usedNonces, signer, processorInputParams)
 			if err != nil {
 				return nil, path.FromChain.ChainID, path.ToChain.ChainID, err
 			}
-			response.Hashes = append(response.Hashes, txDetails.ApprovalTxData.HashToSign)
-
-			// if approval is needed for swap, we cannot build the swap tx before the approval tx is mined
-			if path.ProcessorName == pathProcessorCommon.ProcessorSwapParaswapName {
-				continue
+			hashes = append(hashes, txDetails.TxData.HashToSign)
+
+			if accFrom.Type == accounts.AccountTypeSafe {
+				executor := txDetails.TxData.TxArgs.From
+				if safeExecutor != nil && *safeExecutor != executor {
+					return nil, path.FromChain.ChainID, path.ToChain.ChainID, ErrSafeExecutorsMismatch
+				}
+				safeExecutor = &executor
+				signingAddress = executor
 			}
 		}
+	}
+
+	if accFrom.Type == accounts.AccountTypeSmart {
+		// user operations are signed by the owner of the smart account
+		signingAddress, err = smartAccountOwner(accFrom)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	accSigning := accFrom
+	if keypair == nil {
+		accSigning, err = tm.accountsDB.GetAccountByAddress(signingAddress)
+		if err != nil {
+			return nil, 0, 0, err
+		}
 
-		// build tx for the path
-		txDetails.TxData, err = buildTxForPath(path, pathProcessors, usedNonces, signer, processorInputParams)
+		keypair, err = tm.accountsDB.GetKeypairByKeyUID(accSigning.KeyUID)
 		if err != nil {
-			return nil, path.FromChain.ChainID, path.ToChain.ChainID, err
+			return nil, 0, 0, err
 		}
-		response.Hashes = append(response.Hashes, txDetails.TxData.HashToSign)
+	}
+
+	response := &responses.SigningDetails{
+		Address:       accSigning.Address,
+		AddressPath:   accSigning.Path,
+		KeyUid:        accSigning.KeyUID,
+		SignOnKeycard: keypair.MigratedToKeycard(),
+		Hashes:        hashes,
 	}
 
 	return response, 0, 0, nil
 }
 
+// smartAccountOwner returns the address of the owner of a smart account, the account holds the owner's public key
+func smartAccountOwner(acc *accounts.Account) (types.Address, error) {
+	pubKey, err := crypto.UnmarshalPubkey(acc.PublicKey)
+	if err != nil {
+		return types.Address{}, ErrSmartAccountOwnerNotFound
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
 func getSignatureForTxHash(txHash string, signatures map[string]requests.SignatureDetails) ([]byte, error) {
 	sigDetails, ok := signatures[txHash]
 	if !ok {
@@ -358,7 +354,28 @@ func addSignatureAndSendTransaction(
 	return responses.NewRouterSentTransaction(txData.TxArgs, txData.SentHash, isApproval), nil
 }
 
-func (tm *TransactionManager) SendRouterTransactions(ctx context.Context, multiTx *MultiTransaction) (transactions []*responses.RouterSentTransaction, fromChainID uint64, toChainID uint64, err error) {
+func sendUserOperation(
+	pathProcessors map[string]pathprocessor.PathProcessor,
+	processorName string,
+	txData *wallettypes.TransactionData,
+	multiTransactionID walletCommon.MultiTransactionIDType) (*responses.RouterSentTransaction, error) {
+	userOpProcessor, ok := pathProcessors[processorName].(pathprocessor.UserOperationProcessor)
+	if !ok {
+		return nil, pathprocessor.ErrSmartAccountSendNotSupported
+	}
+
+	var err error
+	txData.SentHash, err = userOpProcessor.SendUserOperation(txData, multiTransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	txData.TxArgs.MultiTransactionID = multiTransactionID
+
+	return responses.NewRouterSentTransaction(txData.TxArgs, txData.SentHash, false), nil
+}
+
+func (tm *TransactionManager) SendRouterTransactions(ctx context.Context, multiTx *MultiTransaction, pathProcessors map[string]pathprocessor.PathProcessor) (transactions []*responses.RouterSentTransaction, fromChainID uint64, toChainID uint64, err error) {
 	transactions = make([]*responses.RouterSentTransaction, 0)
 
 	// send transactions
@@ -382,7 +399,13 @@ func (tm *TransactionManager) SendRouterTransactions(ctx context.Context, multiT
 
 		if desc.TxData != nil && !desc.IsTxPlaced() {
 			var response *responses.RouterSentTransaction
-			response, err = addSignatureAndSendTransaction(tm.transactor, desc.TxData, multiTx.ID, false)
+			if desc.TxData.UserOp != nil {
+				response, err = sendUserOperation(pathProcessors, desc.RouterPath.ProcessorName, desc.TxData, multiTx.ID)
+			} else if desc.TxData.SetCodeTx != nil {
+				response, err = addSignatureAndSendSetCodeTransaction(tm.transactor, desc.TxData, multiTx.ID)
+			} else {
+				response, err = addSignatureAndSendTransaction(tm.transactor, desc.TxData, multiTx.ID, false)
+			}
 			if err != nil {
 				return
 			}
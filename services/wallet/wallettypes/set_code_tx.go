@@ -0,0 +1,222 @@
+package wallettypes
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// SetCodeTxType is the EIP-7702 transaction type
+	SetCodeTxType = 0x04
+	// setCodeAuthorizationMagic prefixes the signed payload of an EIP-7702 authorization
+	setCodeAuthorizationMagic = 0x05
+)
+
+var (
+	ErrInvalidSetCodeSignature = errors.New("invalid EIP-7702 signature")
+	ErrSetCodeTxNotSigned      = errors.New("EIP-7702 transaction is not signed")
+)
+
+// SetCodeAuthorization delegates the code of the signing account to `Address`, the delegation is cleared if it's
+// the zero address. A zero chain ID makes the authorization valid on all chains.
+type SetCodeAuthorization struct {
+	ChainID *hexutil.Big   `json:"chainId"`
+	Address common.Address `json:"address"`
+	Nonce   hexutil.Uint64 `json:"nonce"`
+	V       hexutil.Uint64 `json:"yParity"`
+	R       *hexutil.Big   `json:"r"`
+	S       *hexutil.Big   `json:"s"`
+}
+
+func NewSetCodeAuthorization(chainID uint64, address common.Address, nonce uint64) *SetCodeAuthorization {
+	return &SetCodeAuthorization{
+		ChainID: (*hexutil.Big)(new(big.Int).SetUint64(chainID)),
+		Address: address,
+		Nonce:   hexutil.Uint64(nonce),
+	}
+}
+
+// SigHash returns the hash signed by the authority, `keccak256(0x05 || rlp([chain_id, address, nonce]))`
+func (a *SetCodeAuthorization) SigHash() common.Hash {
+	return prefixedRlpHash(setCodeAuthorizationMagic, []interface{}{
+		bigOrZero(a.ChainID),
+		a.Address,
+		uint64(a.Nonce),
+	})
+}
+
+func (a *SetCodeAuthorization) IsSigned() bool {
+	return a.R != nil && a.S != nil
+}
+
+// WithSignature returns a copy of the authorization with the signature in the [R || S || V] format
+func (a *SetCodeAuthorization) WithSignature(sig []byte) (*SetCodeAuthorization, error) {
+	r, s, v, err := decodeSetCodeSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+	cpy := *a
+	cpy.R, cpy.S, cpy.V = r, s, v
+	return &cpy, nil
+}
+
+// Authority recovers the account delegated by the authorization
+func (a *SetCodeAuthorization) Authority() (common.Address, error) {
+	if !a.IsSigned() {
+		return common.Address{}, ErrInvalidSetCodeSignature
+	}
+	return recoverSetCodeSigner(a.SigHash(), a.R, a.S, a.V)
+}
+
+func (a *SetCodeAuthorization) rlpItems() []interface{} {
+	return []interface{}{
+		bigOrZero(a.ChainID),
+		a.Address,
+		uint64(a.Nonce),
+		uint64(a.V),
+		bigOrZero(a.R),
+		bigOrZero(a.S),
+	}
+}
+
+// SetCodeTx is an EIP-7702 transaction, it's built and encoded here since the go-ethereum version in use doesn't
+// support the type
+type SetCodeTx struct {
+	ChainID    *hexutil.Big           `json:"chainId"`
+	Nonce      hexutil.Uint64         `json:"nonce"`
+	GasTipCap  *hexutil.Big           `json:"maxPriorityFeePerGas"`
+	GasFeeCap  *hexutil.Big           `json:"maxFeePerGas"`
+	Gas        hexutil.Uint64         `json:"gas"`
+	To         common.Address         `json:"to"`
+	Value      *hexutil.Big           `json:"value"`
+	Data       hexutil.Bytes          `json:"input"`
+	AccessList ethTypes.AccessList    `json:"accessList"`
+	AuthList   []SetCodeAuthorization `json:"authorizationList"`
+	V          hexutil.Uint64         `json:"yParity"`
+	R          *hexutil.Big           `json:"r"`
+	S          *hexutil.Big           `json:"s"`
+}
+
+func (tx *SetCodeTx) payload() []interface{} {
+	authList := make([]interface{}, 0, len(tx.AuthList))
+	for i := range tx.AuthList {
+		authList = append(authList, tx.AuthList[i].rlpItems())
+	}
+	accessList := tx.AccessList
+	if accessList == nil {
+		accessList = ethTypes.AccessList{}
+	}
+
+	return []interface{}{
+		bigOrZero(tx.ChainID),
+		uint64(tx.Nonce),
+		bigOrZero(tx.GasTipCap),
+		bigOrZero(tx.GasFeeCap),
+		uint64(tx.Gas),
+		tx.To,
+		bigOrZero(tx.Value),
+		[]byte(tx.Data),
+		accessList,
+		authList,
+	}
+}
+
+// SigHash returns the hash signed by the sender
+func (tx *SetCodeTx) SigHash() common.Hash {
+	return prefixedRlpHash(SetCodeTxType, tx.payload())
+}
+
+func (tx *SetCodeTx) IsSigned() bool {
+	return tx.R != nil && tx.S != nil
+}
+
+// WithSignature returns a copy of the transaction with the signature in the [R || S || V] format
+func (tx *SetCodeTx) WithSignature(sig []byte) (*SetCodeTx, error) {
+	r, s, v, err := decodeSetCodeSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+	cpy := *tx
+	cpy.R, cpy.S, cpy.V = r, s, v
+	return &cpy, nil
+}
+
+// MarshalBinary returns the signed transaction in the format expected by `eth_sendRawTransaction`
+func (tx *SetCodeTx) MarshalBinary() ([]byte, error) {
+	if !tx.IsSigned() {
+		return nil, ErrSetCodeTxNotSigned
+	}
+	data, err := rlp.EncodeToBytes(append(tx.payload(), uint64(tx.V), bigOrZero(tx.R), bigOrZero(tx.S)))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{SetCodeTxType}, data...), nil
+}
+
+// Hash returns the hash of the signed transaction
+func (tx *SetCodeTx) Hash() (common.Hash, error) {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+func (tx *SetCodeTx) Sender() (common.Address, error) {
+	if !tx.IsSigned() {
+		return common.Address{}, ErrSetCodeTxNotSigned
+	}
+	return recoverSetCodeSigner(tx.SigHash(), tx.R, tx.S, tx.V)
+}
+
+func prefixedRlpHash(prefix byte, x interface{}) common.Hash {
+	data, err := rlp.EncodeToBytes(x)
+	if err != nil {
+		// only basic types are encoded, it can't fail
+		panic(err)
+	}
+	return crypto.Keccak256Hash([]byte{prefix}, data)
+}
+
+func bigOrZero(b *hexutil.Big) *big.Int {
+	if b == nil {
+		return new(big.Int)
+	}
+	return b.ToInt()
+}
+
+func decodeSetCodeSignature(sig []byte) (r, s *hexutil.Big, v hexutil.Uint64, err error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, nil, 0, ErrInvalidSetCodeSignature
+	}
+	yParity := sig[64]
+	if yParity >= 27 {
+		yParity -= 27
+	}
+	if yParity > 1 {
+		return nil, nil, 0, ErrInvalidSetCodeSignature
+	}
+	return (*hexutil.Big)(new(big.Int).SetBytes(sig[:32])), (*hexutil.Big)(new(big.Int).SetBytes(sig[32:64])), hexutil.Uint64(yParity), nil
+}
+
+func recoverSetCodeSigner(hash common.Hash, r, s *hexutil.Big, v hexutil.Uint64) (common.Address, error) {
+	if v > 1 || !crypto.ValidateSignatureValues(byte(v), r.ToInt(), s.ToInt(), true) {
+		return common.Address{}, ErrInvalidSetCodeSignature
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	r.ToInt().FillBytes(sig[:32])
+	s.ToInt().FillBytes(sig[32:64])
+	sig[64] = byte(v)
+
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
@@ -0,0 +1,104 @@
+package wallettypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestSetCodeAuthorizationSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	delegate := common.HexToAddress("0x1234")
+
+	auth := NewSetCodeAuthorization(1, delegate, 5)
+	require.False(t, auth.IsSigned())
+	_, err = auth.Authority()
+	require.ErrorIs(t, err, ErrInvalidSetCodeSignature)
+
+	sig, err := crypto.Sign(auth.SigHash().Bytes(), key)
+	require.NoError(t, err)
+
+	signed, err := auth.WithSignature(sig)
+	require.NoError(t, err)
+	require.False(t, auth.IsSigned())
+	require.True(t, signed.IsSigned())
+
+	authority, err := signed.Authority()
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), authority)
+
+	// the signature doesn't cover another nonce
+	signed.Nonce++
+	authority, err = signed.Authority()
+	if err == nil {
+		require.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), authority)
+	}
+
+	_, err = auth.WithSignature(sig[:64])
+	require.ErrorIs(t, err, ErrInvalidSetCodeSignature)
+}
+
+func TestSetCodeTxSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	auth := NewSetCodeAuthorization(10, common.HexToAddress("0x1234"), 1)
+	authSig, err := crypto.Sign(auth.SigHash().Bytes(), key)
+	require.NoError(t, err)
+	auth, err = auth.WithSignature(authSig)
+	require.NoError(t, err)
+
+	tx := &SetCodeTx{
+		ChainID:   (*hexutil.Big)(big.NewInt(10)),
+		Nonce:     0,
+		GasTipCap: (*hexutil.Big)(big.NewInt(1000)),
+		GasFeeCap: (*hexutil.Big)(big.NewInt(2000)),
+		Gas:       100000,
+		To:        from,
+		Value:     (*hexutil.Big)(big.NewInt(0)),
+		Data:      hexutil.Bytes{0x01, 0x02},
+		AuthList:  []SetCodeAuthorization{*auth},
+	}
+
+	_, err = tx.MarshalBinary()
+	require.ErrorIs(t, err, ErrSetCodeTxNotSigned)
+	_, err = tx.Sender()
+	require.ErrorIs(t, err, ErrSetCodeTxNotSigned)
+
+	sig, err := crypto.Sign(tx.SigHash().Bytes(), key)
+	require.NoError(t, err)
+	// the recovery id is also accepted with the legacy offset
+	sig[64] += 27
+	signedTx, err := tx.WithSignature(sig)
+	require.NoError(t, err)
+	require.Equal(t, tx.SigHash(), signedTx.SigHash())
+
+	sender, err := signedTx.Sender()
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+
+	data, err := signedTx.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, byte(SetCodeTxType), data[0])
+
+	var fields []rlp.RawValue
+	require.NoError(t, rlp.DecodeBytes(data[1:], &fields))
+	require.Len(t, fields, 13)
+
+	var authList [][]rlp.RawValue
+	require.NoError(t, rlp.DecodeBytes(fields[9], &authList))
+	require.Len(t, authList, 1)
+	require.Len(t, authList[0], 6)
+
+	hash, err := signedTx.Hash()
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(data), hash)
+}
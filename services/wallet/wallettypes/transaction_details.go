@@ -9,8 +9,13 @@ import (
 )
 
 type TransactionData struct {
-	TxArgs     *SendTxArgs
-	Tx         *ethTypes.Transaction
+	TxArgs *SendTxArgs
+	Tx     *ethTypes.Transaction
+	// UserOp is set instead of Tx when the transaction is sent from a smart account, SentHash is then the user operation hash
+	UserOp *UserOperation
+	// SetCodeTx is set instead of Tx when the calls of the route are batched in a single EIP-7702 transaction, all
+	// paths of the route then share the same TransactionData
+	SetCodeTx  *SetCodeTx
 	HashToSign types.Hash
 	Signature  []byte
 	SentHash   types.Hash
@@ -0,0 +1,77 @@
+package wallettypes
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var userOperationHashArgs = abi.Arguments{
+	{Type: mustNewABIType("address")},
+	{Type: mustNewABIType("uint256")},
+	{Type: mustNewABIType("bytes32")},
+	{Type: mustNewABIType("bytes32")},
+	{Type: mustNewABIType("uint256")},
+	{Type: mustNewABIType("uint256")},
+	{Type: mustNewABIType("uint256")},
+	{Type: mustNewABIType("uint256")},
+	{Type: mustNewABIType("uint256")},
+	{Type: mustNewABIType("bytes32")},
+}
+
+var userOperationIDArgs = abi.Arguments{
+	{Type: mustNewABIType("bytes32")},
+	{Type: mustNewABIType("address")},
+	{Type: mustNewABIType("uint256")},
+}
+
+func mustNewABIType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// UserOperation is an ERC-4337 (EntryPoint v0.6) operation sent from a smart account through a bundler
+type UserOperation struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         *hexutil.Big   `json:"callGasLimit"`
+	VerificationGasLimit *hexutil.Big   `json:"verificationGasLimit"`
+	PreVerificationGas   *hexutil.Big   `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+}
+
+// Hash returns the user operation hash, as computed by `EntryPoint.getUserOpHash`
+func (op *UserOperation) Hash(entryPoint common.Address, chainID uint64) (common.Hash, error) {
+	packed, err := userOperationHashArgs.Pack(
+		op.Sender,
+		op.Nonce.ToInt(),
+		crypto.Keccak256Hash(op.InitCode),
+		crypto.Keccak256Hash(op.CallData),
+		op.CallGasLimit.ToInt(),
+		op.VerificationGasLimit.ToInt(),
+		op.PreVerificationGas.ToInt(),
+		op.MaxFeePerGas.ToInt(),
+		op.MaxPriorityFeePerGas.ToInt(),
+		crypto.Keccak256Hash(op.PaymasterAndData),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	packed, err = userOperationIDArgs.Pack(crypto.Keccak256Hash(packed), entryPoint, new(big.Int).SetUint64(chainID))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(packed), nil
+}
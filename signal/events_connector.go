@@ -1,16 +1,21 @@
 package signal
 
 import (
+	"encoding/json"
+
 	"github.com/status-im/status-go/eth-node/types"
 )
 
 const (
 	EventConnectorSendRequestAccounts   = "connector.sendRequestAccounts"
 	EventConnectorSendTransaction       = "connector.sendTransaction"
+	EventConnectorSendCalls             = "connector.sendCalls"
 	EventConnectorSign                  = "connector.sign"
 	EventConnectorDAppPermissionGranted = "connector.dAppPermissionGranted"
 	EventConnectorDAppPermissionRevoked = "connector.dAppPermissionRevoked"
 	EventConnectorDAppChainIdSwitched   = "connector.dAppChainIdSwitched"
+	EventConnectorAddEthereumChain      = "connector.addEthereumChain"
+	EventConnectorWatchAsset            = "connector.watchAsset"
 )
 
 type ConnectorDApp struct {
@@ -31,6 +36,16 @@ type ConnectorSendTransactionSignal struct {
 	RequestID string `json:"requestId"`
 	ChainID   uint64 `json:"chainId"`
 	TxArgs    string `json:"txArgs"`
+	// Preview is the human readable summary and the risks of the transaction
+	Preview json.RawMessage `json:"preview,omitempty"`
+}
+
+// ConnectorSendCallsSignal is triggered when a batch of calls is requested to be sent (EIP-5792).
+type ConnectorSendCallsSignal struct {
+	ConnectorDApp
+	RequestID string `json:"requestId"`
+	ChainID   uint64 `json:"chainId"`
+	TxsArgs   string `json:"txsArgs"`
 }
 
 type ConnectorSendDappPermissionGrantedSignal struct {
@@ -45,6 +60,24 @@ type ConnectorSignSignal struct {
 	Challenge string `json:"challenge"`
 	Address   string `json:"address"`
 	Method    string `json:"method"`
+	// Preview is the human readable summary and the risks of the signed message or typed data
+	Preview json.RawMessage `json:"preview,omitempty"`
+}
+
+// ConnectorAddEthereumChainSignal is triggered when a dApp asks to add a new network.
+type ConnectorAddEthereumChainSignal struct {
+	ConnectorDApp
+	RequestID string `json:"requestId"`
+	ChainID   uint64 `json:"chainId"`
+	ChainArgs string `json:"chainArgs"`
+}
+
+// ConnectorWatchAssetSignal is triggered when a dApp asks to track a token.
+type ConnectorWatchAssetSignal struct {
+	ConnectorDApp
+	RequestID string `json:"requestId"`
+	ChainID   uint64 `json:"chainId"`
+	AssetArgs string `json:"assetArgs"`
 }
 
 type ConnectorDAppChainIdSwitchedSignal struct {
@@ -59,22 +92,33 @@ func SendConnectorSendRequestAccounts(dApp ConnectorDApp, requestID string) {
 	})
 }
 
-func SendConnectorSendTransaction(dApp ConnectorDApp, chainID uint64, txArgs string, requestID string) {
+func SendConnectorSendTransaction(dApp ConnectorDApp, chainID uint64, txArgs string, requestID string, preview json.RawMessage) {
 	send(EventConnectorSendTransaction, ConnectorSendTransactionSignal{
 		ConnectorDApp: dApp,
 		RequestID:     requestID,
 		ChainID:       chainID,
 		TxArgs:        txArgs,
+		Preview:       preview,
+	})
+}
+
+func SendConnectorSendCalls(dApp ConnectorDApp, chainID uint64, txsArgs string, requestID string) {
+	send(EventConnectorSendCalls, ConnectorSendCallsSignal{
+		ConnectorDApp: dApp,
+		RequestID:     requestID,
+		ChainID:       chainID,
+		TxsArgs:       txsArgs,
 	})
 }
 
-func SendConnectorSign(dApp ConnectorDApp, requestID, challenge, address string, method string) {
+func SendConnectorSign(dApp ConnectorDApp, requestID, challenge, address string, method string, preview json.RawMessage) {
 	send(EventConnectorSign, ConnectorSignSignal{
 		ConnectorDApp: dApp,
 		RequestID:     requestID,
 		Challenge:     challenge,
 		Address:       address,
 		Method:        method,
+		Preview:       preview,
 	})
 }
 
@@ -93,3 +137,21 @@ func SendConnectorDAppPermissionRevoked(dApp ConnectorDApp) {
 func SendConnectorDAppChainIdSwitched(payload ConnectorDAppChainIdSwitchedSignal) {
 	send(EventConnectorDAppChainIdSwitched, payload)
 }
+
+func SendConnectorAddEthereumChain(dApp ConnectorDApp, chainID uint64, chainArgs string, requestID string) {
+	send(EventConnectorAddEthereumChain, ConnectorAddEthereumChainSignal{
+		ConnectorDApp: dApp,
+		RequestID:     requestID,
+		ChainID:       chainID,
+		ChainArgs:     chainArgs,
+	})
+}
+
+func SendConnectorWatchAsset(dApp ConnectorDApp, chainID uint64, assetArgs string, requestID string) {
+	send(EventConnectorWatchAsset, ConnectorWatchAssetSignal{
+		ConnectorDApp: dApp,
+		RequestID:     requestID,
+		ChainID:       chainID,
+		AssetArgs:     assetArgs,
+	})
+}
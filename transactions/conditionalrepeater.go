@@ -96,6 +96,22 @@ func (t *ConditionalRepeater) RunUntilDone() {
 	}()
 }
 
+// Trigger runs the task right away when it is scheduled, unlike RunUntilDone it
+// doesn't start the task again once it returned true.
+func (t *ConditionalRepeater) Trigger() {
+	t.ctxMu.Lock()
+	defer t.ctxMu.Unlock()
+	if t.ctx == nil {
+		return
+	}
+
+	t.runNowMu.Lock()
+	if len(t.runNowCh) == 0 {
+		t.runNowCh <- true
+	}
+	t.runNowMu.Unlock()
+}
+
 // Stop forcefully stops the running task by canceling its context.
 func (t *ConditionalRepeater) Stop() {
 	t.ctxMu.Lock()
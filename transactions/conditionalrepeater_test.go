@@ -77,3 +77,41 @@ func TestConditionalRepeater_Stop(t *testing.T) {
 
 	taskFinishedWG.Wait()
 }
+
+func TestConditionalRepeater_Trigger(t *testing.T) {
+	runCh := make(chan bool, 10)
+	done := false
+	var doneMu sync.Mutex
+	taskRunner := NewConditionalRepeater(1*time.Hour, func(ctx context.Context) bool {
+		runCh <- true
+		doneMu.Lock()
+		defer doneMu.Unlock()
+		return done
+	})
+
+	// Not started, nothing to run
+	taskRunner.Trigger()
+	require.False(t, taskRunner.IsRunning())
+
+	taskRunner.RunUntilDone()
+	<-runCh
+
+	// Runs right away instead of waiting for the interval
+	taskRunner.Trigger()
+	select {
+	case <-runCh:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "task should run when triggered")
+	}
+
+	doneMu.Lock()
+	done = true
+	doneMu.Unlock()
+	taskRunner.Trigger()
+	<-runCh
+	require.Eventually(t, func() bool { return !taskRunner.IsRunning() }, 5*time.Second, 10*time.Millisecond)
+
+	taskRunner.Trigger()
+	require.False(t, taskRunner.IsRunning())
+	require.Len(t, runCh, 0)
+}
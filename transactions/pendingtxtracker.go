@@ -50,6 +50,8 @@ const (
 	Pending TxStatus = "Pending"
 	Success TxStatus = "Success"
 	Failed  TxStatus = "Failed"
+	// Replaced is set when another transaction with the same nonce (speed-up or cancel) was mined instead
+	Replaced TxStatus = "Replaced"
 )
 
 type AutoDeleteType = bool
@@ -73,12 +75,22 @@ type PendingTxUpdatePayload struct {
 	TxIdentity
 	TxDetails
 	Deleted bool `json:"deleted"`
+	// Replacement is set when the added transaction replaces another pending one
+	Replacement *TxReplacement `json:"replacement,omitempty"`
 }
 
 type StatusChangedPayload struct {
 	TxIdentity
 	TxDetails
 	Status TxStatus `json:"status"`
+	// ReplacedBy is the hash of the mined transaction when Status is Replaced
+	ReplacedBy *eth.Hash `json:"replacedBy,omitempty"`
+}
+
+// UserOperationStatusFetcher resolves the status of ERC-4337 user operations, Pending is returned until a bundler
+// includes the operation in a transaction
+type UserOperationStatusFetcher interface {
+	FetchUserOperationStatus(ctx context.Context, chainID uint64, userOpHash eth.Hash) (TxStatus, error)
 }
 
 // PendingTxTracker implements StatusService in common/status_node_service.go
@@ -87,6 +99,7 @@ type PendingTxTracker struct {
 	routeExecutionStorage *storage.DB
 	trackedTxDB           *DB
 	rpcClient             rpc.ClientInterface
+	userOpFetcher         UserOperationStatusFetcher
 
 	eventFeed *event.Feed
 
@@ -109,9 +122,15 @@ func NewPendingTxTracker(db *sql.DB, rpcClient rpc.ClientInterface, eventFeed *e
 	return tm
 }
 
+// SetUserOperationStatusFetcher enables tracking of UserOperation entries, they stay pending until it's set
+func (tm *PendingTxTracker) SetUserOperationStatusFetcher(fetcher UserOperationStatusFetcher) {
+	tm.userOpFetcher = fetcher
+}
+
 type txStatusRes struct {
-	Status TxStatus
-	hash   eth.Hash
+	Status     TxStatus
+	hash       eth.Hash
+	replacedBy *eth.Hash
 }
 
 func (tm *PendingTxTracker) fetchAndUpdateDB(ctx context.Context) bool {
@@ -125,12 +144,32 @@ func (tm *PendingTxTracker) fetchAndUpdateDB(ctx context.Context) bool {
 	tm.logger.Debug("Checking for PT status", zap.Int("count", len(txs)))
 
 	txsMap := make(map[common.ChainID][]eth.Hash)
+	userOpsMap := make(map[common.ChainID][]eth.Hash)
 	for _, tx := range txs {
 		chainID := tx.ChainID
+		if tx.Type == UserOperation {
+			userOpsMap[chainID] = append(userOpsMap[chainID], tx.Hash)
+			continue
+		}
 		txsMap[chainID] = append(txsMap[chainID], tx.Hash)
 	}
 
 	doneCount := 0
+	for chainID, userOps := range userOpsMap {
+		batchRes := tm.fetchUserOperationsStatus(ctx, chainID, userOps)
+		if len(batchRes) == 0 {
+			continue
+		}
+		doneCount += len(batchRes)
+
+		updateRes, err := tm.updateDBStatus(ctx, chainID, batchRes)
+		if err != nil {
+			tm.logger.Error("Failed to update user operations status for", zap.Stringer("chainID", chainID), zap.Error(err))
+			continue
+		}
+		tm.emitNotifications(chainID, updateRes)
+	}
+
 	// Batch request for each chain
 	for chainID, txs := range txsMap {
 		tm.logger.Debug("Processing PTs", zap.Stringer("chainID", chainID), zap.Int("count", len(txs)))
@@ -152,8 +191,13 @@ func (tm *PendingTxTracker) fetchAndUpdateDB(ctx context.Context) bool {
 			continue
 		}
 
-		tm.logger.Debug("Emit notifications for PTs", zap.Stringer("chainID", chainID), zap.Int("count", len(updateRes)))
-		tm.emitNotifications(chainID, updateRes)
+		replacedRes, err := tm.markReplaced(ctx, chainID, updateRes)
+		if err != nil {
+			tm.logger.Error("Failed to mark replaced transactions for", zap.Stringer("chainID", chainID), zap.Error(err))
+		}
+
+		tm.logger.Debug("Emit notifications for PTs", zap.Stringer("chainID", chainID), zap.Int("count", len(updateRes)), zap.Int("replaced", len(replacedRes)))
+		tm.emitNotifications(chainID, append(updateRes, replacedRes...))
 	}
 
 	if len(txs) == doneCount {
@@ -246,6 +290,30 @@ func fetchBatchTxStatus(ctx context.Context, rpcClient rpc.ClientInterface, chai
 	return res, nil
 }
 
+// fetchUserOperationsStatus returns the user operations included by the bundler, the others are still pending
+func (tm *PendingTxTracker) fetchUserOperationsStatus(ctx context.Context, chainID common.ChainID, hashes []eth.Hash) []txStatusRes {
+	if tm.userOpFetcher == nil {
+		return nil
+	}
+
+	res := make([]txStatusRes, 0, len(hashes))
+	for _, hash := range hashes {
+		status, err := tm.userOpFetcher.FetchUserOperationStatus(ctx, uint64(chainID), hash)
+		if err != nil {
+			tm.logger.Error("Failed to get user operation status", zap.Stringer("hash", hash), zap.Error(err))
+			continue
+		}
+		if status == Pending {
+			continue
+		}
+		res = append(res, txStatusRes{
+			hash:   hash,
+			Status: status,
+		})
+	}
+	return res
+}
+
 // updateDBStatus returns entries that were updated only
 func (tm *PendingTxTracker) updateDBStatus(ctx context.Context, chainID common.ChainID, statuses []txStatusRes) ([]txStatusRes, error) {
 	for _, br := range statuses {
@@ -343,6 +411,109 @@ func (tm *PendingTxTracker) updateDBStatus(ctx context.Context, chainID common.C
 	return res, nil
 }
 
+// markReplaced marks the still pending transactions linked by replacement to the mined ones as Replaced
+func (tm *PendingTxTracker) markReplaced(ctx context.Context, chainID common.ChainID, minedTxs []txStatusRes) ([]txStatusRes, error) {
+	replaced := make([]txStatusRes, 0)
+	for _, mined := range minedTxs {
+		group, err := tm.trackedTxDB.GetReplacementGroup(TxIdentity{
+			ChainID: chainID,
+			Hash:    mined.hash,
+		})
+		if err != nil {
+			tm.logger.Error("Failed to get replacement group", zap.Stringer("hash", mined.hash), zap.Error(err))
+			continue
+		}
+
+		for _, hash := range group {
+			trackedTx, err := tm.trackedTxDB.GetTx(TxIdentity{
+				ChainID: chainID,
+				Hash:    hash,
+			})
+			if err != nil {
+				tm.logger.Warn("Missing tracked replacement", zap.Stringer("hash", hash), zap.Error(err))
+				continue
+			}
+
+			if trackedTx.Status != Pending {
+				continue
+			}
+
+			minedHash := mined.hash
+			replaced = append(replaced, txStatusRes{
+				Status:     Replaced,
+				hash:       hash,
+				replacedBy: &minedHash,
+			})
+		}
+	}
+
+	if len(replaced) == 0 {
+		return nil, nil
+	}
+
+	return tm.updateDBStatus(ctx, chainID, replaced)
+}
+
+// RevertReorganized sets back to Pending the tracked transactions mined in blocks dropped by a reorganization of
+// the chain. The ones already mined in the new blocks get their status again right away, the others are watched
+// until they are mined again.
+func (tm *PendingTxTracker) RevertReorganized(ctx context.Context, chainID common.ChainID, hashes []eth.Hash) error {
+	reverted := make([]txStatusRes, 0, len(hashes))
+	revertedHashes := make([]eth.Hash, 0, len(hashes))
+	for _, hash := range hashes {
+		txID := TxIdentity{
+			ChainID: chainID,
+			Hash:    hash,
+		}
+		trackedTx, err := tm.trackedTxDB.GetTx(txID)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				tm.logger.Error("Failed to get tracked transaction", zap.Stringer("hash", hash), zap.Error(err))
+			}
+			continue
+		}
+		if trackedTx.Status == Pending {
+			continue
+		}
+
+		err = tm.trackedTxDB.UpdateTxStatus(txID, Pending)
+		if err != nil {
+			return err
+		}
+		_, err = tm.db.ExecContext(ctx, `UPDATE pending_transactions SET status = ? WHERE network_id = ? AND hash = ?`, Pending, chainID, hash)
+		if err != nil {
+			return err
+		}
+
+		reverted = append(reverted, txStatusRes{
+			Status: Pending,
+			hash:   hash,
+		})
+		revertedHashes = append(revertedHashes, hash)
+	}
+
+	if len(reverted) == 0 {
+		return nil
+	}
+	tm.logger.Debug("Reverted reorganized transactions", zap.Stringer("chainID", chainID), zap.Int("count", len(reverted)))
+	tm.emitNotifications(chainID, reverted)
+
+	batchRes, err := fetchBatchTxStatus(ctx, tm.rpcClient, chainID, revertedHashes, tm.logger)
+	if err != nil {
+		tm.logger.Error("Failed to fetch reorganized transactions status", zap.Stringer("chainID", chainID), zap.Error(err))
+	} else if len(batchRes) > 0 {
+		updateRes, err := tm.updateDBStatus(ctx, chainID, batchRes)
+		if err != nil {
+			return err
+		}
+		tm.emitNotifications(chainID, updateRes)
+	}
+
+	tm.taskRunner.RunUntilDone()
+
+	return nil
+}
+
 func (tm *PendingTxTracker) updateTxDetails(txDetails *TxDetails, chainID uint64, txHash ethTypes.Hash) {
 	if txDetails == nil {
 		txDetails = &TxDetails{}
@@ -382,7 +553,8 @@ func (tm *PendingTxTracker) emitNotifications(chainID common.ChainID, changes []
 					ChainID: chainID,
 					Hash:    change.hash,
 				},
-				Status: change.Status,
+				Status:     change.Status,
+				ReplacedBy: change.replacedBy,
 			}
 
 			tm.updateTxDetails(&payload.TxDetails, chainID.ToUint(), ethTypes.Hash(change.hash))
@@ -422,6 +594,12 @@ func (tm *PendingTxTracker) TrackPendingTransaction(chainID common.ChainID, hash
 	return nil
 }
 
+// OnNewBlock checks the pending transactions right away instead of at the next check interval, the transactions
+// of the block are found as soon as it is known. It doesn't do anything when there are no pending transactions.
+func (tm *PendingTxTracker) OnNewBlock() {
+	tm.taskRunner.Trigger()
+}
+
 func (tm *PendingTxTracker) Start() error {
 	tm.taskRunner.RunUntilDone()
 	return nil
@@ -459,6 +637,9 @@ const (
 	DeployOwnerToken          PendingTrxType = "DeployOwnerToken"
 	SetSignerPublicKey        PendingTrxType = "SetSignerPublicKey"
 	WalletConnectTransfer     PendingTrxType = "WalletConnectTransfer"
+	ConnectorBatchCall        PendingTrxType = "ConnectorBatchCall"
+	// UserOperation entries are tracked by the ERC-4337 user operation hash instead of a transaction hash
+	UserOperation PendingTrxType = "UserOperation"
 )
 
 type PendingTransaction struct {
@@ -591,7 +772,50 @@ func (tm *PendingTxTracker) StoreAndTrackPendingTx(transaction *PendingTransacti
 	return err
 }
 
-func (tm *PendingTxTracker) addPending(transaction *PendingTransaction) error {
+// StoreAndTrackReplacementTx stores a transaction replacing a pending one (same nonce, higher fees) and tracks both
+// until one of them is mined, the other one is then marked as Replaced
+func (tm *PendingTxTracker) StoreAndTrackReplacementTx(replacementType ReplacementType, replacedHash eth.Hash, transaction *PendingTransaction) error {
+	replacement := &TxReplacement{
+		ID: TxIdentity{
+			ChainID: transaction.ChainID,
+			Hash:    transaction.Hash,
+		},
+		ReplacedHash: replacedHash,
+		Type:         replacementType,
+	}
+
+	err := tm.addPending(transaction, replacement)
+	if err != nil {
+		return err
+	}
+
+	tm.notifyPendingTransactionListeners(PendingTxUpdatePayload{
+		TxIdentity:  replacement.ID,
+		Deleted:     false,
+		Replacement: replacement,
+	}, []eth.Address{transaction.From, transaction.To}, transaction.Timestamp)
+
+	tm.taskRunner.RunUntilDone()
+
+	return nil
+}
+
+// GetReplacement returns sql.ErrNoRows if the transaction doesn't replace any other transaction
+func (tm *PendingTxTracker) GetReplacement(chainID common.ChainID, hash eth.Hash) (*TxReplacement, error) {
+	replacement, err := tm.trackedTxDB.GetReplacement(TxIdentity{
+		ChainID: chainID,
+		Hash:    hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &replacement, nil
+}
+
+// addPending replaces the pending entry with the same nonce unless the transaction is a replacement,
+// in which case both are kept until one of them is mined
+func (tm *PendingTxTracker) addPending(transaction *PendingTransaction, replacement *TxReplacement) error {
 	err := tm.trackedTxDB.PutTx(TrackedTx{
 		ID: TxIdentity{
 			ChainID: transaction.ChainID,
@@ -620,37 +844,14 @@ func (tm *PendingTxTracker) addPending(transaction *PendingTransaction) error {
 		_ = tx.Rollback()
 	}()
 
-	exists := true
-	var hash eth.Hash
-
-	err = tx.QueryRow(`
-		SELECT hash
-		FROM
-			pending_transactions
-		WHERE
-			network_id = ?
-		AND
-			from_address = ?
-		AND
-			nonce = ?
-		`,
-		transaction.ChainID,
-		transaction.From,
-		transaction.Nonce).
-		Scan(&hash)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			exists = false
-		} else {
-			return err
-		}
+	if replacement != nil {
+		// Both transactions are kept until one of them is mined
+		err = putReplacement(tx, *replacement)
+	} else {
+		notifyFn, err = tm.deleteSameNonceBySQLTx(tx, transaction)
 	}
-
-	if exists {
-		notifyFn, err = tm.DeleteBySQLTx(tx, transaction.ChainID, hash)
-		if err != nil && err != ErrStillPending {
-			return err
-		}
+	if err != nil {
+		return err
 	}
 
 	// TODO: maybe we should think of making (network_id, from_address, nonce) as primary key instead (network_id, hash) ????
@@ -687,8 +888,40 @@ func (tm *PendingTxTracker) addPending(transaction *PendingTransaction) error {
 	return err
 }
 
+// deleteSameNonceBySQLTx deletes the entry sharing the nonce of the given transaction, if any
+func (tm *PendingTxTracker) deleteSameNonceBySQLTx(tx *sql.Tx, transaction *PendingTransaction) (notify func(), err error) {
+	var hash eth.Hash
+	err = tx.QueryRow(`
+		SELECT hash
+		FROM
+			pending_transactions
+		WHERE
+			network_id = ?
+		AND
+			from_address = ?
+		AND
+			nonce = ?
+		`,
+		transaction.ChainID,
+		transaction.From,
+		transaction.Nonce).
+		Scan(&hash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	notify, err = tm.DeleteBySQLTx(tx, transaction.ChainID, hash)
+	if err != nil && err != ErrStillPending {
+		return nil, err
+	}
+	return notify, nil
+}
+
 func (tm *PendingTxTracker) addPendingAndNotify(transaction *PendingTransaction) error {
-	err := tm.addPending(transaction)
+	err := tm.addPending(transaction, nil)
 
 	// Notify listeners of new pending transaction (used in activity history)
 	if err == nil {
@@ -776,6 +1009,20 @@ func (tm *PendingTxTracker) Watch(ctx context.Context, chainID common.ChainID, h
 	return tx.Status, nil
 }
 
+// GetTrackedTxStatus returns sql.ErrNoRows if the transaction was never tracked
+// Unlike Watch, it keeps reporting the final status after the pending entry was auto-deleted
+func (tm *PendingTxTracker) GetTrackedTxStatus(chainID common.ChainID, hash eth.Hash) (TxStatus, error) {
+	tx, err := tm.trackedTxDB.GetTx(TxIdentity{
+		ChainID: chainID,
+		Hash:    hash,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tx.Status, nil
+}
+
 // Delete returns ErrStillPending if the deleted transaction was still pending
 // The transactions are suppose to be deleted by the client only after they are confirmed
 func (tm *PendingTxTracker) Delete(ctx context.Context, chainID common.ChainID, transactionHash eth.Hash) error {
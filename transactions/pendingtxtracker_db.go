@@ -5,6 +5,9 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 
+	eth "github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/sqlite"
 )
 
@@ -14,6 +17,20 @@ type TrackedTx struct {
 	Status    TxStatus   `json:"status"`
 }
 
+type ReplacementType string
+
+const (
+	SpeedUp ReplacementType = "SpeedUp"
+	Cancel  ReplacementType = "Cancel"
+)
+
+// TxReplacement links a transaction to the pending one it replaces, both sharing the same nonce
+type TxReplacement struct {
+	ID           TxIdentity      `json:"id"`
+	ReplacedHash eth.Hash        `json:"replacedHash"`
+	Type         ReplacementType `json:"type"`
+}
+
 type DB struct {
 	db *sql.DB
 }
@@ -113,3 +130,98 @@ func updateTxStatus(creator sqlite.StatementCreator, txID TxIdentity, status TxS
 
 	return err
 }
+
+func (db *DB) PutReplacement(replacement TxReplacement) error {
+	return putReplacement(db.db, replacement)
+}
+
+func putReplacement(creator sqlite.StatementCreator, replacement TxReplacement) error {
+	q := sq.Replace("tracked_transactions_replacements").
+		Columns("chain_id", "tx_hash", "replaced_tx_hash", "replacement_type").
+		Values(replacement.ID.ChainID, replacement.ID.Hash, replacement.ReplacedHash, replacement.Type)
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := creator.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(args...)
+
+	return err
+}
+
+// GetReplacement returns sql.ErrNoRows if the transaction doesn't replace any other transaction
+func (db *DB) GetReplacement(txID TxIdentity) (replacement TxReplacement, err error) {
+	q := sq.Select("chain_id", "tx_hash", "replaced_tx_hash", "replacement_type").
+		From("tracked_transactions_replacements").
+		Where(sq.Eq{"chain_id": txID.ChainID, "tx_hash": txID.Hash})
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return
+	}
+
+	row := db.db.QueryRow(query, args...)
+	err = row.Scan(&replacement.ID.ChainID, &replacement.ID.Hash, &replacement.ReplacedHash, &replacement.Type)
+
+	return
+}
+
+// GetReplacementGroup returns the hashes of all the transactions linked to txID through replacements, txID excluded.
+// A transaction can be replaced several times (e.g. sped up and then cancelled), only one of the group can be mined
+func (db *DB) GetReplacementGroup(txID TxIdentity) ([]eth.Hash, error) {
+	visited := map[eth.Hash]bool{txID.Hash: true}
+	toVisit := []eth.Hash{txID.Hash}
+	group := make([]eth.Hash, 0)
+
+	for len(toVisit) > 0 {
+		hash := toVisit[0]
+		toVisit = toVisit[1:]
+
+		linked, err := db.getLinkedReplacements(txID.ChainID, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, linkedHash := range linked {
+			if visited[linkedHash] {
+				continue
+			}
+			visited[linkedHash] = true
+			toVisit = append(toVisit, linkedHash)
+			group = append(group, linkedHash)
+		}
+	}
+
+	return group, nil
+}
+
+func (db *DB) getLinkedReplacements(chainID common.ChainID, hash eth.Hash) ([]eth.Hash, error) {
+	rows, err := db.db.Query(`
+		SELECT tx_hash FROM tracked_transactions_replacements WHERE chain_id = ? AND replaced_tx_hash = ?
+		UNION
+		SELECT replaced_tx_hash FROM tracked_transactions_replacements WHERE chain_id = ? AND tx_hash = ?`,
+		chainID, hash, chainID, hash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var linked []eth.Hash
+	for rows.Next() {
+		var linkedHash eth.Hash
+		err = rows.Scan(&linkedHash)
+		if err != nil {
+			return nil, err
+		}
+		linked = append(linked, linkedHash)
+	}
+
+	return linked, rows.Err()
+}
@@ -1,6 +1,7 @@
 package transactions_test
 
 import (
+	"database/sql"
 	"math/rand"
 	"strconv"
 	"testing"
@@ -88,3 +89,51 @@ func Test_PuTrackedTx(t *testing.T) {
 		})
 	}
 }
+
+func Test_ReplacementGroup(t *testing.T) {
+	walletDB, closeFn, err := helpers.SetupTestSQLDB(walletdatabase.DbInitializer{}, "pendingtxtracker-tests")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, closeFn())
+	}()
+
+	db := transactions.NewDB(walletDB)
+
+	chainID := common.ChainID(1)
+	original, speedUp, cancel := eth.Hash{0x01}, eth.Hash{0x02}, eth.Hash{0x03}
+
+	_, err = db.GetReplacement(transactions.TxIdentity{ChainID: chainID, Hash: original})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// The original transaction is sped up and then the speed-up is cancelled
+	err = db.PutReplacement(transactions.TxReplacement{
+		ID:           transactions.TxIdentity{ChainID: chainID, Hash: speedUp},
+		ReplacedHash: original,
+		Type:         transactions.SpeedUp,
+	})
+	require.NoError(t, err)
+	err = db.PutReplacement(transactions.TxReplacement{
+		ID:           transactions.TxIdentity{ChainID: chainID, Hash: cancel},
+		ReplacedHash: speedUp,
+		Type:         transactions.Cancel,
+	})
+	require.NoError(t, err)
+
+	replacement, err := db.GetReplacement(transactions.TxIdentity{ChainID: chainID, Hash: cancel})
+	require.NoError(t, err)
+	require.Equal(t, speedUp, replacement.ReplacedHash)
+	require.Equal(t, transactions.Cancel, replacement.Type)
+
+	group, err := db.GetReplacementGroup(transactions.TxIdentity{ChainID: chainID, Hash: original})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []eth.Hash{speedUp, cancel}, group)
+
+	group, err = db.GetReplacementGroup(transactions.TxIdentity{ChainID: chainID, Hash: cancel})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []eth.Hash{original, speedUp}, group)
+
+	// Replacements are tracked per chain
+	group, err = db.GetReplacementGroup(transactions.TxIdentity{ChainID: common.ChainID(10), Hash: original})
+	require.NoError(t, err)
+	require.Empty(t, group)
+}
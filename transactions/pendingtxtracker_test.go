@@ -20,6 +20,7 @@ import (
 	"github.com/status-im/status-go/rpc/chain/ethclient"
 	mock_rpcclient "github.com/status-im/status-go/rpc/mock/client"
 
+	"github.com/status-im/status-go/services/wallet/bigint"
 	"github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/walletevent"
 	"github.com/status-im/status-go/t/helpers"
@@ -591,3 +592,178 @@ func TestPendingTransactions(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, len(rst))
 }
+
+func TestPendingTxTracker_MarkReplaced(t *testing.T) {
+	m, stop, chainClient, eventFeed := setupTestTransactionDB(t, nil)
+	defer stop()
+
+	txs := MockTestTransactions(t, chainClient, []TestTxSummary{{DontConfirm: true}, {}})
+	// The second transaction speeds up the first one
+	txs[1].From = txs[0].From
+	txs[1].Nonce = txs[0].Nonce
+	*txs[0].AutoDelete = false
+
+	replacement := &TxReplacement{
+		ID: TxIdentity{
+			ChainID: txs[1].ChainID,
+			Hash:    txs[1].Hash,
+		},
+		ReplacedHash: txs[0].Hash,
+		Type:         SpeedUp,
+	}
+
+	err := m.addPending(&txs[0], nil)
+	require.NoError(t, err)
+	err = m.addPending(&txs[1], replacement)
+	require.NoError(t, err)
+
+	// Both transactions are kept until one of them is mined
+	res, err := m.GetAllPending()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(res))
+
+	storedReplacement, err := m.GetReplacement(txs[1].ChainID, txs[1].Hash)
+	require.NoError(t, err)
+	require.Equal(t, replacement, storedReplacement)
+
+	eventChan := make(chan walletevent.Event, 5)
+	sub := eventFeed.Subscribe(eventChan)
+	defer sub.Unsubscribe()
+
+	m.fetchAndUpdateDB(context.Background())
+
+	statuses := make(map[eth.Hash]StatusChangedPayload)
+	for len(statuses) < 2 {
+		select {
+		case we := <-eventChan:
+			if we.Type != EventPendingTransactionStatusChanged {
+				continue
+			}
+			var p StatusChangedPayload
+			err = json.Unmarshal([]byte(we.Message), &p)
+			require.NoError(t, err)
+			statuses[p.Hash] = p
+		case <-time.After(1 * time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	}
+
+	require.Equal(t, Success, statuses[txs[1].Hash].Status)
+	require.Nil(t, statuses[txs[1].Hash].ReplacedBy)
+	require.Equal(t, Replaced, statuses[txs[0].Hash].Status)
+	require.Equal(t, txs[1].Hash, *statuses[txs[0].Hash].ReplacedBy)
+
+	status, err := m.GetTrackedTxStatus(txs[0].ChainID, txs[0].Hash)
+	require.NoError(t, err)
+	require.Equal(t, Replaced, status)
+
+	res, err = m.GetAllPending()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(res))
+
+	// The replaced transaction is kept with its final status since it's not auto deleted
+	replaced, err := m.GetPendingEntry(txs[0].ChainID, txs[0].Hash)
+	require.NoError(t, err)
+	require.Equal(t, Replaced, *replaced.Status)
+}
+
+func TestPendingTxTracker_RevertReorganized(t *testing.T) {
+	m, stop, chainClient, eventFeed := setupTestTransactionDB(t, nil)
+	defer stop()
+
+	// The transaction was mined in a dropped block and is not mined again yet
+	txs := MockTestTransactions(t, chainClient, []TestTxSummary{{DontConfirm: true}})
+	*txs[0].AutoDelete = false
+
+	err := m.addPending(&txs[0], nil)
+	require.NoError(t, err)
+	_, err = m.updateDBStatus(context.Background(), txs[0].ChainID, []txStatusRes{{Status: Success, hash: txs[0].Hash}})
+	require.NoError(t, err)
+
+	eventChan := make(chan walletevent.Event, 5)
+	sub := eventFeed.Subscribe(eventChan)
+	defer sub.Unsubscribe()
+
+	err = m.RevertReorganized(context.Background(), txs[0].ChainID, []eth.Hash{txs[0].Hash, eth.HexToHash("0x1234")})
+	require.NoError(t, err)
+
+	select {
+	case we := <-eventChan:
+		require.Equal(t, EventPendingTransactionStatusChanged, we.Type)
+		var p StatusChangedPayload
+		err = json.Unmarshal([]byte(we.Message), &p)
+		require.NoError(t, err)
+		require.Equal(t, txs[0].Hash, p.Hash)
+		require.Equal(t, Pending, p.Status)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+
+	status, err := m.GetTrackedTxStatus(txs[0].ChainID, txs[0].Hash)
+	require.NoError(t, err)
+	require.Equal(t, Pending, status)
+
+	res, err := m.GetAllPending()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res))
+
+	err = m.Stop()
+	require.NoError(t, err)
+	waitForTaskToStop(m)
+}
+
+type testUserOperationStatusFetcher struct {
+	status TxStatus
+}
+
+func (f *testUserOperationStatusFetcher) FetchUserOperationStatus(ctx context.Context, chainID uint64, userOpHash eth.Hash) (TxStatus, error) {
+	return f.status, nil
+}
+
+func TestPendingTxTracker_UserOperation(t *testing.T) {
+	m, stop, _, eventFeed := setupTestTransactionDB(t, nil)
+	defer stop()
+
+	m.SetUserOperationStatusFetcher(&testUserOperationStatusFetcher{status: Success})
+
+	eventChan := make(chan walletevent.Event, 3)
+	sub := eventFeed.Subscribe(eventChan)
+	defer sub.Unsubscribe()
+
+	userOp := PendingTransaction{
+		Hash:      eth.HexToHash("0x4337"),
+		Timestamp: uint64(time.Now().Unix()),
+		Value:     bigint.BigInt{Int: big.NewInt(0)},
+		From:      eth.HexToAddress("0x5a"),
+		To:        eth.HexToAddress("0xbeef"),
+		Type:      UserOperation,
+		ChainID:   common.ChainID(common.EthereumMainnet),
+	}
+	err := m.StoreAndTrackPendingTx(&userOp)
+	require.NoError(t, err)
+
+	for statusChanged := false; !statusChanged; {
+		select {
+		case we := <-eventChan:
+			if we.Type == EventPendingTransactionStatusChanged {
+				var p StatusChangedPayload
+				err = json.Unmarshal([]byte(we.Message), &p)
+				require.NoError(t, err)
+				require.Equal(t, userOp.Hash, p.Hash)
+				require.Equal(t, Success, p.Status)
+				statusChanged = true
+			}
+		case <-time.After(1 * time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	}
+
+	err = m.Stop()
+	require.NoError(t, err)
+
+	waitForTaskToStop(m)
+
+	res, err := m.GetAllPending()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(res))
+}
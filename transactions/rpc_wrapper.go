@@ -12,6 +12,7 @@ import (
 	"github.com/status-im/status-go/eth-node/types"
 
 	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
 )
 
 // rpcWrapper wraps provides convenient interface for ethereum RPC APIs we need for sending transactions
@@ -72,6 +73,15 @@ func (w *rpcWrapper) SendTransaction(ctx context.Context, tx *gethtypes.Transact
 	return w.SendRawTransaction(ctx, types.EncodeHex(data))
 }
 
+// TransactionByHash returns the transaction with the given hash, isPending is true while it's not mined yet.
+func (w *rpcWrapper) TransactionByHash(ctx context.Context, hash common.Hash) (tx *gethtypes.Transaction, isPending bool, err error) {
+	ethClient, err := w.RPCClient.EthClient(w.chainID)
+	if err != nil {
+		return nil, false, err
+	}
+	return ethClient.TransactionByHash(ctx, hash)
+}
+
 func toCallArg(msg ethereum.CallMsg) interface{} {
 	arg := map[string]interface{}{
 		"from": msg.From,
@@ -91,3 +101,32 @@ func toCallArg(msg ethereum.CallMsg) interface{} {
 	}
 	return arg
 }
+
+// CodeAt returns the code of the given account in the latest state.
+func (w *rpcWrapper) CodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var result hexutil.Bytes
+	err := w.RPCClient.CallContext(ctx, &result, w.chainID, "eth_getCode", account, "latest")
+	return result, err
+}
+
+// EstimateGasWithAuthorizations estimates the gas of an EIP-7702 transaction, the code delegations of the
+// authorizations are applied before the call.
+func (w *rpcWrapper) EstimateGasWithAuthorizations(ctx context.Context, msg ethereum.CallMsg, authList []wallettypes.SetCodeAuthorization) (uint64, error) {
+	arg := toCallArg(msg).(map[string]interface{})
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if len(authList) > 0 {
+		arg["authorizationList"] = authList
+	}
+
+	var hex hexutil.Uint64
+	err := w.RPCClient.CallContext(ctx, &hex, w.chainID, "eth_estimateGas", arg)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(hex), nil
+}
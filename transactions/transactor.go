@@ -5,6 +5,7 @@ package transactions
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"math/big"
@@ -25,6 +26,7 @@ import (
 	"github.com/status-im/status-go/rpc"
 	"github.com/status-im/status-go/services/wallet/bigint"
 	wallet_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/router/fees"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 )
 
@@ -35,11 +37,21 @@ const (
 	defaultGas = 90000
 
 	ValidSignatureSize = 65
+
+	// nodes accept a transaction replacing a pending one only if its fees are bumped by at least 10%
+	replacementFeeBumpPercent = 10
 )
 
 // ErrInvalidSignatureSize is returned if a signature is not 65 bytes to avoid panic from go-ethereum
 var ErrInvalidSignatureSize = errors.New("signature size must be 65")
 
+var (
+	ErrTransactionNotPending     = errors.New("transaction is not pending")
+	ErrInvalidReplacementType    = errors.New("invalid replacement type")
+	ErrReplacementNonceMismatch  = errors.New("replacement transaction must use the nonce of the replaced transaction")
+	ErrReplacementSenderMismatch = errors.New("replacement transaction must be sent from the sender of the replaced transaction")
+)
+
 type ErrBadNonce struct {
 	nonce         uint64
 	expectedNonce uint64
@@ -61,6 +73,13 @@ type TransactorIface interface {
 	BuildTransactionWithSignature(chainID uint64, args wallettypes.SendTxArgs, sig []byte) (*gethtypes.Transaction, error)
 	SendTransactionWithSignature(from common.Address, symbol string, multiTransactionID wallet_common.MultiTransactionIDType, tx *gethtypes.Transaction) (hash types.Hash, err error)
 	StoreAndTrackPendingTx(from common.Address, symbol string, chainID uint64, multiTransactionID wallet_common.MultiTransactionIDType, tx *gethtypes.Transaction) error
+	BuildReplacementTransaction(chainID uint64, replacedHash common.Hash, replacementType ReplacementType) (wallettypes.SendTxArgs, *gethtypes.Transaction, error)
+	SendReplacementTransactionWithSignature(chainID uint64, replacedHash common.Hash, replacementType ReplacementType, args wallettypes.SendTxArgs, sig []byte) (hash types.Hash, err error)
+	GetDelegation(chainID uint64, address common.Address) (delegate common.Address, delegated bool, err error)
+	BuildSetCodeAuthorization(chainID uint64, address common.Address, delegate common.Address, lastUsedNonce int64) (*wallettypes.SetCodeAuthorization, error)
+	BuildSetCodeTransaction(chainID uint64, args wallettypes.SendTxArgs, authList []wallettypes.SetCodeAuthorization, lastUsedNonce int64) (*wallettypes.SetCodeTx, uint64, error)
+	AddSignatureToSetCodeTransaction(tx *wallettypes.SetCodeTx, sig []byte) (*wallettypes.SetCodeTx, error)
+	SendSetCodeTransactionWithSignature(from common.Address, symbol string, multiTransactionID wallet_common.MultiTransactionIDType, tx *wallettypes.SetCodeTx) (hash types.Hash, err error)
 }
 
 // Transactor validates, signs transactions.
@@ -210,6 +229,30 @@ func (t *Transactor) StoreAndTrackPendingTx(from common.Address, symbol string,
 	return t.pendingTracker.StoreAndTrackPendingTx(pTx)
 }
 
+func (t *Transactor) StoreAndTrackReplacementTx(from common.Address, chainID uint64, replacedHash common.Hash, replacementType ReplacementType, tx *gethtypes.Transaction) error {
+	if t.pendingTracker == nil {
+		return nil
+	}
+
+	pTx := createPendingTransaction(from, "", chainID, wallet_common.NoMultiTransactionID, tx)
+
+	// A speed-up keeps the meaning of the replaced transaction, a cancel is a plain self transfer
+	if replacementType == SpeedUp {
+		replaced, err := t.pendingTracker.GetPendingEntry(wallet_common.ChainID(chainID), replacedHash)
+		if err == nil {
+			pTx.Symbol = replaced.Symbol
+			pTx.Type = replaced.Type
+			pTx.AdditionalData = replaced.AdditionalData
+			pTx.MultiTransactionID = replaced.MultiTransactionID
+			pTx.AutoDelete = replaced.AutoDelete
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+	}
+
+	return t.pendingTracker.StoreAndTrackReplacementTx(replacementType, replacedHash, pTx)
+}
+
 func (t *Transactor) sendTransaction(rpcWrapper *rpcWrapper, from common.Address, symbol string,
 	multiTransactionID wallet_common.MultiTransactionIDType, tx *gethtypes.Transaction) (hash types.Hash, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
@@ -267,6 +310,190 @@ func (t *Transactor) BuildTransactionWithSignature(chainID uint64, args walletty
 	return txWithSignature, nil
 }
 
+// BuildReplacementTransaction builds a transaction replacing the pending one with the given hash.
+// It reuses the nonce of the replaced transaction and bumps its fees, a speed-up keeps the original
+// call while a cancel is a 0-value transfer to self.
+func (t *Transactor) BuildReplacementTransaction(chainID uint64, replacedHash common.Hash, replacementType ReplacementType) (args wallettypes.SendTxArgs, tx *gethtypes.Transaction, err error) {
+	rpcWrapper := newRPCWrapper(t.rpcWrapper.RPCClient, chainID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
+	defer cancel()
+
+	replacedTx, from, err := getPendingTransaction(ctx, rpcWrapper, replacedHash)
+	if err != nil {
+		return args, nil, err
+	}
+
+	if replacementType != SpeedUp && replacementType != Cancel {
+		return args, nil, ErrInvalidReplacementType
+	}
+
+	var cancelGas uint64
+	if replacementType == Cancel {
+		cancelGas, err = rpcWrapper.EstimateGas(ctx, ethereum.CallMsg{
+			From:  from,
+			To:    &from,
+			Value: big.NewInt(0),
+		})
+		if err != nil {
+			return args, nil, err
+		}
+	}
+
+	feeManager := &fees.FeeManager{
+		RPCClient: rpcWrapper.RPCClient,
+	}
+	suggestedFees, err := feeManager.SuggestedFees(ctx, chainID)
+	if err != nil {
+		return args, nil, err
+	}
+
+	args, err = buildReplacementArgs(replacedTx, from, replacementType, cancelGas, suggestedFees)
+	if err != nil {
+		return args, nil, err
+	}
+
+	return args, t.buildTransaction(args), nil
+}
+
+// buildReplacementArgs returns the arguments of the transaction replacing replacedTx, cancelGas is the gas of the
+// 0-value transfer to self replacing it on cancel
+func buildReplacementArgs(replacedTx *gethtypes.Transaction, from common.Address, replacementType ReplacementType, cancelGas uint64, suggestedFees *fees.SuggestedFees) (args wallettypes.SendTxArgs, err error) {
+	nonce := hexutil.Uint64(replacedTx.Nonce())
+	args = wallettypes.SendTxArgs{
+		From:  types.Address(from),
+		Nonce: &nonce,
+	}
+
+	var gas uint64
+	switch replacementType {
+	case SpeedUp:
+		if replacedTx.To() != nil {
+			to := types.Address(*replacedTx.To())
+			args.To = &to
+		}
+		args.Value = (*hexutil.Big)(replacedTx.Value())
+		args.Data = replacedTx.Data()
+		gas = replacedTx.Gas()
+	case Cancel:
+		to := types.Address(from)
+		args.To = &to
+		args.Value = (*hexutil.Big)(big.NewInt(0))
+		gas = cancelGas
+	default:
+		return args, ErrInvalidReplacementType
+	}
+	argsGas := hexutil.Uint64(gas)
+	args.Gas = &argsGas
+
+	err = setReplacementFees(&args, replacedTx, suggestedFees)
+	return args, err
+}
+
+// SendReplacementTransactionWithSignature sends a transaction built by BuildReplacementTransaction and tracks it
+// together with the replaced one until one of them is mined
+func (t *Transactor) SendReplacementTransactionWithSignature(chainID uint64, replacedHash common.Hash, replacementType ReplacementType, args wallettypes.SendTxArgs, sig []byte) (hash types.Hash, err error) {
+	if !args.Valid() {
+		return hash, wallettypes.ErrInvalidSendTxArgs
+	}
+
+	if len(sig) != ValidSignatureSize {
+		return hash, ErrInvalidSignatureSize
+	}
+
+	if replacementType != SpeedUp && replacementType != Cancel {
+		return hash, ErrInvalidReplacementType
+	}
+
+	rpcWrapper := newRPCWrapper(t.rpcWrapper.RPCClient, chainID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
+	defer cancel()
+
+	replacedTx, from, err := getPendingTransaction(ctx, rpcWrapper, replacedHash)
+	if err != nil {
+		return hash, err
+	}
+
+	if common.Address(args.From) != from {
+		return hash, ErrReplacementSenderMismatch
+	}
+
+	if args.Nonce == nil || uint64(*args.Nonce) != replacedTx.Nonce() {
+		return hash, ErrReplacementNonceMismatch
+	}
+
+	txWithSignature, err := t.AddSignatureToTransaction(chainID, t.buildTransaction(args), sig)
+	if err != nil {
+		return hash, err
+	}
+
+	err = rpcWrapper.SendTransaction(ctx, txWithSignature)
+	if err != nil {
+		return hash, err
+	}
+
+	err = t.StoreAndTrackReplacementTx(from, chainID, replacedHash, replacementType, txWithSignature)
+	if err != nil {
+		return hash, err
+	}
+
+	return types.Hash(txWithSignature.Hash()), nil
+}
+
+func getPendingTransaction(ctx context.Context, rpcWrapper *rpcWrapper, hash common.Hash) (*gethtypes.Transaction, common.Address, error) {
+	tx, isPending, err := rpcWrapper.TransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	if !isPending {
+		return nil, common.Address{}, ErrTransactionNotPending
+	}
+
+	from, err := gethtypes.Sender(gethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	return tx, from, nil
+}
+
+func setReplacementFees(args *wallettypes.SendTxArgs, replacedTx *gethtypes.Transaction, suggestedFees *fees.SuggestedFees) error {
+	if replacedTx.Type() == gethtypes.DynamicFeeTxType && suggestedFees.EIP1559Enabled {
+		maxFee, priorityFee, _, err := suggestedFees.FeeFor(fees.GasFeeMedium)
+		if err != nil {
+			return err
+		}
+
+		gasTipCap := bumpReplacementFee(replacedTx.GasTipCap(), priorityFee)
+		gasFeeCap := bumpReplacementFee(replacedTx.GasFeeCap(), maxFee)
+		if gasFeeCap.Cmp(gasTipCap) < 0 {
+			gasFeeCap = gasTipCap
+		}
+
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(gasTipCap)
+		args.MaxFeePerGas = (*hexutil.Big)(gasFeeCap)
+		return nil
+	}
+
+	args.GasPrice = (*hexutil.Big)(bumpReplacementFee(replacedTx.GasPrice(), suggestedFees.GasPrice))
+	return nil
+}
+
+// bumpReplacementFee returns the suggested fee if it's high enough for the replacement to be accepted,
+// the minimal accepted fee otherwise
+func bumpReplacementFee(replacedFee *big.Int, suggestedFee *big.Int) *big.Int {
+	minFee := new(big.Int).Mul(replacedFee, big.NewInt(100+replacementFeeBumpPercent))
+	minFee.Div(minFee, big.NewInt(100))
+	minFee.Add(minFee, big.NewInt(1))
+
+	if suggestedFee != nil && suggestedFee.Cmp(minFee) > 0 {
+		return new(big.Int).Set(suggestedFee)
+	}
+	return minFee
+}
+
 func (t *Transactor) HashTransaction(args wallettypes.SendTxArgs) (validatedArgs wallettypes.SendTxArgs, hash types.Hash, err error) {
 	if !args.Valid() {
 		return validatedArgs, hash, wallettypes.ErrInvalidSendTxArgs
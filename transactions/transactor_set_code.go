@@ -0,0 +1,261 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	wallet_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/wallettypes"
+)
+
+// DelegationCodePrefix prefixes the code of an account delegated with EIP-7702, it's followed by the delegate address
+var DelegationCodePrefix = []byte{0xef, 0x01, 0x00}
+
+var (
+	ErrSetCodeTxRequiresDynamicFee = errors.New("EIP-7702 transactions require dynamic fees")
+	ErrSetCodeTxRequiresRecipient  = errors.New("EIP-7702 transactions can't deploy contracts")
+	ErrAuthorizationNotSigned      = errors.New("authorization is not signed")
+	ErrSetCodeTxSenderMismatch     = errors.New("EIP-7702 transaction is not signed by the sender")
+)
+
+// ParseDelegation returns the delegate of an account from its code, false is returned if the account is not delegated
+func ParseDelegation(code []byte) (common.Address, bool) {
+	if len(code) != len(DelegationCodePrefix)+common.AddressLength || !bytes.HasPrefix(code, DelegationCodePrefix) {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(code[len(DelegationCodePrefix):]), true
+}
+
+// GetDelegation returns the address the code of the account is currently delegated to
+func (t *Transactor) GetDelegation(chainID uint64, address common.Address) (delegate common.Address, delegated bool, err error) {
+	rpcWrapper := newRPCWrapper(t.rpcWrapper.RPCClient, chainID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
+	defer cancel()
+
+	code, err := rpcWrapper.CodeAt(ctx, address)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	delegate, delegated = ParseDelegation(code)
+	return delegate, delegated, nil
+}
+
+// BuildSetCodeAuthorization returns the unsigned authorization delegating `address` to `delegate`, to be included in the
+// next transaction sent by `address` (lastUsedNonce is -1 if it's the first tx). The nonce of the sender is incremented
+// before the authorizations are processed, so the authorization uses the nonce following the one of the transaction.
+func (t *Transactor) BuildSetCodeAuthorization(chainID uint64, address common.Address, delegate common.Address, lastUsedNonce int64) (*wallettypes.SetCodeAuthorization, error) {
+	rpcWrapper := newRPCWrapper(t.rpcWrapper.RPCClient, chainID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
+	defer cancel()
+
+	txNonce, err := t.resolveNonce(ctx, rpcWrapper, types.Address(address), nil, lastUsedNonce)
+	if err != nil {
+		return nil, err
+	}
+	return wallettypes.NewSetCodeAuthorization(chainID, delegate, txNonce+1), nil
+}
+
+func (t *Transactor) resolveNonce(ctx context.Context, rpcWrapper *rpcWrapper, from types.Address, nonce *hexutil.Uint64, lastUsedNonce int64) (uint64, error) {
+	if nonce != nil {
+		return uint64(*nonce), nil
+	}
+	if lastUsedNonce < 0 {
+		return t.NextNonce(ctx, rpcWrapper.RPCClient, rpcWrapper.chainID, from)
+	}
+	return uint64(lastUsedNonce) + 1, nil
+}
+
+// BuildSetCodeTransaction builds an EIP-7702 transaction applying the signed authorizations before making the call,
+// returns the transaction and the used nonce (lastUsedNonce is -1 if it's the first tx)
+func (t *Transactor) BuildSetCodeTransaction(chainID uint64, args wallettypes.SendTxArgs, authList []wallettypes.SetCodeAuthorization, lastUsedNonce int64) (*wallettypes.SetCodeTx, uint64, error) {
+	if !args.Valid() {
+		return nil, 0, wallettypes.ErrInvalidSendTxArgs
+	}
+	if !args.IsDynamicFeeTx() {
+		return nil, 0, ErrSetCodeTxRequiresDynamicFee
+	}
+	if args.To == nil {
+		return nil, 0, ErrSetCodeTxRequiresRecipient
+	}
+	for _, auth := range authList {
+		if !auth.IsSigned() {
+			return nil, 0, ErrAuthorizationNotSigned
+		}
+	}
+
+	rpcWrapper := newRPCWrapper(t.rpcWrapper.RPCClient, chainID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
+	defer cancel()
+
+	nonce, err := t.resolveNonce(ctx, rpcWrapper, args.From, args.Nonce, lastUsedNonce)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	to := common.Address(*args.To)
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+
+	var gas uint64
+	if args.Gas != nil {
+		gas = uint64(*args.Gas)
+	} else {
+		gas, err = rpcWrapper.EstimateGasWithAuthorizations(ctx, ethereum.CallMsg{
+			From:      common.Address(args.From),
+			To:        &to,
+			GasFeeCap: args.MaxFeePerGas.ToInt(),
+			GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+			Value:     value,
+			Data:      args.GetInput(),
+		}, authList)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	tx := &wallettypes.SetCodeTx{
+		ChainID:   (*hexutil.Big)(new(big.Int).SetUint64(chainID)),
+		Nonce:     hexutil.Uint64(nonce),
+		GasTipCap: args.MaxPriorityFeePerGas,
+		GasFeeCap: args.MaxFeePerGas,
+		Gas:       hexutil.Uint64(gas),
+		To:        to,
+		Value:     (*hexutil.Big)(value),
+		Data:      hexutil.Bytes(args.GetInput()),
+		AuthList:  authList,
+	}
+	t.logNewTx(args, gas, args.MaxFeePerGas.ToInt(), value)
+
+	return tx, nonce, nil
+}
+
+func (t *Transactor) AddSignatureToSetCodeTransaction(tx *wallettypes.SetCodeTx, sig []byte) (*wallettypes.SetCodeTx, error) {
+	if len(sig) != ValidSignatureSize {
+		return nil, ErrInvalidSignatureSize
+	}
+	return tx.WithSignature(sig)
+}
+
+func (t *Transactor) SendSetCodeTransactionWithSignature(from common.Address, symbol string,
+	multiTransactionID wallet_common.MultiTransactionIDType, tx *wallettypes.SetCodeTx) (hash types.Hash, err error) {
+	sender, err := tx.Sender()
+	if err != nil {
+		return hash, err
+	}
+	if sender != from {
+		return hash, ErrSetCodeTxSenderMismatch
+	}
+
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return hash, err
+	}
+	txHash, err := tx.Hash()
+	if err != nil {
+		return hash, err
+	}
+
+	rpcWrapper := newRPCWrapper(t.rpcWrapper.RPCClient, tx.ChainID.ToInt().Uint64())
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
+	defer cancel()
+
+	err = rpcWrapper.SendRawTransaction(ctx, types.EncodeHex(data))
+	if err != nil {
+		return hash, err
+	}
+
+	if t.pendingTracker != nil {
+		autoDelete := AutoDelete
+		err = t.pendingTracker.StoreAndTrackPendingTx(&PendingTransaction{
+			Hash:               txHash,
+			Timestamp:          uint64(time.Now().Unix()),
+			Value:              bigint.BigInt{Int: tx.Value.ToInt()},
+			From:               from,
+			To:                 tx.To,
+			Nonce:              uint64(tx.Nonce),
+			Data:               string(tx.Data),
+			Type:               WalletTransfer,
+			ChainID:            wallet_common.ChainID(tx.ChainID.ToInt().Uint64()),
+			MultiTransactionID: multiTransactionID,
+			Symbol:             symbol,
+			AutoDelete:         &autoDelete,
+		})
+		if err != nil {
+			return hash, err
+		}
+	}
+
+	return types.Hash(txHash), nil
+}
+
+// SendSetCodeTransaction signs with the account key and sends an EIP-7702 transaction, if `delegate` is set the
+// transaction also delegates the account to it with an authorization signed by the same key
+func (t *Transactor) SendSetCodeTransaction(chainID uint64, args wallettypes.SendTxArgs, delegate *common.Address,
+	verifiedAccount *account.SelectedExtKey, lastUsedNonce int64) (hash types.Hash, nonce uint64, err error) {
+	if err = t.validateAccount(args, verifiedAccount); err != nil {
+		return hash, nonce, err
+	}
+
+	var authList []wallettypes.SetCodeAuthorization
+	if delegate != nil {
+		var auth *wallettypes.SetCodeAuthorization
+		auth, err = t.BuildSetCodeAuthorization(chainID, common.Address(args.From), *delegate, lastUsedNonce)
+		if err != nil {
+			return hash, nonce, err
+		}
+
+		var sig []byte
+		sig, err = gethcrypto.Sign(auth.SigHash().Bytes(), verifiedAccount.AccountKey.PrivateKey)
+		if err != nil {
+			return hash, nonce, err
+		}
+		auth, err = auth.WithSignature(sig)
+		if err != nil {
+			return hash, nonce, err
+		}
+		authList = append(authList, *auth)
+
+		// the tx must use the nonce the authorization was built for
+		txNonce := hexutil.Uint64(uint64(auth.Nonce) - 1)
+		args.Nonce = &txNonce
+	}
+
+	tx, nonce, err := t.BuildSetCodeTransaction(chainID, args, authList, lastUsedNonce)
+	if err != nil {
+		return hash, nonce, err
+	}
+
+	sig, err := gethcrypto.Sign(tx.SigHash().Bytes(), verifiedAccount.AccountKey.PrivateKey)
+	if err != nil {
+		return hash, nonce, err
+	}
+	tx, err = tx.WithSignature(sig)
+	if err != nil {
+		return hash, nonce, err
+	}
+
+	symbol := args.Symbol
+	if args.Version == wallettypes.SendTxArgsVersion1 {
+		symbol = args.FromTokenID
+	}
+
+	hash, err = t.SendSetCodeTransactionWithSignature(common.Address(args.From), symbol, args.MultiTransactionID, tx)
+	return hash, nonce, err
+}
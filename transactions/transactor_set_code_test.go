@@ -0,0 +1,26 @@
+package transactions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseDelegation(t *testing.T) {
+	delegate := common.HexToAddress("0x63c0c19a282a1b52b07dd5a65b58948a07dae32b")
+
+	address, ok := ParseDelegation(append(append([]byte{}, DelegationCodePrefix...), delegate.Bytes()...))
+	require.True(t, ok)
+	require.Equal(t, delegate, address)
+
+	_, ok = ParseDelegation(nil)
+	require.False(t, ok)
+
+	_, ok = ParseDelegation(common.FromHex("0x6080604052"))
+	require.False(t, ok)
+
+	_, ok = ParseDelegation(append([]byte{0xef, 0x01, 0x01}, delegate.Bytes()...))
+	require.False(t, ok)
+}
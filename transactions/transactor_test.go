@@ -11,6 +11,7 @@ import (
 	"github.com/status-im/status-go/rpc/chain/ethclient"
 	"github.com/status-im/status-go/rpc/chain/rpclimiter"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/params"
 	wallet_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/router/fees"
 	"github.com/status-im/status-go/services/wallet/wallettypes"
 	"github.com/status-im/status-go/sqlite"
 	"github.com/status-im/status-go/t/utils"
@@ -512,3 +514,160 @@ func (s *TransactorSuite) TestStoreAndTrackPendingTx() {
 	err := s.manager.StoreAndTrackPendingTx(common.Address{}, "", 0, wallet_common.MultiTransactionIDType(0), nil)
 	s.NoError(err)
 }
+
+func TestBumpReplacementFee(t *testing.T) {
+	tests := []struct {
+		name      string
+		replaced  int64
+		suggested *big.Int
+		expected  int64
+	}{
+		{"no suggestion", 100, nil, 111},
+		{"suggestion below the minimal bump", 100, big.NewInt(105), 111},
+		{"suggestion at the minimal bump", 100, big.NewInt(111), 111},
+		{"suggestion above the minimal bump", 100, big.NewInt(200), 200},
+		{"zero fee", 0, big.NewInt(0), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, bumpReplacementFee(big.NewInt(tt.replaced), tt.suggested).Int64())
+		})
+	}
+}
+
+func TestBuildReplacementArgs(t *testing.T) {
+	from := common.HexToAddress("0x1111")
+	to := common.HexToAddress("0x2222")
+	data := []byte{0x01, 0x02}
+	const cancelGas = 21000
+
+	dynamicTx := func(tip int64, feeCap int64) *gethtypes.Transaction {
+		return gethtypes.NewTx(&gethtypes.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     5,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(feeCap),
+			Gas:       50000,
+			To:        &to,
+			Value:     big.NewInt(7),
+			Data:      data,
+		})
+	}
+	legacyTx := gethtypes.NewTx(&gethtypes.LegacyTx{
+		Nonce:    5,
+		GasPrice: big.NewInt(100),
+		Gas:      50000,
+		To:       &to,
+		Value:    big.NewInt(7),
+		Data:     data,
+	})
+	eip1559Fees := func(maxFee int64, tip int64) *fees.SuggestedFees {
+		return &fees.SuggestedFees{
+			EIP1559Enabled: true,
+			MaxFeesLevels: &fees.MaxFeesLevels{
+				Medium:         (*hexutil.Big)(big.NewInt(maxFee)),
+				MediumPriority: (*hexutil.Big)(big.NewInt(tip)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		replacedTx      *gethtypes.Transaction
+		replacementType ReplacementType
+		suggestedFees   *fees.SuggestedFees
+		expectedFeeCap  int64
+		expectedTip     int64
+		expectedPrice   int64
+	}{
+		{
+			name:            "1559 speed up bumps both fees by 10%",
+			replacedTx:      dynamicTx(10, 100),
+			replacementType: SpeedUp,
+			suggestedFees:   eip1559Fees(50, 5),
+			expectedFeeCap:  111,
+			expectedTip:     12,
+		},
+		{
+			name:            "1559 speed up uses the higher suggested fees",
+			replacedTx:      dynamicTx(10, 100),
+			replacementType: SpeedUp,
+			suggestedFees:   eip1559Fees(300, 30),
+			expectedFeeCap:  300,
+			expectedTip:     30,
+		},
+		{
+			name:            "1559 max fee is at least the tip",
+			replacedTx:      dynamicTx(100, 100),
+			replacementType: SpeedUp,
+			suggestedFees:   eip1559Fees(50, 500),
+			expectedFeeCap:  500,
+			expectedTip:     500,
+		},
+		{
+			name:            "1559 cancel bumps both fees by 10%",
+			replacedTx:      dynamicTx(10, 100),
+			replacementType: Cancel,
+			suggestedFees:   eip1559Fees(50, 5),
+			expectedFeeCap:  111,
+			expectedTip:     12,
+		},
+		{
+			name:            "legacy speed up bumps the gas price by 10%",
+			replacedTx:      legacyTx,
+			replacementType: SpeedUp,
+			suggestedFees:   &fees.SuggestedFees{GasPrice: big.NewInt(105)},
+			expectedPrice:   111,
+		},
+		{
+			name:            "legacy cancel uses the higher suggested gas price",
+			replacedTx:      legacyTx,
+			replacementType: Cancel,
+			suggestedFees:   &fees.SuggestedFees{GasPrice: big.NewInt(150)},
+			expectedPrice:   150,
+		},
+		{
+			name:            "1559 transaction on a legacy chain bumps its max fee as gas price",
+			replacedTx:      dynamicTx(10, 100),
+			replacementType: SpeedUp,
+			suggestedFees:   &fees.SuggestedFees{GasPrice: big.NewInt(50)},
+			expectedPrice:   111,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := buildReplacementArgs(tt.replacedTx, from, tt.replacementType, cancelGas, tt.suggestedFees)
+			require.NoError(t, err)
+
+			require.Equal(t, types.Address(from), args.From)
+			require.Equal(t, tt.replacedTx.Nonce(), uint64(*args.Nonce))
+
+			if tt.replacementType == Cancel {
+				require.Equal(t, types.Address(from), *args.To)
+				require.Equal(t, int64(0), args.Value.ToInt().Int64())
+				require.Empty(t, args.Data)
+				require.Equal(t, uint64(cancelGas), uint64(*args.Gas))
+			} else {
+				require.Equal(t, types.Address(to), *args.To)
+				require.Equal(t, tt.replacedTx.Value(), args.Value.ToInt())
+				require.Equal(t, data, []byte(args.Data))
+				require.Equal(t, tt.replacedTx.Gas(), uint64(*args.Gas))
+			}
+
+			if tt.expectedPrice != 0 {
+				require.Equal(t, tt.expectedPrice, args.GasPrice.ToInt().Int64())
+				require.Nil(t, args.MaxFeePerGas)
+				require.Nil(t, args.MaxPriorityFeePerGas)
+				return
+			}
+			require.Nil(t, args.GasPrice)
+			require.Equal(t, tt.expectedFeeCap, args.MaxFeePerGas.ToInt().Int64())
+			require.Equal(t, tt.expectedTip, args.MaxPriorityFeePerGas.ToInt().Int64())
+		})
+	}
+
+	_, err := buildReplacementArgs(legacyTx, from, ReplacementType("Unknown"), cancelGas, &fees.SuggestedFees{})
+	require.ErrorIs(t, err, ErrInvalidReplacementType)
+}
@@ -0,0 +1,21 @@
+-- connector_calls_batches table keeps track of EIP-5792 call batches sent by connected dApps
+-- so that their status can be reported back through wallet_getCallsStatus. The ids are chosen by the dApps, so they
+-- are unique per dApp only
+
+CREATE TABLE IF NOT EXISTS connector_calls_batches (
+    id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    from_address TEXT NOT NULL,
+    timestamp INTEGER NOT NULL,
+    PRIMARY KEY (url, id)
+) WITHOUT ROWID;
+
+CREATE TABLE IF NOT EXISTS connector_calls_batches_transactions (
+    batch_url TEXT NOT NULL,
+    batch_id TEXT NOT NULL,
+    call_index INTEGER NOT NULL,
+    tx_hash BLOB NOT NULL,
+    PRIMARY KEY (batch_url, batch_id, call_index),
+    FOREIGN KEY (batch_url, batch_id) REFERENCES connector_calls_batches(url, id) ON DELETE CASCADE
+) WITHOUT ROWID;
@@ -0,0 +1,11 @@
+-- links a transaction sent to replace a pending one (speed-up or cancel) to the transaction it replaces,
+-- both share the same nonce and only one of them can be mined
+CREATE TABLE IF NOT EXISTS tracked_transactions_replacements (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    tx_hash BLOB NOT NULL,
+    replaced_tx_hash BLOB NOT NULL,
+    replacement_type TEXT NOT NULL,
+    PRIMARY KEY (chain_id, tx_hash)
+) WITHOUT ROWID;
+
+CREATE INDEX IF NOT EXISTS idx_tracked_transactions_replacements_replaced_tx_hash ON tracked_transactions_replacements (chain_id, replaced_tx_hash);
@@ -0,0 +1,39 @@
+-- safe_accounts keeps the on-chain configuration of the Safe multisig accounts added to the wallet
+CREATE TABLE IF NOT EXISTS safe_accounts (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    address BLOB NOT NULL,
+    owners TEXT NOT NULL,
+    threshold INTEGER NOT NULL,
+    nonce INTEGER NOT NULL,
+    version TEXT NOT NULL,
+    updated_at INTEGER NOT NULL,
+    PRIMARY KEY (chain_id, address)
+) WITHOUT ROWID;
+
+-- safe_transactions keeps the Safe transactions waiting for enough owner signatures to be executed
+CREATE TABLE IF NOT EXISTS safe_transactions (
+    safe_tx_hash BLOB PRIMARY KEY,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    safe_address BLOB NOT NULL,
+    to_address BLOB NOT NULL,
+    value TEXT NOT NULL,
+    data BLOB,
+    operation INTEGER NOT NULL,
+    safe_tx_gas TEXT NOT NULL,
+    base_gas TEXT NOT NULL,
+    gas_price TEXT NOT NULL,
+    gas_token BLOB NOT NULL,
+    refund_receiver BLOB NOT NULL,
+    nonce INTEGER NOT NULL,
+    created_at INTEGER NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX IF NOT EXISTS idx_safe_transactions_safe ON safe_transactions (chain_id, safe_address, nonce);
+
+CREATE TABLE IF NOT EXISTS safe_transaction_signatures (
+    safe_tx_hash BLOB NOT NULL,
+    signer BLOB NOT NULL,
+    signature BLOB NOT NULL,
+    PRIMARY KEY (safe_tx_hash, signer),
+    FOREIGN KEY (safe_tx_hash) REFERENCES safe_transactions(safe_tx_hash) ON DELETE CASCADE
+) WITHOUT ROWID;
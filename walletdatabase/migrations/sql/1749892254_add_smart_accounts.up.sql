@@ -0,0 +1,10 @@
+-- smart_accounts keeps how the ERC-4337 smart accounts are deployed, the counterfactual address is the same on all
+-- chains since it only depends on the factory, the owner and the salt
+CREATE TABLE IF NOT EXISTS smart_accounts (
+    address BLOB PRIMARY KEY,
+    owner BLOB NOT NULL,
+    factory BLOB NOT NULL,
+    entry_point BLOB NOT NULL,
+    salt TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+) WITHOUT ROWID;
@@ -0,0 +1,21 @@
+-- token_allowances keeps the current ERC20 allowances and ERC721/ERC1155 operator approvals given by the accounts,
+-- amount is NULL for operator approvals
+CREATE TABLE IF NOT EXISTS token_allowances (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    owner BLOB NOT NULL,
+    token BLOB NOT NULL,
+    spender BLOB NOT NULL,
+    type TEXT NOT NULL,
+    amount TEXT,
+    block_number UNSIGNED BIGINT NOT NULL,
+    tx_hash BLOB NOT NULL,
+    PRIMARY KEY (chain_id, owner, token, spender, type)
+) WITHOUT ROWID;
+
+-- token_allowances_scanned_blocks keeps the last block the approval logs of the account were scanned to
+CREATE TABLE IF NOT EXISTS token_allowances_scanned_blocks (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    owner BLOB NOT NULL,
+    block_number UNSIGNED BIGINT NOT NULL,
+    PRIMARY KEY (chain_id, owner)
+) WITHOUT ROWID;
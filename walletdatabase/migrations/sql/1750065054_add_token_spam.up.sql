@@ -0,0 +1,39 @@
+-- token_spam_verdicts keeps the result of the spam classification of the tokens, reasons is a comma separated list
+CREATE TABLE IF NOT EXISTS token_spam_verdicts (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    address BLOB NOT NULL,
+    is_spam BOOLEAN NOT NULL,
+    score INT NOT NULL,
+    reasons TEXT NOT NULL,
+    updated_at INT NOT NULL,
+    PRIMARY KEY (chain_id, address)
+) WITHOUT ROWID;
+
+-- token_spam_overrides keeps the verdicts set by the user, they are synced with the paired devices
+CREATE TABLE IF NOT EXISTS token_spam_overrides (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    address BLOB NOT NULL,
+    is_spam BOOLEAN NOT NULL,
+    PRIMARY KEY (chain_id, address)
+) WITHOUT ROWID;
+
+CREATE TABLE IF NOT EXISTS token_spam_overrides_clock (
+    synthetic_id VARCHAR DEFAULT 'id' PRIMARY KEY,
+    clock INT NOT NULL
+);
+
+ALTER TABLE collection_data_cache ADD COLUMN is_spam BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- spam_tokens lists the tokens and collections considered spam, the user overrides win over the classification
+-- and the provider flags
+CREATE VIEW IF NOT EXISTS spam_tokens AS
+SELECT chain_id, address FROM token_spam_overrides WHERE is_spam
+UNION
+SELECT chain_id, address FROM (
+    SELECT chain_id, address FROM token_spam_verdicts WHERE is_spam
+    UNION
+    SELECT chain_id, contract_address AS address FROM collection_data_cache WHERE is_spam
+) classified
+WHERE NOT EXISTS (
+    SELECT 1 FROM token_spam_overrides o WHERE o.chain_id = classified.chain_id AND o.address = classified.address
+);
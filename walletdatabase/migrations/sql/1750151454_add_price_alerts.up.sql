@@ -0,0 +1,14 @@
+-- price_alerts keeps the alert rules set by the user, condition_met and last_triggered_at keep the evaluation state
+-- used to not notify twice for the same crossing
+CREATE TABLE IF NOT EXISTS price_alerts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    symbol VARCHAR NOT NULL,
+    currency VARCHAR NOT NULL,
+    type VARCHAR NOT NULL,
+    threshold REAL NOT NULL,
+    cooldown INT NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    condition_met BOOLEAN NOT NULL DEFAULT FALSE,
+    last_triggered_at INT NOT NULL DEFAULT 0,
+    created_at INT NOT NULL
+);
@@ -0,0 +1,8 @@
+-- blocks_hashes keeps the hashes of the recently checked chain heads, a head no longer matching them reveals a
+-- reorganization of the chain and its common ancestor with the stored ones
+CREATE TABLE IF NOT EXISTS blocks_hashes (
+    network_id UNSIGNED BIGINT NOT NULL,
+    blk_number BIGINT NOT NULL,
+    blk_hash BLOB NOT NULL,
+    PRIMARY KEY (network_id, blk_number)
+) WITHOUT ROWID;
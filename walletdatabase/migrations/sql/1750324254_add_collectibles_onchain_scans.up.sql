@@ -0,0 +1,22 @@
+-- collectibles_onchain_scanned_blocks keeps the last block the transfer logs of the owner were scanned to by the
+-- on-chain collectibles provider, scan_contract is the contract the scan was restricted to, the zero address for the
+-- scans of all the contracts
+CREATE TABLE IF NOT EXISTS collectibles_onchain_scanned_blocks (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    owner BLOB NOT NULL,
+    scan_contract BLOB NOT NULL,
+    block_number UNSIGNED BIGINT NOT NULL,
+    PRIMARY KEY (chain_id, owner, scan_contract)
+) WITHOUT ROWID;
+
+-- collectibles_onchain_balances keeps the balances replayed from the logs scanned up to the scanned block
+CREATE TABLE IF NOT EXISTS collectibles_onchain_balances (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    owner BLOB NOT NULL,
+    scan_contract BLOB NOT NULL,
+    contract BLOB NOT NULL,
+    token_id TEXT NOT NULL,
+    contract_type INTEGER NOT NULL,
+    balance TEXT NOT NULL,
+    PRIMARY KEY (chain_id, owner, scan_contract, contract, token_id)
+) WITHOUT ROWID;